@@ -0,0 +1,67 @@
+package supergin
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AsyncValidator checks validated input against external state, e.g. that an email is not
+// taken or a referenced record exists. It runs after struct validation with the request
+// context, so request-scoped DI services resolve through TryResolve or GetFromContextT(c, ...).
+// Returning FieldErrors rejects the input with 400; other errors respond with 500.
+type AsyncValidator func(c *gin.Context, input interface{}) error
+
+// WithAsyncValidation adds validators run in order after the route's input passed struct
+// validation. Field errors from all validators are reported together.
+func (rb *RouteBuilder) WithAsyncValidation(validators ...AsyncValidator) *RouteBuilder {
+	rb.asyncValidators = append(rb.asyncValidators, validators...)
+	return rb
+}
+
+// ValidateAsync adapts a validator for a route input type, e.g.
+//
+//	supergin.ValidateAsync(func(c *gin.Context, req *CreateUserRequest) error {
+//		users := supergin.GetFromContextT[*UserRepository](c, "users")
+//		if users.EmailExists(c, req.Email) {
+//			return supergin.InvalidField("email", "unique", "is already taken")
+//		}
+//		return nil
+//	})
+func ValidateAsync[T any](fn func(c *gin.Context, input *T) error) AsyncValidator {
+	return func(c *gin.Context, input interface{}) error {
+		typed, ok := input.(*T)
+		if !ok {
+			return fmt.Errorf("async validator expects %T, got %T", typed, input)
+		}
+		return fn(c, typed)
+	}
+}
+
+// runAsyncValidators runs the route's async validators on the validated input
+func (rb *RouteBuilder) runAsyncValidators(c *gin.Context) error {
+	if len(rb.asyncValidators) == 0 {
+		return nil
+	}
+	input, exists := GetValidatedInput(c)
+	if !exists {
+		return nil
+	}
+
+	var fields FieldErrors
+	for _, validate := range rb.asyncValidators {
+		err := validate(c, input)
+		if err == nil {
+			continue
+		}
+		invalid := fieldErrorsOf(err)
+		if len(invalid) == 0 {
+			return err
+		}
+		fields = append(fields, invalid...)
+	}
+	if len(fields) > 0 {
+		return NewSuperGinErrorWithCause(ErrValidationFailed, fields, "validation error")
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package supergin
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AsyncAPIVersion is the AsyncAPI specification version emitted by the generator
+const AsyncAPIVersion = "2.6.0"
+
+// asyncAPINameUnsafe matches characters not allowed in AsyncAPI component names
+var asyncAPINameUnsafe = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// AsyncAPI generates an AsyncAPI 2.x document for the engine's event-driven endpoints:
+// WebSocket endpoints, with the message types their MessageRouter handles (publish) and
+// declares with Emits (subscribe), and gRPC server-streaming routes, served as server-sent
+// events. Payload schemas come from the message registry; named structs become schema
+// components as in the OpenAPI document.
+func (e *Engine) AsyncAPI() map[string]interface{} {
+	schemas := make(map[string]interface{})
+	messages := make(map[string]interface{})
+	sb := newSchemaBuilder(schemas)
+
+	routes := e.GetRoutes()
+	names := make([]string, 0, len(routes))
+	for name := range routes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var bridge *GrpcBridge
+	if e.di.Has("grpc_bridge") {
+		bridge, _ = e.di.Get("grpc_bridge").(*GrpcBridge)
+	}
+
+	channels := make(map[string]interface{})
+	for _, name := range names {
+		route := routes[name]
+		if hub, ok := route.Metadata["websocket_hub"].(*WebSocketHub); ok {
+			channels[openAPIPath(route.Path)] = e.webSocketChannel(route, hub, sb, messages)
+			continue
+		}
+		if method := streamingMethod(bridge, route); method != nil {
+			channels[openAPIPath(route.Path)] = e.eventStreamChannel(route, method, sb, messages)
+		}
+	}
+
+	title := e.config.Title
+	if title == "" {
+		title = "SuperGin API"
+	}
+	version := e.config.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+
+	return map[string]interface{}{
+		"asyncapi": AsyncAPIVersion,
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": version,
+		},
+		"defaultContentType": "application/json",
+		"channels":           channels,
+		"components": map[string]interface{}{
+			"messages": messages,
+			"schemas":  schemas,
+		},
+	}
+}
+
+// webSocketChannel describes a WebSocket endpoint. Clients publish the message types the
+// hub's router handles and subscribe to those it emits.
+func (e *Engine) webSocketChannel(route *RouteInfo, hub *WebSocketHub, sb *schemaBuilder, messages map[string]interface{}) map[string]interface{} {
+	channel := asyncAPIChannel(route)
+	channel["bindings"] = map[string]interface{}{
+		"ws": map[string]interface{}{"method": http.MethodGet, "bindingVersion": "0.1.0"},
+	}
+
+	router, ok := hub.handler.(*MessageRouter)
+	if !ok {
+		return channel
+	}
+	handled, emitted := router.messageTypes()
+	if refs := e.webSocketMessages(handled, sb, messages); len(refs) > 0 {
+		channel["publish"] = map[string]interface{}{
+			"operationId": route.Name + "_publish",
+			"summary":     "Messages clients send",
+			"message":     map[string]interface{}{"oneOf": refs},
+		}
+	}
+	if refs := e.webSocketMessages(emitted, sb, messages); len(refs) > 0 {
+		channel["subscribe"] = map[string]interface{}{
+			"operationId": route.Name + "_subscribe",
+			"summary":     "Messages the server sends",
+			"message":     map[string]interface{}{"oneOf": refs},
+		}
+	}
+	return channel
+}
+
+// webSocketMessages registers message components for registered types and returns their
+// references. Payloads are WebSocketMessage envelopes around the registered data schema.
+func (e *Engine) webSocketMessages(types []string, sb *schemaBuilder, messages map[string]interface{}) []interface{} {
+	refs := []interface{}{}
+	for _, messageType := range types {
+		def, exists := e.messages.Lookup(messageType)
+		if !exists {
+			continue
+		}
+		key := asyncAPIName(messageType)
+		if _, done := messages[key]; !done {
+			message := map[string]interface{}{
+				"name": messageType,
+				"payload": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"type":      map[string]interface{}{"type": "string", "const": messageType},
+						"data":      sb.build(def.PayloadType),
+						"timestamp": map[string]interface{}{"type": "string", "format": "date-time"},
+						"id":        map[string]interface{}{"type": "string"},
+					},
+					"required": []string{"type", "data"},
+				},
+			}
+			if def.Description != "" {
+				message["summary"] = def.Description
+			}
+			messages[key] = message
+		}
+		refs = append(refs, map[string]interface{}{"$ref": "#/components/messages/" + key})
+	}
+	return refs
+}
+
+// eventStreamChannel describes a server-streaming route, whose messages clients receive
+// as server-sent events
+func (e *Engine) eventStreamChannel(route *RouteInfo, method *GrpcMethod, sb *schemaBuilder, messages map[string]interface{}) map[string]interface{} {
+	channel := asyncAPIChannel(route)
+	if _, described := channel["description"]; !described {
+		channel["description"] = "Server-sent events, or NDJSON without Accept: text/event-stream; failures end the stream with an error event"
+	}
+
+	payload := map[string]interface{}{"type": "object"}
+	if method.OutputType != nil {
+		payload = sb.build(method.OutputType)
+	}
+	key := asyncAPIName(route.Name + "_event")
+	messages[key] = map[string]interface{}{
+		"name":    method.FullName,
+		"payload": payload,
+	}
+
+	channel["subscribe"] = map[string]interface{}{
+		"operationId": route.Name + "_subscribe",
+		"summary":     "Messages of the " + method.FullName + " stream",
+		"bindings": map[string]interface{}{
+			"http": map[string]interface{}{"type": "request", "method": route.Method, "bindingVersion": "0.1.0"},
+		},
+		"message": map[string]interface{}{"$ref": "#/components/messages/" + key},
+	}
+	return channel
+}
+
+// asyncAPIChannel starts a channel item with the route's description and path parameters
+func asyncAPIChannel(route *RouteInfo) map[string]interface{} {
+	channel := make(map[string]interface{})
+	if route.Description != "" {
+		channel["description"] = route.Description
+	}
+	parameters := make(map[string]interface{})
+	for _, segment := range strings.Split(route.Path, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			parameters[segment[1:]] = map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}
+		}
+	}
+	if len(parameters) > 0 {
+		channel["parameters"] = parameters
+	}
+	return channel
+}
+
+// streamingMethod returns the server-streaming gRPC method a route bridges as server-sent
+// events, if any
+func streamingMethod(bridge *GrpcBridge, route *RouteInfo) *GrpcMethod {
+	if _, web := route.Metadata["grpc_protocols"]; bridge == nil || web {
+		return nil
+	}
+	serviceName, _ := route.Metadata["grpc_service"].(string)
+	methodName, _ := route.Metadata["grpc_method"].(string)
+	service, exists := bridge.services[serviceName]
+	if !exists {
+		return nil
+	}
+	method, exists := service.Methods[methodName]
+	if !exists || !method.StreamingOutput {
+		return nil
+	}
+	return method
+}
+
+// asyncAPIName makes a message type usable as a component name
+func asyncAPIName(name string) string {
+	return asyncAPINameUnsafe.ReplaceAllString(name, "_")
+}
+
+// setupAsyncAPIEndpoint serves the generated AsyncAPI document under the docs path
+func (e *Engine) setupAsyncAPIEndpoint() {
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, e.AsyncAPI())
+	}
+	e.Engine.GET(strings.TrimSuffix(e.config.DocsPath, "/")+"/asyncapi.json", e.docsHandlers(handler)...)
+}
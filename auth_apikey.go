@@ -0,0 +1,218 @@
+package supergin
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// AuthMetadata is the route metadata key WithAuth stores its scheme name under
+	AuthMetadata = "auth"
+
+	// DefaultAPIKeyHeader is the header APIKeyAuth reads keys from by default
+	DefaultAPIKeyHeader = "X-API-Key"
+	// DefaultSignatureHeader carries the hex HMAC-SHA256 request signature
+	DefaultSignatureHeader = "X-Signature"
+	// DefaultTimestampHeader carries the Unix time the request was signed at
+	DefaultTimestampHeader = "X-Timestamp"
+	// DefaultSignatureMaxSkew bounds how old, or how far in the future, a signature may be
+	DefaultSignatureMaxSkew = 5 * time.Minute
+)
+
+// APIKey is a machine client's credential
+type APIKey struct {
+	// Client identifies the key's owner; it becomes the request's ClientID and principal ID
+	Client      string
+	Roles       []string
+	Permissions []string
+	// Secret signs the client's requests when signatures are verified
+	Secret []byte
+}
+
+// APIKeyStore looks API keys up, e.g. in a database. Unknown keys return nil and no error.
+type APIKeyStore interface {
+	LookupAPIKey(ctx context.Context, key string) (*APIKey, error)
+}
+
+// StaticAPIKeys is an APIKeyStore holding keys in memory, mapped from the key string
+type StaticAPIKeys map[string]APIKey
+
+// LookupAPIKey implements APIKeyStore
+func (s StaticAPIKeys) LookupAPIKey(ctx context.Context, key string) (*APIKey, error) {
+	if apiKey, exists := s[key]; exists {
+		return &apiKey, nil
+	}
+	return nil, nil
+}
+
+// APIKeyOptions configures APIKeyAuth
+type APIKeyOptions struct {
+	Store APIKeyStore
+	// Header is read first; empty means DefaultAPIKeyHeader
+	Header string
+	// Query, when set, names a query parameter read when the header is absent
+	Query string
+	// Signature, when set, also requires requests to be signed with the key's secret
+	Signature *SignatureOptions
+}
+
+// SignatureOptions configures HMAC request signature verification. Clients sign
+//
+//	METHOD "\n" request URI "\n" timestamp "\n" hex(sha256(body))
+//
+// with HMAC-SHA256 and their key's secret, sending the hex digest in the signature header
+// and the Unix timestamp in the timestamp header.
+type SignatureOptions struct {
+	// Header is empty for DefaultSignatureHeader
+	Header string
+	// TimestampHeader is empty for DefaultTimestampHeader
+	TimestampHeader string
+	// MaxSkew is zero for DefaultSignatureMaxSkew
+	MaxSkew time.Duration
+}
+
+// WithAuth authenticates the route with a scheme registered with RegisterAuthScheme, e.g.
+// WithAuth("apikey") for machine-to-machine endpoints. The scheme runs before tag-bound and
+// route middleware; naming an unregistered scheme panics when the route is registered.
+func (rb *RouteBuilder) WithAuth(scheme string) *RouteBuilder {
+	return rb.WithMetadata(AuthMetadata, scheme)
+}
+
+// WithAuth authenticates all resource routes with a registered scheme
+func (rb *ResourceBuilder) WithAuth(scheme string) *ResourceBuilder {
+	return rb.WithMetadata(AuthMetadata, scheme)
+}
+
+// routeAuth returns the middleware of the scheme a route names with WithAuth, if any
+func (e *Engine) routeAuth(route string, metadata map[string]interface{}) gin.HandlerFunc {
+	scheme, _ := metadata[AuthMetadata].(string)
+	if scheme == "" {
+		return nil
+	}
+	middleware, exists := e.authScheme(scheme)
+	if !exists {
+		panic(fmt.Sprintf("route '%s' uses unknown auth scheme '%s'", route, scheme))
+	}
+	return middleware
+}
+
+// APIKeyAuth authenticates requests by API key, optionally verifying an HMAC signature of
+// the request made with the key's secret. Authenticated requests get the key's client as
+// ClientID and a BasicPrincipal with its roles and permissions; others get 401 as
+// application/problem+json. Register it as a scheme:
+//
+//	app.RegisterAuthScheme("apikey", supergin.APIKeyAuth(supergin.APIKeyOptions{
+//		Store:     supergin.StaticAPIKeys{os.Getenv("BILLING_KEY"): {Client: "billing"}},
+//		Signature: &supergin.SignatureOptions{},
+//	}))
+func APIKeyAuth(opts APIKeyOptions) gin.HandlerFunc {
+	if opts.Store == nil {
+		panic("API key auth requires a key store")
+	}
+	if opts.Header == "" {
+		opts.Header = DefaultAPIKeyHeader
+	}
+	if opts.Signature != nil {
+		sig := *opts.Signature
+		opts.Signature = &sig
+		if sig.Header == "" {
+			sig.Header = DefaultSignatureHeader
+		}
+		if sig.TimestampHeader == "" {
+			sig.TimestampHeader = DefaultTimestampHeader
+		}
+		if sig.MaxSkew <= 0 {
+			sig.MaxSkew = DefaultSignatureMaxSkew
+		}
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(opts.Header)
+		if key == "" && opts.Query != "" {
+			key = c.Query(opts.Query)
+		}
+		if key == "" {
+			writeProblem(c, http.StatusUnauthorized, "API key required")
+			return
+		}
+
+		apiKey, err := opts.Store.LookupAPIKey(c.Request.Context(), key)
+		if err != nil {
+			LoggerFor(c).Error("API key lookup failed", "error", err)
+			writeProblem(c, http.StatusServiceUnavailable, "API key verification unavailable")
+			return
+		}
+		if apiKey == nil {
+			writeProblem(c, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+		if opts.Signature != nil {
+			if err := opts.Signature.verify(c, apiKey.Secret); err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					writeBodyTooLarge(c, tooLarge.Limit)
+					return
+				}
+				LoggerFor(c).Info("request signature rejected", "client", apiKey.Client, "error", err)
+				writeProblem(c, http.StatusUnauthorized, err.Error())
+				return
+			}
+		}
+
+		SetClientID(c, apiKey.Client)
+		SetPrincipal(c, BasicPrincipal{ID: apiKey.Client, Roles: apiKey.Roles, Permissions: apiKey.Permissions})
+	}
+}
+
+// verify checks the request's timestamp and signature, restoring the body it reads
+func (o *SignatureOptions) verify(c *gin.Context, secret []byte) error {
+	if len(secret) == 0 {
+		return fmt.Errorf("API key cannot sign requests")
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(c.GetHeader(o.Header), "sha256="))
+	if err != nil || len(signature) == 0 {
+		return fmt.Errorf("request signature required in %s", o.Header)
+	}
+	timestamp := c.GetHeader(o.TimestampHeader)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("request timestamp required in %s", o.TimestampHeader)
+	}
+	if skew := time.Since(time.Unix(seconds, 0)); skew > o.MaxSkew || skew < -o.MaxSkew {
+		return fmt.Errorf("request timestamp outside the allowed %s skew", o.MaxSkew)
+	}
+
+	var body []byte
+	if c.Request.Body != nil {
+		if body, err = io.ReadAll(c.Request.Body); err != nil {
+			return fmt.Errorf("failed to read request body: %w", err)
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if !hmac.Equal(signature, SignRequest(secret, c.Request.Method, c.Request.URL.RequestURI(), timestamp, body)) {
+		return fmt.Errorf("invalid request signature")
+	}
+	return nil
+}
+
+// SignRequest computes the HMAC-SHA256 signature APIKeyAuth verifies, for clients and tests;
+// send it hex encoded
+func SignRequest(secret []byte, method, requestURI, timestamp string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s", method, requestURI, timestamp, hex.EncodeToString(bodyHash[:]))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,140 @@
+package supergin
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// failingKeyStore is an APIKeyStore whose backend is down
+type failingKeyStore struct{}
+
+func (failingKeyStore) LookupAPIKey(ctx context.Context, key string) (*APIKey, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	app := newTestEngine(Config{})
+	app.RegisterAuthScheme("apikey", APIKeyAuth(APIKeyOptions{
+		Store: StaticAPIKeys{"reports-key": {Client: "reports", Roles: []string{"reader"}}},
+		Query: "api_key",
+	}))
+	app.RegisterAuthScheme("broken", APIKeyAuth(APIKeyOptions{Store: failingKeyStore{}}))
+	whoami := func(c *gin.Context) {
+		principal, _ := GetPrincipal(c)
+		c.String(http.StatusOK, ClientID(c)+" "+principal.PrincipalID())
+	}
+	app.Named("whoami").GET("/whoami").WithAuth("apikey").Handler(whoami)
+	app.Named("reports").GET("/reports").WithAuth("apikey").RequireRoles("admin").Handler(whoami)
+	app.Named("broken").GET("/broken").WithAuth("broken").Handler(whoami)
+
+	tests := []struct {
+		name   string
+		target string
+		header string
+		want   int
+		body   string
+	}{
+		{"header", "/whoami", "reports-key", http.StatusOK, "reports reports"},
+		{"query", "/whoami?api_key=reports-key", "", http.StatusOK, "reports reports"},
+		{"missing", "/whoami", "", http.StatusUnauthorized, ""},
+		{"unknown", "/whoami", "other-key", http.StatusUnauthorized, ""},
+		{"missing role", "/reports", "reports-key", http.StatusForbidden, ""},
+		{"store down", "/broken", "reports-key", http.StatusServiceUnavailable, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set(DefaultAPIKeyHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", w.Body, tt.body)
+			}
+			if tt.want == http.StatusUnauthorized && !strings.HasPrefix(w.Header().Get("Content-Type"), "application/problem+json") {
+				t.Errorf("Content-Type = %q, want problem details", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestAPIKeySignatures(t *testing.T) {
+	secret := []byte("webhook-secret")
+	app := newTestEngine(Config{})
+	app.RegisterAuthScheme("signed", APIKeyAuth(APIKeyOptions{
+		Store: StaticAPIKeys{
+			"billing-key":  {Client: "billing", Secret: secret},
+			"unsigned-key": {Client: "legacy"},
+		},
+		Signature: &SignatureOptions{MaxSkew: time.Minute},
+	}))
+	app.Named("charge").POST("/charges").WithAuth("signed").Handler(func(c *gin.Context) {
+		// The signature check leaves the body readable
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+
+	const target = "/charges?currency=eur"
+	now := time.Now()
+	body := `{"amount": 100}`
+	sign := func(secret []byte, uri string, at time.Time, body string) (string, string) {
+		timestamp := strconv.FormatInt(at.Unix(), 10)
+		return hex.EncodeToString(SignRequest(secret, http.MethodPost, uri, timestamp, []byte(body))), timestamp
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		secret []byte
+		// The client signed signedURI and signedBody at time at
+		signedURI  string
+		at         time.Time
+		signedBody string
+		prefix     string
+		omitSig    bool
+		want       int
+	}{
+		{"valid", "billing-key", secret, target, now, body, "", false, http.StatusOK},
+		{"sha256= prefix", "billing-key", secret, target, now, body, "sha256=", false, http.StatusOK},
+		{"no signature", "billing-key", secret, target, now, body, "", true, http.StatusUnauthorized},
+		{"wrong secret", "billing-key", []byte("guess"), target, now, body, "", false, http.StatusUnauthorized},
+		{"tampered body", "billing-key", secret, target, now, `{"amount": 1}`, "", false, http.StatusUnauthorized},
+		{"other query", "billing-key", secret, "/charges?currency=usd", now, body, "", false, http.StatusUnauthorized},
+		{"expired", "billing-key", secret, target, now.Add(-2 * time.Minute), body, "", false, http.StatusUnauthorized},
+		{"from the future", "billing-key", secret, target, now.Add(2 * time.Minute), body, "", false, http.StatusUnauthorized},
+		{"key without secret", "unsigned-key", nil, target, now, body, "", false, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature, timestamp := sign(tt.secret, tt.signedURI, tt.at, tt.signedBody)
+			req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+			req.Header.Set(DefaultAPIKeyHeader, tt.key)
+			req.Header.Set(DefaultTimestampHeader, timestamp)
+			if !tt.omitSig {
+				req.Header.Set(DefaultSignatureHeader, tt.prefix+signature)
+			}
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if tt.want == http.StatusOK && w.Body.String() != body {
+				t.Errorf("handler read %q, want the signed body", w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package supergin
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// principalKey stores the authenticated principal in the gin context
+	principalKey = "supergin:principal"
+
+	// RolesMetadata is the route metadata key RequireRoles stores its roles under
+	RolesMetadata = "required_roles"
+	// PermissionsMetadata is the route metadata key RequirePermissions stores its
+	// permissions under
+	PermissionsMetadata = "required_permissions"
+)
+
+// Principal is the authenticated caller of a request
+type Principal interface {
+	PrincipalID() string
+	HasRole(role string) bool
+	HasPermission(permission string) bool
+}
+
+// BasicPrincipal is a Principal with fixed roles and permissions
+type BasicPrincipal struct {
+	ID          string
+	Roles       []string
+	Permissions []string
+}
+
+// PrincipalID implements Principal
+func (p BasicPrincipal) PrincipalID() string { return p.ID }
+
+// HasRole implements Principal
+func (p BasicPrincipal) HasRole(role string) bool { return slices.Contains(p.Roles, role) }
+
+// HasPermission implements Principal
+func (p BasicPrincipal) HasPermission(permission string) bool {
+	return slices.Contains(p.Permissions, permission)
+}
+
+// SetPrincipal records the authenticated caller; authentication middleware calls it once
+// the credentials are verified
+func SetPrincipal(c *gin.Context, principal Principal) {
+	c.Set(principalKey, principal)
+}
+
+// GetPrincipal returns the caller recorded with SetPrincipal
+func GetPrincipal(c *gin.Context) (Principal, bool) {
+	if value, exists := c.Get(principalKey); exists {
+		principal, ok := value.(Principal)
+		return principal, ok
+	}
+	return nil, false
+}
+
+// Requirements are the roles and permissions a route requires
+type Requirements struct {
+	// Roles are alternatives: the principal needs one of them
+	Roles []string `json:"roles,omitempty"`
+	// Permissions are all needed
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// Authorizer decides whether a principal meets a route's requirements, returning an error
+// explaining the refusal otherwise
+type Authorizer interface {
+	Authorize(c *gin.Context, principal Principal, required Requirements) error
+}
+
+// AuthorizerFunc adapts a function to Authorizer
+type AuthorizerFunc func(c *gin.Context, principal Principal, required Requirements) error
+
+// Authorize implements Authorizer
+func (f AuthorizerFunc) Authorize(c *gin.Context, principal Principal, required Requirements) error {
+	return f(c, principal, required)
+}
+
+// RoleAuthorizer is the default Authorizer: the principal needs one of the roles, if any
+// are required, and every permission
+type RoleAuthorizer struct{}
+
+// Authorize implements Authorizer
+func (RoleAuthorizer) Authorize(c *gin.Context, principal Principal, required Requirements) error {
+	if len(required.Roles) > 0 && !slices.ContainsFunc(required.Roles, principal.HasRole) {
+		return fmt.Errorf("requires one of the roles %s", strings.Join(required.Roles, ", "))
+	}
+	for _, permission := range required.Permissions {
+		if !principal.HasPermission(permission) {
+			return fmt.Errorf("requires the permission %s", permission)
+		}
+	}
+	return nil
+}
+
+// SetAuthorizer replaces the RoleAuthorizer checking routes' RequireRoles and
+// RequirePermissions, e.g. with one consulting a policy service
+func (e *Engine) SetAuthorizer(authorizer Authorizer) *Engine {
+	if authorizer == nil {
+		panic("authorizer must not be nil")
+	}
+	e.authorizer.Store(&authorizer)
+	return e
+}
+
+// currentAuthorizer returns the authorizer set with SetAuthorizer, or RoleAuthorizer
+func (e *Engine) currentAuthorizer() Authorizer {
+	if authorizer := e.authorizer.Load(); authorizer != nil {
+		return *authorizer
+	}
+	return RoleAuthorizer{}
+}
+
+// RequireRoles restricts the route to principals with one of the roles. Requirements are
+// checked after the route's middleware, which must authenticate the caller with SetPrincipal.
+func (rb *RouteBuilder) RequireRoles(roles ...string) *RouteBuilder {
+	return rb.WithMetadata(RolesMetadata, appendRequirement(rb.metadata[RolesMetadata], roles))
+}
+
+// RequirePermissions restricts the route to principals with all of the permissions
+func (rb *RouteBuilder) RequirePermissions(permissions ...string) *RouteBuilder {
+	return rb.WithMetadata(PermissionsMetadata, appendRequirement(rb.metadata[PermissionsMetadata], permissions))
+}
+
+// RequireRoles restricts all resource routes to principals with one of the roles
+func (rb *ResourceBuilder) RequireRoles(roles ...string) *ResourceBuilder {
+	return rb.WithMetadata(RolesMetadata, appendRequirement(rb.modelInfo.Metadata[RolesMetadata], roles))
+}
+
+// RequirePermissions restricts all resource routes to principals with all of the permissions
+func (rb *ResourceBuilder) RequirePermissions(permissions ...string) *ResourceBuilder {
+	return rb.WithMetadata(PermissionsMetadata, appendRequirement(rb.modelInfo.Metadata[PermissionsMetadata], permissions))
+}
+
+// appendRequirement adds names to a requirement already stored in metadata
+func appendRequirement(existing interface{}, names []string) []string {
+	current, _ := existing.([]string)
+	return append(slices.Clip(current), names...)
+}
+
+// routeRequirements reads a route's requirements from its metadata
+func routeRequirements(metadata map[string]interface{}) Requirements {
+	roles, _ := metadata[RolesMetadata].([]string)
+	permissions, _ := metadata[PermissionsMetadata].([]string)
+	return Requirements{Roles: roles, Permissions: permissions}
+}
+
+// empty reports whether nothing is required
+func (r Requirements) empty() bool {
+	return len(r.Roles) == 0 && len(r.Permissions) == 0
+}
+
+// authorizationMiddleware answers 401 when no principal was authenticated and 403 when the
+// authorizer refuses it, as application/problem+json
+func (e *Engine) authorizationMiddleware(required Requirements) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, authenticated := GetPrincipal(c)
+		if !authenticated {
+			writeProblem(c, http.StatusUnauthorized, "authentication required")
+			return
+		}
+		if err := e.currentAuthorizer().Authorize(c, principal, required); err != nil {
+			LoggerFor(c).Info("request forbidden", "principal", principal.PrincipalID(), "error", err)
+			writeProblem(c, http.StatusForbidden, err.Error())
+			return
+		}
+		c.Next()
+	}
+}
+
+// writeProblem aborts with an RFC 9457 problem details response
+func writeProblem(c *gin.Context, status int, detail string) {
+	WriteProblem(c, status, detail)
+}
+
+// WriteProblem aborts with an RFC 9457 application/problem+json response, as SuperGin's
+// own authentication and authorization middleware do
+func WriteProblem(c *gin.Context, status int, detail string) {
+	c.Header("Content-Type", "application/problem+json")
+	c.AbortWithStatusJSON(status, problemDetails(status, detail))
+}
+
+// problemDetails is the body of an RFC 9457 problem details response
+func problemDetails(status int, detail string) gin.H {
+	return gin.H{
+		"type":   "about:blank",
+		"title":  http.StatusText(status),
+		"status": status,
+		"detail": detail,
+	}
+}
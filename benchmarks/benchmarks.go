@@ -0,0 +1,261 @@
+// Package benchmarks provides reproducible load scenarios for SuperGin and helpers to
+// record and compare runs, so performance work can be measured rather than guessed.
+//
+// Scenarios run in-process against fixed inputs with logging discarded and gin in release
+// mode. Run them with Run, or from go test:
+//
+//	func BenchmarkSuperGin(b *testing.B) {
+//		for _, scenario := range benchmarks.Scenarios() {
+//			b.Run(scenario.Name, scenario.Benchmark)
+//		}
+//	}
+//
+// The supergin command wraps both: "supergin bench -out new.json" records a run and
+// "supergin bench compare old.json new.json" reports the change per scenario.
+package benchmarks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"runtime"
+	"sort"
+	"testing"
+	"text/tabwriter"
+	"time"
+)
+
+// Operation performs one measured iteration of a scenario
+type Operation func() error
+
+// Scenario is a reproducible load scenario
+type Scenario struct {
+	Name        string
+	Description string
+	// Setup builds the scenario's engine and fixtures, returning the operation to measure
+	// and a cleanup releasing what Setup started
+	Setup func() (Operation, func(), error)
+}
+
+// RunOptions configures Run
+type RunOptions struct {
+	// Filter selects scenarios by name; nil runs all
+	Filter *regexp.Regexp
+	// Count repeats each scenario; zero means 1. Compare uses the median of repeats.
+	Count int
+	// Progress, when set, receives a line per finished measurement
+	Progress io.Writer
+}
+
+// Result is one measurement of a scenario
+type Result struct {
+	Scenario    string  `json:"scenario"`
+	Iterations  int     `json:"iterations"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+}
+
+// Report is a recorded run with the environment it ran in
+type Report struct {
+	GoVersion  string    `json:"go_version"`
+	GOOS       string    `json:"goos"`
+	GOARCH     string    `json:"goarch"`
+	GOMAXPROCS int       `json:"gomaxprocs"`
+	StartedAt  time.Time `json:"started_at"`
+	Results    []Result  `json:"results"`
+}
+
+// Benchmark runs the scenario as a Go benchmark
+func (s Scenario) Benchmark(b *testing.B) {
+	op, cleanup, err := s.Setup()
+	if err != nil {
+		b.Fatalf("%s setup failed: %v", s.Name, err)
+	}
+	b.Cleanup(cleanup)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := op(); err != nil {
+			b.Fatalf("%s failed: %v", s.Name, err)
+		}
+	}
+}
+
+// Run measures the selected scenarios, setting each up once per repeat
+func Run(scenarios []Scenario, opts ...RunOptions) (*Report, error) {
+	options := RunOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Count <= 0 {
+		options.Count = 1
+	}
+
+	report := &Report{
+		GoVersion:  runtime.Version(),
+		GOOS:       runtime.GOOS,
+		GOARCH:     runtime.GOARCH,
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		StartedAt:  time.Now().UTC(),
+	}
+	for _, scenario := range scenarios {
+		if options.Filter != nil && !options.Filter.MatchString(scenario.Name) {
+			continue
+		}
+		for i := 0; i < options.Count; i++ {
+			result, err := measure(scenario)
+			if err != nil {
+				return report, err
+			}
+			report.Results = append(report.Results, result)
+			if options.Progress != nil {
+				fmt.Fprintf(options.Progress, "%-24s %10d %14.0f ns/op %8d B/op %6d allocs/op\n",
+					result.Scenario, result.Iterations, result.NsPerOp, result.BytesPerOp, result.AllocsPerOp)
+			}
+		}
+	}
+	return report, nil
+}
+
+// measure sets a scenario up and benchmarks its operation
+func measure(scenario Scenario) (Result, error) {
+	op, cleanup, err := scenario.Setup()
+	if err != nil {
+		return Result{}, fmt.Errorf("%s setup failed: %w", scenario.Name, err)
+	}
+	defer cleanup()
+
+	var opErr error
+	result := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N && opErr == nil; i++ {
+			opErr = op()
+		}
+	})
+	if opErr != nil {
+		return Result{}, fmt.Errorf("%s failed: %w", scenario.Name, opErr)
+	}
+
+	return Result{
+		Scenario:    scenario.Name,
+		Iterations:  result.N,
+		NsPerOp:     float64(result.T.Nanoseconds()) / float64(max(result.N, 1)),
+		AllocsPerOp: result.AllocsPerOp(),
+		BytesPerOp:  result.AllocedBytesPerOp(),
+	}, nil
+}
+
+// WriteReport saves a report as JSON
+func WriteReport(path string, report *Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ReadReport loads a report saved by WriteReport
+func ReadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid benchmark report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// Comparison is the change of a scenario between two runs, using the median of repeats
+type Comparison struct {
+	Scenario   string  `json:"scenario"`
+	BaseNsOp   float64 `json:"base_ns_per_op"`
+	HeadNsOp   float64 `json:"head_ns_per_op"`
+	BaseAllocs int64   `json:"base_allocs_per_op"`
+	HeadAllocs int64   `json:"head_allocs_per_op"`
+	BaseBytes  int64   `json:"base_bytes_per_op"`
+	HeadBytes  int64   `json:"head_bytes_per_op"`
+	// Delta is the relative change in ns/op, e.g. -0.12 for 12% faster
+	Delta float64 `json:"delta"`
+}
+
+// Compare matches the scenarios of two runs; scenarios missing from either are skipped
+func Compare(base, head *Report) []Comparison {
+	baseResults, headResults := medians(base), medians(head)
+
+	names := make([]string, 0, len(headResults))
+	for name := range headResults {
+		if _, exists := baseResults[name]; exists {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	comparisons := make([]Comparison, 0, len(names))
+	for _, name := range names {
+		b, h := baseResults[name], headResults[name]
+		comparison := Comparison{
+			Scenario:   name,
+			BaseNsOp:   b.NsPerOp,
+			HeadNsOp:   h.NsPerOp,
+			BaseAllocs: b.AllocsPerOp,
+			HeadAllocs: h.AllocsPerOp,
+			BaseBytes:  b.BytesPerOp,
+			HeadBytes:  h.BytesPerOp,
+		}
+		if b.NsPerOp > 0 {
+			comparison.Delta = (h.NsPerOp - b.NsPerOp) / b.NsPerOp
+		}
+		comparisons = append(comparisons, comparison)
+	}
+	return comparisons
+}
+
+// medians reduces repeated results to the median ns/op per scenario
+func medians(report *Report) map[string]Result {
+	byScenario := make(map[string][]Result)
+	for _, result := range report.Results {
+		byScenario[result.Scenario] = append(byScenario[result.Scenario], result)
+	}
+
+	medians := make(map[string]Result, len(byScenario))
+	for name, results := range byScenario {
+		sort.Slice(results, func(i, j int) bool { return results[i].NsPerOp < results[j].NsPerOp })
+		medians[name] = results[len(results)/2]
+	}
+	return medians
+}
+
+// WriteComparison renders comparisons as a table, marking changes beyond threshold (e.g.
+// 0.05 for 5%) as regressions or improvements
+func WriteComparison(w io.Writer, comparisons []Comparison, threshold float64) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "scenario\tbase ns/op\thead ns/op\tdelta\tbase allocs\thead allocs\t\t")
+	for _, c := range comparisons {
+		verdict := ""
+		switch {
+		case c.Delta > threshold:
+			verdict = "regression"
+		case c.Delta < -threshold:
+			verdict = "improvement"
+		}
+		fmt.Fprintf(tw, "%s\t%.0f\t%.0f\t%+.1f%%\t%d\t%d\t%s\t\n",
+			c.Scenario, c.BaseNsOp, c.HeadNsOp, c.Delta*100, c.BaseAllocs, c.HeadAllocs, verdict)
+	}
+	return tw.Flush()
+}
+
+// Regressions returns the comparisons slower than threshold, e.g. 0.05 for 5%
+func Regressions(comparisons []Comparison, threshold float64) []Comparison {
+	var regressions []Comparison
+	for _, c := range comparisons {
+		if c.Delta > threshold {
+			regressions = append(regressions, c)
+		}
+	}
+	return regressions
+}
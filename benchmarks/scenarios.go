@@ -0,0 +1,361 @@
+package benchmarks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivikasavnish/supergin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// FanoutConnections is how many connections the WebSocket fanout scenario broadcasts to
+const FanoutConnections = 100
+
+// Scenarios returns the built-in scenarios: a plain route, a validated route, a resource
+// CRUD cycle, a WebSocket broadcast fanout and a gRPC bridge call with conversion
+func Scenarios() []Scenario {
+	return []Scenario{
+		{
+			Name:        "simple_route",
+			Description: "GET returning a small JSON body through the default middleware",
+			Setup:       setupSimpleRoute,
+		},
+		{
+			Name:        "validated_route",
+			Description: "POST binding and validating a JSON input and rendering a typed output",
+			Setup:       setupValidatedRoute,
+		},
+		{
+			Name:        "resource_crud",
+			Description: "Create, read, update, list and delete through a generated CRUD resource",
+			Setup:       setupResourceCRUD,
+		},
+		{
+			Name:        "ws_broadcast_fanout",
+			Description: fmt.Sprintf("Broadcast to %d in-memory WebSocket connections until all receive it", FanoutConnections),
+			Setup:       setupBroadcastFanout,
+		},
+		{
+			Name:        "grpc_bridge_call",
+			Description: "HTTP request bridged to an in-process gRPC backend with JSON/proto conversion",
+			Setup:       setupBridgeCall,
+		},
+	}
+}
+
+// newEngine returns an engine with input validation on and logging discarded
+func newEngine() *supergin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	return supergin.New(supergin.Config{
+		ValidateInput: true,
+		Logger:        supergin.NewSlogLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	})
+}
+
+// shutdown returns a cleanup shutting the engine down
+func shutdown(app *supergin.Engine) func() {
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		app.Shutdown(ctx)
+	}
+}
+
+// serve sends a request through the engine and checks the response status
+func serve(app *supergin.Engine, method, path string, body []byte, status int) (*httptest.ResponseRecorder, error) {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != status {
+		return w, fmt.Errorf("%s %s: expected status %d, got %d: %s", method, path, status, w.Code, w.Body.String())
+	}
+	return w, nil
+}
+
+func setupSimpleRoute() (Operation, func(), error) {
+	app := newEngine()
+	app.Named("ping").
+		GET("/ping").
+		Handler(func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "pong"})
+		})
+
+	return func() error {
+		_, err := serve(app, http.MethodGet, "/ping", nil, http.StatusOK)
+		return err
+	}, shutdown(app), nil
+}
+
+// CreateUser is the input of the validated and CRUD scenarios
+type CreateUser struct {
+	Name  string   `json:"name" validate:"required,min=2,max=50"`
+	Email string   `json:"email" validate:"required,email"`
+	Age   int      `json:"age" validate:"gte=0,lte=130"`
+	Tags  []string `json:"tags" validate:"max=5,dive,required"`
+}
+
+// User is the output of the validated and CRUD scenarios
+type User struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Email string   `json:"email"`
+	Age   int      `json:"age"`
+	Tags  []string `json:"tags"`
+}
+
+// UserSearch is the search criteria of the CRUD scenario
+type UserSearch struct {
+	Name string `form:"name"`
+}
+
+var createUserBody = []byte(`{"name":"Ada Lovelace","email":"ada@example.com","age":36,"tags":["math","engines"]}`)
+
+func setupValidatedRoute() (Operation, func(), error) {
+	app := newEngine()
+	app.Named("create_user").
+		POST("/users").
+		WithIO(CreateUser{}, User{}).
+		Handler(func(c *gin.Context) {
+			input, _ := supergin.GetValidatedInput(c)
+			in := input.(*CreateUser)
+			c.JSON(http.StatusCreated, User{ID: 1, Name: in.Name, Email: in.Email, Age: in.Age, Tags: in.Tags})
+		})
+
+	return func() error {
+		_, err := serve(app, http.MethodPost, "/users", createUserBody, http.StatusCreated)
+		return err
+	}, shutdown(app), nil
+}
+
+// userStore is an in-memory CRUD service
+type userStore struct {
+	users  map[int]User
+	nextID int
+	mutex  sync.Mutex
+}
+
+func (s *userStore) Create(ctx context.Context, in *CreateUser) (*User, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.nextID++
+	user := User{ID: s.nextID, Name: in.Name, Email: in.Email, Age: in.Age, Tags: in.Tags}
+	s.users[user.ID] = user
+	return &user, nil
+}
+
+func (s *userStore) Get(ctx context.Context, id int) (*User, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	user, exists := s.users[id]
+	if !exists {
+		return nil, supergin.ErrNotFound
+	}
+	return &user, nil
+}
+
+func (s *userStore) Update(ctx context.Context, id int, in *CreateUser) (*User, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.users[id]; !exists {
+		return nil, supergin.ErrNotFound
+	}
+	user := User{ID: id, Name: in.Name, Email: in.Email, Age: in.Age, Tags: in.Tags}
+	s.users[id] = user
+	return &user, nil
+}
+
+func (s *userStore) Delete(ctx context.Context, id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.users[id]; !exists {
+		return supergin.ErrNotFound
+	}
+	delete(s.users, id)
+	return nil
+}
+
+func (s *userStore) List(ctx context.Context) ([]User, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	users := make([]User, 0, len(s.users))
+	for _, user := range s.users {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (s *userStore) Search(ctx context.Context, criteria *UserSearch) ([]User, error) {
+	return s.List(ctx)
+}
+
+func setupResourceCRUD() (Operation, func(), error) {
+	app := newEngine()
+	store := &userStore{users: make(map[int]User)}
+	app.Resource("User", supergin.CRUD[CreateUser, User, UserSearch, int](store)).Build()
+
+	// Keep a fixed population so listing costs the same on every iteration
+	for i := 0; i < 10; i++ {
+		store.Create(context.Background(), &CreateUser{Name: "Grace Hopper", Email: "grace@example.com", Age: 85})
+	}
+	updateBody := []byte(`{"name":"Ada King","email":"ada@example.com","age":37,"tags":["math"]}`)
+
+	return func() error {
+		store.mutex.Lock()
+		path := "/users/" + strconv.Itoa(store.nextID+1)
+		store.mutex.Unlock()
+
+		steps := []struct {
+			method string
+			path   string
+			body   []byte
+			status int
+		}{
+			{http.MethodPost, "/users", createUserBody, http.StatusCreated},
+			{http.MethodGet, path, nil, http.StatusOK},
+			{http.MethodPut, path, updateBody, http.StatusOK},
+			{http.MethodGet, "/users", nil, http.StatusOK},
+			{http.MethodDelete, path, nil, http.StatusNoContent},
+		}
+		for _, step := range steps {
+			if _, err := serve(app, step.method, step.path, step.body, step.status); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, shutdown(app), nil
+}
+
+// memoryTransport upgrades to in-memory connections that count delivered messages
+type memoryTransport struct {
+	delivered *sync.WaitGroup
+}
+
+func (t *memoryTransport) Upgrade(w http.ResponseWriter, r *http.Request) (supergin.WebSocketConn, error) {
+	return &memoryConn{delivered: t.delivered, closed: make(chan struct{})}, nil
+}
+
+// memoryConn is a WebSocket connection whose peer never sends and receives instantly
+type memoryConn struct {
+	delivered *sync.WaitGroup
+	closed    chan struct{}
+	once      sync.Once
+}
+
+func (c *memoryConn) ReadMessage() ([]byte, error) {
+	<-c.closed
+	return nil, &supergin.WebSocketCloseError{Code: supergin.CloseGoingAway}
+}
+
+func (c *memoryConn) WriteMessage(data []byte) error {
+	// Queued messages are written together, separated by newlines
+	for i := 0; i <= bytes.Count(data, []byte{'\n'}); i++ {
+		c.delivered.Done()
+	}
+	return nil
+}
+
+func (c *memoryConn) WritePing() error                    { return nil }
+func (c *memoryConn) WriteClose(code int, _ string) error { return c.Close() }
+func (c *memoryConn) SetReadLimit(int64)                  {}
+func (c *memoryConn) SetReadDeadline(time.Time) error     { return nil }
+func (c *memoryConn) SetWriteDeadline(time.Time) error    { return nil }
+func (c *memoryConn) SetPongHandler(func())               {}
+
+func (c *memoryConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+
+func setupBroadcastFanout() (Operation, func(), error) {
+	app := newEngine()
+	delivered := &sync.WaitGroup{}
+	hub := app.WebSocket("fanout", "/ws", &supergin.DefaultWebSocketHandler{},
+		supergin.WithTransport(&memoryTransport{delivered: delivered}))
+
+	for i := 0; i < FanoutConnections; i++ {
+		if _, err := serve(app, http.MethodGet, "/ws", nil, http.StatusOK); err != nil {
+			return nil, nil, err
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(hub.GetConnections()) < FanoutConnections {
+		if time.Now().After(deadline) {
+			return nil, nil, fmt.Errorf("only %d of %d connections registered", len(hub.GetConnections()), FanoutConnections)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	payload := map[string]interface{}{"symbol": "SGIN", "price": 42.5, "volume": 1200}
+	return func() error {
+		delivered.Add(FanoutConnections)
+		if err := hub.Broadcast("tick", payload); err != nil {
+			return err
+		}
+		delivered.Wait()
+		return nil
+	}, shutdown(app), nil
+}
+
+// HealthCheck is the HTTP side of the bridge scenario
+type HealthCheck struct {
+	Service string `json:"service"`
+}
+
+// HealthStatus is the HTTP response of the bridge scenario
+type HealthStatus struct {
+	Status string `json:"status"`
+}
+
+func setupBridgeCall() (Operation, func(), error) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener)
+
+	app := newEngine()
+	bridge := app.GrpcBridge()
+	err := bridge.RegisterGrpcService("health", "passthrough:///bufconn", "grpc.health.v1.Health", supergin.GrpcServiceOptions{
+		DialOptions: []grpc.DialOption{
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+		},
+	})
+	if err != nil {
+		server.Stop()
+		return nil, nil, err
+	}
+	bridge.RegisterGrpcMethod("health", "Check", HealthCheck{}, HealthStatus{},
+		&grpc_health_v1.HealthCheckRequest{}, &grpc_health_v1.HealthCheckResponse{})
+
+	app.Named("health_check").
+		POST("/health").
+		WithGrpcBridge("health", "Check").
+		Handler(func(c *gin.Context) {})
+
+	body := []byte(`{"service":""}`)
+	cleanup := shutdown(app)
+	return func() error {
+			_, err := serve(app, http.MethodPost, "/health", body, http.StatusOK)
+			return err
+		}, func() {
+			cleanup()
+			server.Stop()
+		}, nil
+}
@@ -0,0 +1,197 @@
+package supergin
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// defaultMultipartMemory matches gin's in-memory limit for parsed multipart forms
+const defaultMultipartMemory = 32 << 20
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	bindUnmarshalerType = reflect.TypeOf((*binding.BindUnmarshaler)(nil)).Elem()
+)
+
+// bindRequest binds request data based on content type and method. Fields tagged `uri`
+// are bound from path parameters and fields tagged `header` from request headers; both
+// take precedence over the body. Besides gin's form types (including time.Time with
+// time_format and time.Duration), query, form, path and header values bind into any
+// encoding.TextUnmarshaler such as uuid.UUID. Other bodies use the binder registered for
+// their Content-Type (see RegisterBinder), defaulting to JSON.
+func bindRequest(c *gin.Context, target interface{}) error {
+	// Parameters are bound first for binders that validate, and again after the query or
+	// body so neither can override them
+	if err := bindParams(c, target); err != nil {
+		return err
+	}
+
+	contentType := c.ContentType()
+	method := c.Request.Method
+
+	if method == "GET" || method == "DELETE" {
+		// For GET/DELETE, bind query parameters
+		if err := mapValues(target, c.Request.URL.Query(), "form"); err != nil {
+			return err
+		}
+		if err := bindParams(c, target); err != nil {
+			return err
+		}
+		return validateBinding(target)
+	} else if contentType == "application/x-www-form-urlencoded" || contentType == "multipart/form-data" {
+		// For form data; ParseMultipartForm drops ParseForm's errors on urlencoded bodies,
+		// such as a body cut off by the body limit
+		parse := c.Request.ParseForm
+		if contentType == "multipart/form-data" {
+			parse = func() error { return c.Request.ParseMultipartForm(multipartMemory(c)) }
+		}
+		if err := parse(); err != nil {
+			return err
+		}
+		if err := mapValues(target, c.Request.Form, "form"); err != nil {
+			return err
+		}
+		if err := bindParams(c, target); err != nil {
+			return err
+		}
+		return validateBinding(target)
+	}
+	binder, exists := lookupBinder(contentType)
+	if !exists {
+		// Default to JSON binding
+		binder = binding.JSON
+	}
+	if err := c.ShouldBindWith(target, binder); err != nil {
+		return err
+	}
+	return bindParams(c, target)
+}
+
+// bindParams maps path parameters onto `uri` fields and headers onto `header` fields
+func bindParams(c *gin.Context, target interface{}) error {
+	t := reflect.TypeOf(target)
+	if hasTag(t, "uri") {
+		params := make(map[string][]string, len(c.Params))
+		for _, param := range c.Params {
+			params[param.Key] = []string{param.Value}
+		}
+		if err := mapValues(target, params, "uri"); err != nil {
+			return err
+		}
+	}
+
+	if headers := taggedFields(t, "header"); len(headers) > 0 {
+		values := make(map[string][]string, len(headers))
+		for name := range headers {
+			if value := c.Request.Header.Values(name); len(value) > 0 {
+				values[name] = value
+			}
+		}
+		if err := mapValues(target, values, "header"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapValues maps string values onto tagged struct fields, decoding text types itself
+// and leaving the rest to gin's form mapping
+func mapValues(target interface{}, values map[string][]string, tag string) error {
+	rest, err := mapTextValues(reflect.ValueOf(target), values, tag)
+	if err != nil {
+		return err
+	}
+	return binding.MapFormWithTag(target, rest, tag)
+}
+
+// validateBinding runs gin's `binding` tag validation, as gin's own binders do
+func validateBinding(target interface{}) error {
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(target)
+}
+
+// mapTextValues sets fields implementing encoding.TextUnmarshaler from values and returns
+// the values without their keys, since gin's mapping would treat e.g. uuid.UUID as an array
+func mapTextValues(v reflect.Value, values map[string][]string, tag string) (map[string][]string, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return values, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return values, nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		fieldValue := v.Field(i)
+		if field.Anonymous && name == "" {
+			var err error
+			if values, err = mapTextValues(fieldValue, values, tag); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !isTextBindable(field.Type) {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		raw, ok := values[name]
+		if !ok || len(raw) == 0 {
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				fieldValue.Set(reflect.New(field.Type.Elem()))
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if err := fieldValue.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw[0])); err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s: %v", raw[0], name, err)
+		}
+
+		// Copy before removing so the request's own values stay intact
+		rest := make(map[string][]string, len(values))
+		for key, value := range values {
+			if key != name {
+				rest[key] = value
+			}
+		}
+		values = rest
+	}
+	return values, nil
+}
+
+// isTextBindable reports whether mapTextValues handles a field type; time.Time and
+// gin's BindUnmarshaler types are left to gin
+func isTextBindable(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return false
+	}
+	ptr := reflect.PointerTo(t)
+	return ptr.Implements(textUnmarshalerType) && !ptr.Implements(bindUnmarshalerType)
+}
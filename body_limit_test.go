@@ -0,0 +1,78 @@
+package supergin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// countingReader records how much of a request body was read
+type countingReader struct {
+	reader io.Reader
+	read   int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += n
+	return n, err
+}
+
+func TestBodyLimitRunsBeforePolicyAuth(t *testing.T) {
+	const limit = 1024
+	secret := []byte("billing-secret")
+	app := newTestEngine(Config{MaxBodyBytes: limit})
+	app.RegisterAuthScheme("signed", APIKeyAuth(APIKeyOptions{
+		Store:     StaticAPIKeys{"billing-key": {Client: "billing", Secret: secret}},
+		Signature: &SignatureOptions{},
+	}))
+	app.Named("create_invoice").POST("/invoices").Handler(func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	if err := app.ApplyPolicies(PolicyFile{Policies: []Policy{{Routes: []string{"create_invoice"}, Auth: "signed"}}}); err != nil {
+		t.Fatalf("ApplyPolicies: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		size    int
+		chunked bool
+		want    int
+		maxRead int
+	}{
+		{"within the limit", limit, false, http.StatusCreated, limit},
+		{"declared too large", 4 * limit, false, http.StatusRequestEntityTooLarge, 0},
+		{"chunked too large", 4 * limit, true, http.StatusRequestEntityTooLarge, limit + 512},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := bytes.Repeat([]byte("x"), tt.size)
+			body := &countingReader{reader: bytes.NewReader(payload)}
+			req := httptest.NewRequest(http.MethodPost, "/invoices", body)
+			req.ContentLength = int64(tt.size)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			req.Header.Set(DefaultAPIKeyHeader, "billing-key")
+			req.Header.Set(DefaultTimestampHeader, timestamp)
+			req.Header.Set(DefaultSignatureHeader, hex.EncodeToString(SignRequest(secret, http.MethodPost, "/invoices", timestamp, payload)))
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if body.read > tt.maxRead {
+				t.Errorf("%d body bytes were read, want at most %d", body.read, tt.maxRead)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package supergin
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CircuitsPath is where EnableCircuitBreakerEndpoint mounts the circuit breaker status
+const CircuitsPath = "/_admin/circuits"
+
+// Circuit breaker defaults applied when CircuitBreakerOptions leaves them unset
+const (
+	DefaultCircuitFailureThreshold = 5
+	DefaultCircuitOpenTimeout      = 30 * time.Second
+)
+
+// CircuitState is the state of a circuit breaker
+type CircuitState string
+
+const (
+	// CircuitClosed lets calls through and counts consecutive failures
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects calls until its open timeout elapses
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a limited number of probe calls through; they close the circuit
+	// if they all succeed and reopen it on the first failure
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreakerOptions configures a circuit breaker
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit;
+	// defaults to 5
+	FailureThreshold int
+	// OpenTimeout is how long the circuit rejects calls before probing; defaults to 30s
+	OpenTimeout time.Duration
+	// HalfOpenProbes is the number of probe calls let through, and required to succeed,
+	// before the circuit closes again; defaults to 1
+	HalfOpenProbes int
+}
+
+// CircuitBreakerStatus is a snapshot of a circuit breaker
+type CircuitBreakerStatus struct {
+	Name                string       `json:"name"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+	// Trips counts how often the circuit opened; Rejected counts calls refused while open
+	Trips     uint64 `json:"trips"`
+	Rejected  uint64 `json:"rejected"`
+	Successes uint64 `json:"successes"`
+	Failures  uint64 `json:"failures"`
+}
+
+// CircuitBreaker stops calling a failing backend for a while, failing calls fast with
+// ErrBackendUnavailable so routes degrade through their fallbacks instead of waiting on
+// timeouts
+type CircuitBreaker struct {
+	name    string
+	options CircuitBreakerOptions
+
+	mutex    sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+	probed   int
+	stats    CircuitBreakerStatus
+}
+
+// newCircuitBreaker creates a closed circuit breaker, filling option defaults
+func newCircuitBreaker(name string, options CircuitBreakerOptions) *CircuitBreaker {
+	if options.FailureThreshold <= 0 {
+		options.FailureThreshold = DefaultCircuitFailureThreshold
+	}
+	if options.OpenTimeout <= 0 {
+		options.OpenTimeout = DefaultCircuitOpenTimeout
+	}
+	if options.HalfOpenProbes <= 0 {
+		options.HalfOpenProbes = 1
+	}
+	return &CircuitBreaker{name: name, options: options, state: CircuitClosed}
+}
+
+// CircuitBreaker returns the named circuit breaker, creating it with the given options on
+// first use. Bridged gRPC services use "grpc:<service>" and outbound HTTP calls
+// "http:<host>".
+func (e *Engine) CircuitBreaker(name string, opts ...CircuitBreakerOptions) *CircuitBreaker {
+	options := CircuitBreakerOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	e.circuitsMux.Lock()
+	defer e.circuitsMux.Unlock()
+	if breaker, exists := e.circuits[name]; exists {
+		return breaker
+	}
+	if e.circuits == nil {
+		e.circuits = make(map[string]*CircuitBreaker)
+	}
+	breaker := newCircuitBreaker(name, options)
+	e.circuits[name] = breaker
+	return breaker
+}
+
+// CircuitBreakers returns the status of every circuit breaker, by name
+func (e *Engine) CircuitBreakers() []CircuitBreakerStatus {
+	e.circuitsMux.Lock()
+	breakers := make([]*CircuitBreaker, 0, len(e.circuits))
+	for _, breaker := range e.circuits {
+		breakers = append(breakers, breaker)
+	}
+	e.circuitsMux.Unlock()
+
+	statuses := make([]CircuitBreakerStatus, 0, len(breakers))
+	for _, breaker := range breakers {
+		statuses = append(statuses, breaker.Status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// Allow reports whether a call may go ahead, returning an ErrBackendUnavailable error
+// while the circuit is open. Every allowed call must be followed by Record.
+func (cb *CircuitBreaker) Allow() error {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.state == CircuitOpen && time.Since(cb.openedAt) >= cb.options.OpenTimeout {
+		cb.state, cb.probes, cb.probed = CircuitHalfOpen, 0, 0
+	}
+	switch cb.state {
+	case CircuitOpen:
+		cb.stats.Rejected++
+		return NewSuperGinError(ErrBackendUnavailable, "circuit breaker %s is open", cb.name)
+	case CircuitHalfOpen:
+		if cb.probes >= cb.options.HalfOpenProbes {
+			cb.stats.Rejected++
+			return NewSuperGinError(ErrBackendUnavailable, "circuit breaker %s is half-open and probing", cb.name)
+		}
+		cb.probes++
+	}
+	return nil
+}
+
+// Record reports the outcome of an allowed call
+func (cb *CircuitBreaker) Record(success bool) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if success {
+		cb.stats.Successes++
+		cb.failures = 0
+		if cb.state == CircuitHalfOpen {
+			cb.probed++
+			if cb.probed >= cb.options.HalfOpenProbes {
+				cb.state = CircuitClosed
+			}
+		}
+		return
+	}
+
+	cb.stats.Failures++
+	cb.failures++
+	if cb.state == CircuitHalfOpen || (cb.state == CircuitClosed && cb.failures >= cb.options.FailureThreshold) {
+		cb.state = CircuitOpen
+		cb.openedAt = time.Now()
+		cb.stats.Trips++
+	}
+}
+
+// Reset closes the circuit, e.g. after the backend was fixed
+func (cb *CircuitBreaker) Reset() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.state, cb.failures = CircuitClosed, 0
+}
+
+// Status returns a snapshot of the circuit breaker
+func (cb *CircuitBreaker) Status() CircuitBreakerStatus {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	status := cb.stats
+	status.Name = cb.name
+	status.State = cb.state
+	status.ConsecutiveFailures = cb.failures
+	if cb.state != CircuitClosed {
+		openedAt := cb.openedAt
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+// writeCircuitMetrics writes circuit breaker state and counters in the Prometheus text
+// exposition format
+func (e *Engine) writeCircuitMetrics(w io.Writer) error {
+	statuses := e.CircuitBreakers()
+	if len(statuses) == 0 {
+		return nil
+	}
+	states := map[CircuitState]int{CircuitClosed: 0, CircuitHalfOpen: 1, CircuitOpen: 2}
+
+	families := []struct {
+		name, kind, help string
+		value            func(CircuitBreakerStatus) uint64
+	}{
+		{"supergin_circuit_state", "gauge", "Circuit breaker state: 0 closed, 1 half-open, 2 open",
+			func(s CircuitBreakerStatus) uint64 { return uint64(states[s.State]) }},
+		{"supergin_circuit_trips_total", "counter", "Times the circuit opened",
+			func(s CircuitBreakerStatus) uint64 { return s.Trips }},
+		{"supergin_circuit_rejected_total", "counter", "Calls rejected while the circuit was open",
+			func(s CircuitBreakerStatus) uint64 { return s.Rejected }},
+		{"supergin_circuit_failures_total", "counter", "Calls that failed",
+			func(s CircuitBreakerStatus) uint64 { return s.Failures }},
+	}
+
+	var b strings.Builder
+	for _, family := range families {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", family.name, family.help, family.name, family.kind)
+		for _, status := range statuses {
+			fmt.Fprintf(&b, "%s{circuit=%s} %d\n", family.name, strconv.Quote(status.Name), family.value(status))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// EnableCircuitBreakerEndpoint registers GET /_admin/circuits, listing every circuit
+// breaker, and POST /_admin/circuits/:name/reset, closing one, behind the given
+// authentication middleware
+func (e *Engine) EnableCircuitBreakerEndpoint(auth gin.HandlerFunc, middleware ...gin.HandlerFunc) *Engine {
+	if auth == nil {
+		panic("circuit breaker endpoint requires an authentication middleware")
+	}
+	handlers := append([]gin.HandlerFunc{auth}, middleware...)
+
+	e.Named("admin_circuits").
+		GET(CircuitsPath).
+		WithDescription("Circuit breaker state of bridged gRPC services and outbound HTTP hosts").
+		WithTags("admin").
+		WithMiddleware(handlers...).
+		Handler(func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"circuits": e.CircuitBreakers()})
+		})
+
+	e.Named("admin_circuit_reset").
+		POST(CircuitsPath + "/:name/reset").
+		WithDescription("Close a circuit breaker").
+		WithTags("admin").
+		WithMiddleware(handlers...).
+		Handler(func(c *gin.Context) {
+			e.circuitsMux.Lock()
+			breaker, exists := e.circuits[c.Param("name")]
+			e.circuitsMux.Unlock()
+			if !exists {
+				c.JSON(http.StatusNotFound, gin.H{"error": "circuit breaker not found"})
+				return
+			}
+			breaker.Reset()
+			c.JSON(http.StatusOK, breaker.Status())
+		})
+
+	return e
+}
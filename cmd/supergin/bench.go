@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/ivikasavnish/supergin/benchmarks"
+)
+
+func runBench(args []string) error {
+	if len(args) > 0 && args[0] == "compare" {
+		return runBenchCompare(args[1:])
+	}
+
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	run := fs.String("run", "", "regular expression selecting scenarios")
+	count := fs.Int("count", 1, "repeats per scenario")
+	out := fs.String("out", "", "file to write the JSON report to")
+	list := fs.Bool("list", false, "list scenarios and exit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *list {
+		for _, scenario := range benchmarks.Scenarios() {
+			fmt.Printf("%-24s %s\n", scenario.Name, scenario.Description)
+		}
+		return nil
+	}
+
+	options := benchmarks.RunOptions{Count: *count, Progress: os.Stdout}
+	if *run != "" {
+		filter, err := regexp.Compile(*run)
+		if err != nil {
+			return fmt.Errorf("invalid -run: %w", err)
+		}
+		options.Filter = filter
+	}
+	report, err := benchmarks.Run(benchmarks.Scenarios(), options)
+	if err != nil {
+		return err
+	}
+	if *out != "" {
+		return benchmarks.WriteReport(*out, report)
+	}
+	return nil
+}
+
+func runBenchCompare(args []string) error {
+	fs := flag.NewFlagSet("bench compare", flag.ExitOnError)
+	threshold := fs.Float64("threshold", 5, "percent change reported as a regression or improvement")
+	failOnRegression := fs.Bool("fail", false, "exit with an error when a scenario regressed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: supergin bench compare [-threshold 5] [-fail] base.json head.json")
+	}
+
+	base, err := benchmarks.ReadReport(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	head, err := benchmarks.ReadReport(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	comparisons := benchmarks.Compare(base, head)
+	if err := benchmarks.WriteComparison(os.Stdout, comparisons, *threshold/100); err != nil {
+		return err
+	}
+	if regressions := benchmarks.Regressions(comparisons, *threshold/100); *failOnRegression && len(regressions) > 0 {
+		return fmt.Errorf("%d scenario(s) regressed by more than %.1f%%", len(regressions), *threshold)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// globalDIReplacements maps the supergin functions that use the global DI container to
+// their per-engine replacements
+var globalDIReplacements = map[string]string{
+	"Register":          "app.Register",
+	"RegisterSingleton": "app.RegisterSingleton",
+	"RegisterRequest":   "app.RegisterRequest",
+	"RegisterTransient": "app.RegisterTransient",
+	"RegisterInstance":  "app.RegisterInstance",
+	"Get":               "supergin.Resolve in handlers or supergin.ResolveIn(app.DI(), ...)",
+	"GetT":              "supergin.Resolve in handlers or supergin.ResolveIn(app.DI(), ...)",
+	"TryGetT":           "supergin.TryResolve in handlers or supergin.TryResolveIn(app.DI(), ...)",
+	"GetFromContext":    "supergin.GetFromContextT",
+	"Inject":            "app.DI().Inject",
+	"ResolveTagged":     "app.DI().GetTagged",
+	"Provide":           "supergin.ProvideIn(app.DI(), ...)",
+	"GetDI":             "app.DI(), or supergin.NewContainer bound with app.WithDI",
+}
+
+// globalDIFinding is a reference to a global DI function in scanned source
+type globalDIFinding struct {
+	Position token.Position
+	Function string
+}
+
+func runDICheck(args []string) error {
+	fs := flag.NewFlagSet("dicheck", flag.ExitOnError)
+	dir := fs.String("dir", ".", "directory to scan recursively")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	findings, err := checkGlobalDI(*dir)
+	if err != nil {
+		return err
+	}
+	writeDIFindings(os.Stdout, findings)
+	if len(findings) > 0 {
+		return fmt.Errorf("%d references to the global DI container", len(findings))
+	}
+	return nil
+}
+
+// checkGlobalDI scans the Go files under dir, skipping vendor, testdata and hidden
+// directories, for references to the supergin functions that use the global container
+func checkGlobalDI(dir string) ([]globalDIFinding, error) {
+	fset := token.NewFileSet()
+	var findings []globalDIFinding
+
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, globalDIReferences(fset, file)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i].Position, findings[j].Position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return findings, nil
+}
+
+// globalDIReferences finds references to global DI functions through the file's supergin
+// import, whatever its local name. Dot imports are not followed.
+func globalDIReferences(fset *token.FileSet, file *ast.File) []globalDIFinding {
+	importName := ""
+	for _, spec := range file.Imports {
+		if path, err := strconv.Unquote(spec.Path.Value); err == nil && path == superginImportPath {
+			importName = "supergin"
+			if spec.Name != nil {
+				importName = spec.Name.Name
+			}
+		}
+	}
+	if importName == "" || importName == "_" || importName == "." {
+		return nil
+	}
+
+	var findings []globalDIFinding
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == importName {
+			if _, global := globalDIReplacements[sel.Sel.Name]; global {
+				findings = append(findings, globalDIFinding{Position: fset.Position(sel.Pos()), Function: sel.Sel.Name})
+			}
+		}
+		return true
+	})
+	return findings
+}
+
+// writeDIFindings prints a line per finding with its replacement
+func writeDIFindings(w io.Writer, findings []globalDIFinding) {
+	for _, finding := range findings {
+		fmt.Fprintf(w, "%s: supergin.%s uses the global DI container; use %s\n",
+			finding.Position, finding.Function, globalDIReplacements[finding.Function])
+	}
+}
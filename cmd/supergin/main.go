@@ -0,0 +1,79 @@
+// Command supergin provides development tooling for SuperGin applications.
+//
+// Usage:
+//
+//	supergin wire [-dir .] [-out wire_gen.go] [-func WireServices]
+//	supergin bench [-run regexp] [-count n] [-out report.json] [-list]
+//	supergin bench compare [-threshold 5] [-fail] base.json head.json
+//	supergin dicheck [-dir .]
+//
+// The wire subcommand scans a package for DI registrations
+// (Register, RegisterSingleton, RegisterRequest, RegisterTransient) and generates
+// static constructor wiring for every registration whose factory is a named
+// function declared in that package. Call the generated function with the
+// container after registering services to bypass reflection at resolve time.
+//
+// The bench subcommand runs the load scenarios of the benchmarks package and records
+// the results as JSON; bench compare reports the change per scenario between two runs.
+//
+// The dicheck subcommand lists references to the deprecated functions using the global DI
+// container, with their per-engine replacements, and fails if it finds any.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "wire":
+		if err := runWire(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "supergin wire: %v\n", err)
+			os.Exit(1)
+		}
+	case "bench":
+		if err := runBench(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "supergin bench: %v\n", err)
+			os.Exit(1)
+		}
+	case "dicheck":
+		if err := runDICheck(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "supergin dicheck: %v\n", err)
+			os.Exit(1)
+		}
+	case "help", "-h", "--help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "supergin: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: supergin <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  wire    generate reflection-free DI constructors")
+	fmt.Fprintln(os.Stderr, "  bench   run load scenarios and compare recorded runs")
+	fmt.Fprintln(os.Stderr, "  dicheck report code still using the global DI container")
+}
+
+func runWire(args []string) error {
+	fs := flag.NewFlagSet("wire", flag.ExitOnError)
+	dir := fs.String("dir", ".", "package directory to scan")
+	out := fs.String("out", "wire_gen.go", "output file, relative to -dir")
+	funcName := fs.String("func", "WireServices", "name of the generated wiring function")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return generateWiring(*dir, *out, *funcName)
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const superginImportPath = "github.com/ivikasavnish/supergin"
+
+// registration is a DI registration discovered in source
+type registration struct {
+	Name         string
+	Factory      string
+	Dependencies []string
+	Position     token.Position
+}
+
+// provider is a named factory function declared in the scanned package
+type provider struct {
+	Params  []ast.Expr
+	Results int
+	File    *ast.File
+}
+
+// generateWiring scans dir and writes static wiring for discovered registrations
+func generateWiring(dir, out, funcName string) error {
+	fset := token.NewFileSet()
+	files, pkgName, err := parsePackage(fset, dir, out)
+	if err != nil {
+		return err
+	}
+	if pkgName == "supergin" {
+		return fmt.Errorf("refusing to generate wiring inside the supergin package itself")
+	}
+
+	providers := collectProviders(files)
+	registrations := collectRegistrations(fset, files)
+
+	var body bytes.Buffer
+	var skipped []string
+	imports := map[string]string{superginImportPath: "supergin"}
+
+	for _, reg := range registrations {
+		prov, ok := providers[reg.Factory]
+		switch {
+		case reg.Factory == "":
+			skipped = append(skipped, fmt.Sprintf("%s (%s): factory is not a named function", reg.Name, reg.Position))
+			continue
+		case !ok:
+			skipped = append(skipped, fmt.Sprintf("%s (%s): factory %s not declared in package", reg.Name, reg.Position, reg.Factory))
+			continue
+		case prov.Results != 1:
+			skipped = append(skipped, fmt.Sprintf("%s (%s): factory %s must return exactly one value", reg.Name, reg.Position, reg.Factory))
+			continue
+		case len(prov.Params) != len(reg.Dependencies):
+			skipped = append(skipped, fmt.Sprintf("%s (%s): factory %s expects %d arguments, got %d dependencies",
+				reg.Name, reg.Position, reg.Factory, len(prov.Params), len(reg.Dependencies)))
+			continue
+		}
+
+		fmt.Fprintf(&body, "\tdi.SetBuilder(%q, func(resolve func(string) interface{}) interface{} {\n", reg.Name)
+		fmt.Fprintf(&body, "\t\treturn %s(", reg.Factory)
+		for i, param := range prov.Params {
+			typeExpr, err := renderType(fset, param)
+			if err != nil {
+				return err
+			}
+			addTypeImports(param, prov.File, imports)
+			fmt.Fprintf(&body, "\n\t\t\tresolve(%q).(%s),", reg.Dependencies[i], typeExpr)
+		}
+		if len(prov.Params) > 0 {
+			body.WriteString("\n\t\t")
+		}
+		body.WriteString(")\n\t})\n")
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by supergin wire. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", pkgName)
+	src.WriteString("import (\n")
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		name := imports[path]
+		if name == filepath.Base(path) || (path == superginImportPath && name == "supergin") {
+			fmt.Fprintf(&src, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&src, "\t%s %q\n", name, path)
+		}
+	}
+	src.WriteString(")\n\n")
+	for _, s := range skipped {
+		fmt.Fprintf(&src, "// skipped: %s\n", s)
+	}
+	if len(skipped) > 0 {
+		src.WriteString("\n")
+	}
+	fmt.Fprintf(&src, "// %s installs reflection-free constructors for the package's DI registrations.\n", funcName)
+	fmt.Fprintf(&src, "func %s(di *supergin.DIContainer) {\n", funcName)
+	src.Write(body.Bytes())
+	src.WriteString("}\n")
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %v", err)
+	}
+
+	return os.WriteFile(filepath.Join(dir, out), formatted, 0o644)
+}
+
+// parsePackage parses the non-test Go files of a directory, excluding the output file
+func parsePackage(fset *token.FileSet, dir, out string) ([]*ast.File, string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var files []*ast.File
+	pkgName := ""
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == out {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, "", err
+		}
+		if pkgName == "" {
+			pkgName = file.Name.Name
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, "", fmt.Errorf("no Go files found in %s", dir)
+	}
+	return files, pkgName, nil
+}
+
+// collectProviders indexes top-level functions by name
+func collectProviders(files []*ast.File) map[string]provider {
+	providers := make(map[string]provider)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Type.TypeParams != nil {
+				continue
+			}
+
+			var params []ast.Expr
+			for _, field := range fn.Type.Params.List {
+				count := len(field.Names)
+				if count == 0 {
+					count = 1
+				}
+				for i := 0; i < count; i++ {
+					params = append(params, field.Type)
+				}
+			}
+
+			results := 0
+			if fn.Type.Results != nil {
+				for _, field := range fn.Type.Results.List {
+					if len(field.Names) == 0 {
+						results++
+					} else {
+						results += len(field.Names)
+					}
+				}
+			}
+
+			providers[fn.Name.Name] = provider{Params: params, Results: results, File: file}
+		}
+	}
+	return providers
+}
+
+// collectRegistrations finds Register* calls with literal service names
+func collectRegistrations(fset *token.FileSet, files []*ast.File) []registration {
+	var registrations []registration
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			depsStart := 2
+			switch sel.Sel.Name {
+			case "RegisterSingleton", "RegisterRequest", "RegisterTransient":
+			case "Register":
+				depsStart = 3
+			default:
+				return true
+			}
+			if len(call.Args) < depsStart {
+				return true
+			}
+
+			name, ok := stringLiteral(call.Args[0])
+			if !ok {
+				return true
+			}
+
+			reg := registration{Name: name, Position: fset.Position(call.Pos())}
+			if ident, ok := call.Args[1].(*ast.Ident); ok {
+				reg.Factory = ident.Name
+			}
+			for _, arg := range call.Args[depsStart:] {
+				dep, ok := stringLiteral(arg)
+				if !ok {
+					reg.Factory = ""
+					break
+				}
+				reg.Dependencies = append(reg.Dependencies, dep)
+			}
+
+			registrations = append(registrations, reg)
+			return true
+		})
+	}
+	return registrations
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
+
+func renderType(fset *token.FileSet, expr ast.Expr) (string, error) {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// addTypeImports records the imports referenced by package selectors in a type expression
+func addTypeImports(expr ast.Expr, file *ast.File, imports map[string]string) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := filepath.Base(path)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			if name == pkg.Name {
+				imports[path] = name
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,332 @@
+package supergin
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+// DefaultCompressionMinLength is the smallest response body compressed unless
+// CompressionOptions.MinLength says otherwise
+const DefaultCompressionMinLength = 1024
+
+// Compressor wraps a response in an encoder for one Content-Encoding
+type Compressor func(w io.Writer) (io.WriteCloser, error)
+
+var (
+	compressors   = make(map[string]Compressor)
+	compressionMu sync.RWMutex
+	// compressionPreference breaks ties between encodings a client accepts equally;
+	// encodings registered later are preferred least
+	compressionPreference = []string{"br", "zstd", "gzip"}
+
+	// defaultCompressionExclusions are media types that are already compressed; entries
+	// ending in "/" match a whole type
+	defaultCompressionExclusions = []string{
+		"image/png", "image/jpeg", "image/gif", "image/webp", "image/avif",
+		"video/", "audio/", "font/woff", "font/woff2",
+		"application/zip", "application/gzip", "application/x-gzip", "application/zstd",
+		"application/grpc", "text/event-stream",
+	}
+
+	gzipWriters = sync.Pool{New: func() interface{} {
+		writer, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return writer
+	}}
+	brotliWriters = sync.Pool{New: func() interface{} {
+		return brotli.NewWriterLevel(nil, brotli.DefaultCompression)
+	}}
+	// zstd windows stay within the 8 MiB browsers accept, and each encoder compresses on
+	// the writing goroutine
+	zstdWriters = sync.Pool{New: func() interface{} {
+		writer, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithWindowSize(8<<20))
+		return writer
+	}}
+)
+
+func init() {
+	RegisterCompressor("gzip", pooledCompressor(&gzipWriters))
+	RegisterCompressor("br", pooledCompressor(&brotliWriters))
+	RegisterCompressor("zstd", pooledCompressor(&zstdWriters))
+}
+
+// RegisterCompressor adds a Content-Encoding compression can negotiate, or replaces the
+// encoder of one. gzip, br and zstd are registered by default, e.g. to tune brotli:
+//
+//	supergin.RegisterCompressor("br", func(w io.Writer) (io.WriteCloser, error) {
+//		return brotli.NewWriterLevel(w, brotli.BestSpeed), nil
+//	})
+func RegisterCompressor(encoding string, compressor Compressor) {
+	if compressor == nil {
+		panic("compressor cannot be nil")
+	}
+	encoding = strings.ToLower(encoding)
+
+	compressionMu.Lock()
+	defer compressionMu.Unlock()
+	if _, exists := compressors[encoding]; !exists && !contains(compressionPreference, encoding) {
+		compressionPreference = append(compressionPreference, encoding)
+	}
+	compressors[encoding] = compressor
+}
+
+// CompressionOptions configures response compression for Config.Compression and
+// WithCompression
+type CompressionOptions struct {
+	// MinLength is the smallest body compressed, judged on Content-Length or the first
+	// write; zero means DefaultCompressionMinLength
+	MinLength int
+	// Encodings limits the encodings offered, in preference order; nil offers every
+	// registered one
+	Encodings []string
+	// ExcludedContentTypes are left uncompressed besides already compressed media such as
+	// images, video and archives; entries ending in "/" match a whole type, e.g. "image/"
+	ExcludedContentTypes []string
+}
+
+// WithCompression compresses the route's responses in the encoding negotiated from
+// Accept-Encoding, overriding Config.Compression. Server-sent events, WebSocket upgrades,
+// partial content, responses that already carry a Content-Encoding and responses flushed
+// before their first write are sent as is.
+func (rb *RouteBuilder) WithCompression(opts ...CompressionOptions) *RouteBuilder {
+	options := CompressionOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	rb.compression = &options
+	rb.WithMetadata("compression", true)
+	return rb
+}
+
+// compressionOptions returns the route's compression options, or nil when its responses
+// are sent uncompressed
+func (rb *RouteBuilder) compressionOptions() *CompressionOptions {
+	if rb.compression != nil {
+		return rb.compression
+	}
+	return rb.engine.config.Compression
+}
+
+// compressionMiddleware negotiates an encoding and compresses the response with it
+func compressionMiddleware(options CompressionOptions) gin.HandlerFunc {
+	if options.MinLength <= 0 {
+		options.MinLength = DefaultCompressionMinLength
+	}
+	excluded := append(append([]string{}, defaultCompressionExclusions...), options.ExcludedContentTypes...)
+
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead || c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding, compressor := negotiateEncoding(c.GetHeader("Accept-Encoding"), options.Encodings)
+		if compressor == nil {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &compressWriter{
+			ResponseWriter: original,
+			encoding:       encoding,
+			compressor:     compressor,
+			minLength:      options.MinLength,
+			excluded:       excluded,
+		}
+		c.Writer = writer
+		defer func() {
+			c.Writer = original
+			writer.close()
+		}()
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks the offered encoding the client accepts with the highest
+// quality, breaking ties by server preference
+func negotiateEncoding(acceptEncoding string, offered []string) (string, Compressor) {
+	if acceptEncoding == "" {
+		return "", nil
+	}
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		qualities[strings.ToLower(strings.TrimSpace(name))] = quality
+	}
+
+	compressionMu.RLock()
+	defer compressionMu.RUnlock()
+	if offered == nil {
+		offered = compressionPreference
+	}
+	best, bestQuality := "", 0.0
+	for _, encoding := range offered {
+		encoding = strings.ToLower(encoding)
+		if _, registered := compressors[encoding]; !registered {
+			continue
+		}
+		quality, listed := qualities[encoding]
+		if !listed {
+			quality = qualities["*"]
+		}
+		if quality > bestQuality {
+			best, bestQuality = encoding, quality
+		}
+	}
+	if best == "" {
+		return "", nil
+	}
+	return best, compressors[best]
+}
+
+// compressWriter decides on the first write whether the response is worth compressing
+// and then encodes it
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding   string
+	compressor Compressor
+	minLength  int
+	excluded   []string
+
+	started bool
+	encoder io.WriteCloser
+}
+
+// start decides whether to compress given the response's first bytes, nil when the
+// headers are sent before any body
+func (w *compressWriter) start(data []byte) {
+	w.started = true
+	header := w.ResponseWriter.Header()
+	status := w.ResponseWriter.Status()
+	if data == nil || header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" ||
+		status < http.StatusOK || status == http.StatusNoContent || status == http.StatusPartialContent ||
+		status == http.StatusNotModified {
+		return
+	}
+	length := len(data)
+	if declared := header.Get("Content-Length"); declared != "" {
+		length, _ = strconv.Atoi(declared)
+	}
+	if length < w.minLength {
+		return
+	}
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		// Sniff as net/http would, since the encoded body could not be sniffed
+		contentType = http.DetectContentType(data)
+		header.Set("Content-Type", contentType)
+	}
+	if compressionExcluded(contentType, w.excluded) {
+		return
+	}
+
+	encoder, err := w.compressor(w.ResponseWriter)
+	if err != nil {
+		return
+	}
+	w.encoder = encoder
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	// The encoded body is no longer byte-identical to what a strong ETag names
+	if etag := header.Get("ETag"); strings.HasPrefix(etag, `"`) {
+		header.Set("ETag", "W/"+etag)
+	}
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.started {
+		w.start(data)
+	}
+	if w.encoder == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+	return w.encoder.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.started {
+		w.start(nil)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *compressWriter) Flush() {
+	if !w.started {
+		w.start(nil)
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// close writes the encoding's trailer
+func (w *compressWriter) close() {
+	if w.encoder != nil {
+		w.encoder.Close()
+	}
+}
+
+// compressionExcluded reports whether a content type is left uncompressed
+func compressionExcluded(contentType string, excluded []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+	for _, entry := range excluded {
+		entry = strings.ToLower(entry)
+		if mediaType == entry || (strings.HasSuffix(entry, "/") && strings.HasPrefix(mediaType, entry)) {
+			return true
+		}
+	}
+	return false
+}
+
+// resettableWriter is an encoder that can be reused for another response
+type resettableWriter interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// pooledCompressor compresses with encoders taken from a pool
+func pooledCompressor(pool *sync.Pool) Compressor {
+	return func(w io.Writer) (io.WriteCloser, error) {
+		writer := pool.Get().(resettableWriter)
+		writer.Reset(w)
+		return &pooledWriter{resettableWriter: writer, pool: pool}, nil
+	}
+}
+
+// pooledWriter returns its encoder to the pool once closed
+type pooledWriter struct {
+	resettableWriter
+	pool *sync.Pool
+}
+
+func (w *pooledWriter) Close() error {
+	err := w.resettableWriter.Close()
+	w.pool.Put(w.resettableWriter)
+	return err
+}
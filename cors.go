@@ -0,0 +1,166 @@
+package supergin
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithCORS sets the route's CORS policy, overriding its group's and Config.CORS; nil
+// removes an inherited one. Routes with a policy answer OPTIONS preflight requests, and
+// a policy file's CORS settings override it.
+func (rb *RouteBuilder) WithCORS(policy *CORSPolicy) *RouteBuilder {
+	mustValidCORS(policy)
+	rb.cors = policy
+	rb.corsSet = true
+	return rb
+}
+
+// WithCORS sets the CORS policy of the group's routes, overriding Config.CORS; nil
+// removes it. Routes and nested groups inherit it unless they set their own.
+func (g *GroupBuilder) WithCORS(policy *CORSPolicy) *GroupBuilder {
+	mustValidCORS(policy)
+	g.cors = policy
+	g.corsSet = true
+	return g
+}
+
+// corsPolicy returns the route's declared CORS policy, or nil for none
+func (rb *RouteBuilder) corsPolicy() *CORSPolicy {
+	if rb.corsSet {
+		return rb.cors
+	}
+	return rb.engine.config.CORS
+}
+
+// routeCORS returns the CORS policy in effect for a route: a policy file's, falling back
+// to the one it was registered with
+func (e *Engine) routeCORS(name string) *CORSPolicy {
+	if rp := e.currentPolicy(name); rp != nil && rp.cors != nil {
+		return rp.cors
+	}
+	route, exists := e.GetRoute(name)
+	if !exists {
+		return nil
+	}
+	policy, _ := route.Metadata["cors"].(*CORSPolicy)
+	return policy
+}
+
+// problems lists what makes the policy invalid
+func (p *CORSPolicy) problems() []string {
+	var problems []string
+	if len(p.AllowOrigins) == 0 {
+		problems = append(problems, "cors needs allow_origins")
+	}
+	if p.AllowCredentials && slices.Contains(p.AllowOrigins, "*") {
+		problems = append(problems, "cors cannot allow credentials for any origin")
+	}
+	if p.MaxAge != "" {
+		if _, err := time.ParseDuration(p.MaxAge); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid cors max_age %q", p.MaxAge))
+		}
+	}
+	return problems
+}
+
+// mustValidCORS panics on an invalid declared policy
+func mustValidCORS(policy *CORSPolicy) {
+	if policy == nil {
+		return
+	}
+	if problems := policy.problems(); len(problems) > 0 {
+		panic("invalid CORS policy: " + strings.Join(problems, "; "))
+	}
+}
+
+// allowsOrigin reports whether the policy allows an origin
+func (p *CORSPolicy) allowsOrigin(origin string) bool {
+	return slices.Contains(p.AllowOrigins, origin) || slices.Contains(p.AllowOrigins, "*")
+}
+
+// writeHeaders sets the CORS response headers for an allowed origin
+func (p *CORSPolicy) writeHeaders(c *gin.Context) bool {
+	origin := c.GetHeader("Origin")
+	if origin == "" {
+		return false
+	}
+	c.Writer.Header().Add("Vary", "Origin")
+	if !p.allowsOrigin(origin) {
+		return false
+	}
+
+	c.Header("Access-Control-Allow-Origin", origin)
+	if p.AllowCredentials {
+		c.Header("Access-Control-Allow-Credentials", "true")
+	}
+	if len(p.ExposeHeaders) > 0 {
+		c.Header("Access-Control-Expose-Headers", strings.Join(p.ExposeHeaders, ", "))
+	}
+	return true
+}
+
+// applyCORS sets the CORS headers for allowed origins and rejects WebSocket upgrades from
+// other origins with 403, since browsers do not subject WebSockets to CORS. It reports
+// whether the request may proceed.
+func applyCORS(c *gin.Context, policy *CORSPolicy) bool {
+	if policy.writeHeaders(c) {
+		return true
+	}
+	origin := c.GetHeader("Origin")
+	if origin != "" && strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+		writeProblem(c, http.StatusForbidden, fmt.Sprintf("origin %s is not allowed", origin))
+		return false
+	}
+	return true
+}
+
+// registerPreflight adds an OPTIONS handler for a route's path unless one exists. It
+// answers with the CORS policy of the route at that path for the requested method.
+func (e *Engine) registerPreflight(route *RouteInfo) {
+	router := e.Engine
+	if route.Host != "" {
+		router = e.hostRouter(route.Host)
+	}
+	for _, existing := range router.Routes() {
+		if existing.Method == http.MethodOptions && existing.Path == route.Path {
+			return
+		}
+	}
+
+	host, path := route.Host, route.Path
+	router.OPTIONS(path, func(c *gin.Context) {
+		method := c.GetHeader("Access-Control-Request-Method")
+		var policy *CORSPolicy
+		for name, info := range e.GetRoutes() {
+			if info.Host == host && info.Path == path && info.Method == method {
+				policy = e.routeCORS(name)
+				break
+			}
+		}
+		if policy == nil || !policy.writeHeaders(c) {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		methods := policy.AllowMethods
+		if len(methods) == 0 {
+			methods = []string{method}
+		}
+		c.Header("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		if len(policy.AllowHeaders) > 0 {
+			c.Header("Access-Control-Allow-Headers", strings.Join(policy.AllowHeaders, ", "))
+		} else if requested := c.GetHeader("Access-Control-Request-Headers"); requested != "" {
+			c.Header("Access-Control-Allow-Headers", requested)
+		}
+		if maxAge, _ := time.ParseDuration(policy.MaxAge); maxAge > 0 {
+			c.Header("Access-Control-Max-Age", strconv.Itoa(int(maxAge.Seconds())))
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,222 @@
+package supergin
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CriteriaOp is a comparison in a search criteria clause
+type CriteriaOp string
+
+const (
+	OpEq       CriteriaOp = "eq"
+	OpNe       CriteriaOp = "ne"
+	OpLt       CriteriaOp = "lt"
+	OpLte      CriteriaOp = "lte"
+	OpGt       CriteriaOp = "gt"
+	OpGte      CriteriaOp = "gte"
+	OpContains CriteriaOp = "contains"
+	OpPrefix   CriteriaOp = "prefix"
+	OpSuffix   CriteriaOp = "suffix"
+	OpIn       CriteriaOp = "in"
+)
+
+// likeEscape escapes LIKE wildcards; '!' needs no string escaping in any common dialect
+const likeEscape = "!"
+
+var (
+	criteriaOperators = map[CriteriaOp]string{
+		OpEq: "=", OpNe: "<>", OpLt: "<", OpLte: "<=", OpGt: ">", OpGte: ">=",
+		OpContains: "LIKE", OpPrefix: "LIKE", OpSuffix: "LIKE", OpIn: "IN",
+	}
+	columnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+	likeEscaper   = strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+)
+
+// Placeholder renders the n-th (1-based) bind parameter of a query
+type Placeholder func(n int) string
+
+var (
+	// QuestionPlaceholders renders "?" as used by MySQL and SQLite
+	QuestionPlaceholders Placeholder = func(int) string { return "?" }
+	// DollarPlaceholders renders "$1", "$2", ... as used by PostgreSQL
+	DollarPlaceholders Placeholder = func(n int) string { return "$" + strconv.Itoa(n) }
+)
+
+// criteriaClause is one parameterized comparison
+type criteriaClause struct {
+	column string
+	op     CriteriaOp
+	args   []interface{}
+}
+
+// Criteria builds a parameterized WHERE clause. User input only ever reaches the query as
+// bind arguments; columns are checked to be plain identifiers.
+type Criteria struct {
+	placeholder Placeholder
+	clauses     []criteriaClause
+	err         error
+}
+
+// NewCriteria returns empty criteria using the given placeholder style, "?" by default
+func NewCriteria(placeholder ...Placeholder) *Criteria {
+	c := &Criteria{placeholder: QuestionPlaceholders}
+	if len(placeholder) > 0 && placeholder[0] != nil {
+		c.placeholder = placeholder[0]
+	}
+	return c
+}
+
+// CriteriaFrom builds criteria from a typed search struct. Fields tagged
+// `search:"column,op"` become clauses when set; zero values and nil pointers are skipped,
+// the column defaults to the JSON name and the op to eq, e.g.
+//
+//	type UserSearchRequest struct {
+//	    Name   string   `form:"name" search:"name,contains"`
+//	    MinAge *int     `form:"min_age" search:"age,gte"`
+//	    Roles  []string `form:"role" search:"role,in"`
+//	    Page   int      `form:"page"`
+//	}
+func CriteriaFrom(search interface{}, placeholder ...Placeholder) *Criteria {
+	c := NewCriteria(placeholder...)
+	v := reflect.ValueOf(search)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return c
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		c.err = NewSuperGinError(ErrInvalidCriteria, "search criteria must be a struct, got %s", v.Type())
+		return c
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, tagged := field.Tag.Lookup("search")
+		if !tagged || tag == "-" || !field.IsExported() {
+			continue
+		}
+		value := v.Field(i)
+		if value.IsZero() {
+			continue
+		}
+		if value.Kind() == reflect.Ptr {
+			value = value.Elem()
+		}
+
+		column, op, _ := strings.Cut(tag, ",")
+		if column == "" {
+			column, _ = jsonFieldName(field)
+		}
+		if op == "" {
+			op = string(OpEq)
+		}
+		c.Where(column, CriteriaOp(op), value.Interface())
+	}
+	return c
+}
+
+// Where adds "column op value"; contains, prefix and suffix escape LIKE wildcards in value
+// and in takes a slice, skipping the clause when it is empty
+func (c *Criteria) Where(column string, op CriteriaOp, value interface{}) *Criteria {
+	if c.err != nil {
+		return c
+	}
+	if !columnPattern.MatchString(column) {
+		c.err = NewSuperGinError(ErrInvalidCriteria, "invalid column name '%s'", column)
+		return c
+	}
+	if _, exists := criteriaOperators[op]; !exists {
+		c.err = NewSuperGinError(ErrInvalidCriteria, "unknown operator '%s' for column '%s'", op, column)
+		return c
+	}
+
+	clause := criteriaClause{column: column, op: op}
+	switch op {
+	case OpContains, OpPrefix, OpSuffix:
+		pattern := likeEscaper.Replace(fmt.Sprint(value))
+		switch op {
+		case OpContains:
+			pattern = "%" + pattern + "%"
+		case OpPrefix:
+			pattern += "%"
+		case OpSuffix:
+			pattern = "%" + pattern
+		}
+		clause.args = []interface{}{pattern}
+	case OpIn:
+		values := reflect.ValueOf(value)
+		if values.Kind() != reflect.Slice && values.Kind() != reflect.Array {
+			c.err = NewSuperGinError(ErrInvalidCriteria, "operator 'in' for column '%s' needs a slice, got %T", column, value)
+			return c
+		}
+		if values.Len() == 0 {
+			return c
+		}
+		for i := 0; i < values.Len(); i++ {
+			clause.args = append(clause.args, values.Index(i).Interface())
+		}
+	default:
+		clause.args = []interface{}{value}
+	}
+	c.clauses = append(c.clauses, clause)
+	return c
+}
+
+// SQL returns the clauses joined with AND, without the WHERE keyword, and their bind
+// arguments. Numbered placeholders start at 1.
+func (c *Criteria) SQL() (string, []interface{}, error) {
+	return c.render(1)
+}
+
+// Apply appends the criteria to query as a WHERE clause, e.g.
+//
+//	query, args, err := supergin.CriteriaFrom(req).Apply("SELECT * FROM users")
+//	rows, err := db.QueryContext(ctx, query, args...)
+//
+// Numbered placeholders continue after any already in query when offset is given.
+func (c *Criteria) Apply(query string, offset ...int) (string, []interface{}, error) {
+	start := 1
+	if len(offset) > 0 {
+		start += offset[0]
+	}
+	where, args, err := c.render(start)
+	if err != nil || where == "" {
+		return query, args, err
+	}
+	return query + " WHERE " + where, args, nil
+}
+
+func (c *Criteria) render(start int) (string, []interface{}, error) {
+	if c.err != nil {
+		return "", nil, c.err
+	}
+
+	n := start
+	var args []interface{}
+	parts := make([]string, len(c.clauses))
+	for i, clause := range c.clauses {
+		placeholders := make([]string, len(clause.args))
+		for j := range clause.args {
+			placeholders[j] = c.placeholder(n)
+			n++
+		}
+		args = append(args, clause.args...)
+
+		operator := criteriaOperators[clause.op]
+		switch clause.op {
+		case OpIn:
+			parts[i] = fmt.Sprintf("%s IN (%s)", clause.column, strings.Join(placeholders, ", "))
+		case OpContains, OpPrefix, OpSuffix:
+			parts[i] = fmt.Sprintf("%s LIKE %s ESCAPE '%s'", clause.column, placeholders[0], likeEscape)
+		default:
+			parts[i] = fmt.Sprintf("%s %s %s", clause.column, operator, placeholders[0])
+		}
+	}
+	return strings.Join(parts, " AND "), args, nil
+}
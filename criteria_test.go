@@ -0,0 +1,122 @@
+package supergin
+
+import (
+	"reflect"
+	"testing"
+)
+
+type criteriaSearch struct {
+	Name   string   `json:"name" search:",contains"`
+	Email  string   `json:"email" search:"users.email"`
+	MinAge *int     `json:"min_age" search:"age,gte"`
+	Roles  []string `json:"roles" search:"role,in"`
+	Page   int      `json:"page"`
+}
+
+func TestCriteriaFrom(t *testing.T) {
+	age := 0
+	injection := "x' OR '1'='1"
+
+	tests := []struct {
+		name     string
+		search   interface{}
+		query    string
+		offset   []int
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "set fields become bound clauses",
+			search:   criteriaSearch{Name: "50%_off!", MinAge: &age, Roles: []string{"admin", "owner"}, Page: 3},
+			query:    "SELECT * FROM users",
+			wantSQL:  "SELECT * FROM users WHERE name LIKE $1 ESCAPE '!' AND age >= $2 AND role IN ($3, $4)",
+			wantArgs: []interface{}{"%50!%!_off!!%", 0, "admin", "owner"},
+		},
+		{
+			name:     "user input stays out of the query",
+			search:   &criteriaSearch{Email: injection},
+			query:    "SELECT * FROM users",
+			wantSQL:  "SELECT * FROM users WHERE users.email = $1",
+			wantArgs: []interface{}{injection},
+		},
+		{
+			name:     "placeholders continue after the offset",
+			search:   criteriaSearch{Email: "a@example.com"},
+			query:    "SELECT * FROM tenant_users($1) AS users",
+			offset:   []int{1},
+			wantSQL:  "SELECT * FROM tenant_users($1) AS users WHERE users.email = $2",
+			wantArgs: []interface{}{"a@example.com"},
+		},
+		{
+			name:    "zero values and empty slices are skipped",
+			search:  criteriaSearch{Roles: []string{}, Page: 1},
+			query:   "SELECT * FROM users",
+			wantSQL: "SELECT * FROM users",
+		},
+		{
+			name:    "nil pointer",
+			search:  (*criteriaSearch)(nil),
+			query:   "SELECT * FROM users",
+			wantSQL: "SELECT * FROM users",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := CriteriaFrom(tt.search, DollarPlaceholders).Apply(tt.query, tt.offset...)
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCriteriaRejectsInvalidClauses(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria *Criteria
+	}{
+		{"column with SQL", NewCriteria().Where("name; DROP TABLE users", OpEq, "x")},
+		{"quoted column", NewCriteria().Where(`"name"`, OpEq, "x")},
+		{"unknown operator", NewCriteria().Where("name", CriteriaOp("like"), "x")},
+		{"in without a slice", NewCriteria().Where("role", OpIn, "admin")},
+		{"not a struct", CriteriaFrom("name=x")},
+		{"error kept across clauses", NewCriteria().Where("1name", OpEq, "x").Where("name", OpEq, "y")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.criteria.Apply("SELECT * FROM users")
+			if !IsErrorCode(err, ErrInvalidCriteria) {
+				t.Fatalf("err = %v, want %s", err, ErrInvalidCriteria)
+			}
+			if args != nil {
+				t.Errorf("args = %v, want none", args)
+			}
+			if sql != "SELECT * FROM users" {
+				t.Errorf("sql = %q, want the query unchanged", sql)
+			}
+		})
+	}
+}
+
+func TestCriteriaQuestionPlaceholders(t *testing.T) {
+	sql, args, err := NewCriteria().
+		Where("status", OpNe, "deleted").
+		Where("name", OpPrefix, "ad_").
+		Where("id", OpIn, []int{1, 2}).
+		SQL()
+	if err != nil {
+		t.Fatalf("SQL: %v", err)
+	}
+	if want := "status <> ? AND name LIKE ? ESCAPE '!' AND id IN (?, ?)"; sql != want {
+		t.Errorf("sql = %q, want %q", sql, want)
+	}
+	if want := []interface{}{"deleted", "ad!_%", 1, 2}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %#v, want %#v", args, want)
+	}
+}
@@ -0,0 +1,243 @@
+package supergin
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrNotFound is returned by services for missing records; WriteError maps it to 404
+var ErrNotFound = errors.New("not found")
+
+// CRUDService is a typed service that CRUD adapts into a CRUDController. The context
+// passed to each method is the request's *gin.Context.
+type CRUDService[In, Out, Search, ID any] interface {
+	Create(ctx context.Context, in *In) (*Out, error)
+	Get(ctx context.Context, id ID) (*Out, error)
+	Update(ctx context.Context, id ID, in *In) (*Out, error)
+	Delete(ctx context.Context, id ID) error
+	List(ctx context.Context) ([]Out, error)
+	Search(ctx context.Context, criteria *Search) ([]Out, error)
+}
+
+// PagedLister is implemented by services that list one page at a time; CRUD uses it on
+// paginated resources and responds with WritePage
+type PagedLister[Out any] interface {
+	ListPage(ctx context.Context, page PageRequest) ([]Out, int, error)
+}
+
+// PagedSearcher is implemented by services that search one page at a time
+type PagedSearcher[Out, Search any] interface {
+	SearchPage(ctx context.Context, criteria *Search, page PageRequest) ([]Out, int, error)
+}
+
+// crudController adapts a CRUDService to CRUDController
+type crudController[In, Out, Search, ID any] struct {
+	service CRUDService[In, Out, Search, ID]
+	// validate is the engine's validator once Resource has adopted the controller
+	validate *validator.Validate
+}
+
+// CRUD adapts a typed service into a CRUDController, e.g.
+//
+//	app.Resource("User", supergin.CRUD[CreateUserRequest, UserResponse, UserSearchRequest, int](users)).Build()
+//
+// The :id parameter is parsed into ID (strings, integers and encoding.TextUnmarshaler
+// types such as uuid.UUID), inputs are bound and validated, and responses use 201 for
+// create, 204 for delete and 200 otherwise. Errors are written with WriteError, so
+// ErrNotFound maps to 404. Resource picks up the model types, so WithModel is optional.
+func CRUD[In, Out, Search, ID any](service CRUDService[In, Out, Search, ID]) CRUDController {
+	return &crudController[In, Out, Search, ID]{service: service}
+}
+
+// modelTypes reports the adapter's input, output and search types to Resource
+func (cc *crudController[In, Out, Search, ID]) modelTypes() (input, output, search reflect.Type) {
+	return reflect.TypeOf((*In)(nil)).Elem(), reflect.TypeOf((*Out)(nil)).Elem(), reflect.TypeOf((*Search)(nil)).Elem()
+}
+
+// useValidator makes the adapter validate with the engine's validator, so its custom
+// rules and tag namespaces apply
+func (cc *crudController[In, Out, Search, ID]) useValidator(validate *validator.Validate) {
+	cc.validate = validate
+}
+
+// inputValidator returns the validator for input the route did not validate
+func (cc *crudController[In, Out, Search, ID]) inputValidator() *validator.Validate {
+	if cc.validate != nil {
+		return cc.validate
+	}
+	return defaultValidator
+}
+
+func (cc *crudController[In, Out, Search, ID]) Create(c *gin.Context) {
+	in, err := typedInput[In](c, reflect.TypeOf((*In)(nil)).Elem(), cc.inputValidator())
+	if err != nil {
+		writeValidationError(c, err)
+		return
+	}
+	out, err := cc.service.Create(c, in)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	Respond(c, http.StatusCreated, out)
+}
+
+func (cc *crudController[In, Out, Search, ID]) Read(c *gin.Context) {
+	id, ok := crudID[ID](c)
+	if !ok {
+		return
+	}
+	out, err := cc.service.Get(c, id)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	Respond(c, http.StatusOK, out)
+}
+
+func (cc *crudController[In, Out, Search, ID]) Update(c *gin.Context) {
+	id, ok := crudID[ID](c)
+	if !ok {
+		return
+	}
+	in, err := typedInput[In](c, reflect.TypeOf((*In)(nil)).Elem(), cc.inputValidator())
+	if err != nil {
+		writeValidationError(c, err)
+		return
+	}
+	out, err := cc.service.Update(c, id, in)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	Respond(c, http.StatusOK, out)
+}
+
+func (cc *crudController[In, Out, Search, ID]) Delete(c *gin.Context) {
+	id, ok := crudID[ID](c)
+	if !ok {
+		return
+	}
+	if err := cc.service.Delete(c, id); err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (cc *crudController[In, Out, Search, ID]) List(c *gin.Context) {
+	if _, paginated := c.Get(pageKey); paginated {
+		if lister, ok := cc.service.(PagedLister[Out]); ok {
+			items, total, err := lister.ListPage(c, Pagination(c))
+			if err != nil {
+				HandleError(c, err)
+				return
+			}
+			WritePage(c, items, total)
+			return
+		}
+	}
+
+	items, err := cc.service.List(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	cc.respondList(c, items)
+}
+
+func (cc *crudController[In, Out, Search, ID]) Search(c *gin.Context) {
+	criteria, err := typedInput[Search](c, reflect.TypeOf((*Search)(nil)).Elem(), cc.inputValidator())
+	if err != nil {
+		writeValidationError(c, err)
+		return
+	}
+
+	if _, paginated := c.Get(pageKey); paginated {
+		if searcher, ok := cc.service.(PagedSearcher[Out, Search]); ok {
+			items, total, err := searcher.SearchPage(c, criteria, Pagination(c))
+			if err != nil {
+				HandleError(c, err)
+				return
+			}
+			WritePage(c, items, total)
+			return
+		}
+	}
+
+	items, err := cc.service.Search(c, criteria)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	cc.respondList(c, items)
+}
+
+// respondList writes a full result set, slicing out the requested page when paginated
+func (cc *crudController[In, Out, Search, ID]) respondList(c *gin.Context, items []Out) {
+	if items == nil {
+		items = []Out{}
+	}
+	if _, paginated := c.Get(pageKey); !paginated {
+		Respond(c, http.StatusOK, items)
+		return
+	}
+
+	page := Pagination(c)
+	start := min(page.Offset(), len(items))
+	end := min(start+page.PerPage, len(items))
+	WritePage(c, items[start:end], len(items))
+}
+
+// crudID parses the :id path parameter, responding 400 when it does not parse
+func crudID[ID any](c *gin.Context) (ID, bool) {
+	id, err := parseID[ID](c.Param("id"))
+	if err != nil {
+		HandleError(c, InvalidField("id", "id", err.Error()))
+		return id, false
+	}
+	return id, true
+}
+
+// parseID converts a path parameter to an ID type
+func parseID[ID any](raw string) (ID, error) {
+	var id ID
+	if raw == "" {
+		return id, fmt.Errorf("is required")
+	}
+	if unmarshaler, ok := any(&id).(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(raw)); err != nil {
+			return id, fmt.Errorf("is not a valid identifier")
+		}
+		return id, nil
+	}
+
+	v := reflect.ValueOf(&id).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return id, fmt.Errorf("must be an integer")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return id, fmt.Errorf("must be a non-negative integer")
+		}
+		v.SetUint(n)
+	default:
+		return id, fmt.Errorf("cannot be parsed into %s", v.Type())
+	}
+	return id, nil
+}
@@ -0,0 +1,75 @@
+package supergin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type crudItem struct {
+	SKU string `json:"sku" validate:"required,sku"`
+}
+
+type crudItemSearch struct {
+	SKU string `form:"sku" validate:"omitempty,sku"`
+}
+
+// crudItems is an in-memory CRUDService of items
+type crudItems struct{}
+
+func (crudItems) Create(ctx context.Context, in *crudItem) (*crudItem, error) { return in, nil }
+func (crudItems) Get(ctx context.Context, id int) (*crudItem, error)          { return nil, ErrNotFound }
+func (crudItems) Update(ctx context.Context, id int, in *crudItem) (*crudItem, error) {
+	return in, nil
+}
+func (crudItems) Delete(ctx context.Context, id int) error     { return nil }
+func (crudItems) List(ctx context.Context) ([]crudItem, error) { return nil, nil }
+func (crudItems) Search(ctx context.Context, criteria *crudItemSearch) ([]crudItem, error) {
+	return []crudItem{{SKU: criteria.SKU}}, nil
+}
+
+func TestCRUDUsesEngineValidator(t *testing.T) {
+	app := newTestEngine(Config{})
+	err := app.RegisterValidation("sku", func(fl validator.FieldLevel) bool {
+		return strings.HasPrefix(fl.Field().String(), "SKU-")
+	})
+	if err != nil {
+		t.Fatalf("RegisterValidation: %v", err)
+	}
+	controller := CRUD[crudItem, crudItem, crudItemSearch, int](crudItems{})
+	app.Resource("Item", controller)
+
+	// Called outside the resource's routes, nothing validated the input upstream
+	tests := []struct {
+		name   string
+		serve  gin.HandlerFunc
+		method string
+		target string
+		body   string
+		want   int
+	}{
+		{"create", controller.Create, http.MethodPost, "/items", `{"sku": "SKU-1"}`, http.StatusCreated},
+		{"create with a bad SKU", controller.Create, http.MethodPost, "/items", `{"sku": "1"}`, http.StatusBadRequest},
+		{"update with a bad SKU", controller.Update, http.MethodPut, "/items/1", `{"sku": "1"}`, http.StatusBadRequest},
+		{"search", controller.Search, http.MethodGet, "/items/search?sku=SKU-1", "", http.StatusOK},
+		{"search with a bad SKU", controller.Search, http.MethodGet, "/items/search?sku=1", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+			tt.serve(c)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
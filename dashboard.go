@@ -0,0 +1,234 @@
+package supergin
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DashboardPath is where EnableDashboardEndpoint mounts the Grafana dashboard
+	DashboardPath = "/_admin/dashboard"
+	// MetricsPath is where EnableDashboardEndpoint mounts the Prometheus metrics the
+	// dashboard queries
+	MetricsPath = "/_admin/metrics"
+)
+
+// untaggedRow groups routes declared without tags in generated dashboards
+const untaggedRow = "untagged"
+
+// DashboardOptions configures a generated Grafana dashboard
+type DashboardOptions struct {
+	// Title defaults to Config.Title, or "SuperGin API"
+	Title string
+	// UID is the dashboard's stable Grafana UID; empty derives one from the title, so
+	// re-importing replaces the previous version
+	UID string
+	// Job, when set, restricts queries to the Prometheus job scraping this service
+	Job string
+}
+
+// Dashboard generates a Grafana dashboard for the route registry, ready to import: a
+// collapsible row per tag with request/error rate and latency panels for each named route
+// in it. Latency panels draw the route's SLO p99 budget as a threshold. Panels query the
+// series served by the metrics endpoint through a selectable Prometheus datasource.
+func (e *Engine) Dashboard(opts ...DashboardOptions) map[string]interface{} {
+	options := DashboardOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Title == "" {
+		options.Title = e.config.Title
+	}
+	if options.Title == "" {
+		options.Title = "SuperGin API"
+	}
+	if options.UID == "" {
+		options.UID = dashboardUID(options.Title)
+	}
+
+	rows := make(map[string][]*RouteInfo)
+	for _, route := range e.GetRoutes() {
+		if len(route.Tags) == 0 {
+			rows[untaggedRow] = append(rows[untaggedRow], route)
+			continue
+		}
+		for _, tag := range route.Tags {
+			rows[tag] = append(rows[tag], route)
+		}
+	}
+	tags := make([]string, 0, len(rows))
+	for tag := range rows {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	panels := []interface{}{}
+	id, y := 1, 0
+	for _, tag := range tags {
+		routes := rows[tag]
+		sort.Slice(routes, func(i, j int) bool { return routes[i].Name < routes[j].Name })
+
+		panels = append(panels, map[string]interface{}{
+			"id":        id,
+			"type":      "row",
+			"title":     tag,
+			"collapsed": false,
+			"gridPos":   gridPos(0, y, 24, 1),
+			"panels":    []interface{}{},
+		})
+		id++
+		y++
+
+		for _, route := range routes {
+			selector := dashboardSelector(route.Name, options.Job)
+			panels = append(panels,
+				timeseriesPanel(id, fmt.Sprintf("%s %s: requests", route.Method, route.Path), "reqps", gridPos(0, y, 12, 8), nil,
+					dashboardTarget("A", "requests", fmt.Sprintf("sum(rate(supergin_route_requests_total%s[$__rate_interval]))", selector)),
+					dashboardTarget("B", "5xx", fmt.Sprintf("sum(rate(supergin_route_errors_total%s[$__rate_interval]))", selector)),
+				),
+				timeseriesPanel(id+1, fmt.Sprintf("%s %s: latency", route.Method, route.Path), "s", gridPos(12, y, 12, 8), route.SLO,
+					dashboardTarget("A", "mean", fmt.Sprintf("sum(rate(supergin_route_latency_seconds_total%[1]s[$__rate_interval])) / sum(rate(supergin_route_requests_total%[1]s[$__rate_interval]))", selector)),
+					dashboardTarget("B", "max", fmt.Sprintf("max(supergin_route_latency_seconds_max%s)", selector)),
+				),
+			)
+			id += 2
+			y += 8
+		}
+	}
+
+	return map[string]interface{}{
+		"uid":           options.UID,
+		"title":         options.Title,
+		"tags":          []string{"supergin"},
+		"timezone":      "browser",
+		"schemaVersion": 39,
+		"refresh":       "30s",
+		"time":          map[string]interface{}{"from": "now-6h", "to": "now"},
+		"templating": map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{
+					"name":  "datasource",
+					"label": "Datasource",
+					"type":  "datasource",
+					"query": "prometheus",
+				},
+			},
+		},
+		"panels": panels,
+	}
+}
+
+// EnableDashboardEndpoint registers GET /_admin/dashboard, serving the Grafana dashboard
+// generated from the current route registry, and GET /_admin/metrics, serving route
+// metrics in the Prometheus text format, both behind the given authentication middleware.
+// The dashboard route accepts ?title=, ?uid= and ?job=.
+func (e *Engine) EnableDashboardEndpoint(auth gin.HandlerFunc, middleware ...gin.HandlerFunc) *Engine {
+	if auth == nil {
+		panic("dashboard endpoint requires an authentication middleware")
+	}
+	handlers := append([]gin.HandlerFunc{auth}, middleware...)
+
+	e.Named("admin_dashboard").
+		GET(DashboardPath).
+		WithDescription("Grafana dashboard for the registered routes, grouped by tag").
+		WithTags("admin").
+		WithMiddleware(handlers...).
+		Handler(func(c *gin.Context) {
+			c.JSON(http.StatusOK, e.Dashboard(DashboardOptions{
+				Title: c.Query("title"),
+				UID:   c.Query("uid"),
+				Job:   c.Query("job"),
+			}))
+		})
+
+	e.Named("admin_metrics").
+		GET(MetricsPath).
+		WithDescription("Route metrics in the Prometheus text exposition format").
+		WithTags("admin").
+		WithMiddleware(handlers...).
+		Handler(func(c *gin.Context) {
+			c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+			c.Status(http.StatusOK)
+			if err := e.metrics.WritePrometheus(c.Writer); err != nil {
+				LoggerFor(c).Error("failed to write metrics", "error", err)
+			}
+			if err := e.writeCircuitMetrics(c.Writer); err != nil {
+				LoggerFor(c).Error("failed to write circuit breaker metrics", "error", err)
+			}
+		})
+
+	return e
+}
+
+// timeseriesPanel builds a Grafana time series panel; a route SLO adds its p99 budget as
+// a threshold line
+func timeseriesPanel(id int, title, unit string, pos map[string]int, slo *SLO, targets ...map[string]interface{}) map[string]interface{} {
+	steps := []interface{}{map[string]interface{}{"color": "green", "value": nil}}
+	thresholdStyle := "off"
+	if slo != nil && slo.P99 > 0 {
+		steps = append(steps, map[string]interface{}{"color": "red", "value": slo.P99.Seconds()})
+		thresholdStyle = "line"
+	}
+
+	return map[string]interface{}{
+		"id":         id,
+		"type":       "timeseries",
+		"title":      title,
+		"gridPos":    pos,
+		"datasource": map[string]string{"type": "prometheus", "uid": "${datasource}"},
+		"targets":    targets,
+		"fieldConfig": map[string]interface{}{
+			"defaults": map[string]interface{}{
+				"unit":       unit,
+				"custom":     map[string]interface{}{"thresholdsStyle": map[string]string{"mode": thresholdStyle}},
+				"thresholds": map[string]interface{}{"mode": "absolute", "steps": steps},
+			},
+			"overrides": []interface{}{},
+		},
+	}
+}
+
+// dashboardTarget builds a Prometheus query for a panel
+func dashboardTarget(ref, legend, expr string) map[string]interface{} {
+	return map[string]interface{}{
+		"refId":        ref,
+		"expr":         expr,
+		"legendFormat": legend,
+		"datasource":   map[string]string{"type": "prometheus", "uid": "${datasource}"},
+	}
+}
+
+// dashboardSelector is the label selector matching a route's series
+func dashboardSelector(route, job string) string {
+	selector := fmt.Sprintf("route=%q", route)
+	if job != "" {
+		selector = fmt.Sprintf("job=%q,%s", job, selector)
+	}
+	return "{" + selector + "}"
+}
+
+func gridPos(x, y, w, h int) map[string]int {
+	return map[string]int{"x": x, "y": y, "w": w, "h": h}
+}
+
+// dashboardUID derives a Grafana UID (at most 40 characters) from a dashboard title
+func dashboardUID(title string) string {
+	uid := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, title)
+	uid = "supergin-" + strings.Trim(uid, "-")
+	if len(uid) > 40 {
+		uid = uid[:40]
+	}
+	return uid
+}
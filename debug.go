@@ -0,0 +1,155 @@
+package supergin
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/metrics"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugPathPrefix is where Config.EnableDebug mounts the debug endpoints
+const DebugPathPrefix = "/debug"
+
+// RuntimeStats is the Go runtime snapshot served at /debug/runtime
+type RuntimeStats struct {
+	GoVersion  string `json:"go_version"`
+	GOOS       string `json:"goos"`
+	GOARCH     string `json:"goarch"`
+	NumCPU     int    `json:"num_cpu"`
+	GOMAXPROCS int    `json:"gomaxprocs"`
+	Goroutines int    `json:"goroutines"`
+	CgoCalls   int64  `json:"cgo_calls"`
+
+	Memory struct {
+		Alloc        uint64 `json:"alloc_bytes"`
+		TotalAlloc   uint64 `json:"total_alloc_bytes"`
+		Sys          uint64 `json:"sys_bytes"`
+		HeapAlloc    uint64 `json:"heap_alloc_bytes"`
+		HeapInuse    uint64 `json:"heap_inuse_bytes"`
+		HeapIdle     uint64 `json:"heap_idle_bytes"`
+		HeapReleased uint64 `json:"heap_released_bytes"`
+		HeapObjects  uint64 `json:"heap_objects"`
+		StackInuse   uint64 `json:"stack_inuse_bytes"`
+		Mallocs      uint64 `json:"mallocs"`
+		Frees        uint64 `json:"frees"`
+	} `json:"memory"`
+
+	GC struct {
+		NumGC        uint32     `json:"num_gc"`
+		NumForcedGC  uint32     `json:"num_forced_gc"`
+		NextGC       uint64     `json:"next_gc_bytes"`
+		LastGC       *time.Time `json:"last_gc,omitempty"`
+		PauseTotalNs uint64     `json:"pause_total_ns"`
+		LastPauseNs  uint64     `json:"last_pause_ns"`
+		CPUFraction  float64    `json:"cpu_fraction"`
+		// GCPercent and MemoryLimit are the GOGC and GOMEMLIMIT settings in effect
+		GCPercent   uint64 `json:"gc_percent"`
+		MemoryLimit uint64 `json:"memory_limit_bytes"`
+	} `json:"gc"`
+}
+
+// ReadRuntimeStats takes a snapshot of the Go runtime. Reading memory statistics stops
+// the world briefly, so poll it sparingly.
+func ReadRuntimeStats() RuntimeStats {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	stats := RuntimeStats{
+		GoVersion:  runtime.Version(),
+		GOOS:       runtime.GOOS,
+		GOARCH:     runtime.GOARCH,
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		Goroutines: runtime.NumGoroutine(),
+		CgoCalls:   runtime.NumCgoCall(),
+	}
+
+	stats.Memory.Alloc = memStats.Alloc
+	stats.Memory.TotalAlloc = memStats.TotalAlloc
+	stats.Memory.Sys = memStats.Sys
+	stats.Memory.HeapAlloc = memStats.HeapAlloc
+	stats.Memory.HeapInuse = memStats.HeapInuse
+	stats.Memory.HeapIdle = memStats.HeapIdle
+	stats.Memory.HeapReleased = memStats.HeapReleased
+	stats.Memory.HeapObjects = memStats.HeapObjects
+	stats.Memory.StackInuse = memStats.StackInuse
+	stats.Memory.Mallocs = memStats.Mallocs
+	stats.Memory.Frees = memStats.Frees
+
+	settings := []metrics.Sample{{Name: "/gc/gogc:percent"}, {Name: "/gc/gomemlimit:bytes"}}
+	metrics.Read(settings)
+
+	stats.GC.NumGC = memStats.NumGC
+	stats.GC.NumForcedGC = memStats.NumForcedGC
+	stats.GC.NextGC = memStats.NextGC
+	stats.GC.PauseTotalNs = memStats.PauseTotalNs
+	stats.GC.CPUFraction = memStats.GCCPUFraction
+	if settings[0].Value.Kind() == metrics.KindUint64 {
+		stats.GC.GCPercent = settings[0].Value.Uint64()
+	}
+	if settings[1].Value.Kind() == metrics.KindUint64 {
+		stats.GC.MemoryLimit = settings[1].Value.Uint64()
+	}
+	if memStats.NumGC > 0 {
+		lastGC := time.Unix(0, int64(memStats.LastGC))
+		stats.GC.LastGC = &lastGC
+		stats.GC.LastPauseNs = memStats.PauseNs[(memStats.NumGC+255)%256]
+	}
+	return stats
+}
+
+// setupDebugEndpoints mounts pprof, expvar, runtime stats and route and DI introspection
+// under DebugPathPrefix, behind Config.DebugAuth
+func (e *Engine) setupDebugEndpoints() {
+	if e.config.DebugAuth == nil {
+		panic("debug endpoints require Config.DebugAuth")
+	}
+	debugGroup := e.Engine.Group(DebugPathPrefix, e.config.DebugAuth)
+
+	// Index serves named profiles such as heap and goroutine, and the profile list
+	profiles := func(c *gin.Context) {
+		switch strings.TrimPrefix(c.Param("profile"), "/") {
+		case "cmdline":
+			pprof.Cmdline(c.Writer, c.Request)
+		case "profile":
+			pprof.Profile(c.Writer, c.Request)
+		case "symbol":
+			pprof.Symbol(c.Writer, c.Request)
+		case "trace":
+			pprof.Trace(c.Writer, c.Request)
+		default:
+			pprof.Index(c.Writer, c.Request)
+		}
+	}
+	debugGroup.GET("/pprof", func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, DebugPathPrefix+"/pprof/")
+	})
+	debugGroup.GET("/pprof/*profile", profiles)
+	debugGroup.POST("/pprof/*profile", profiles)
+
+	debugGroup.GET("/vars", gin.WrapH(expvar.Handler()))
+
+	debugGroup.GET("/runtime", func(c *gin.Context) {
+		c.JSON(http.StatusOK, ReadRuntimeStats())
+	})
+
+	debugGroup.GET("/routes", func(c *gin.Context) {
+		routes := e.GetRoutes()
+		list := make([]*RouteInfo, 0, len(routes))
+		for _, route := range routes {
+			list = append(list, route)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+		c.JSON(http.StatusOK, gin.H{"routes": list, "total_routes": len(list), "environment": e.environment})
+	})
+
+	debugGroup.GET("/di", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"services": e.di.ListServices(), "graph": e.di.Graph()})
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,6 +29,11 @@ type ServiceDefinition struct {
 	Dependencies []string               `json:"dependencies"`
 	Singleton    interface{}            `json:"-"`
 	Metadata     map[string]interface{} `json:"metadata"`
+	Tags         []string               `json:"tags,omitempty"`
+
+	// creating serializes singleton creation without holding the container mutex, which
+	// dependencies resolved by the factory need
+	creating sync.Mutex
 }
 
 // DIContainer manages dependency injection
@@ -34,29 +41,42 @@ type DIContainer struct {
 	services   map[string]*ServiceDefinition
 	singletons map[string]interface{}
 	mutex      sync.RWMutex
-	requestKey string
+	started    bool
+	disposed   bool
+	last       string
+	logger     Logger
+	typed      map[typedKey]string
+	parent     *DIContainer
+
+	builders   map[string]ServiceBuilder
+	buildersMu sync.RWMutex
 }
 
+// ServiceBuilder constructs a service without reflection. It is normally produced by
+// the `supergin wire` code generator; resolve returns a named dependency.
+type ServiceBuilder func(resolve func(name string) interface{}) interface{}
+
 // RequestScope holds request-scoped dependencies
 type RequestScope struct {
 	instances map[string]interface{}
+	order     []string
+	container *DIContainer
 	mutex     sync.RWMutex
+	// creating guards each service's creation in the scope
+	creating map[string]*sync.Mutex
 }
 
 // Global DI container instance
-var globalDI *DIContainer
+var globalDI atomic.Pointer[DIContainer]
 var diOnce sync.Once
 
-// GetDI returns the global DI container
+// GetDI returns the global DI container, which engines use unless bound to their own with
+// WithDI. New code should prefer a container per engine; see NewContainer.
 func GetDI() *DIContainer {
 	diOnce.Do(func() {
-		globalDI = &DIContainer{
-			services:   make(map[string]*ServiceDefinition),
-			singletons: make(map[string]interface{}),
-			requestKey: "supergin:request_scope",
-		}
+		globalDI.Store(NewContainer())
 	})
-	return globalDI
+	return globalDI.Load()
 }
 
 // Register registers a service with the DI container
@@ -66,9 +86,9 @@ func (di *DIContainer) Register(name string, factory interface{}, scope DIScope,
 		panic(fmt.Sprintf("factory for service '%s' must be a function", name))
 	}
 
-	// Validate factory function returns exactly one value
-	if factoryType.NumOut() != 1 {
-		panic(fmt.Sprintf("factory for service '%s' must return exactly one value", name))
+	// Validate factory function returns a value, optionally followed by an error
+	if !validFactoryResults(factoryType) {
+		panic(fmt.Sprintf("factory for service '%s' must return a value or (value, error)", name))
 	}
 
 	di.mutex.Lock()
@@ -82,10 +102,25 @@ func (di *DIContainer) Register(name string, factory interface{}, scope DIScope,
 		Dependencies: dependencies,
 		Metadata:     make(map[string]interface{}),
 	}
+	di.last = name
 
 	return di
 }
 
+// validFactoryResults reports whether a factory returns T or (T, error)
+func validFactoryResults(factoryType reflect.Type) bool {
+	switch factoryType.NumOut() {
+	case 1:
+		return true
+	case 2:
+		return factoryType.Out(1) == errorType
+	default:
+		return false
+	}
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
 // RegisterSingleton registers a singleton service
 func (di *DIContainer) RegisterSingleton(name string, factory interface{}, dependencies ...string) *DIContainer {
 	return di.Register(name, factory, ScopeSingleton, dependencies...)
@@ -115,22 +150,106 @@ func (di *DIContainer) RegisterInstance(name string, instance interface{}) *DICo
 		Metadata:  make(map[string]interface{}),
 	}
 	di.singletons[name] = instance
+	di.last = name
 
 	return di
 }
 
-// Get resolves and returns a service instance
+// WithTag tags the most recently registered service, e.g.
+// RegisterSingleton("db_check", newDBCheck).WithTag("health-checker")
+func (di *DIContainer) WithTag(tags ...string) *DIContainer {
+	di.mutex.Lock()
+	defer di.mutex.Unlock()
+
+	service, exists := di.services[di.last]
+	if !exists {
+		panic("WithTag called before any service was registered")
+	}
+	for _, tag := range tags {
+		if !contains(service.Tags, tag) {
+			service.Tags = append(service.Tags, tag)
+		}
+	}
+	return di
+}
+
+// SetBuilder installs a reflection-free builder for a service. Builders may be set before
+// or after the service is registered; the registration still defines scope and dependencies.
+func (di *DIContainer) SetBuilder(name string, builder ServiceBuilder) *DIContainer {
+	di.buildersMu.Lock()
+	defer di.buildersMu.Unlock()
+
+	di.builders[name] = builder
+	return di
+}
+
+// TaggedNames returns the names of services carrying the tag, sorted by name
+func (di *DIContainer) TaggedNames(tag string) []string {
+	var names []string
+	for name, service := range di.ListServices() {
+		if contains(service.Tags, tag) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetTagged resolves all services carrying the tag, ordered by service name
+func (di *DIContainer) GetTagged(tag string) []interface{} {
+	return di.GetTaggedFromContext(nil, tag)
+}
+
+// GetTaggedFromContext resolves all services carrying the tag with request context
+func (di *DIContainer) GetTaggedFromContext(ctx context.Context, tag string) []interface{} {
+	names := di.TaggedNames(tag)
+	instances := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		instances = append(instances, mustResolve(di.resolve(name, nil, ctx)))
+	}
+	return instances
+}
+
+// Get resolves and returns a service instance. Resolution failures panic with a
+// *SuperGinError; use TryGet to handle them as errors.
 func (di *DIContainer) Get(name string) interface{} {
-	return di.resolve(name, make(map[string]bool), nil)
+	return mustResolve(di.TryGet(name))
 }
 
-// GetFromContext resolves a service with request context
+// GetFromContext resolves a service with request context, panicking like Get on failure
 func (di *DIContainer) GetFromContext(ctx context.Context, name string) interface{} {
-	return di.resolve(name, make(map[string]bool), ctx)
+	return mustResolve(di.TryGetFromContext(ctx, name))
+}
+
+// TryGet resolves a service, returning a *SuperGinError instead of panicking when the
+// service is missing, its dependencies are circular or a factory fails
+func (di *DIContainer) TryGet(name string) (interface{}, error) {
+	return di.resolve(name, nil, nil)
 }
 
-// GetT returns a typed service instance
+// TryGetFromContext resolves a service with request context, returning failures as errors
+func (di *DIContainer) TryGetFromContext(ctx context.Context, name string) (interface{}, error) {
+	return di.resolve(name, nil, ctx)
+}
+
+// mustResolve panics with the resolution error, if any
+func mustResolve(instance interface{}, err error) interface{} {
+	if err != nil {
+		panic(err)
+	}
+	return instance
+}
+
+// GetT returns a typed service instance from the global container.
+//
+// Deprecated: Use Resolve in handlers, or ResolveIn with the engine's container.
 func GetT[T any](name string) T {
+	noteGlobalDI("GetT")
+	return mustResolveT[T](name)
+}
+
+// mustResolveT resolves a typed service from the global container, panicking on failure
+func mustResolveT[T any](name string) T {
 	instance := GetDI().Get(name)
 	if instance == nil {
 		var zero T
@@ -141,7 +260,7 @@ func GetT[T any](name string) T {
 
 // GetFromContextT returns a typed service instance with context
 func GetFromContextT[T any](ctx context.Context, name string) T {
-	instance := GetDI().GetFromContext(ctx, name)
+	instance := containerFor(ctx).GetFromContext(ctx, name)
 	if instance == nil {
 		var zero T
 		return zero
@@ -149,146 +268,344 @@ func GetFromContextT[T any](ctx context.Context, name string) T {
 	return instance.(T)
 }
 
+// TryGetT returns a typed service instance from the global container, or an error if it
+// cannot be resolved.
+//
+// Deprecated: Use TryResolve in handlers, or TryResolveIn with the engine's container.
+func TryGetT[T any](name string) (T, error) {
+	noteGlobalDI("TryGetT")
+	instance, err := GetDI().TryGet(name)
+	return asService[T](name, instance, err)
+}
+
+// asService asserts a resolved instance to T, passing resolution errors through
+func asService[T any](name string, instance interface{}, err error) (T, error) {
+	var zero T
+	if err != nil || instance == nil {
+		return zero, err
+	}
+	typed, ok := instance.(T)
+	if !ok {
+		return zero, NewSuperGinError(ErrInvalidFactory, "service '%s' has type %T, not %T", name, instance, zero)
+	}
+	return typed, nil
+}
+
 // resolve internal method to resolve dependencies
-func (di *DIContainer) resolve(name string, resolving map[string]bool, ctx context.Context) interface{} {
+func (di *DIContainer) resolve(name string, resolving resolutionPath, ctx context.Context) (interface{}, error) {
 	// Check for circular dependencies
-	if resolving[name] {
-		panic(fmt.Sprintf("circular dependency detected for service '%s'", name))
+	if chain, circular := resolving.cycle(name); circular {
+		return nil, circularDependencyError(chain)
 	}
-	resolving[name] = true
-	defer delete(resolving, name)
 
 	di.mutex.RLock()
 	service, exists := di.services[name]
 	di.mutex.RUnlock()
 
+	if !exists && di.parent != nil {
+		if inherited := di.parent.definition(name); inherited != nil {
+			// Parent services are shared unless they depend on a service shadowed here
+			if !di.shadows(inherited, make(map[string]bool)) {
+				return di.parent.resolve(name, resolving, ctx)
+			}
+			service, exists = di.adopt(inherited), true
+		}
+	}
 	if !exists {
-		panic(fmt.Sprintf("service '%s' not registered", name))
+		return nil, NewSuperGinError(ErrDIServiceNotFound, "service '%s' not registered", name)
 	}
+	resolving = append(resolving, name)
 
+	var instance interface{}
+	var err error
 	switch service.Scope {
 	case ScopeSingleton:
-		return di.resolveSingleton(service, resolving, ctx)
+		instance, err = di.resolveSingleton(service, resolving, ctx)
 	case ScopeRequest:
-		return di.resolveRequest(service, resolving, ctx)
+		instance, err = di.resolveRequest(service, resolving, ctx)
 	case ScopeTransient:
-		return di.resolveTransient(service, resolving, ctx)
+		instance, err = di.resolveTransient(service, resolving, ctx)
 	default:
-		panic(fmt.Sprintf("unknown scope '%s' for service '%s'", service.Scope, name))
+		return nil, NewSuperGinError(ErrInvalidFactory, "unknown scope '%s' for service '%s'", service.Scope, name)
+	}
+	if err != nil {
+		return nil, err
 	}
+	// Services resolved for a caller, not as dependencies, follow the request's deadline
+	if len(resolving) == 1 {
+		instance = boundToDeadline(ctx, instance)
+	}
+	return instance, nil
 }
 
-func (di *DIContainer) resolveSingleton(service *ServiceDefinition, resolving map[string]bool, ctx context.Context) interface{} {
+func (di *DIContainer) resolveSingleton(service *ServiceDefinition, resolving resolutionPath, ctx context.Context) (interface{}, error) {
 	// Check if already cached
 	if service.Singleton != nil {
-		return service.Singleton
+		return service.Singleton, nil
 	}
 
-	di.mutex.Lock()
-	defer di.mutex.Unlock()
+	service.creating.Lock()
+	defer service.creating.Unlock()
 
 	// Double-check after acquiring lock
-	if service.Singleton != nil {
-		return service.Singleton
+	if instance, created := di.singleton(service.Name); created {
+		return instance, nil
 	}
 
-	instance := di.createInstance(service, resolving, ctx)
+	// Failed factories are not cached, so a later Get retries them
+	instance, err := di.createInstance(service, resolving, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	di.mutex.Lock()
+	defer di.mutex.Unlock()
 	service.Singleton = instance
 	di.singletons[service.Name] = instance
-	return instance
+	return instance, nil
+}
+
+// singleton returns a created singleton instance
+func (di *DIContainer) singleton(name string) (interface{}, bool) {
+	di.mutex.RLock()
+	defer di.mutex.RUnlock()
+
+	instance, created := di.singletons[name]
+	return instance, created
 }
 
-func (di *DIContainer) resolveRequest(service *ServiceDefinition, resolving map[string]bool, ctx context.Context) interface{} {
+func (di *DIContainer) resolveRequest(service *ServiceDefinition, resolving resolutionPath, ctx context.Context) (interface{}, error) {
 	if ctx == nil {
-		panic(fmt.Sprintf("request-scoped service '%s' requires context", service.Name))
+		return nil, NewSuperGinError(ErrContextRequired, "request-scoped service '%s' requires context", service.Name)
 	}
 
-	// Get or create request scope
-	var requestScope *RequestScope
-	if ginCtx, ok := ctx.(*gin.Context); ok {
-		if scope, exists := ginCtx.Get(di.requestKey); exists {
-			requestScope = scope.(*RequestScope)
-		} else {
-			requestScope = &RequestScope{
-				instances: make(map[string]interface{}),
-			}
-			ginCtx.Set(di.requestKey, requestScope)
-		}
-	} else {
-		// For non-gin contexts, create a new scope
-		requestScope = &RequestScope{
-			instances: make(map[string]interface{}),
-		}
-	}
+	requestScope := di.scopeFor(ctx)
 
 	requestScope.mutex.RLock()
 	if instance, exists := requestScope.instances[service.Name]; exists {
 		requestScope.mutex.RUnlock()
-		return instance
+		return instance, nil
 	}
 	requestScope.mutex.RUnlock()
 
-	requestScope.mutex.Lock()
-	defer requestScope.mutex.Unlock()
+	// The factory runs without the scope lock, since it may resolve other request-scoped
+	// services; the creation guard keeps it from running twice
+	creating := requestScope.creationLock(service.Name)
+	creating.Lock()
+	defer creating.Unlock()
 
 	// Double-check after acquiring lock
-	if instance, exists := requestScope.instances[service.Name]; exists {
-		return instance
+	requestScope.mutex.RLock()
+	instance, exists := requestScope.instances[service.Name]
+	requestScope.mutex.RUnlock()
+	if exists {
+		return instance, nil
 	}
 
-	instance := di.createInstance(service, resolving, ctx)
+	instance, err := di.createInstance(service, resolving, ctx)
+	if err != nil {
+		return nil, err
+	}
+	requestScope.mutex.Lock()
+	defer requestScope.mutex.Unlock()
 	requestScope.instances[service.Name] = instance
-	return instance
+	requestScope.order = append(requestScope.order, service.Name)
+	return instance, nil
 }
 
-func (di *DIContainer) resolveTransient(service *ServiceDefinition, resolving map[string]bool, ctx context.Context) interface{} {
+func (di *DIContainer) resolveTransient(service *ServiceDefinition, resolving resolutionPath, ctx context.Context) (interface{}, error) {
 	return di.createInstance(service, resolving, ctx)
 }
 
-func (di *DIContainer) createInstance(service *ServiceDefinition, resolving map[string]bool, ctx context.Context) interface{} {
+// builderResolveError carries a dependency failure out of a ServiceBuilder
+type builderResolveError struct {
+	err error
+}
+
+func (di *DIContainer) createInstance(service *ServiceDefinition, resolving resolutionPath, ctx context.Context) (instance interface{}, err error) {
+	// Prefer statically generated builders over reflective factory calls
+	builder, compiled := di.builder(service.Name)
+	if compiled {
+		// Builders cannot return errors, so dependency failures unwind as panics
+		defer func() {
+			if r := recover(); r != nil {
+				failure, ok := r.(builderResolveError)
+				if !ok {
+					panic(r)
+				}
+				instance, err = nil, failure.err
+			}
+		}()
+		return builder(func(name string) interface{} {
+			dep, err := di.resolve(name, resolving, ctx)
+			if err != nil {
+				panic(builderResolveError{err: err})
+			}
+			return dep
+		}), nil
+	}
+
 	if service.Factory == nil {
-		panic(fmt.Sprintf("no factory function for service '%s'", service.Name))
+		return nil, NewSuperGinError(ErrInvalidFactory, "no factory function for service '%s'", service.Name)
 	}
 
 	factoryValue := reflect.ValueOf(service.Factory)
 	factoryType := factoryValue.Type()
 
+	// Validate argument types
+	if len(service.Dependencies) != factoryType.NumIn() {
+		return nil, NewSuperGinError(ErrInvalidFactory, "service '%s' factory expects %d arguments, got %d dependencies",
+			service.Name, factoryType.NumIn(), len(service.Dependencies))
+	}
+
 	// Resolve dependencies
 	args := make([]reflect.Value, len(service.Dependencies))
 	for i, depName := range service.Dependencies {
-		dep := di.resolve(depName, resolving, ctx)
+		dep, err := di.resolve(depName, resolving, ctx)
+		if IsErrorCode(err, ErrCircularDependency) {
+			// The cycle's chain already names every service on it
+			return nil, err
+		}
+		if err != nil {
+			return nil, NewSuperGinErrorWithCause(ErrFactoryFailed, err, "failed to resolve dependency '%s' of service '%s'", depName, service.Name)
+		}
 		args[i] = reflect.ValueOf(dep)
-	}
-
-	// Validate argument types
-	if len(args) != factoryType.NumIn() {
-		panic(fmt.Sprintf("service '%s' factory expects %d arguments, got %d dependencies",
-			service.Name, factoryType.NumIn(), len(args)))
+		if !args[i].IsValid() {
+			args[i] = reflect.Zero(factoryType.In(i))
+		}
 	}
 
 	// Call factory function
 	results := factoryValue.Call(args)
-	return results[0].Interface()
+	if len(results) == 2 && !results[1].IsNil() {
+		return nil, NewSuperGinErrorWithCause(ErrFactoryFailed, results[1].Interface().(error), "factory for service '%s' failed", service.Name)
+	}
+	return results[0].Interface(), nil
 }
 
-// Middleware for DI integration
-func (di *DIContainer) Middleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Create request scope
-		requestScope := &RequestScope{
-			instances: make(map[string]interface{}),
+// Inject populates struct fields tagged with `inject:"serviceName"` from the container
+func (di *DIContainer) Inject(target interface{}) error {
+	return di.InjectFromContext(nil, target)
+}
+
+// InjectFromContext populates tagged struct fields, resolving request-scoped services from ctx
+func (di *DIContainer) InjectFromContext(ctx context.Context, target interface{}) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return NewSuperGinError(ErrInjectionFailed, "inject target must be a pointer to a struct, got %T", target)
+	}
+	value = value.Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, ok := field.Tag.Lookup("inject")
+		if !ok || name == "" {
+			continue
+		}
+		if !field.IsExported() {
+			return NewSuperGinError(ErrInjectionFailed, "field '%s' tagged for injection must be exported", field.Name)
+		}
+
+		service := di.definition(name)
+		if service == nil {
+			return NewSuperGinError(ErrDIServiceNotFound, "service '%s' not registered (field '%s')", name, field.Name)
+		}
+		if service.Scope == ScopeRequest && ctx == nil {
+			return NewSuperGinError(ErrContextRequired, "request-scoped service '%s' requires context (field '%s')", name, field.Name)
+		}
+
+		resolved, err := di.resolve(name, nil, ctx)
+		if err != nil {
+			return NewSuperGinErrorWithCause(ErrInjectionFailed, err, "failed to resolve service '%s' (field '%s')", name, field.Name)
+		}
+		instance := reflect.ValueOf(resolved)
+		if !instance.IsValid() {
+			continue
 		}
-		c.Set(di.requestKey, requestScope)
-		c.Next()
+		if !instance.Type().AssignableTo(field.Type) {
+			return NewSuperGinError(ErrInjectionFailed, "service '%s' of type %s is not assignable to field '%s' of type %s",
+				name, instance.Type(), field.Name, field.Type)
+		}
+		value.Field(i).Set(instance)
 	}
+
+	return nil
 }
 
-// ListServices returns all registered services
-func (di *DIContainer) ListServices() map[string]*ServiceDefinition {
+// needsRequestScope reports whether any injected field of the struct type refers to a request-scoped service
+func (di *DIContainer) needsRequestScope(structType reflect.Type) bool {
+	for i := 0; i < structType.NumField(); i++ {
+		name, ok := structType.Field(i).Tag.Lookup("inject")
+		if !ok {
+			continue
+		}
+		if service := di.definition(name); service != nil && service.Scope != ScopeSingleton {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware for DI integration. It creates the request scope, attaches it to both the
+// gin context and the request's context.Context, and binds the gin context to the
+// handling goroutine for Resolve. The scope is closed when the request ends.
+func (di *DIContainer) Middleware() gin.HandlerFunc {
+	return di.serve
+}
+
+// serve runs the rest of the chain within a new request scope bound to the container
+func (di *DIContainer) serve(c *gin.Context) {
+	// Create request scope
+	requestScope := NewRequestScope()
+	requestScope.container = di
+	c.Set(requestScopeGinKey, requestScope)
+	c.Request = c.Request.WithContext(ContextWithScope(c.Request.Context(), requestScope))
+
+	unbind := bindGinContext(c)
+	defer unbind()
+	defer func() {
+		// Dispose even if the client went away and cancelled the request context
+		if err := requestScope.Close(context.WithoutCancel(c.Request.Context())); err != nil {
+			di.log().Error("failed to dispose request-scoped services", "error", err)
+		}
+	}()
+	c.Next()
+}
+
+// SetLogger sets the logger used for container events such as disposal failures
+func (di *DIContainer) SetLogger(logger Logger) {
+	di.mutex.Lock()
+	defer di.mutex.Unlock()
+
+	di.logger = logger
+}
+
+// log returns the container's logger, falling back to the default logger
+func (di *DIContainer) log() Logger {
 	di.mutex.RLock()
 	defer di.mutex.RUnlock()
 
+	if di.logger == nil {
+		return defaultLogger
+	}
+	return di.logger
+}
+
+// Has reports whether a service is registered here or in a parent container
+func (di *DIContainer) Has(name string) bool {
+	return di.definition(name) != nil
+}
+
+// ListServices returns all registered services, including those inherited from parent
+// containers
+func (di *DIContainer) ListServices() map[string]*ServiceDefinition {
 	services := make(map[string]*ServiceDefinition)
+	if di.parent != nil {
+		services = di.parent.ListServices()
+	}
+
+	di.mutex.RLock()
+	defer di.mutex.RUnlock()
 	for k, v := range di.services {
 		services[k] = v
 	}
@@ -296,47 +613,106 @@ func (di *DIContainer) ListServices() map[string]*ServiceDefinition {
 }
 
 // Global convenience functions
+
+// Register registers a service with the global container.
+//
+// Deprecated: Use Engine.Register, or DIContainer.Register on a container bound with WithDI.
 func Register(name string, factory interface{}, scope DIScope, dependencies ...string) *DIContainer {
+	noteGlobalDI("Register")
 	return GetDI().Register(name, factory, scope, dependencies...)
 }
 
+// RegisterSingleton registers a singleton with the global container.
+//
+// Deprecated: Use Engine.RegisterSingleton.
 func RegisterSingleton(name string, factory interface{}, dependencies ...string) *DIContainer {
+	noteGlobalDI("RegisterSingleton")
 	return GetDI().RegisterSingleton(name, factory, dependencies...)
 }
 
+// RegisterRequest registers a request-scoped service with the global container.
+//
+// Deprecated: Use Engine.RegisterRequest.
 func RegisterRequest(name string, factory interface{}, dependencies ...string) *DIContainer {
+	noteGlobalDI("RegisterRequest")
 	return GetDI().RegisterRequest(name, factory, dependencies...)
 }
 
+// RegisterTransient registers a transient service with the global container.
+//
+// Deprecated: Use Engine.RegisterTransient.
 func RegisterTransient(name string, factory interface{}, dependencies ...string) *DIContainer {
+	noteGlobalDI("RegisterTransient")
 	return GetDI().RegisterTransient(name, factory, dependencies...)
 }
 
+// RegisterInstance registers an instance with the global container.
+//
+// Deprecated: Use Engine.RegisterInstance.
 func RegisterInstance(name string, instance interface{}) *DIContainer {
+	noteGlobalDI("RegisterInstance")
 	return GetDI().RegisterInstance(name, instance)
 }
 
+// Get resolves a service from the global container.
+//
+// Deprecated: Use Resolve in handlers, or ResolveIn with the engine's container.
 func Get(name string) interface{} {
+	noteGlobalDI("Get")
 	return GetDI().Get(name)
 }
 
+// GetFromContext resolves a service from the global container with request context.
+//
+// Deprecated: Use GetFromContextT, which uses the container serving the request.
 func GetFromContext(ctx context.Context, name string) interface{} {
+	noteGlobalDI("GetFromContext")
 	return GetDI().GetFromContext(ctx, name)
 }
 
-// Service resolver that works without context in handlers
+// Inject populates tagged struct fields from the global container.
+//
+// Deprecated: Use DIContainer.Inject on the engine's container.
+func Inject(target interface{}) error {
+	noteGlobalDI("Inject")
+	return GetDI().Inject(target)
+}
+
+// ResolveTagged returns all services of the global container carrying the tag that are
+// assignable to T.
+//
+// Deprecated: Use DIContainer.GetTagged on the engine's container.
+func ResolveTagged[T any](tag string) []T {
+	noteGlobalDI("ResolveTagged")
+	var result []T
+	for _, instance := range GetDI().GetTagged(tag) {
+		if typed, ok := instance.(T); ok {
+			result = append(result, typed)
+		}
+	}
+	return result
+}
+
+// Resolve resolves a service without passing a context. Inside a request handled
+// through the DI middleware it uses the request's scope, so request-scoped services
+// work from handlers and controllers; elsewhere request-scoped services panic.
+// Goroutines started by a handler should use GetFromContextT with the request context.
 func Resolve[T any](name string) T {
-	// Try to get from current goroutine's gin context if available
+	// Use the gin context bound to the current goroutine by the DI middleware
 	if ginCtx := getCurrentGinContext(); ginCtx != nil {
 		return GetFromContextT[T](ginCtx, name)
 	}
-	return GetT[T](name)
+	noteGlobalDI("Resolve")
+	return mustResolveT[T](name)
 }
 
-// getCurrentGinContext attempts to get gin context from current goroutine
-// This is a simplified implementation - in production you'd want a more robust solution
-func getCurrentGinContext() *gin.Context {
-	// This would require goroutine-local storage implementation
-	// For now, we'll return nil and fall back to singleton resolution
-	return nil
+// TryResolve is Resolve returning resolution failures as a *SuperGinError instead of panicking
+func TryResolve[T any](name string) (T, error) {
+	if ginCtx := getCurrentGinContext(); ginCtx != nil {
+		instance, err := containerFor(ginCtx).TryGetFromContext(ginCtx, name)
+		return asService[T](name, instance, err)
+	}
+	noteGlobalDI("TryResolve")
+	instance, err := GetDI().TryGet(name)
+	return asService[T](name, instance, err)
 }
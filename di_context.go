@@ -0,0 +1,135 @@
+package supergin
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestScopeKey carries a RequestScope in a context.Context
+type requestScopeKey struct{}
+
+// requestScopeGinKey stores the request's scope in the gin context, whichever container
+// created it
+const requestScopeGinKey = "supergin:request_scope"
+
+// NewRequestScope creates an empty request scope, e.g. for background jobs or
+// WebSocket messages that should share request-scoped services
+func NewRequestScope() *RequestScope {
+	return &RequestScope{instances: make(map[string]interface{})}
+}
+
+// ContextWithScope returns a context carrying the request scope. Request-scoped services
+// resolved with any context derived from it share the scope's instances.
+func ContextWithScope(ctx context.Context, scope *RequestScope) context.Context {
+	return context.WithValue(ctx, requestScopeKey{}, scope)
+}
+
+// ScopeFromContext returns the request scope carried by a context
+func ScopeFromContext(ctx context.Context) (*RequestScope, bool) {
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		if scope, exists := ginCtx.Get(requestScopeGinKey); exists {
+			return scope.(*RequestScope), true
+		}
+		if ginCtx.Request == nil {
+			return nil, false
+		}
+		ctx = ginCtx.Request.Context()
+	}
+	scope, ok := ctx.Value(requestScopeKey{}).(*RequestScope)
+	return scope, ok
+}
+
+// Provide stores an instance of a request-scoped service in the scope, e.g. claims that
+// authentication middleware verified, so resolving the service in the request returns it
+// instead of calling the factory. The scope does not dispose provided instances.
+func (s *RequestScope) Provide(name string, instance interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.instances[name] = instance
+}
+
+// creationLock returns the guard serialising creation of a service in the scope
+func (s *RequestScope) creationLock(name string) *sync.Mutex {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.creating == nil {
+		s.creating = make(map[string]*sync.Mutex)
+	}
+	lock, exists := s.creating[name]
+	if !exists {
+		lock = &sync.Mutex{}
+		s.creating[name] = lock
+	}
+	return lock
+}
+
+// scopeFor returns the request scope for ctx, creating one when none is attached.
+// Scopes created for plain contexts without a scope are not shared between calls.
+func (di *DIContainer) scopeFor(ctx context.Context) *RequestScope {
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		if scope, exists := ginCtx.Get(requestScopeGinKey); exists {
+			return scope.(*RequestScope)
+		}
+		var scope *RequestScope
+		if ginCtx.Request != nil {
+			scope, _ = ginCtx.Request.Context().Value(requestScopeKey{}).(*RequestScope)
+		}
+		if scope == nil {
+			scope = NewRequestScope()
+			if ginCtx.Request != nil {
+				ginCtx.Request = ginCtx.Request.WithContext(ContextWithScope(ginCtx.Request.Context(), scope))
+			}
+		}
+		ginCtx.Set(requestScopeGinKey, scope)
+		return scope
+	}
+
+	if scope, ok := ctx.Value(requestScopeKey{}).(*RequestScope); ok {
+		return scope
+	}
+	return NewRequestScope()
+}
+
+// boundContexts maps goroutine IDs to the gin context they are handling
+var boundContexts sync.Map
+
+// bindGinContext associates the gin context with the current goroutine until unbind is called
+func bindGinContext(c *gin.Context) (unbind func()) {
+	id := goroutineID()
+	previous, hadPrevious := boundContexts.Load(id)
+	boundContexts.Store(id, c)
+	return func() {
+		if hadPrevious {
+			boundContexts.Store(id, previous)
+		} else {
+			boundContexts.Delete(id)
+		}
+	}
+}
+
+// getCurrentGinContext returns the gin context bound to the current goroutine
+func getCurrentGinContext() *gin.Context {
+	if c, ok := boundContexts.Load(goroutineID()); ok {
+		return c.(*gin.Context)
+	}
+	return nil
+}
+
+// goroutineID parses the current goroutine's ID from its stack header
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i > 0 {
+		header = header[:i]
+	}
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+	return id
+}
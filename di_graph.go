@@ -0,0 +1,157 @@
+package supergin
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DIGraphPath is where EnableDIGraphEndpoint mounts the dependency graph
+const DIGraphPath = "/_admin/di/graph"
+
+// DependencyGraph is the wiring of a DI container: a node per service and an edge from
+// each service to each of its dependencies
+type DependencyGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a service in a dependency graph
+type GraphNode struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type,omitempty"`
+	Scope DIScope  `json:"scope,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+	// Inherited marks services registered in a parent container
+	Inherited bool `json:"inherited,omitempty"`
+	// Missing marks dependencies no container registers; resolving their dependents fails
+	Missing bool `json:"missing,omitempty"`
+}
+
+// GraphEdge is a dependency of one service on another
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph returns the container's services, including those inherited from parent
+// containers, and their dependencies, sorted by name
+func (di *DIContainer) Graph() DependencyGraph {
+	services := di.ListServices()
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	graph := DependencyGraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	missing := make(map[string]bool)
+	for _, name := range names {
+		service := services[name]
+		di.mutex.RLock()
+		_, local := di.services[name]
+		di.mutex.RUnlock()
+
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			Name:      name,
+			Type:      typeName(service.Type),
+			Scope:     service.Scope,
+			Tags:      service.Tags,
+			Inherited: !local,
+		})
+		for _, dep := range service.Dependencies {
+			graph.Edges = append(graph.Edges, GraphEdge{From: name, To: dep})
+			if _, exists := services[dep]; !exists {
+				missing[dep] = true
+			}
+		}
+	}
+
+	missingNames := make([]string, 0, len(missing))
+	for name := range missing {
+		missingNames = append(missingNames, name)
+	}
+	sort.Strings(missingNames)
+	for _, name := range missingNames {
+		graph.Nodes = append(graph.Nodes, GraphNode{Name: name, Missing: true})
+	}
+	return graph
+}
+
+// dotStyles draws each scope with its own border style
+var dotStyles = map[DIScope]string{
+	ScopeSingleton: "solid",
+	ScopeRequest:   "dashed",
+	ScopeTransient: "dotted",
+}
+
+// WriteDOT renders the graph in Graphviz DOT, e.g. for `dot -Tsvg`. Borders show the
+// scope (solid singleton, dashed request, dotted transient), inherited services are
+// grey and missing dependencies red.
+func (g DependencyGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph services {\n\trankdir=LR;\n\tnode [shape=box, fontname=\"Helvetica\"];\n")
+	for _, node := range g.Nodes {
+		label := node.Name
+		attrs := []string{}
+		switch {
+		case node.Missing:
+			label += "\nnot registered"
+			attrs = append(attrs, "color=red", "fontcolor=red")
+		default:
+			if node.Type != "" {
+				label += "\n" + node.Type
+			}
+			label += "\n" + string(node.Scope)
+			if style, exists := dotStyles[node.Scope]; exists {
+				attrs = append(attrs, "style="+style)
+			}
+			if node.Inherited {
+				attrs = append(attrs, "color=grey50", "fontcolor=grey50")
+			}
+		}
+		attrs = append([]string{"label=" + strconv.Quote(label)}, attrs...)
+		fmt.Fprintf(&b, "\t%s [%s];\n", strconv.Quote(node.Name), strings.Join(attrs, ", "))
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s;\n", strconv.Quote(edge.From), strconv.Quote(edge.To))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// EnableDIGraphEndpoint registers GET /_admin/di/graph, serving the engine container's
+// dependency graph as JSON, or as Graphviz DOT with ?format=dot, behind the given
+// authentication middleware
+func (e *Engine) EnableDIGraphEndpoint(auth gin.HandlerFunc, middleware ...gin.HandlerFunc) *Engine {
+	if auth == nil {
+		panic("DI graph endpoint requires an authentication middleware")
+	}
+
+	e.Named("admin_di_graph").
+		GET(DIGraphPath).
+		WithDescription("Dependency graph of the DI container's services, as JSON or Graphviz DOT").
+		WithTags("admin").
+		WithMiddleware(append([]gin.HandlerFunc{auth}, middleware...)...).
+		Handler(func(c *gin.Context) {
+			graph := e.di.Graph()
+			if c.Query("format") != "dot" {
+				c.JSON(http.StatusOK, graph)
+				return
+			}
+			c.Header("Content-Type", "text/vnd.graphviz; charset=utf-8")
+			c.Status(http.StatusOK)
+			if err := graph.WriteDOT(c.Writer); err != nil {
+				LoggerFor(c).Error("failed to write DI graph", "error", err)
+			}
+		})
+
+	return e
+}
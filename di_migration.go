@@ -0,0 +1,148 @@
+package supergin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// NewContainer creates a standalone DI container, e.g. one per engine bound with WithDI,
+// replacing the process-wide container the global Register and Get functions use
+func NewContainer() *DIContainer {
+	return &DIContainer{
+		services:   make(map[string]*ServiceDefinition),
+		singletons: make(map[string]interface{}),
+		builders:   make(map[string]ServiceBuilder),
+	}
+}
+
+// UseGlobalDI is a migration shim: it points GetDI, and with it the deprecated global
+// functions and engines created afterwards without WithDI, at container. Code not yet
+// migrated keeps working against the engine's container while callers move to it.
+// Call it during startup, before resolving services.
+func UseGlobalDI(container *DIContainer) {
+	if container == nil {
+		panic("global DI container must not be nil")
+	}
+	GetDI()
+	globalDI.Store(container)
+}
+
+// Register registers a service with the engine's container
+func (e *Engine) Register(name string, factory interface{}, scope DIScope, dependencies ...string) *DIContainer {
+	return e.di.Register(name, factory, scope, dependencies...)
+}
+
+// RegisterSingleton registers a singleton with the engine's container
+func (e *Engine) RegisterSingleton(name string, factory interface{}, dependencies ...string) *DIContainer {
+	return e.di.RegisterSingleton(name, factory, dependencies...)
+}
+
+// RegisterRequest registers a request-scoped service with the engine's container
+func (e *Engine) RegisterRequest(name string, factory interface{}, dependencies ...string) *DIContainer {
+	return e.di.RegisterRequest(name, factory, dependencies...)
+}
+
+// RegisterTransient registers a transient service with the engine's container
+func (e *Engine) RegisterTransient(name string, factory interface{}, dependencies ...string) *DIContainer {
+	return e.di.RegisterTransient(name, factory, dependencies...)
+}
+
+// RegisterInstance registers an existing instance with the engine's container
+func (e *Engine) RegisterInstance(name string, instance interface{}) *DIContainer {
+	return e.di.RegisterInstance(name, instance)
+}
+
+// ProvideIn registers a factory for T with a container, like Provide does with the global
+// one, e.g. supergin.ProvideIn[UserRepository](app.DI(), NewPostgresRepository)
+func ProvideIn[T any](di *DIContainer, factory interface{}, opts ...ProvideOptions) *DIContainer {
+	return di.provide(reflect.TypeOf((*T)(nil)).Elem(), factory, opts...)
+}
+
+// ResolveIn resolves a service from a container, using the request scope inside handlers
+// like Resolve; resolution failures panic
+func ResolveIn[T any](di *DIContainer, name string) T {
+	service, err := TryResolveIn[T](di, name)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
+
+// TryResolveIn is ResolveIn returning resolution failures as a *SuperGinError
+func TryResolveIn[T any](di *DIContainer, name string) (T, error) {
+	var ctx context.Context
+	if ginCtx := getCurrentGinContext(); ginCtx != nil {
+		ctx = ginCtx
+	}
+	instance, err := di.resolve(name, nil, ctx)
+	return asService[T](name, instance, err)
+}
+
+// GlobalDIUse is a call site of a deprecated global DI function
+type GlobalDIUse struct {
+	Function string `json:"function"`
+	Caller   string `json:"caller"`
+	Calls    uint64 `json:"calls"`
+}
+
+// globalDIUses counts global DI calls per function and call site while TrackGlobalDI is
+// enabled
+var (
+	trackGlobalDI atomic.Bool
+	globalDIUses  = make(map[string]*GlobalDIUse)
+	globalDIMutex sync.Mutex
+)
+
+// TrackGlobalDI enables or disables recording of calls to the deprecated global DI
+// functions, for GlobalDIUsage. Recording costs a stack lookup per call.
+func TrackGlobalDI(enabled bool) {
+	trackGlobalDI.Store(enabled)
+}
+
+// GlobalDIUsage returns the call sites of deprecated global DI functions recorded while
+// TrackGlobalDI was enabled, sorted by caller. Run the application's tests or traffic with
+// tracking on to find the code paths still using the global container; `supergin dicheck`
+// finds them statically.
+func GlobalDIUsage() []GlobalDIUse {
+	globalDIMutex.Lock()
+	defer globalDIMutex.Unlock()
+
+	uses := make([]GlobalDIUse, 0, len(globalDIUses))
+	for _, use := range globalDIUses {
+		uses = append(uses, *use)
+	}
+	sort.Slice(uses, func(i, j int) bool {
+		if uses[i].Caller != uses[j].Caller {
+			return uses[i].Caller < uses[j].Caller
+		}
+		return uses[i].Function < uses[j].Function
+	})
+	return uses
+}
+
+// noteGlobalDI records the caller of a deprecated global DI function
+func noteGlobalDI(function string) {
+	if !trackGlobalDI.Load() {
+		return
+	}
+	caller := "unknown"
+	// Skip noteGlobalDI and the global function itself
+	if _, file, line, ok := runtime.Caller(2); ok {
+		caller = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	globalDIMutex.Lock()
+	defer globalDIMutex.Unlock()
+	key := function + "\x00" + caller
+	use, exists := globalDIUses[key]
+	if !exists {
+		use = &GlobalDIUse{Function: function, Caller: caller}
+		globalDIUses[key] = use
+	}
+	use.Calls++
+}
@@ -0,0 +1,132 @@
+package supergin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewScope creates a child container that sees every registration of di and can shadow
+// them with its own, e.g. test doubles or per-tenant configuration, without mutating di.
+// Services registered in di are shared with it unless they depend, directly or
+// transitively, on a service the child registers; those are rebuilt in the child from
+// di's registration. Shutting the child down disposes only what it created.
+func (di *DIContainer) NewScope() *DIContainer {
+	return &DIContainer{
+		services:   make(map[string]*ServiceDefinition),
+		singletons: make(map[string]interface{}),
+		builders:   make(map[string]ServiceBuilder),
+		logger:     di.log(),
+		parent:     di,
+	}
+}
+
+// Override replaces a registration with an instance, e.g. a fake in tests. Unlike
+// RegisterInstance it panics if the service is not registered here or in a parent, so
+// renamed services do not leave tests silently using the real implementation. Override
+// before resolving services that depend on it.
+func (di *DIContainer) Override(name string, instance interface{}) *DIContainer {
+	if !di.Has(name) {
+		panic(fmt.Sprintf("cannot override service '%s': not registered", name))
+	}
+	return di.RegisterInstance(name, instance)
+}
+
+// definition returns the registration of a service here or in the nearest parent
+func (di *DIContainer) definition(name string) *ServiceDefinition {
+	for container := di; container != nil; container = container.parent {
+		container.mutex.RLock()
+		service, exists := container.services[name]
+		container.mutex.RUnlock()
+		if exists {
+			return service
+		}
+	}
+	return nil
+}
+
+// builder returns the generated builder of a service here or in the nearest parent
+func (di *DIContainer) builder(name string) (ServiceBuilder, bool) {
+	for container := di; container != nil; container = container.parent {
+		container.buildersMu.RLock()
+		builder, exists := container.builders[name]
+		container.buildersMu.RUnlock()
+		if exists {
+			return builder, true
+		}
+	}
+	return nil, false
+}
+
+// shadows reports whether an inherited service depends, directly or transitively, on a
+// service registered in this container
+func (di *DIContainer) shadows(service *ServiceDefinition, seen map[string]bool) bool {
+	for _, dep := range service.Dependencies {
+		if seen[dep] {
+			continue
+		}
+		seen[dep] = true
+
+		di.mutex.RLock()
+		_, local := di.services[dep]
+		di.mutex.RUnlock()
+		if local {
+			return true
+		}
+		if inherited := di.parent.definition(dep); inherited != nil && di.shadows(inherited, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// adopt copies an inherited registration into this container, so its instances are
+// created and cached here
+func (di *DIContainer) adopt(inherited *ServiceDefinition) *ServiceDefinition {
+	di.mutex.Lock()
+	defer di.mutex.Unlock()
+
+	if service, exists := di.services[inherited.Name]; exists {
+		return service
+	}
+	service := &ServiceDefinition{
+		Name:         inherited.Name,
+		Type:         inherited.Type,
+		Scope:        inherited.Scope,
+		Factory:      inherited.Factory,
+		Dependencies: inherited.Dependencies,
+		Metadata:     inherited.Metadata,
+		Tags:         inherited.Tags,
+	}
+	di.services[service.Name] = service
+	return service
+}
+
+// containerFor returns the container serving the request ctx belongs to, or the global one
+func containerFor(ctx context.Context) *DIContainer {
+	if ctx != nil {
+		if scope, ok := ScopeFromContext(ctx); ok && scope.container != nil {
+			return scope.container
+		}
+	}
+	return GetDI()
+}
+
+// WithDI binds the engine to a container other than the global one, e.g. a child created
+// with NewScope. Call it before registering routes and services through the engine;
+// Resolve, InjectT and GetFromContextT in its handlers then use the container.
+func (e *Engine) WithDI(container *DIContainer) *Engine {
+	if e.config.Logger != nil {
+		container.SetLogger(e.config.Logger)
+	}
+	e.di = container
+	return e
+}
+
+// diMiddleware runs the DI middleware of the engine's current container
+func (e *Engine) diMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		e.di.serve(c)
+	}
+}
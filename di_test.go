@@ -0,0 +1,64 @@
+package supergin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testRequestID struct{ n int }
+
+type testAuditLog struct{ request *testRequestID }
+
+func TestRequestScopedServices(t *testing.T) {
+	app := newTestEngine(Config{}).WithDI(NewContainer())
+	created := 0
+	app.DI().RegisterRequest("request_id", func() *testRequestID {
+		created++
+		return &testRequestID{n: created}
+	})
+	// A request-scoped service depending on another one
+	app.DI().RegisterRequest("audit_log", func(id *testRequestID) *testAuditLog {
+		return &testAuditLog{request: id}
+	}, "request_id")
+
+	app.Named("audit").GET("/audit").Handler(func(c *gin.Context) {
+		audit := Resolve[*testAuditLog]("audit_log")
+		id := Resolve[*testRequestID]("request_id")
+		if audit.request != id {
+			t.Error("request-scoped dependency is not shared within the request")
+		}
+		if Resolve[*testAuditLog]("audit_log") != audit {
+			t.Error("request-scoped service is created twice in one request")
+		}
+		// The engine's own container holds the scope, not the global one
+		if scope, ok := ScopeFromContext(c); !ok || !slices.Contains(scope.names(), "audit_log") {
+			t.Error("ScopeFromContext does not find the engine container's request scope")
+		}
+		c.JSON(http.StatusOK, gin.H{"request": id.n})
+	})
+
+	for want := 1; want <= 2; want++ {
+		w := httptest.NewRecorder()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/audit", nil))
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("resolving a request-scoped dependency deadlocked")
+		}
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, body %s", w.Code, w.Body)
+		}
+		if created != want {
+			t.Errorf("after %d requests %d instances were created", want, created)
+		}
+	}
+}
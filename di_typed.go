@@ -0,0 +1,142 @@
+package supergin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ProvideOptions configures a service registered by type
+type ProvideOptions struct {
+	// Name distinguishes implementations of the same type. It is also the service's string
+	// key, so Get and inject tags find it; unnamed services are keyed by their type.
+	Name string
+	// Scope is the service's lifecycle; empty means ScopeSingleton
+	Scope DIScope
+	// Dependencies names the services passed to the factory. Nil resolves each factory
+	// parameter by its type.
+	Dependencies []string
+}
+
+// typedKey identifies a service registered by type and optional name
+type typedKey struct {
+	t    reflect.Type
+	name string
+}
+
+// Provide registers a factory for T, which may be an interface the factory's result
+// implements. Factory parameters are resolved by their types unless Dependencies are given:
+//
+//	supergin.Provide[UserRepository](NewPostgresRepository)
+//	supergin.Provide[UserService](func(repo UserRepository) UserService {
+//		return &UserServiceImpl{repo: repo}
+//	}, supergin.ProvideOptions{Scope: supergin.ScopeRequest})
+//
+// Provide registers with the global container.
+//
+// Deprecated: Use ProvideIn with the engine's container.
+func Provide[T any](factory interface{}, opts ...ProvideOptions) *DIContainer {
+	noteGlobalDI("Provide")
+	return GetDI().provide(reflect.TypeOf((*T)(nil)).Elem(), factory, opts...)
+}
+
+func (di *DIContainer) provide(t reflect.Type, factory interface{}, opts ...ProvideOptions) *DIContainer {
+	options := ProvideOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Scope == "" {
+		options.Scope = ScopeSingleton
+	}
+
+	factoryType := reflect.TypeOf(factory)
+	if factoryType == nil || factoryType.Kind() != reflect.Func || !validFactoryResults(factoryType) {
+		panic(fmt.Sprintf("factory for %s must be a function returning a value or (value, error)", t))
+	}
+	if !factoryType.Out(0).AssignableTo(t) {
+		panic(fmt.Sprintf("factory for %s returns %s, which is not assignable to it", t, factoryType.Out(0)))
+	}
+
+	dependencies := options.Dependencies
+	if dependencies == nil {
+		dependencies = make([]string, factoryType.NumIn())
+		for i := range dependencies {
+			dependencies[i] = di.typedName(factoryType.In(i), "")
+		}
+	}
+
+	name := options.Name
+	if name == "" {
+		name = typedServiceName(t)
+	}
+	di.Register(name, factory, options.Scope, dependencies...)
+
+	di.mutex.Lock()
+	defer di.mutex.Unlock()
+	if di.typed == nil {
+		di.typed = make(map[typedKey]string)
+	}
+	di.typed[typedKey{t: t, name: options.Name}] = name
+	return di
+}
+
+// typedServiceName is the string key of a service registered by type without a name
+func typedServiceName(t reflect.Type) string {
+	return t.String()
+}
+
+// typedName returns the string key of the service registered for t and name. A name
+// without a typed registration is taken as a plain string key.
+func (di *DIContainer) typedName(t reflect.Type, name string) string {
+	for container := di; container != nil; container = container.parent {
+		container.mutex.RLock()
+		service, exists := container.typed[typedKey{t: t, name: name}]
+		container.mutex.RUnlock()
+		if exists {
+			return service
+		}
+	}
+	if name != "" {
+		return name
+	}
+	return typedServiceName(t)
+}
+
+// InjectT resolves the service registered for T, or the implementation with the given
+// name. Like Resolve, it uses the request scope inside handlers; resolution failures panic.
+func InjectT[T any](name ...string) T {
+	service, err := TryInjectT[T](name...)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
+
+// TryInjectT is InjectT returning resolution failures as a *SuperGinError
+func TryInjectT[T any](name ...string) (T, error) {
+	if ginCtx := getCurrentGinContext(); ginCtx != nil {
+		return tryInjectT[T](ginCtx, name...)
+	}
+	return tryInjectT[T](nil, name...)
+}
+
+// InjectFromContextT resolves the service registered for T with the request context, e.g.
+// from goroutines started by a handler
+func InjectFromContextT[T any](ctx context.Context, name ...string) T {
+	service, err := tryInjectT[T](ctx, name...)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
+
+func tryInjectT[T any](ctx context.Context, name ...string) (T, error) {
+	implementation := ""
+	if len(name) > 0 {
+		implementation = name[0]
+	}
+	di := containerFor(ctx)
+	service := di.typedName(reflect.TypeOf((*T)(nil)).Elem(), implementation)
+	instance, err := di.resolve(service, nil, ctx)
+	return asService[T](service, instance, err)
+}
@@ -0,0 +1,95 @@
+package supergin
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// resolutionPath is the chain of services being resolved, outermost first
+type resolutionPath []string
+
+// cycle returns the chain from name's earlier occurrence back to name, e.g.
+// "A → B → C → A", or false if name is not being resolved
+func (p resolutionPath) cycle(name string) (string, bool) {
+	for i, resolving := range p {
+		if resolving == name {
+			chain := append(append([]string(nil), p[i:]...), name)
+			return strings.Join(chain, " → "), true
+		}
+	}
+	return "", false
+}
+
+// circularDependencyError reports a dependency cycle with its full chain
+func circularDependencyError(chain string) *SuperGinError {
+	return NewSuperGinError(ErrCircularDependency, "circular dependency: %s", chain)
+}
+
+// Validate checks the service graph, including services inherited from parent containers,
+// without creating any service: dependencies must be registered, factories must take one
+// argument per dependency and no dependency chain may lead back to its start. Every
+// problem found is returned, joined. Start and Run call it before serving traffic.
+func (di *DIContainer) Validate() error {
+	services := di.ListServices()
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		service := services[name]
+		for _, dep := range service.Dependencies {
+			if _, exists := services[dep]; !exists {
+				errs = append(errs, NewSuperGinError(ErrDIServiceNotFound,
+					"service '%s' depends on '%s', which is not registered", name, dep))
+			}
+		}
+		if _, compiled := di.builder(name); compiled || service.Factory == nil {
+			continue
+		}
+		if in := reflect.TypeOf(service.Factory).NumIn(); in != len(service.Dependencies) {
+			errs = append(errs, NewSuperGinError(ErrInvalidFactory, "service '%s' factory expects %d arguments, got %d dependencies",
+				name, in, len(service.Dependencies)))
+		}
+	}
+
+	// Depth-first walk; reaching a service still on the path closes a cycle
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(services))
+	var path resolutionPath
+	var visit func(name string)
+	visit = func(name string) {
+		switch state[name] {
+		case visiting:
+			chain, _ := path.cycle(name)
+			errs = append(errs, circularDependencyError(chain))
+			return
+		case done:
+			return
+		}
+		service, exists := services[name]
+		if !exists {
+			return
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range service.Dependencies {
+			visit(dep)
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+	}
+	for _, name := range names {
+		visit(name)
+	}
+
+	return errors.Join(errs...)
+}
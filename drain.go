@@ -0,0 +1,217 @@
+package supergin
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DrainPath is where EnableDrainEndpoint mounts the drain action
+	DrainPath = "/_admin/drain"
+	// ReadyPath reports readiness for load balancer health checks: 200, or 503 while draining
+	ReadyPath = "/_admin/ready"
+)
+
+const (
+	// DefaultDrainWindow is how long migrate frames are spread over
+	DefaultDrainWindow = 30 * time.Second
+	// DefaultRetryAfter is the reconnect hint given to rejected and migrated clients
+	DefaultRetryAfter = 5 * time.Second
+)
+
+// DrainOptions configures draining
+type DrainOptions struct {
+	// Window spreads migrate frames over this duration so clients do not reconnect at once;
+	// connections left at its end are closed. Zero means DefaultDrainWindow.
+	Window time.Duration
+	// RetryAfter is the reconnect hint; zero means DefaultRetryAfter
+	RetryAfter time.Duration
+}
+
+// DrainStatus describes the engine's draining state
+type DrainStatus struct {
+	Draining    bool      `json:"draining"`
+	Since       time.Time `json:"since,omitempty"`
+	Window      string    `json:"window,omitempty"`
+	RetryAfter  int       `json:"retry_after,omitempty"`
+	Connections int       `json:"websocket_connections"`
+}
+
+// drainState records an engine's drain
+type drainState struct {
+	since   time.Time
+	options DrainOptions
+}
+
+// Drain marks the instance as draining ahead of a rolling restart: the ready route answers
+// 503 so load balancers deregister it, HTTP responses carry Connection: close, new
+// WebSocket upgrades are rejected with a Retry-After hint and existing connections receive
+// a "migrate" message spread over the window, then are closed. Draining again is a no-op.
+func (e *Engine) Drain(opts ...DrainOptions) {
+	options := DrainOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Window <= 0 {
+		options.Window = DefaultDrainWindow
+	}
+	if options.RetryAfter <= 0 {
+		options.RetryAfter = DefaultRetryAfter
+	}
+
+	if !e.drain.CompareAndSwap(nil, &drainState{since: time.Now(), options: options}) {
+		return
+	}
+	// Hubs tracked from here on see the drain in trackHub
+	e.lifecycleMux.Lock()
+	server := e.server
+	hubs := append([]*WebSocketHub(nil), e.hubs...)
+	e.lifecycleMux.Unlock()
+
+	e.logger.Info("draining", "window", options.Window.String(), "retry_after", options.RetryAfter.String())
+	if server != nil {
+		server.SetKeepAlivesEnabled(false)
+	}
+	for _, hub := range hubs {
+		hub.drain(options)
+	}
+}
+
+// DrainStatus reports whether the engine is draining
+func (e *Engine) DrainStatus() DrainStatus {
+	state := e.drain.Load()
+	e.lifecycleMux.Lock()
+	hubs := append([]*WebSocketHub(nil), e.hubs...)
+	e.lifecycleMux.Unlock()
+
+	status := DrainStatus{}
+	for _, hub := range hubs {
+		status.Connections += len(hub.GetConnections())
+	}
+	if state != nil {
+		status.Draining = true
+		status.Since = state.since
+		status.Window = state.options.Window.String()
+		status.RetryAfter = retryAfterSeconds(state.options.RetryAfter)
+	}
+	return status
+}
+
+// Draining reports whether Drain has been called
+func (e *Engine) Draining() bool {
+	return e.drain.Load() != nil
+}
+
+// EnableDrainEndpoint registers POST /_admin/drain, which starts draining, behind the given
+// authentication middleware, and the unauthenticated GET /_admin/ready readiness route.
+// The drain action accepts ?window=30s and ?retry_after=5s.
+func (e *Engine) EnableDrainEndpoint(auth gin.HandlerFunc, middleware ...gin.HandlerFunc) *Engine {
+	if auth == nil {
+		panic("drain endpoint requires an authentication middleware")
+	}
+
+	e.Named("admin_drain").
+		POST(DrainPath).
+		WithDescription("Start draining this instance for a rolling restart").
+		WithTags("admin").
+		WithMiddleware(append([]gin.HandlerFunc{auth}, middleware...)...).
+		WithOutput(DrainStatus{}).
+		Handler(func(c *gin.Context) {
+			options := DrainOptions{}
+			for param, target := range map[string]*time.Duration{"window": &options.Window, "retry_after": &options.RetryAfter} {
+				raw := c.Query(param)
+				if raw == "" {
+					continue
+				}
+				d, err := time.ParseDuration(raw)
+				if err != nil || d < 0 {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + ": " + raw})
+					return
+				}
+				*target = d
+			}
+
+			LoggerFor(c).Warn("drain requested", "client_ip", c.ClientIP())
+			e.Drain(options)
+			c.JSON(http.StatusAccepted, e.DrainStatus())
+		})
+
+	e.Named("admin_ready").
+		GET(ReadyPath).
+		WithDescription("Readiness for load balancers; 503 while draining").
+		WithTags("admin").
+		WithOutput(DrainStatus{}).
+		Handler(func(c *gin.Context) {
+			status := e.DrainStatus()
+			if status.Draining {
+				c.Header("Retry-After", strconv.Itoa(status.RetryAfter))
+				c.JSON(http.StatusServiceUnavailable, status)
+				return
+			}
+			c.JSON(http.StatusOK, status)
+		})
+
+	return e
+}
+
+// drainMiddleware asks clients to close keep-alive connections while draining
+func (e *Engine) drainMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if e.Draining() {
+			c.Header("Connection", "close")
+		}
+		c.Next()
+	}
+}
+
+// drain rejects new upgrades and sends every connection a migrate message, spread evenly
+// over the window, before closing whatever remains
+func (h *WebSocketHub) drain(options DrainOptions) {
+	h.mutex.Lock()
+	if h.draining != nil {
+		h.mutex.Unlock()
+		return
+	}
+	h.draining = &options
+	connections := make([]*WebSocketConnection, 0, len(h.connections))
+	for _, conn := range h.connections {
+		connections = append(connections, conn)
+	}
+	h.mutex.Unlock()
+
+	go func() {
+		interval := options.Window / time.Duration(len(connections)+1)
+		migrate := map[string]interface{}{
+			"reason":      "draining",
+			"retry_after": retryAfterSeconds(options.RetryAfter),
+		}
+		for _, conn := range connections {
+			if err := conn.Send("migrate", migrate); err != nil {
+				h.log().Warn("failed to send migrate message", "connection_id", conn.ID, "error", err)
+			}
+			time.Sleep(interval)
+		}
+		time.Sleep(interval)
+		h.closeAll(CloseGoingAway, "server draining")
+	}()
+}
+
+// drainingRetryAfter returns the hub's retry hint and whether it is draining
+func (h *WebSocketHub) drainingRetryAfter() (time.Duration, bool) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if h.draining == nil {
+		return 0, false
+	}
+	return h.draining.RetryAfter, true
+}
+
+// retryAfterSeconds rounds a retry hint up to whole seconds for Retry-After
+func retryAfterSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,56 @@
+package supergin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDrain(t *testing.T) {
+	app := newTestEngine(Config{})
+	app.Named("ping").GET("/ping").Handler(func(c *gin.Context) { c.Status(http.StatusOK) })
+	app.EnableDrainEndpoint(func(c *gin.Context) {})
+	before := app.WebSocket("chat", "/chat", &DefaultWebSocketHandler{}, WithLogger(quietLogger()))
+	defer before.Shutdown(context.Background())
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	if w := get("/ping"); w.Header().Get("Connection") == "close" {
+		t.Error("Connection: close before draining")
+	}
+
+	// Requests read the drain state while it starts
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			get("/ping")
+		}()
+	}
+	app.Drain(DrainOptions{Window: 10 * time.Millisecond, RetryAfter: 2 * time.Second})
+	wg.Wait()
+
+	after := app.WebSocket("alerts", "/alerts", &DefaultWebSocketHandler{}, WithLogger(quietLogger()))
+	defer after.Shutdown(context.Background())
+
+	if w := get("/ping"); w.Header().Get("Connection") != "close" {
+		t.Error("no Connection: close while draining")
+	}
+	if w := get(ReadyPath); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("ready = %d while draining, want 503", w.Code)
+	}
+	for _, path := range []string{"/chat", "/alerts"} {
+		if w := get(path); w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "2" {
+			t.Errorf("upgrade on %s = %d with Retry-After %q, want 503 with 2", path, w.Code, w.Header().Get("Retry-After"))
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package supergin
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// EnumValue constrains the underlying types RegisterEnum accepts
+type EnumValue interface {
+	~string | ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// EnumDefinition lists the allowed values of a registered enum type
+type EnumDefinition struct {
+	Type   reflect.Type  `json:"-"`
+	Name   string        `json:"name"`
+	Values []interface{} `json:"values"`
+
+	allowed map[interface{}]bool
+}
+
+var (
+	enums   = make(map[reflect.Type]*EnumDefinition)
+	enumsMu sync.RWMutex
+)
+
+// RegisterEnum declares the allowed values of a string or integer type, e.g.
+//
+//	type Status string
+//	supergin.RegisterEnum(StatusActive, StatusSuspended)
+//
+// Fields of the type are then checked whenever SuperGin validates input, without oneof
+// tags, and schemas list the values as an enum. Zero values are left to `required`.
+func RegisterEnum[T EnumValue](values ...T) *EnumDefinition {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if len(values) == 0 {
+		panic(fmt.Sprintf("enum %s requires at least one value", t))
+	}
+
+	def := &EnumDefinition{
+		Type:    t,
+		Name:    t.String(),
+		allowed: make(map[interface{}]bool, len(values)),
+	}
+	for _, value := range values {
+		basic := enumBasicValue(reflect.ValueOf(value))
+		if !def.allowed[basic] {
+			def.allowed[basic] = true
+			def.Values = append(def.Values, basic)
+		}
+	}
+
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	enums[t] = def
+	return def
+}
+
+// LookupEnum returns the registered definition for an enum type
+func LookupEnum(t reflect.Type) (*EnumDefinition, bool) {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+
+	def, exists := enums[t]
+	return def, exists
+}
+
+// Allows reports whether v, a value of the enum type, is allowed
+func (d *EnumDefinition) Allows(v reflect.Value) bool {
+	return d.allowed[enumBasicValue(v)]
+}
+
+// enumBasicValue converts an enum value to string, int64 or uint64 for comparison and docs
+func enumBasicValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	default:
+		return v.Int()
+	}
+}
+
+// validateStruct runs tag validation followed by the registered enum checks
+func validateStruct(validate *validator.Validate, target interface{}) error {
+	if err := validate.Struct(target); err != nil {
+		var errs validator.ValidationErrors
+		if errors.As(err, &errs) {
+			return newStructValidationError(target, errs)
+		}
+		return err
+	}
+	return validateEnums(target)
+}
+
+// validateEnums checks every non-zero field of a registered enum type reachable from v
+func validateEnums(v interface{}) error {
+	enumsMu.RLock()
+	empty := len(enums) == 0
+	enumsMu.RUnlock()
+	if empty {
+		return nil
+	}
+	return checkEnums(reflect.ValueOf(v), "")
+}
+
+func checkEnums(v reflect.Value, path string) error {
+	if !v.IsValid() {
+		return nil
+	}
+	if def, exists := LookupEnum(v.Type()); exists {
+		if v.IsZero() || def.Allows(v) {
+			return nil
+		}
+		return fmt.Errorf("%s: invalid %s value %v, allowed: %s", strings.TrimPrefix(path, "."), def.Name, v.Interface(), def.describe())
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return checkEnums(v.Elem(), path)
+	case reflect.Struct:
+		t := v.Type()
+		if t == timeType {
+			return nil
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, skip := jsonFieldName(field)
+			if skip {
+				name = field.Name
+			}
+			if err := checkEnums(v.Field(i), path+"."+name); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := checkEnums(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := checkEnums(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key().Interface())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// describe lists the allowed values for error messages
+func (d *EnumDefinition) describe() string {
+	values := make([]string, len(d.Values))
+	for i, value := range d.Values {
+		values[i] = fmt.Sprint(value)
+	}
+	return strings.Join(values, ", ")
+}
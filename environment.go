@@ -0,0 +1,84 @@
+package supergin
+
+import "os"
+
+// EnvironmentVariable names the environment when Config.Environment is empty
+const EnvironmentVariable = "SUPERGIN_ENV"
+
+// DefaultEnvironment is used when neither Config.Environment nor SUPERGIN_ENV is set
+const DefaultEnvironment = "development"
+
+// ProductionEnvironment is the environment strict configuration checks hold to the
+// highest standard
+const ProductionEnvironment = "production"
+
+// resolveEnvironment picks the configured environment, then SUPERGIN_ENV, then the default
+func resolveEnvironment(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	if env := os.Getenv(EnvironmentVariable); env != "" {
+		return env
+	}
+	return DefaultEnvironment
+}
+
+// Environment returns the environment the engine runs in
+func (e *Engine) Environment() string {
+	return e.environment
+}
+
+// WithEnvironments limits the route to the named environments, e.g. a debug endpoint to
+// "development". Elsewhere the route is not registered at all, so it neither serves
+// requests nor appears in the docs.
+func (rb *RouteBuilder) WithEnvironments(envs ...string) *RouteBuilder {
+	rb.envs = append(rb.envs, envs...)
+	return rb
+}
+
+// WithEnvironments limits routes created afterwards in the group to the named environments.
+// Routes and nested groups can narrow the limit further but not widen it.
+func (g *GroupBuilder) WithEnvironments(envs ...string) *GroupBuilder {
+	g.envs = append(g.envs, envs...)
+	return g
+}
+
+// environmentSets adds a builder's own environments to the limits inherited from its groups
+func environmentSets(inherited [][]string, own []string) [][]string {
+	sets := append([][]string{}, inherited...)
+	if len(own) > 0 {
+		sets = append(sets, own)
+	}
+	return sets
+}
+
+// inEnvironments reports whether the engine's environment satisfies every limit
+func (e *Engine) inEnvironments(sets [][]string) bool {
+	for _, envs := range sets {
+		if !contains(envs, e.environment) {
+			return false
+		}
+	}
+	return true
+}
+
+// intersectEnvironments lists the environments allowed by every limit, nil when unlimited
+func intersectEnvironments(sets [][]string) []string {
+	if len(sets) == 0 {
+		return nil
+	}
+	var allowed []string
+	for _, env := range sets[0] {
+		if contains(allowed, env) {
+			continue
+		}
+		inAll := true
+		for _, envs := range sets[1:] {
+			inAll = inAll && contains(envs, env)
+		}
+		if inAll {
+			allowed = append(allowed, env)
+		}
+	}
+	return allowed
+}
@@ -6,12 +6,24 @@ import "fmt"
 type ErrorCode string
 
 const (
-	ErrRouteNotFound      ErrorCode = "ROUTE_NOT_FOUND"
-	ErrValidationFailed   ErrorCode = "VALIDATION_FAILED"
-	ErrDIServiceNotFound  ErrorCode = "DI_SERVICE_NOT_FOUND"
-	ErrCircularDependency ErrorCode = "CIRCULAR_DEPENDENCY"
-	ErrInvalidFactory     ErrorCode = "INVALID_FACTORY"
-	ErrContextRequired    ErrorCode = "CONTEXT_REQUIRED"
+	ErrRouteNotFound       ErrorCode = "ROUTE_NOT_FOUND"
+	ErrValidationFailed    ErrorCode = "VALIDATION_FAILED"
+	ErrDIServiceNotFound   ErrorCode = "DI_SERVICE_NOT_FOUND"
+	ErrCircularDependency  ErrorCode = "CIRCULAR_DEPENDENCY"
+	ErrInvalidFactory      ErrorCode = "INVALID_FACTORY"
+	ErrFactoryFailed       ErrorCode = "FACTORY_FAILED"
+	ErrContextRequired     ErrorCode = "CONTEXT_REQUIRED"
+	ErrUnsupportedEncoding ErrorCode = "UNSUPPORTED_ENCODING"
+	ErrInjectionFailed     ErrorCode = "INJECTION_FAILED"
+	ErrDisposeFailed       ErrorCode = "DISPOSE_FAILED"
+	ErrStartFailed         ErrorCode = "START_FAILED"
+	ErrLimitExceeded       ErrorCode = "LIMIT_EXCEEDED"
+	ErrUnknownMessageType  ErrorCode = "UNKNOWN_MESSAGE_TYPE"
+	ErrInvalidCriteria     ErrorCode = "INVALID_CRITERIA"
+	ErrInvalidPolicy       ErrorCode = "INVALID_POLICY"
+	ErrBackendUnavailable  ErrorCode = "BACKEND_UNAVAILABLE"
+	ErrMisconfiguration    ErrorCode = "MISCONFIGURATION"
+	ErrInvalidURLParams    ErrorCode = "INVALID_URL_PARAMS"
 )
 
 // SuperGinError represents an error within the SuperGin framework
@@ -11,6 +11,7 @@ import (
 	"github.com/ivikasavnish/supergin"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
 )
 
 // HTTP Models
@@ -52,7 +53,8 @@ type UserGrpcResponse struct {
 	CreatedAt int64  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 }
 
-// Implement proto.Message interface for mock types
+// Implement the legacy (v1) proto.Message interface for mock types;
+// protoadapt wraps them into v2 messages at the bridge boundary
 func (x *CreateUserGrpcRequest) Reset()         { *x = CreateUserGrpcRequest{} }
 func (x *CreateUserGrpcRequest) String() string { return "CreateUserGrpcRequest{}" }
 func (*CreateUserGrpcRequest) ProtoMessage()    {}
@@ -63,15 +65,15 @@ func (*UserGrpcResponse) ProtoMessage()    {}
 
 // Implement GrpcConverter for custom conversion logic
 func (req *CreateUserRequest) ToGrpc() (proto.Message, error) {
-	return &CreateUserGrpcRequest{
+	return protoadapt.MessageV2Of(&CreateUserGrpcRequest{
 		Name:  req.Name,
 		Email: req.Email,
 		Age:   int32(req.Age),
-	}, nil
+	}), nil
 }
 
 func (req *CreateUserRequest) FromGrpc(msg proto.Message) error {
-	grpcReq, ok := msg.(*CreateUserGrpcRequest)
+	grpcReq, ok := protoadapt.MessageV1Of(msg).(*CreateUserGrpcRequest)
 	if !ok {
 		return fmt.Errorf("invalid gRPC message type")
 	}
@@ -83,17 +85,17 @@ func (req *CreateUserRequest) FromGrpc(msg proto.Message) error {
 }
 
 func (resp *UserResponse) ToGrpc() (proto.Message, error) {
-	return &UserGrpcResponse{
+	return protoadapt.MessageV2Of(&UserGrpcResponse{
 		Id:        int32(resp.ID),
 		Name:      resp.Name,
 		Email:     resp.Email,
 		Age:       int32(resp.Age),
 		CreatedAt: resp.CreatedAt.Unix(),
-	}, nil
+	}), nil
 }
 
 func (resp *UserResponse) FromGrpc(msg proto.Message) error {
-	grpcResp, ok := msg.(*UserGrpcResponse)
+	grpcResp, ok := protoadapt.MessageV1Of(msg).(*UserGrpcResponse)
 	if !ok {
 		return fmt.Errorf("invalid gRPC message type")
 	}
@@ -290,9 +292,6 @@ func (uc *UserController) GetUser(c *gin.Context) {
 }
 
 func main() {
-	// Setup Dependency Injection
-	setupDI()
-
 	// Create SuperGin engine
 	app := supergin.New(supergin.Config{
 		EnableDocs:     true,
@@ -301,6 +300,9 @@ func main() {
 		DocsPath:       "/api/docs",
 	})
 
+	// Setup Dependency Injection
+	setupDI(app)
+
 	// Setup gRPC bridge
 	setupGrpcBridge(app)
 
@@ -323,13 +325,13 @@ func main() {
 	app.Run(":8080")
 }
 
-func setupDI() {
+func setupDI(app *supergin.Engine) {
 	// Register services
-	supergin.RegisterSingleton("userService", func() UserService {
+	app.RegisterSingleton("userService", func() UserService {
 		return &UserServiceImpl{}
 	})
 
-	supergin.RegisterSingleton("chatService", func() ChatService {
+	app.RegisterSingleton("chatService", func() ChatService {
 		return &ChatServiceImpl{
 			messages: make([]*ChatMessage, 0),
 		}
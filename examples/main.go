@@ -26,8 +26,8 @@ type UserResponse struct {
 }
 
 type UserSearchRequest struct {
-	Name  string `json:"name,omitempty" form:"name"`
-	Email string `json:"email,omitempty" form:"email"`
+	Name  string `json:"name,omitempty" form:"name" search:"name,contains"`
+	Email string `json:"email,omitempty" form:"email" search:"email,contains"`
 	Page  int    `json:"page,omitempty" form:"page"`
 	Limit int    `json:"limit,omitempty" form:"limit"`
 }
@@ -42,25 +42,25 @@ type DatabaseConfig struct {
 }
 
 type Database interface {
-	Query(sql string) ([]map[string]interface{}, error)
-	Execute(sql string) error
+	Query(sql string, args ...interface{}) ([]map[string]interface{}, error)
+	Execute(sql string, args ...interface{}) error
 }
 
 type PostgresDB struct {
 	config *DatabaseConfig
 }
 
-func (db *PostgresDB) Query(sql string) ([]map[string]interface{}, error) {
+func (db *PostgresDB) Query(sql string, args ...interface{}) ([]map[string]interface{}, error) {
 	// Mock implementation
-	fmt.Printf("Executing query: %s\n", sql)
+	fmt.Printf("Executing query: %s %v\n", sql, args)
 	return []map[string]interface{}{
 		{"id": 1, "name": "John Doe", "email": "john@example.com", "age": 30},
 		{"id": 2, "name": "Jane Smith", "email": "jane@example.com", "age": 25},
 	}, nil
 }
 
-func (db *PostgresDB) Execute(sql string) error {
-	fmt.Printf("Executing SQL: %s\n", sql)
+func (db *PostgresDB) Execute(sql string, args ...interface{}) error {
+	fmt.Printf("Executing SQL: %s %v\n", sql, args)
 	return nil
 }
 
@@ -79,7 +79,7 @@ type UserRepositoryImpl struct {
 }
 
 func (r *UserRepositoryImpl) FindByID(id int) (*UserResponse, error) {
-	rows, err := r.db.Query(fmt.Sprintf("SELECT * FROM users WHERE id = %d", id))
+	rows, err := r.db.Query("SELECT * FROM users WHERE id = $1", id)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +98,7 @@ func (r *UserRepositoryImpl) FindByID(id int) (*UserResponse, error) {
 }
 
 func (r *UserRepositoryImpl) Create(user *CreateUserRequest) (*UserResponse, error) {
-	err := r.db.Execute("INSERT INTO users (name, email, age) VALUES ...")
+	err := r.db.Execute("INSERT INTO users (name, email, age) VALUES ($1, $2, $3)", user.Name, user.Email, user.Age)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +113,7 @@ func (r *UserRepositoryImpl) Create(user *CreateUserRequest) (*UserResponse, err
 }
 
 func (r *UserRepositoryImpl) Update(id int, user *CreateUserRequest) (*UserResponse, error) {
-	err := r.db.Execute(fmt.Sprintf("UPDATE users SET ... WHERE id = %d", id))
+	err := r.db.Execute("UPDATE users SET name = $1, email = $2, age = $3 WHERE id = $4", user.Name, user.Email, user.Age, id)
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +128,7 @@ func (r *UserRepositoryImpl) Update(id int, user *CreateUserRequest) (*UserRespo
 }
 
 func (r *UserRepositoryImpl) Delete(id int) error {
-	return r.db.Execute(fmt.Sprintf("DELETE FROM users WHERE id = %d", id))
+	return r.db.Execute("DELETE FROM users WHERE id = $1", id)
 }
 
 func (r *UserRepositoryImpl) List() ([]*UserResponse, error) {
@@ -144,15 +144,12 @@ func (r *UserRepositoryImpl) List() ([]*UserResponse, error) {
 }
 
 func (r *UserRepositoryImpl) Search(criteria *UserSearchRequest) ([]*UserResponse, error) {
-	query := "SELECT * FROM users WHERE 1=1"
-	if criteria.Name != "" {
-		query += fmt.Sprintf(" AND name LIKE '%%%s%%'", criteria.Name)
-	}
-	if criteria.Email != "" {
-		query += fmt.Sprintf(" AND email LIKE '%%%s%%'", criteria.Email)
+	query, args, err := supergin.CriteriaFrom(criteria, supergin.DollarPlaceholders).Apply("SELECT * FROM users")
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := r.db.Query(query)
+	_, err = r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -314,9 +311,6 @@ func (uc *UserController) Search(c *gin.Context) {
 }
 
 func main() {
-	// Setup Dependency Injection
-	setupDI()
-
 	// Create SuperGin engine
 	app := supergin.New(supergin.Config{
 		EnableDocs:     true,
@@ -325,6 +319,9 @@ func main() {
 		DocsPath:       "/api/docs",
 	})
 
+	// Setup Dependency Injection
+	setupDI(app)
+
 	// Setup routes
 	setupRoutes(app)
 
@@ -336,9 +333,9 @@ func main() {
 	app.Run(":8080")
 }
 
-func setupDI() {
+func setupDI(app *supergin.Engine) {
 	// Register configuration as singleton
-	supergin.RegisterInstance("dbConfig", &DatabaseConfig{
+	app.RegisterInstance("dbConfig", &DatabaseConfig{
 		Host:     "localhost",
 		Port:     5432,
 		Database: "myapp",
@@ -347,18 +344,18 @@ func setupDI() {
 	})
 
 	// Register database as singleton with dependency on config
-	supergin.RegisterSingleton("database", func(config *DatabaseConfig) Database {
+	app.RegisterSingleton("database", func(config *DatabaseConfig) Database {
 		fmt.Printf("🔌 Creating database connection to %s:%d/%s\n", config.Host, config.Port, config.Database)
 		return &PostgresDB{config: config}
 	}, "dbConfig")
 
 	// Register repository as request-scoped with dependency on database
-	supergin.RegisterRequest("userRepository", func(db Database) UserRepository {
+	app.RegisterRequest("userRepository", func(db Database) UserRepository {
 		return &UserRepositoryImpl{db: db}
 	}, "database")
 
 	// Register service as request-scoped with dependency on repository
-	supergin.RegisterRequest("userService", func(repo UserRepository) UserService {
+	app.RegisterRequest("userService", func(repo UserRepository) UserService {
 		return &UserServiceImpl{repo: repo}
 	}, "userRepository")
 
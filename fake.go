@@ -0,0 +1,205 @@
+package supergin
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fakeMaxDepth bounds recursion into nested and self-referencing types
+const fakeMaxDepth = 4
+
+var (
+	fakeFirstNames = []string{"Ada", "Grace", "Alan", "Linus", "Barbara", "Ken", "Margaret", "Dennis"}
+	fakeLastNames  = []string{"Lovelace", "Hopper", "Turing", "Torvalds", "Liskov", "Thompson", "Hamilton", "Ritchie"}
+	fakeWords      = []string{"alpha", "bravo", "delta", "echo", "lima", "nova", "orbit", "pixel", "quartz", "sierra"}
+)
+
+// Fake returns a T filled with plausible random data that satisfies common validate rules
+// (required, email, url, uuid, oneof, min/max/len, gte/lte) and registered enums.
+// Custom validations are not known to the faker and may reject its values.
+func Fake[T any]() T {
+	var value T
+	fakeInto(reflect.ValueOf(&value).Elem(), "", nil, 0)
+	return value
+}
+
+// fakeValue returns a pointer to a new faked value of type t
+func fakeValue(t reflect.Type) interface{} {
+	value := reflect.New(t)
+	fakeInto(value.Elem(), "", nil, 0)
+	return value.Interface()
+}
+
+// fakeRules are the validate rules of a field, keyed by rule name
+type fakeRules map[string]string
+
+func parseFakeRules(tag string) fakeRules {
+	rules := make(fakeRules)
+	for _, rule := range strings.Split(tag, ",") {
+		if rule == "dive" {
+			break
+		}
+		key, value, _ := strings.Cut(rule, "=")
+		rules[key] = value
+	}
+	return rules
+}
+
+// bounds returns the lower and upper limits from min/gte/len and max/lte/len rules
+func (r fakeRules) bounds(lo, hi float64) (float64, float64) {
+	for _, key := range []string{"min", "gte", "gt", "len"} {
+		if n, err := strconv.ParseFloat(r[key], 64); err == nil {
+			lo = n
+			if key == "gt" {
+				lo++
+			}
+		}
+	}
+	for _, key := range []string{"max", "lte", "lt", "len"} {
+		if n, err := strconv.ParseFloat(r[key], 64); err == nil {
+			hi = n
+			if key == "lt" {
+				hi--
+			}
+		}
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
+
+func fakeInto(v reflect.Value, name string, rules fakeRules, depth int) {
+	if depth > fakeMaxDepth {
+		return
+	}
+
+	if def, exists := LookupEnum(v.Type()); exists {
+		setBasic(v, def.Values[rand.IntN(len(def.Values))])
+		return
+	}
+	if v.Type() == timeType {
+		v.Set(reflect.ValueOf(time.Now().Add(-time.Duration(rand.IntN(365*24)) * time.Hour).Truncate(time.Second)))
+		return
+	}
+	if options := strings.Fields(rules["oneof"]); len(options) > 0 && v.Kind() != reflect.Ptr {
+		setParsed(v, options[rand.IntN(len(options))])
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		fakeInto(elem.Elem(), name, rules, depth+1)
+		v.Set(elem)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if _, skip := jsonFieldName(field); skip {
+				continue
+			}
+			fakeInto(v.Field(i), field.Name, parseFakeRules(validationRules(field)), depth+1)
+		}
+	case reflect.String:
+		v.SetString(fakeString(name, rules))
+	case reflect.Bool:
+		v.SetBool(rand.IntN(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lo, hi := rules.bounds(1, 100)
+		v.SetInt(int64(lo) + rand.Int64N(int64(hi-lo)+1))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		lo, hi := rules.bounds(1, 100)
+		v.SetUint(uint64(lo) + rand.Uint64N(uint64(hi-lo)+1))
+	case reflect.Float32, reflect.Float64:
+		lo, hi := rules.bounds(0, 1000)
+		v.SetFloat(lo + rand.Float64()*(hi-lo))
+	case reflect.Slice:
+		lo, hi := rules.bounds(1, 3)
+		n := int(lo) + rand.IntN(int(hi-lo)+1)
+		slice := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			fakeInto(slice.Index(i), name, nil, depth+1)
+		}
+		v.Set(slice)
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+}
+
+// fakeString produces a string guided by the field's format rules and name
+func fakeString(name string, rules fakeRules) string {
+	lower := strings.ToLower(name)
+	first := fakeFirstNames[rand.IntN(len(fakeFirstNames))]
+	last := fakeLastNames[rand.IntN(len(fakeLastNames))]
+	word := fakeWords[rand.IntN(len(fakeWords))]
+
+	var s string
+	_, isEmail := rules["email"]
+	_, isURL := rules["url"]
+	_, isURI := rules["uri"]
+	_, isUUID := rules["uuid"]
+	_, isUUID4 := rules["uuid4"]
+	switch {
+	case isEmail || strings.Contains(lower, "email"):
+		s = fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(first), strings.ToLower(last), rand.IntN(1000))
+	case isURL || isURI || strings.HasSuffix(lower, "url"):
+		s = fmt.Sprintf("https://example.com/%s/%d", word, rand.IntN(1000))
+	case isUUID || isUUID4 || lower == "id" || strings.HasSuffix(lower, "uuid"):
+		s = newUUID()
+	case strings.Contains(lower, "phone"):
+		s = fmt.Sprintf("+1555%07d", rand.IntN(10000000))
+	case lower == "name" || strings.HasSuffix(lower, "name"):
+		s = first + " " + last
+	default:
+		s = word + " " + fakeWords[rand.IntN(len(fakeWords))]
+	}
+
+	lo, hi := rules.bounds(0, 0)
+	for float64(len(s)) < lo {
+		s += fakeWords[rand.IntN(len(fakeWords))]
+	}
+	if hi > 0 && float64(len(s)) > hi {
+		s = s[:int(hi)]
+	}
+	return s
+}
+
+// setBasic assigns a string, int64 or uint64 to a value of a compatible kind
+func setBasic(v reflect.Value, basic interface{}) {
+	switch b := basic.(type) {
+	case string:
+		v.SetString(b)
+	case int64:
+		v.SetInt(b)
+	case uint64:
+		v.SetUint(b)
+	}
+}
+
+// setParsed assigns a oneof option, parsed for the value's kind
+func setParsed(v reflect.Value, option string) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(option)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(option, 10, 64); err == nil {
+			v.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseUint(option, 10, 64); err == nil {
+			v.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if n, err := strconv.ParseFloat(option, 64); err == nil {
+			v.SetFloat(n)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package supergin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes why one input field is invalid
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. "email" or "items[0].sku"
+	Field string `json:"field"`
+	// Rule is the failed rule, e.g. "required", "email" or "unique"
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+// FieldErrors rejects input field by field. Validation failures respond with 400 and list
+// them under "fields" next to the usual "error" and "details".
+type FieldErrors []FieldError
+
+// Error implements the error interface
+func (e FieldErrors) Error() string {
+	parts := make([]string, len(e))
+	for i, fe := range e {
+		parts[i] = fe.Field + ": " + fe.Message
+	}
+	return strings.Join(parts, "; ")
+}
+
+// InvalidField returns a single field error, e.g. InvalidField("email", "unique", "is already taken")
+func InvalidField(field, rule, message string) FieldErrors {
+	return FieldErrors{{Field: field, Rule: rule, Message: message}}
+}
+
+// structValidationError keeps validator's error text while exposing field errors
+type structValidationError struct {
+	errs   validator.ValidationErrors
+	fields FieldErrors
+}
+
+func (e *structValidationError) Error() string { return e.errs.Error() }
+func (e *structValidationError) Unwrap() error { return e.errs }
+
+// newStructValidationError converts validator errors for target into field errors
+func newStructValidationError(target interface{}, errs validator.ValidationErrors) error {
+	root := reflect.TypeOf(target)
+	fields := make(FieldErrors, len(errs))
+	for i, fe := range errs {
+		fields[i] = FieldError{
+			Field:   jsonPath(root, fe.StructNamespace()),
+			Rule:    fe.Tag(),
+			Message: ruleMessage(fe.Tag(), fe.Param()),
+		}
+	}
+	return &structValidationError{errs: errs, fields: fields}
+}
+
+// fieldErrorsOf returns the field errors carried by err, if any
+func fieldErrorsOf(err error) FieldErrors {
+	var fields FieldErrors
+	if errors.As(err, &fields) {
+		return fields
+	}
+	var structErr *structValidationError
+	if errors.As(err, &structErr) {
+		return structErr.fields
+	}
+	return nil
+}
+
+// validationFailureKey stores the field errors of a request rejected by validation, for
+// the route's rejection stats
+const validationFailureKey = "supergin:validation_failure"
+
+// writeValidationError notifies validation listeners and responds 400 with the error and
+// any field errors, localized, or 413 when the body limit cut the request off
+func writeValidationError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeBodyTooLarge(c, tooLarge.Limit)
+		return
+	}
+	c.Set(validationFailureKey, fieldErrorsOf(err))
+	if value, exists := c.Get(hooksKey); exists {
+		value.(*engineHooks).notifyValidationFailed(c, err)
+	}
+	body := gin.H{
+		"error":   "Input validation failed",
+		"details": err.Error(),
+	}
+	if fields := localizedFieldErrors(c, err); len(fields) > 0 {
+		body["fields"] = fields
+	}
+	c.JSON(http.StatusBadRequest, body)
+}
+
+// jsonPath maps a validator namespace such as "CreateOrder.Items[0].SKU" to JSON field
+// names, e.g. "items[0].sku", using root as the namespace's first element. Path and
+// header fields use their parameter names.
+func jsonPath(root reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")
+	if len(segments) > 1 {
+		segments = segments[1:]
+	}
+
+	t := root
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		name, index, _ := strings.Cut(segment, "[")
+		if index != "" {
+			index = "[" + index
+		}
+		for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			path = append(path, segment)
+			t = nil
+			continue
+		}
+		field, ok := t.FieldByName(name)
+		if !ok {
+			path = append(path, segment)
+			t = nil
+			continue
+		}
+		jsonName, _ := jsonFieldName(field)
+		for _, key := range []string{"uri", "header"} {
+			// Path and header fields are reported by parameter name
+			if name := strings.Split(field.Tag.Get(key), ",")[0]; name != "" && name != "-" {
+				jsonName = name
+			}
+		}
+		path = append(path, jsonName+index)
+		t = field.Type
+	}
+	return strings.Join(path, ".")
+}
+
+// ruleMessage describes a failed validate rule in plain English
+func ruleMessage(tag, param string) string {
+	switch tag {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url", "uri":
+		return "must be a valid URL"
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.Join(strings.Fields(param), ", "))
+	case "min", "gte":
+		return fmt.Sprintf("must be at least %s", param)
+	case "max", "lte":
+		return fmt.Sprintf("must be at most %s", param)
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", param)
+	case "lt":
+		return fmt.Sprintf("must be less than %s", param)
+	case "len":
+		return fmt.Sprintf("must have length %s", param)
+	}
+	if param != "" {
+		return fmt.Sprintf("failed the '%s=%s' rule", tag, param)
+	}
+	return fmt.Sprintf("failed the '%s' rule", tag)
+}
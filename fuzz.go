@@ -0,0 +1,586 @@
+package supergin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultFuzzPayloadBytes sizes the oversized strings, arrays and bodies of fuzz cases
+	DefaultFuzzPayloadBytes = 1 << 20
+	// DefaultFuzzNestingDepth is how deeply the nested-document fuzz cases nest
+	DefaultFuzzNestingDepth = 10000
+)
+
+// FuzzOptions configures fuzz case generation
+type FuzzOptions struct {
+	// PayloadBytes sizes oversized values; zero means DefaultFuzzPayloadBytes
+	PayloadBytes int
+	// NestingDepth is the depth of deeply nested documents; zero means
+	// DefaultFuzzNestingDepth
+	NestingDepth int
+}
+
+// FuzzCase is a generated request body or message payload
+type FuzzCase struct {
+	// Name describes the case, e.g. "body/truncated" or "address.zip/wrong_type/number"
+	Name string `json:"name"`
+	// Body is the raw payload, which is not necessarily valid JSON
+	Body []byte `json:"-"`
+}
+
+// FuzzFailure is a fuzz case the framework mishandled
+type FuzzFailure struct {
+	// Target is the route name or message type
+	Target string `json:"target"`
+	Case   string `json:"case"`
+	Error  string `json:"error"`
+}
+
+// fuzzDelete marks a field to remove rather than set
+type fuzzDelete struct{}
+
+// FuzzInputs generates structured fuzz cases for an input or payload type, starting from a
+// Fake value: malformed, truncated, deeply nested and oversized documents, and for every
+// field (nested and union variant fields included) missing, null and wrongly typed values
+// and boundary values such as overflowing numbers and lengths just outside the field's
+// min/max rules. Bodies can also seed native Go fuzz tests via f.Add.
+func FuzzInputs(t reflect.Type, opts ...FuzzOptions) []FuzzCase {
+	options := FuzzOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.PayloadBytes <= 0 {
+		options.PayloadBytes = DefaultFuzzPayloadBytes
+	}
+	if options.NestingDepth <= 0 {
+		options.NestingDepth = DefaultFuzzNestingDepth
+	}
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil
+	}
+
+	g := &fuzzGenerator{options: options}
+	union, isUnion := LookupUnion(t)
+	if !isUnion {
+		g.baseline = fuzzBaseline(t)
+		g.documents()
+		g.fields(nil, t, 0)
+		return g.cases
+	}
+
+	for i, value := range union.Values() {
+		variant := union.Variants[value]
+		baseline, ok := fuzzBaseline(variant).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		baseline[union.Discriminator] = value
+		g.baseline, g.prefix = baseline, value+":"
+		if i == 0 {
+			g.prefix = ""
+			g.documents()
+			g.set(union.Discriminator+"/missing", []string{union.Discriminator}, fuzzDelete{})
+			g.set(union.Discriminator+"/null", []string{union.Discriminator}, nil)
+			g.set(union.Discriminator+"/unknown", []string{union.Discriminator}, "supergin.fuzz")
+			g.set(union.Discriminator+"/wrong_type/number", []string{union.Discriminator}, 1)
+			g.prefix = value + ":"
+		}
+		for variant.Kind() == reflect.Ptr {
+			variant = variant.Elem()
+		}
+		g.fields(nil, variant, 0)
+	}
+	return g.cases
+}
+
+// fuzzGenerator accumulates the cases of one type, each derived from a baseline document
+type fuzzGenerator struct {
+	options  FuzzOptions
+	baseline interface{}
+	prefix   string
+	cases    []FuzzCase
+}
+
+// fuzzBaseline fakes a valid value of t as a generic JSON document
+func fuzzBaseline(t reflect.Type) interface{} {
+	raw, err := json.Marshal(fakeValue(t))
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return map[string]interface{}{}
+	}
+	return doc
+}
+
+func (g *fuzzGenerator) add(name string, body []byte) {
+	g.cases = append(g.cases, FuzzCase{Name: g.prefix + name, Body: body})
+}
+
+// set adds the baseline with the value at path replaced, or removed for fuzzDelete
+func (g *fuzzGenerator) set(name string, path []string, value interface{}) {
+	doc := cloneFuzzDocument(g.baseline)
+	if !setFuzzPath(&doc, path, value) {
+		return
+	}
+	if body, err := json.Marshal(doc); err == nil {
+		g.add(name, body)
+	}
+}
+
+// documents adds cases that break the document as a whole
+func (g *fuzzGenerator) documents() {
+	raw, _ := json.Marshal(g.baseline)
+	depth := g.options.NestingDepth
+
+	g.add("body/empty", []byte{})
+	g.add("body/null", []byte("null"))
+	g.add("body/array", []byte("[]"))
+	g.add("body/string", []byte(`"supergin"`))
+	g.add("body/number", []byte("0"))
+	g.add("body/truncated", raw[:len(raw)/2])
+	g.add("body/trailing_garbage", append(append([]byte{}, raw...), "}{"...))
+	g.add("body/invalid_utf8", []byte("{\"\xff\xfe\":\"\xc3\x28\"}"))
+	g.add("body/nested_arrays", []byte(strings.Repeat("[", depth)+strings.Repeat("]", depth)))
+	g.add("body/nested_objects", []byte(strings.Repeat(`{"a":`, depth)+"null"+strings.Repeat("}", depth)))
+
+	doc, isObject := g.baseline.(map[string]interface{})
+	if !isObject {
+		g.add("body/oversized", []byte(strconv.Quote(strings.Repeat("x", g.options.PayloadBytes))))
+		return
+	}
+	padded := cloneFuzzDocument(doc).(map[string]interface{})
+	padded["supergin_fuzz_padding"] = strings.Repeat("x", g.options.PayloadBytes)
+	if body, err := json.Marshal(padded); err == nil {
+		g.add("body/oversized", body)
+	}
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 && len(raw) > 2 {
+		sort.Strings(keys)
+		key, _ := json.Marshal(keys[0])
+		body := append(append([]byte{}, raw[:len(raw)-1]...), ',')
+		body = append(append(append(body, key...), `:{"duplicate":true}`...), '}')
+		g.add("body/duplicate_key", body)
+	}
+}
+
+// fields adds missing, null, wrongly typed and boundary cases for each field of struct t
+// found at path in the baseline
+func (g *fuzzGenerator) fields(path []string, t reflect.Type, depth int) {
+	if depth > fakeMaxDepth || t.Kind() != reflect.Struct || t == timeType {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		fieldPath := append(append([]string{}, path...), name)
+		label := strings.Join(fieldPath, ".")
+		rules := parseFakeRules(validationRules(field))
+
+		g.set(label+"/missing", fieldPath, fuzzDelete{})
+		g.set(label+"/null", fieldPath, nil)
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		g.values(label, fieldPath, ft, rules)
+
+		switch ft.Kind() {
+		case reflect.Struct:
+			g.fields(fieldPath, ft, depth+1)
+		case reflect.Slice, reflect.Array:
+			elem := ft.Elem()
+			for elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct {
+				g.fields(append(fieldPath, "0"), elem, depth+1)
+			}
+		}
+	}
+}
+
+// values adds wrongly typed and boundary cases for a field of type t
+func (g *fuzzGenerator) values(label string, path []string, t reflect.Type, rules fakeRules) {
+	wrong := func(values map[string]interface{}) {
+		kinds := make([]string, 0, len(values))
+		for kind := range values {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			g.set(label+"/wrong_type/"+kind, path, values[kind])
+		}
+	}
+	boundary := func(name string, value interface{}) {
+		g.set(label+"/boundary/"+name, path, value)
+	}
+	long := strings.Repeat("x", g.options.PayloadBytes)
+
+	switch {
+	case t == timeType:
+		wrong(map[string]interface{}{"number": 1, "object": map[string]interface{}{}})
+		boundary("invalid", "not-a-time")
+		boundary("out_of_range", "9999-99-99T99:99:99Z")
+		boundary("empty", "")
+		return
+	case reflect.PointerTo(t).Implements(textUnmarshalerType) && t.Kind() != reflect.Struct:
+		wrong(map[string]interface{}{"number": 1, "object": map[string]interface{}{}})
+		boundary("invalid", "supergin-fuzz")
+		boundary("empty", "")
+		return
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		boundary("invalid", "supergin-fuzz")
+		boundary("empty", "")
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		wrong(map[string]interface{}{"number": 12345, "bool": true, "object": map[string]interface{}{}, "array": []interface{}{}})
+		boundary("empty", "")
+		boundary("whitespace", "   ")
+		boundary("control", "\x00\x1b[0m\r\n")
+		boundary("unicode", "ǅ\u202e𝔘\U0001F600\ufffd")
+		boundary("oversized", long)
+		if n, ok := fuzzRuleInt(rules, "max", "lte", "len"); ok && n+1 < g.options.PayloadBytes {
+			boundary("above_max", strings.Repeat("x", n+1))
+		}
+		if n, ok := fuzzRuleInt(rules, "min", "gte", "len"); ok && n > 0 {
+			boundary("below_min", strings.Repeat("x", n-1))
+		}
+	case reflect.Bool:
+		wrong(map[string]interface{}{"string": "true", "number": 1, "object": map[string]interface{}{}, "array": []interface{}{}})
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		wrong(map[string]interface{}{"string": "1", "bool": true, "object": map[string]interface{}{}, "array": []interface{}{}})
+		bits := uint(t.Bits())
+		lo, hi := new(big.Int), new(big.Int)
+		if t.Kind() >= reflect.Uint {
+			hi.Lsh(big.NewInt(1), bits)
+			lo.SetInt64(-1)
+		} else {
+			hi.Lsh(big.NewInt(1), bits-1)
+			lo.Neg(hi).Sub(lo, big.NewInt(1))
+		}
+		boundary("zero", 0)
+		boundary("negative", -1)
+		boundary("fraction", 1.5)
+		boundary("overflow", json.Number(hi.String()))
+		boundary("underflow", json.Number(lo.String()))
+		boundary("exponent", json.Number("1e400"))
+		g.ruleBounds(label, path, rules)
+	case reflect.Float32, reflect.Float64:
+		wrong(map[string]interface{}{"string": "1.5", "bool": true, "object": map[string]interface{}{}, "array": []interface{}{}})
+		boundary("zero", 0)
+		boundary("max", json.Number("1.7976931348623157e308"))
+		boundary("overflow", json.Number("1e400"))
+		boundary("underflow", json.Number("-1e400"))
+		boundary("tiny", json.Number("5e-324"))
+		g.ruleBounds(label, path, rules)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is base64 in JSON
+			wrong(map[string]interface{}{"number": 1, "object": map[string]interface{}{}})
+			boundary("invalid_base64", "***")
+			return
+		}
+		wrong(map[string]interface{}{"string": "x", "number": 1, "object": map[string]interface{}{}})
+		boundary("empty", []interface{}{})
+		boundary("null_element", []interface{}{nil})
+		boundary("oversized", make([]interface{}, g.options.PayloadBytes/8))
+		if n, ok := fuzzRuleInt(rules, "max", "lte", "len"); ok && n+1 < g.options.PayloadBytes {
+			boundary("above_max", make([]interface{}, n+1))
+		}
+	case reflect.Map:
+		wrong(map[string]interface{}{"string": "x", "number": 1, "array": []interface{}{}})
+		boundary("empty", map[string]interface{}{})
+	case reflect.Struct:
+		wrong(map[string]interface{}{"string": "x", "number": 1, "bool": true, "array": []interface{}{}})
+		boundary("empty", map[string]interface{}{})
+	}
+}
+
+// ruleBounds adds numbers just outside a field's min/max rules
+func (g *fuzzGenerator) ruleBounds(label string, path []string, rules fakeRules) {
+	for _, key := range []string{"min", "gte", "gt"} {
+		if n, err := strconv.ParseFloat(rules[key], 64); err == nil {
+			g.set(label+"/boundary/below_"+key, path, n-1)
+		}
+	}
+	for _, key := range []string{"max", "lte", "lt"} {
+		if n, err := strconv.ParseFloat(rules[key], 64); err == nil {
+			g.set(label+"/boundary/above_"+key, path, n+1)
+		}
+	}
+}
+
+// fuzzRuleInt returns the first integer rule among keys
+func fuzzRuleInt(rules fakeRules, keys ...string) (int, bool) {
+	for _, key := range keys {
+		if n, err := strconv.Atoi(rules[key]); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// cloneFuzzDocument deep-copies a generic JSON document
+func cloneFuzzDocument(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			clone[key] = cloneFuzzDocument(value)
+		}
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(v))
+		for i, value := range v {
+			clone[i] = cloneFuzzDocument(value)
+		}
+		return clone
+	default:
+		return v
+	}
+}
+
+// setFuzzPath replaces the value at path, addressing array elements by index; it reports
+// false when the path does not exist in the document
+func setFuzzPath(doc *interface{}, path []string, value interface{}) bool {
+	if len(path) == 0 {
+		*doc = value
+		return true
+	}
+	switch v := (*doc).(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			if _, remove := value.(fuzzDelete); remove {
+				delete(v, path[0])
+			} else {
+				v[path[0]] = value
+			}
+			return true
+		}
+		child, exists := v[path[0]]
+		if !exists || !setFuzzPath(&child, path[1:], value) {
+			return false
+		}
+		v[path[0]] = child
+		return true
+	case []interface{}:
+		i, err := strconv.Atoi(path[0])
+		if err != nil || i >= len(v) {
+			return false
+		}
+		if len(path) == 1 {
+			if _, remove := value.(fuzzDelete); remove {
+				return false
+			}
+			v[i] = value
+			return true
+		}
+		return setFuzzPath(&v[i], path[1:], value)
+	}
+	return false
+}
+
+// FuzzRoute drives FuzzInputs of a route's input type through its binding and validation,
+// without running middleware, async validators or the handler. Each case must either bind
+// or fail with a validation error rendered as 400; cases that panic or fail otherwise are
+// returned. Routes without an input type have nothing to fuzz.
+func (e *Engine) FuzzRoute(name string, opts ...FuzzOptions) ([]FuzzFailure, error) {
+	route, exists := e.GetRoute(name)
+	if !exists {
+		return nil, NewSuperGinError(ErrRouteNotFound, "route '%s' not found", name)
+	}
+	if route.InputType == nil {
+		return nil, nil
+	}
+
+	var failures []FuzzFailure
+	for _, fc := range FuzzInputs(route.InputType, opts...) {
+		if err := e.fuzzRouteCase(route, fc); err != nil {
+			failures = append(failures, FuzzFailure{Target: route.Name, Case: fc.Name, Error: err.Error()})
+		}
+	}
+	return failures, nil
+}
+
+// FuzzRoutes runs FuzzRoute on every route with an input type, in name order
+func (e *Engine) FuzzRoutes(opts ...FuzzOptions) []FuzzFailure {
+	routes := e.GetRoutes()
+	names := make([]string, 0, len(routes))
+	for name, route := range routes {
+		if route.InputType != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var failures []FuzzFailure
+	for _, name := range names {
+		routeFailures, _ := e.FuzzRoute(name, opts...)
+		failures = append(failures, routeFailures...)
+	}
+	return failures
+}
+
+// fuzzRouteCase binds one case as a request to the route
+func (e *Engine) fuzzRouteCase(route *RouteInfo, fc FuzzCase) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	c, ok := e.fuzzContext(route, fc)
+	if !ok {
+		return nil
+	}
+	_, bindErr := e.bindInput(c, route.InputType)
+	if bindErr == nil {
+		return nil
+	}
+	if !IsErrorCode(bindErr, ErrValidationFailed) {
+		return fmt.Errorf("unexpected error: %v", bindErr)
+	}
+	writeValidationError(c, bindErr)
+	if status := c.Writer.Status(); status != http.StatusBadRequest {
+		return fmt.Errorf("validation error rendered with status %d", status)
+	}
+	return nil
+}
+
+// fuzzContext builds a request carrying the case: as the JSON body, or as query
+// parameters for GET and DELETE routes, which skips cases that are not JSON objects
+func (e *Engine) fuzzContext(route *RouteInfo, fc FuzzCase) (*gin.Context, bool) {
+	var params gin.Params
+	path := route.Path
+	for _, segment := range strings.Split(route.Path, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, gin.Param{Key: segment[1:], Value: "1"})
+			path = strings.Replace(path, segment, "1", 1)
+		}
+	}
+
+	var body []byte
+	if route.Method == http.MethodGet || route.Method == http.MethodDelete {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(fc.Body, &doc); err != nil {
+			return nil, false
+		}
+		names := fuzzQueryNames(route.InputType)
+		query := url.Values{}
+		for key, value := range doc {
+			if name, exists := names[key]; exists {
+				key = name
+			}
+			if s, ok := value.(string); ok {
+				query.Set(key, s)
+			} else if encoded, err := json.Marshal(value); err == nil {
+				query.Set(key, string(encoded))
+			}
+		}
+		path += "?" + query.Encode()
+	} else {
+		body = fc.Body
+	}
+
+	c := gin.CreateTestContextOnly(httptest.NewRecorder(), e.Engine)
+	c.Request = httptest.NewRequest(route.Method, path, bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	return c, true
+}
+
+// fuzzQueryNames maps the JSON names of a struct's fields to their form tags
+func fuzzQueryNames(t reflect.Type) map[string]string {
+	names := make(map[string]string)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		form := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name, skip := jsonFieldName(field); !skip && form != "" && form != "-" {
+			names[name] = form
+		}
+	}
+	return names
+}
+
+// Fuzz drives FuzzInputs of every handled message type through the router's decoding and
+// validation, without calling handlers. Cases that are not valid JSON are skipped, as
+// connections drop them before routing. Each case must either decode or fail with a
+// validation error; cases that panic or fail otherwise are returned.
+func (mr *MessageRouter) Fuzz(opts ...FuzzOptions) []FuzzFailure {
+	mr.mutex.RLock()
+	messageTypes := make([]string, 0, len(mr.handlers))
+	for messageType := range mr.handlers {
+		messageTypes = append(messageTypes, messageType)
+	}
+	mr.mutex.RUnlock()
+	sort.Strings(messageTypes)
+
+	var failures []FuzzFailure
+	for _, messageType := range messageTypes {
+		def, exists := mr.registry.Lookup(messageType)
+		if !exists {
+			continue
+		}
+		for _, fc := range FuzzInputs(def.PayloadType, opts...) {
+			var data interface{}
+			if err := json.Unmarshal(fc.Body, &data); err != nil {
+				continue
+			}
+			if err := mr.fuzzCase(messageType, data); err != nil {
+				failures = append(failures, FuzzFailure{Target: messageType, Case: fc.Name, Error: err.Error()})
+			}
+		}
+	}
+	return failures
+}
+
+// fuzzCase decodes one case as a message payload
+func (mr *MessageRouter) fuzzCase(messageType string, data interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if _, decodeErr := mr.decode(messageType, data); decodeErr != nil && !IsErrorCode(decodeErr, ErrValidationFailed) {
+		return fmt.Errorf("unexpected error: %v", decodeErr)
+	}
+	return nil
+}
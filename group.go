@@ -0,0 +1,198 @@
+package supergin
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouterGroup wraps a gin.RouterGroup so named SuperGin routes can be registered inside
+// an existing gin group. Plain gin registration through the embedded group keeps working.
+type RouterGroup struct {
+	*gin.RouterGroup
+	engine *Engine
+}
+
+// WrapGroup adopts a gin.RouterGroup created from the engine, e.g.
+// api := app.WrapGroup(app.Engine.Group("/api", auth)); api.Named("users").GET("/users")...
+func (e *Engine) WrapGroup(group *gin.RouterGroup) *RouterGroup {
+	return &RouterGroup{RouterGroup: group, engine: e}
+}
+
+// Named creates a route builder whose route is registered inside the group, inheriting
+// its prefix and gin middleware
+func (g *RouterGroup) Named(name string) *RouteBuilder {
+	rb := g.engine.Named(name)
+	rb.router = g.RouterGroup
+	return rb
+}
+
+// Group creates a wrapped child group
+func (g *RouterGroup) Group(relativePath string, handlers ...gin.HandlerFunc) *RouterGroup {
+	return g.engine.WrapGroup(g.RouterGroup.Group(relativePath, handlers...))
+}
+
+// GroupBuilder provides a fluent interface for a named group of routes sharing a
+// prefix, middleware, tags and metadata. Settings apply to routes created afterwards.
+type GroupBuilder struct {
+	engine     *Engine
+	name       string
+	router     *gin.RouterGroup
+	middleware []gin.HandlerFunc
+	tags       []string
+	metadata   map[string]interface{}
+	host       string
+	envs       []string
+	parentEnvs [][]string
+	cors       *CORSPolicy
+	corsSet    bool
+}
+
+// GroupInfo describes a route group in the docs output
+type GroupInfo struct {
+	Name     string                 `json:"name"`
+	Prefix   string                 `json:"prefix"`
+	Tags     []string               `json:"tags"`
+	Metadata map[string]interface{} `json:"metadata"`
+	Routes   []string               `json:"routes"`
+}
+
+// Group creates a named route group under a path prefix
+func (e *Engine) Group(name, prefix string) *GroupBuilder {
+	return e.newGroup(name, e.Engine.Group(prefix))
+}
+
+func (e *Engine) newGroup(name string, router *gin.RouterGroup) *GroupBuilder {
+	if name == "" {
+		panic("group name is required")
+	}
+
+	group := &GroupBuilder{
+		engine:   e,
+		name:     name,
+		router:   router,
+		metadata: make(map[string]interface{}),
+	}
+
+	e.routesMux.Lock()
+	defer e.routesMux.Unlock()
+	if _, exists := e.groups[name]; exists {
+		panic(fmt.Sprintf("route group '%s' already registered", name))
+	}
+	e.groups[name] = group
+	return group
+}
+
+// WithMiddleware adds middleware run by every route in the group
+func (g *GroupBuilder) WithMiddleware(middleware ...gin.HandlerFunc) *GroupBuilder {
+	g.middleware = append(g.middleware, middleware...)
+	return g
+}
+
+// WithTags adds tags inherited by every route in the group
+func (g *GroupBuilder) WithTags(tags ...string) *GroupBuilder {
+	g.tags = append(g.tags, tags...)
+	return g
+}
+
+// WithMetadata adds metadata inherited by every route in the group
+func (g *GroupBuilder) WithMetadata(key string, value interface{}) *GroupBuilder {
+	g.metadata[key] = value
+	return g
+}
+
+// Named creates a route builder inheriting the group's prefix, middleware, tags and metadata
+func (g *GroupBuilder) Named(name string) *RouteBuilder {
+	rb := g.engine.Named(name)
+	rb.router = g.router
+	rb.group = g.name
+	rb.host = g.host
+	rb.groupEnvs = environmentSets(g.parentEnvs, g.envs)
+	rb.cors, rb.corsSet = g.cors, g.corsSet
+	rb.middleware = append(rb.middleware, g.middleware...)
+	rb.tags = append(rb.tags, g.tags...)
+	for k, v := range g.metadata {
+		rb.metadata[k] = v
+	}
+	return rb
+}
+
+// Group creates a nested named group inheriting this group's settings
+func (g *GroupBuilder) Group(name, prefix string) *GroupBuilder {
+	child := g.engine.newGroup(name, g.router.Group(prefix))
+	child.middleware = append(child.middleware, g.middleware...)
+	child.tags = append(child.tags, g.tags...)
+	child.host = g.host
+	child.cors, child.corsSet = g.cors, g.corsSet
+	child.parentEnvs = environmentSets(g.parentEnvs, g.envs)
+	for k, v := range g.metadata {
+		child.metadata[k] = v
+	}
+	return child
+}
+
+// Name returns the group name
+func (g *GroupBuilder) Name() string {
+	return g.name
+}
+
+// Prefix returns the group's full path prefix
+func (g *GroupBuilder) Prefix() string {
+	return g.router.BasePath()
+}
+
+// GetRoutesByGroup returns routes registered through a named group
+func (e *Engine) GetRoutesByGroup(name string) []*RouteInfo {
+	e.routesMux.RLock()
+	defer e.routesMux.RUnlock()
+
+	var routes []*RouteInfo
+	for _, route := range e.routes {
+		if route.Group == name {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// groupDocs describes registered groups and their routes, ordered by name
+func (e *Engine) groupDocs(routes map[string]*RouteInfo) []GroupInfo {
+	e.routesMux.RLock()
+	groups := make([]GroupInfo, 0, len(e.groups))
+	for _, g := range e.groups {
+		groups = append(groups, GroupInfo{
+			Name:     g.name,
+			Prefix:   g.Prefix(),
+			Tags:     g.tags,
+			Metadata: g.metadata,
+			Routes:   []string{},
+		})
+	}
+	e.routesMux.RUnlock()
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	for i := range groups {
+		for name, route := range routes {
+			if route.Group == groups[i].Name {
+				groups[i].Routes = append(groups[i].Routes, name)
+			}
+		}
+		sort.Strings(groups[i].Routes)
+	}
+	return groups
+}
+
+// joinPaths joins a group base path and a relative route path as gin does
+func joinPaths(base, relative string) string {
+	if relative == "" {
+		return base
+	}
+	joined := path.Join(base, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
@@ -2,20 +2,25 @@ package supergin
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 // GrpcConverter interface for types that can convert to/from gRPC
@@ -31,6 +36,17 @@ type GrpcService struct {
 	ServiceName string
 	Methods     map[string]*GrpcMethod
 	Connection  *grpc.ClientConn
+	// MetadataPolicy overrides the bridge's metadata policy for this service
+	MetadataPolicy *MetadataPolicy
+	// Retry retries failed unary calls; nil makes a single attempt
+	Retry *RetryPolicy
+	// CallTimeout bounds every call unless the route or request sets a shorter deadline
+	CallTimeout time.Duration
+	// Breaker fails calls fast while the backend keeps failing; nil disables it
+	Breaker *CircuitBreaker
+
+	// pool holds the service's connections; Connection is the first of them
+	pool *grpcPool
 }
 
 // GrpcMethod represents a gRPC method configuration
@@ -43,12 +59,33 @@ type GrpcMethod struct {
 	GrpcOutputType  reflect.Type
 	StreamingInput  bool
 	StreamingOutput bool
+	Logging         *BridgeLogConfig
+	ProtoValidator  ProtoValidator
+	HttpRule        *HttpRule
+	// InputDescriptor and OutputDescriptor describe methods discovered via server
+	// reflection, which have no generated Go types; their messages are dynamic and
+	// requests and responses are bridged as protojson
+	InputDescriptor  protoreflect.MessageDescriptor
+	OutputDescriptor protoreflect.MessageDescriptor
+	// Fallback answers the method's routes while the backend is unavailable
+	Fallback *GrpcFallback
+}
+
+// ProtoValidator validates converted proto messages before dispatch,
+// e.g. an adapter around buf.build/go/protovalidate
+type ProtoValidator interface {
+	Validate(msg proto.Message) error
 }
 
 // GrpcBridge manages HTTP to gRPC conversions
 type GrpcBridge struct {
-	services map[string]*GrpcService
-	engine   *Engine
+	services       map[string]*GrpcService
+	engine         *Engine
+	protoValidator ProtoValidator
+	metadataPolicy *MetadataPolicy
+	dryRun         DryRunMode
+	httpBreaker    *CircuitBreakerOptions
+	interceptors   []BridgeInterceptor
 }
 
 // NewGrpcBridge creates a new gRPC bridge
@@ -59,10 +96,21 @@ func NewGrpcBridge(engine *Engine) *GrpcBridge {
 	}
 }
 
-// RegisterGrpcService registers a gRPC service for HTTP bridging
-func (gb *GrpcBridge) RegisterGrpcService(name, address, serviceName string) error {
-	// Create gRPC connection
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// RegisterGrpcService registers a gRPC service for HTTP bridging. With
+// GrpcServiceOptions{Reflection: true} its methods are discovered from the server; TLS,
+// CAFile and CertFile/KeyFile secure the connection.
+func (gb *GrpcBridge) RegisterGrpcService(name, address, serviceName string, opts ...GrpcServiceOptions) error {
+	options := GrpcServiceOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	dialOptions, err := options.dialOptions()
+	if err != nil {
+		return fmt.Errorf("invalid options for gRPC service %s: %w", name, err)
+	}
+
+	// Create the service's connections
+	pool, err := newGrpcPool(address, options.PoolSize, dialOptions)
 	if err != nil {
 		return fmt.Errorf("failed to connect to gRPC service %s at %s: %v", name, address, err)
 	}
@@ -72,13 +120,62 @@ func (gb *GrpcBridge) RegisterGrpcService(name, address, serviceName string) err
 		Address:     address,
 		ServiceName: serviceName,
 		Methods:     make(map[string]*GrpcMethod),
-		Connection:  conn,
+		Connection:  pool.conns[0],
+		Retry:       options.Retry,
+		CallTimeout: options.CallTimeout,
+		pool:        pool,
+	}
+	service.MetadataPolicy = options.Metadata
+	if options.CircuitBreaker != nil {
+		service.Breaker = gb.engine.CircuitBreaker("grpc:"+name, *options.CircuitBreaker)
+	}
+	if options.HealthCheckInterval > 0 {
+		pool.healthCheck(service, options.HealthCheckInterval, gb.engine.logger)
 	}
 
 	gb.services[name] = service
+
+	if !options.Reflection {
+		return nil
+	}
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultReflectionTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	methods, err := gb.DiscoverGrpcMethods(ctx, name)
+	if err != nil {
+		return err
+	}
+	if options.RoutePrefix != "" {
+		gb.registerReflectedRoutes(name, options.RoutePrefix, methods)
+	}
 	return nil
 }
 
+// Close closes every service connection. Closed services cannot be called again.
+func (gb *GrpcBridge) Close() error {
+	var errs []error
+	for name, service := range gb.services {
+		if service.Connection == nil {
+			continue
+		}
+		var err error
+		if service.pool != nil {
+			err = service.pool.close()
+		} else {
+			err = service.Connection.Close()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to close gRPC service %s: %v", name, err))
+		}
+		service.Connection = nil
+	}
+	return errors.Join(errs...)
+}
+
 // RegisterGrpcMethod registers a gRPC method with type mappings
 func (gb *GrpcBridge) RegisterGrpcMethod(serviceName, methodName string,
 	httpInputType, httpOutputType, grpcInputType, grpcOutputType interface{}) error {
@@ -97,14 +194,17 @@ func (gb *GrpcBridge) RegisterGrpcMethod(serviceName, methodName string,
 		GrpcOutputType: reflect.TypeOf(grpcOutputType),
 	}
 
+	gb.engine.prepareValidation(method.InputType)
 	service.Methods[methodName] = method
 	return nil
 }
 
 // Engine extension for gRPC bridge
 func (e *Engine) GrpcBridge() *GrpcBridge {
-	if bridge, exists := e.di.Get("grpc_bridge").(*GrpcBridge); exists {
-		return bridge
+	if e.di.Has("grpc_bridge") {
+		if bridge, ok := e.di.Get("grpc_bridge").(*GrpcBridge); ok {
+			return bridge
+		}
 	}
 
 	bridge := NewGrpcBridge(e)
@@ -117,27 +217,79 @@ func (rb *RouteBuilder) WithGrpcBridge(serviceName, methodName string) *RouteBui
 	rb.WithMetadata("grpc_service", serviceName)
 	rb.WithMetadata("grpc_method", methodName)
 
-	// Create the bridging handler
-	originalHandler := rb.handler
-	rb.handler = func(c *gin.Context) {
-		bridge := rb.engine.GrpcBridge()
-
-		// Handle gRPC bridging
-		if err := bridge.handleHttpToGrpc(c, serviceName, methodName); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":   "gRPC bridge error",
-				"details": err.Error(),
-			})
-			return
+	// Wrap the route handler with bridging once it is set
+	rb.handlerWrappers = append(rb.handlerWrappers, func(originalHandler gin.HandlerFunc) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			bridge := rb.engine.GrpcBridge()
+
+			// Handle gRPC bridging
+			if err := bridge.handleHttpToGrpc(c, serviceName, methodName); err != nil {
+				writeBridgeError(c, err)
+				return
+			}
+
+			// Call original handler if needed
+			if originalHandler != nil {
+				originalHandler(c)
+			}
 		}
+	})
 
-		// Call original handler if needed
-		if originalHandler != nil {
-			originalHandler(c)
-		}
+	return rb
+}
+
+// writeBridgeError responds with 400 for validation failures, 503 for unavailable
+// backends, the status of errors implementing StatusCoder, e.g. returned by interceptors,
+// and 500 otherwise
+func writeBridgeError(c *gin.Context, err error) {
+	if IsErrorCode(err, ErrValidationFailed) {
+		writeValidationError(c, err)
+		return
+	}
+	if IsErrorCode(err, ErrBackendUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "gRPC backend unavailable",
+			"details": err.Error(),
+		})
+		return
 	}
+	var coded StatusCoder
+	if errors.As(err, &coded) {
+		c.JSON(coded.StatusCode(), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error":   "gRPC bridge error",
+		"details": err.Error(),
+	})
+}
 
-	return rb
+// SetProtoValidator sets the validator run on every converted proto request
+func (gb *GrpcBridge) SetProtoValidator(validator ProtoValidator) *GrpcBridge {
+	gb.protoValidator = validator
+	return gb
+}
+
+// SetMethodProtoValidator overrides the proto validator for a single method
+func (gb *GrpcBridge) SetMethodProtoValidator(serviceName, methodName string, validator ProtoValidator) error {
+	service, exists := gb.services[serviceName]
+	if !exists {
+		return fmt.Errorf("gRPC service %s not found", serviceName)
+	}
+	method, exists := service.Methods[methodName]
+	if !exists {
+		return fmt.Errorf("gRPC method %s not found in service %s", methodName, serviceName)
+	}
+
+	method.ProtoValidator = validator
+	return nil
+}
+
+func (gb *GrpcBridge) protoValidatorFor(method *GrpcMethod) ProtoValidator {
+	if method.ProtoValidator != nil {
+		return method.ProtoValidator
+	}
+	return gb.protoValidator
 }
 
 // handleHttpToGrpc handles HTTP to gRPC conversion
@@ -152,43 +304,149 @@ func (gb *GrpcBridge) handleHttpToGrpc(c *gin.Context, serviceName, methodName s
 		return fmt.Errorf("gRPC method %s not found in service %s", methodName, serviceName)
 	}
 
-	// Get validated HTTP input
+	if method.StreamingInput || method.StreamingOutput {
+		return gb.handleStreaming(c, service, method)
+	}
+
+	grpcInput, err := gb.bindGrpcInput(c, method)
+	if err != nil {
+		return err
+	}
+
+	grpcOutput, err := gb.dispatch(c, service, method, grpcInput)
+	if err != nil || grpcOutput == nil {
+		return err
+	}
+
+	// Convert gRPC output to HTTP output
+	httpOutput, err := gb.convertFromGrpc(grpcOutput, method.OutputType)
+	if err != nil {
+		return fmt.Errorf("failed to convert gRPC output to HTTP: %v", err)
+	}
+
+	// Send HTTP response
+	c.JSON(http.StatusOK, httpOutput)
+	return nil
+}
+
+// bindGrpcInput binds and validates the HTTP input and converts it to the gRPC request
+func (gb *GrpcBridge) bindGrpcInput(c *gin.Context, method *GrpcMethod) (proto.Message, error) {
+	grpcInput, err := gb.newGrpcInput(method)
+	if err != nil {
+		return nil, err
+	}
+
+	// Discovered methods have no HTTP type; bind the body straight onto the proto request
+	if method.InputType == nil {
+		body, err := c.GetRawData()
+		if err != nil {
+			return nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "failed to read request body")
+		}
+		if len(body) > 0 {
+			if err := protojson.Unmarshal(body, grpcInput); err != nil {
+				return nil, NewSuperGinError(ErrValidationFailed, "binding error: %v", err)
+			}
+		}
+		return grpcInput, nil
+	}
+
+	// Reuse route-validated input when it has the method's input type
 	var httpInput interface{}
-	if input, exists := GetValidatedInput(c); exists {
+	if input, exists := GetValidatedInput(c); exists && reflect.TypeOf(input) == reflect.PointerTo(method.InputType) {
 		httpInput = input
 	} else {
-		// Create new instance and bind
+		// Create new instance, bind and validate
 		httpInput = reflect.New(method.InputType).Interface()
 		if err := c.ShouldBindJSON(httpInput); err != nil {
-			return fmt.Errorf("failed to bind HTTP input: %v", err)
+			return nil, bindingError(err)
+		}
+		if err := gb.validateHttpInput(method, httpInput); err != nil {
+			return nil, err
 		}
 	}
 
 	// Convert HTTP input to gRPC input
-	grpcInput, err := gb.convertToGrpc(httpInput, method.GrpcInputType)
+	grpcInput, err = gb.convertToGrpc(httpInput, grpcInput)
 	if err != nil {
-		return fmt.Errorf("failed to convert HTTP input to gRPC: %v", err)
+		return nil, fmt.Errorf("failed to convert HTTP input to gRPC: %v", err)
 	}
+	return grpcInput, nil
+}
 
-	// Make gRPC call
-	grpcOutput, err := gb.callGrpcMethod(c.Request.Context(), service, method, grpcInput)
-	if err != nil {
-		return fmt.Errorf("gRPC call failed: %v", err)
+// validateHttpInput runs struct validation on bound HTTP input
+func (gb *GrpcBridge) validateHttpInput(method *GrpcMethod, httpInput interface{}) error {
+	if method.InputType.Kind() != reflect.Struct {
+		return nil
+	}
+	if err := validateStruct(gb.engine.validator, httpInput); err != nil {
+		return NewSuperGinErrorWithCause(ErrValidationFailed, err, "validation error")
 	}
+	return nil
+}
 
-	// Convert gRPC output to HTTP output
-	httpOutput, err := gb.convertFromGrpc(grpcOutput, method.OutputType)
+// validateProto runs the method's proto validator, if any
+func (gb *GrpcBridge) validateProto(method *GrpcMethod, grpcInput proto.Message) error {
+	if validator := gb.protoValidatorFor(method); validator != nil {
+		if err := validator.Validate(grpcInput); err != nil {
+			return NewSuperGinError(ErrValidationFailed, "proto validation error: %v", err)
+		}
+	}
+	return nil
+}
+
+// dispatch runs a converted request through the bridge's interceptors to invoke. It returns
+// a nil message without error when the request was answered as a dry run or by a fallback.
+func (gb *GrpcBridge) dispatch(c *gin.Context, service *GrpcService, method *GrpcMethod, grpcInput proto.Message) (proto.Message, error) {
+	return gb.intercept(c, service, method, grpcInput, func(c *gin.Context, grpcInput proto.Message) (proto.Message, error) {
+		return gb.invoke(c, service, method, grpcInput)
+	})
+}
+
+// invoke validates a converted request and calls the backend
+func (gb *GrpcBridge) invoke(c *gin.Context, service *GrpcService, method *GrpcMethod, grpcInput proto.Message) (proto.Message, error) {
+	// Run proto-level validation rules before dispatch
+	if err := gb.validateProto(method, grpcInput); err != nil {
+		return nil, err
+	}
+
+	// Forward the headers the metadata policy allows, within the call's deadline
+	ctx, cancel, err := gb.callContext(c, service)
 	if err != nil {
-		return fmt.Errorf("failed to convert gRPC output to HTTP: %v", err)
+		return nil, err
 	}
+	defer cancel()
 
-	// Send HTTP response
-	c.JSON(http.StatusOK, httpOutput)
-	return nil
+	// In dry-run mode, render the converted request instead of calling the backend
+	if gb.isDryRun(c) {
+		return nil, gb.renderDryRun(ctx, c, service, method, grpcInput)
+	}
+
+	// Make gRPC call
+	var header, trailer metadata.MD
+	start := time.Now()
+	grpcOutput, err := gb.callGrpcMethod(ctx, service, method, grpcInput, grpc.Header(&header), grpc.Trailer(&trailer))
+	gb.logCall(c, method, grpcInput, grpcOutput, time.Since(start), err)
+	gb.exposeMetadata(c, service, header, trailer)
+	fallback := gb.fallbackFor(c, method)
+	if err != nil {
+		if !backendUnavailable(err) {
+			return nil, fmt.Errorf("gRPC call failed: %v", err)
+		}
+		if !IsErrorCode(err, ErrBackendUnavailable) {
+			err = NewSuperGinErrorWithCause(ErrBackendUnavailable, err, "gRPC service %s unavailable", service.Name)
+		}
+		if fallback != nil {
+			return fallback.serve(c, method, grpcInput, err)
+		}
+		return nil, err
+	}
+	fallback.remember(method, grpcInput, grpcOutput)
+	return grpcOutput, nil
 }
 
-// convertToGrpc converts HTTP input to gRPC message
-func (gb *GrpcBridge) convertToGrpc(httpInput interface{}, grpcType reflect.Type) (proto.Message, error) {
+// convertToGrpc converts HTTP input to gRPC message, filling target unless the
+// input implements GrpcConverter
+func (gb *GrpcBridge) convertToGrpc(httpInput interface{}, target proto.Message) (proto.Message, error) {
 	// Check if input implements GrpcConverter
 	if converter, ok := httpInput.(GrpcConverter); ok {
 		return converter.ToGrpc()
@@ -200,23 +458,25 @@ func (gb *GrpcBridge) convertToGrpc(httpInput interface{}, grpcType reflect.Type
 		return nil, fmt.Errorf("failed to marshal HTTP input: %v", err)
 	}
 
-	// Create new gRPC message instance
-	grpcValue := reflect.New(grpcType.Elem()).Interface()
-	grpcMsg, ok := grpcValue.(proto.Message)
-	if !ok {
-		return nil, fmt.Errorf("gRPC type %s does not implement proto.Message", grpcType)
-	}
-
 	// Convert JSON to protobuf
-	if err := protojson.Unmarshal(httpJSON, grpcMsg); err != nil {
+	if err := protojson.Unmarshal(httpJSON, target); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON to protobuf: %v", err)
 	}
 
-	return grpcMsg, nil
+	return target, nil
 }
 
 // convertFromGrpc converts gRPC message to HTTP output
 func (gb *GrpcBridge) convertFromGrpc(grpcOutput proto.Message, httpType reflect.Type) (interface{}, error) {
+	// Discovered methods have no HTTP type; render the proto message as is
+	if httpType == nil {
+		rendered, err := protojson.Marshal(grpcOutput)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal protobuf to JSON: %v", err)
+		}
+		return json.RawMessage(rendered), nil
+	}
+
 	// Create new HTTP output instance
 	httpValue := reflect.New(httpType)
 	httpOutput := httpValue.Interface()
@@ -243,25 +503,57 @@ func (gb *GrpcBridge) convertFromGrpc(grpcOutput proto.Message, httpType reflect
 	return httpOutput, nil
 }
 
-// callGrpcMethod makes the actual gRPC call
-func (gb *GrpcBridge) callGrpcMethod(ctx context.Context, service *GrpcService, method *GrpcMethod, input proto.Message) (proto.Message, error) {
-	// Create gRPC output message instance
-	outputValue := reflect.New(method.GrpcOutputType.Elem()).Interface()
-	output, ok := outputValue.(proto.Message)
-	if !ok {
-		return nil, fmt.Errorf("gRPC output type does not implement proto.Message")
+// newGrpcInput instantiates the method's gRPC request message
+func (gb *GrpcBridge) newGrpcInput(method *GrpcMethod) (proto.Message, error) {
+	if method.InputDescriptor != nil {
+		return dynamicpb.NewMessage(method.InputDescriptor), nil
 	}
+	return newProtoMessage(method.GrpcInputType)
+}
 
-	// Prepare gRPC metadata from HTTP headers
-	md := metadata.New(nil)
-
-	// Make the gRPC call using the generic Invoke method
-	err := service.Connection.Invoke(ctx, method.FullName, input, output, grpc.Header(&md))
-	if err != nil {
-		return nil, err
+// newGrpcOutput instantiates the method's gRPC response message
+func (gb *GrpcBridge) newGrpcOutput(method *GrpcMethod) (proto.Message, error) {
+	if method.OutputDescriptor != nil {
+		return dynamicpb.NewMessage(method.OutputDescriptor), nil
 	}
+	return newProtoMessage(method.GrpcOutputType)
+}
+
+// callGrpcMethod makes the actual gRPC call, retrying per the service's retry policy
+func (gb *GrpcBridge) callGrpcMethod(ctx context.Context, service *GrpcService, method *GrpcMethod, input proto.Message, opts ...grpc.CallOption) (proto.Message, error) {
+	for attempt := 1; ; attempt++ {
+		// Create gRPC output message instance
+		output, err := gb.newGrpcOutput(method)
+		if err != nil {
+			return nil, err
+		}
 
-	return output, nil
+		// An open circuit fails fast; retrying would only wait for it
+		if err := service.allowCall(); err != nil {
+			return nil, err
+		}
+		conn, err := service.conn()
+		if err == nil {
+			// Make the gRPC call using the generic Invoke method
+			err = conn.Invoke(ctx, method.FullName, input, output, opts...)
+		}
+		service.recordCall(err)
+		if err == nil {
+			return output, nil
+		}
+
+		policy := service.Retry
+		if policy == nil || attempt >= policy.MaxAttempts || !policy.retryable(err) {
+			return nil, err
+		}
+		wait := policy.backoff(attempt)
+		gb.engine.logger.Warn("retrying gRPC call", "method", method.FullName, "attempt", attempt, "backoff", wait, "error", err)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, err
+		}
+	}
 }
 
 // Reverse proxy: gRPC to HTTP
@@ -279,17 +571,34 @@ func (gb *GrpcBridge) CreateGrpcToHttpProxy(serviceName, methodName string, http
 			return
 		}
 
-		// Read gRPC request (assuming protobuf in request body)
-		body, err := io.ReadAll(c.Request.Body)
+		// Only accept protobuf payloads
+		if !isProtobufContentType(c.ContentType()) {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{
+				"error":   "unsupported content type",
+				"details": fmt.Sprintf("expected one of %s", strings.Join(protobufContentTypes, ", ")),
+			})
+			return
+		}
+
+		// Read gRPC request, decompressing according to Content-Encoding
+		body, err := readProtobufBody(c)
 		if err != nil {
+			if IsErrorCode(err, ErrUnsupportedEncoding) {
+				c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+				return
+			}
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeBodyTooLarge(c, tooLarge.Limit)
+				return
+			}
 			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
 			return
 		}
 
 		// Create gRPC input message
-		grpcInputValue := reflect.New(method.GrpcInputType.Elem()).Interface()
-		grpcInput, ok := grpcInputValue.(proto.Message)
-		if !ok {
+		grpcInput, err := gb.newGrpcInput(method)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid gRPC input type"})
 			return
 		}
@@ -310,12 +619,21 @@ func (gb *GrpcBridge) CreateGrpcToHttpProxy(serviceName, methodName string, http
 		// Make HTTP call
 		httpResponse, err := gb.makeHttpCall(httpEndpoint, httpInput)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			code := http.StatusInternalServerError
+			if IsErrorCode(err, ErrBackendUnavailable) {
+				code = http.StatusServiceUnavailable
+			}
+			c.JSON(code, gin.H{"error": err.Error()})
 			return
 		}
 
 		// Convert HTTP response back to gRPC
-		grpcOutput, err := gb.convertToGrpc(httpResponse, method.GrpcOutputType)
+		grpcOutput, err := gb.newGrpcOutput(method)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		grpcOutput, err = gb.convertToGrpc(httpResponse, grpcOutput)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
@@ -328,10 +646,100 @@ func (gb *GrpcBridge) CreateGrpcToHttpProxy(serviceName, methodName string, http
 			return
 		}
 
-		c.Data(http.StatusOK, "application/x-protobuf", grpcBytes)
+		writeProtobuf(c, http.StatusOK, grpcBytes)
+	}
+}
+
+// protobufContentTypes lists the media types accepted as protobuf request bodies
+var protobufContentTypes = []string{"application/x-protobuf", "application/protobuf"}
+
+// isProtobufContentType reports whether the content type denotes a protobuf body
+func isProtobufContentType(contentType string) bool {
+	return contains(protobufContentTypes, strings.ToLower(contentType))
+}
+
+// maxDecompressedMessage bounds decompressed messages on routes without a body limit,
+// matching gRPC's default maximum received message size
+const maxDecompressedMessage = 4 << 20
+
+// decompressedLimit returns how large a request message may grow when decompressed: the
+// route's body limit, or maxDecompressedMessage
+func decompressedLimit(c *gin.Context) int64 {
+	if limit := c.GetInt64(bodyLimitKey); limit > 0 {
+		return limit
+	}
+	return maxDecompressedMessage
+}
+
+// readGzip decompresses r, failing with *http.MaxBytesError once the output exceeds limit
+// so small compressed bodies cannot inflate without bound
+func readGzip(r io.Reader, limit int64) ([]byte, error) {
+	reader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, &http.MaxBytesError{Limit: limit}
+	}
+	return data, nil
+}
+
+// readProtobufBody reads the request body, honouring gzip Content-Encoding
+func readProtobufBody(c *gin.Context) ([]byte, error) {
+	encoding := strings.ToLower(strings.TrimSpace(c.GetHeader("Content-Encoding")))
+
+	switch encoding {
+	case "", "identity":
+		return io.ReadAll(c.Request.Body)
+	case "gzip":
+		return readGzip(c.Request.Body, decompressedLimit(c))
+	default:
+		return nil, NewSuperGinError(ErrUnsupportedEncoding, "unsupported content encoding '%s'", encoding)
 	}
 }
 
+// writeProtobuf writes a protobuf response, gzip-compressing it when the client accepts gzip
+func writeProtobuf(c *gin.Context, status int, data []byte) {
+	addVary(c.Writer.Header(), []string{"Accept-Encoding"})
+
+	encoding, compressor := negotiateEncoding(c.GetHeader("Accept-Encoding"), []string{"gzip"})
+	if compressor == nil {
+		c.Data(status, protobufContentTypes[0], data)
+		return
+	}
+
+	var buf bytes.Buffer
+	writer, err := compressor(&buf)
+	if err != nil {
+		c.Data(status, protobufContentTypes[0], data)
+		return
+	}
+	if _, err := writer.Write(data); err != nil {
+		c.Data(status, protobufContentTypes[0], data)
+		return
+	}
+	if err := writer.Close(); err != nil {
+		c.Data(status, protobufContentTypes[0], data)
+		return
+	}
+
+	c.Header("Content-Encoding", encoding)
+	c.Data(status, protobufContentTypes[0], buf.Bytes())
+}
+
+// SetHttpCircuitBreaker guards the reverse proxy's outbound HTTP calls with a circuit
+// breaker per host, named "http:<host>". Connection failures and 5xx responses count as
+// failures; calls to an open circuit fail as unavailable without being sent.
+func (gb *GrpcBridge) SetHttpCircuitBreaker(options CircuitBreakerOptions) *GrpcBridge {
+	gb.httpBreaker = &options
+	return gb
+}
+
 // makeHttpCall makes an HTTP call to the specified endpoint
 func (gb *GrpcBridge) makeHttpCall(endpoint string, input interface{}) (interface{}, error) {
 	// Marshal input to JSON
@@ -340,8 +748,23 @@ func (gb *GrpcBridge) makeHttpCall(endpoint string, input interface{}) (interfac
 		return nil, fmt.Errorf("failed to marshal input: %v", err)
 	}
 
+	var breaker *CircuitBreaker
+	if gb.httpBreaker != nil {
+		target, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP endpoint %s: %v", endpoint, err)
+		}
+		breaker = gb.engine.CircuitBreaker("http:"+target.Host, *gb.httpBreaker)
+		if err := breaker.Allow(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Make HTTP POST request
 	resp, err := http.Post(endpoint, "application/json", bytes.NewBuffer(jsonData))
+	if breaker != nil {
+		breaker.Record(err == nil && resp.StatusCode < http.StatusInternalServerError)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %v", err)
 	}
@@ -0,0 +1,251 @@
+package supergin
+
+import (
+	"fmt"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// httpRuleExtension is the field number of the google.api.http method option
+const httpRuleExtension = 72295728
+
+// Field numbers of google.api.HttpRule and google.api.CustomHttpPattern
+const (
+	httpRuleGet                = 2
+	httpRulePut                = 3
+	httpRulePost               = 4
+	httpRuleDelete             = 5
+	httpRulePatch              = 6
+	httpRuleBody               = 7
+	httpRuleCustom             = 8
+	httpRuleAdditionalBindings = 11
+	httpRuleResponseBody       = 12
+	customPatternKind          = 1
+	customPatternPath          = 2
+)
+
+// TranscodeDescriptorFile reads a compiled descriptor set, as written by
+// protoc --include_imports --descriptor_set_out, and transcodes the service's annotated
+// methods with TranscodeDescriptorSet
+func (gb *GrpcBridge) TranscodeDescriptorFile(serviceName, path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set %s: %v", path, err)
+	}
+	return gb.TranscodeDescriptorSet(serviceName, data)
+}
+
+// TranscodeDescriptorSet registers a transcoded route for every google.api.http binding
+// of a bridged service's methods, read from a serialized FileDescriptorSet that includes
+// the service's imports. Methods not registered with RegisterGrpcMethod are bridged as
+// protojson with dynamic messages, like discovered ones. Routes are named <name>_<Method>,
+// with additional bindings suffixed _1, _2 and so on; their names are returned. Streaming
+// methods are skipped, as transcoding only covers unary calls.
+func (gb *GrpcBridge) TranscodeDescriptorSet(serviceName string, descriptorSet []byte) ([]string, error) {
+	service, exists := gb.services[serviceName]
+	if !exists {
+		return nil, fmt.Errorf("gRPC service %s not found", serviceName)
+	}
+
+	// Leave google.api.http unresolved even when its Go package is linked, so the
+	// annotation is always read from unknown fields
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := (proto.UnmarshalOptions{Resolver: new(protoregistry.Types)}).Unmarshal(descriptorSet, set); err != nil {
+		return nil, fmt.Errorf("invalid descriptor set: %v", err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set (was it built with --include_imports?): %v", err)
+	}
+	found, err := files.FindDescriptorByName(protoreflect.FullName(service.ServiceName))
+	if err != nil {
+		return nil, fmt.Errorf("gRPC service %s not found in descriptor set", service.ServiceName)
+	}
+	descriptor, ok := found.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a gRPC service", service.ServiceName)
+	}
+
+	// Collect and validate every binding before registering any route
+	type binding struct {
+		method string
+		rules  []HttpRule
+	}
+	var bindings []binding
+	methods := descriptor.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		name := string(md.Name())
+		options, _ := md.Options().(*descriptorpb.MethodOptions)
+		if options == nil {
+			continue
+		}
+		rules, err := parseHttpRules(options.ProtoReflect().GetUnknown())
+		if err != nil {
+			return nil, fmt.Errorf("invalid google.api.http annotation on %s: %v", md.FullName(), err)
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		if md.IsStreamingClient() || md.IsStreamingServer() {
+			gb.engine.logger.Warn("skipping google.api.http annotation on streaming gRPC method", "method", md.FullName())
+			continue
+		}
+
+		if _, exists := service.Methods[name]; !exists {
+			service.Methods[name] = &GrpcMethod{
+				Name:             name,
+				FullName:         fmt.Sprintf("/%s/%s", service.ServiceName, name),
+				InputDescriptor:  md.Input(),
+				OutputDescriptor: md.Output(),
+			}
+		}
+		for _, rule := range rules {
+			if err := gb.SetHttpRule(serviceName, name, rule); err != nil {
+				return nil, err
+			}
+		}
+		bindings = append(bindings, binding{method: name, rules: rules})
+	}
+
+	var routes []string
+	for _, b := range bindings {
+		for i, rule := range b.rules {
+			route := fmt.Sprintf("%s_%s", serviceName, b.method)
+			if i > 0 {
+				route = fmt.Sprintf("%s_%d", route, i)
+			}
+			if err := gb.Transcode(route, serviceName, b.method, rule); err != nil {
+				return routes, err
+			}
+			routes = append(routes, route)
+		}
+		// The method's own rule is its primary binding
+		gb.SetHttpRule(serviceName, b.method, b.rules[0])
+	}
+	return routes, nil
+}
+
+// parseHttpRules decodes the google.api.http option from a method's unknown option
+// fields, returning the rule followed by its additional bindings
+func parseHttpRules(raw protoreflect.RawFields) ([]HttpRule, error) {
+	var rules []HttpRule
+	for len(raw) > 0 {
+		num, typ, n := protowire.ConsumeTag(raw)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		raw = raw[n:]
+		if num == httpRuleExtension && typ == protowire.BytesType {
+			value, n := protowire.ConsumeBytes(raw)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			rule, additional, err := decodeHttpRule(value)
+			if err != nil {
+				return nil, err
+			}
+			rules = append([]HttpRule{rule}, additional...)
+		}
+		n = protowire.ConsumeFieldValue(num, typ, raw)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		raw = raw[n:]
+	}
+	return rules, nil
+}
+
+// decodeHttpRule decodes a google.api.HttpRule message and its additional bindings
+func decodeHttpRule(data []byte) (HttpRule, []HttpRule, error) {
+	var rule HttpRule
+	var additional []HttpRule
+	verbs := map[protowire.Number]string{
+		httpRuleGet: "GET", httpRulePut: "PUT", httpRulePost: "POST",
+		httpRuleDelete: "DELETE", httpRulePatch: "PATCH",
+	}
+
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return rule, nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return rule, nil, protowire.ParseError(n)
+			}
+			data = data[n:]
+			continue
+		}
+		value, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return rule, nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		switch num {
+		case httpRuleGet, httpRulePut, httpRulePost, httpRuleDelete, httpRulePatch:
+			rule.Method, rule.Path = verbs[num], string(value)
+		case httpRuleCustom:
+			kind, path, err := decodeCustomPattern(value)
+			if err != nil {
+				return rule, nil, err
+			}
+			rule.Method, rule.Path = kind, path
+		case httpRuleBody:
+			rule.Body = string(value)
+		case httpRuleResponseBody:
+			rule.ResponseBody = string(value)
+		case httpRuleAdditionalBindings:
+			// Additional bindings cannot nest further bindings
+			binding, _, err := decodeHttpRule(value)
+			if err != nil {
+				return rule, nil, err
+			}
+			additional = append(additional, binding)
+		}
+	}
+	if rule.Path == "" {
+		return rule, nil, fmt.Errorf("HTTP rule has no pattern")
+	}
+	return rule, additional, nil
+}
+
+// decodeCustomPattern decodes a google.api.CustomHttpPattern into its verb and path
+func decodeCustomPattern(data []byte) (string, string, error) {
+	var kind, path string
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return "", "", protowire.ParseError(n)
+		}
+		data = data[n:]
+		if typ == protowire.BytesType && (num == customPatternKind || num == customPatternPath) {
+			value, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return "", "", protowire.ParseError(n)
+			}
+			if num == customPatternKind {
+				kind = string(value)
+			} else {
+				path = string(value)
+			}
+			data = data[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, data)
+		if n < 0 {
+			return "", "", protowire.ParseError(n)
+		}
+		data = data[n:]
+	}
+	return kind, path, nil
+}
@@ -0,0 +1,71 @@
+package supergin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DryRunHeader requests a dry run when the bridge allows header-gated dry runs
+const DryRunHeader = "X-SuperGin-Dry-Run"
+
+// DryRunMode controls when the bridge returns the converted request instead of calling the backend
+type DryRunMode int
+
+const (
+	// DryRunDisabled always calls the backend
+	DryRunDisabled DryRunMode = iota
+	// DryRunOnHeader dry-runs requests carrying a truthy X-SuperGin-Dry-Run header
+	DryRunOnHeader
+	// DryRunAlways never calls the backend
+	DryRunAlways
+)
+
+// SetDryRun configures the bridge's dry-run mode. Header-gated dry runs expose request
+// conversion details and should only be enabled in trusted environments.
+func (gb *GrpcBridge) SetDryRun(mode DryRunMode) *GrpcBridge {
+	gb.dryRun = mode
+	return gb
+}
+
+func (gb *GrpcBridge) isDryRun(c *gin.Context) bool {
+	switch gb.dryRun {
+	case DryRunAlways:
+		return true
+	case DryRunOnHeader:
+		enabled, err := strconv.ParseBool(c.GetHeader(DryRunHeader))
+		return err == nil && enabled
+	default:
+		return false
+	}
+}
+
+// renderDryRun responds with the converted gRPC request and outgoing metadata
+func (gb *GrpcBridge) renderDryRun(ctx context.Context, c *gin.Context, service *GrpcService, method *GrpcMethod, request proto.Message) error {
+	rendered, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if md == nil {
+		md = metadata.MD{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dry_run":   true,
+		"service":   service.Name,
+		"address":   service.Address,
+		"method":    method.FullName,
+		"request":   json.RawMessage(rendered),
+		"metadata":  md,
+		"proto_msg": string(proto.MessageName(request)),
+	})
+	return nil
+}
@@ -0,0 +1,210 @@
+package supergin
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// GrpcFallbackHeader marks responses served by a fallback: "cached", "handler" or "static"
+	GrpcFallbackHeader = "X-Grpc-Fallback"
+
+	// grpcFallbackKey stores a route's fallback in the gin context for dispatch
+	grpcFallbackKey = "supergin:grpc_fallback"
+
+	// defaultFallbackEntries bounds the responses a cached fallback keeps
+	defaultFallbackEntries = 1000
+)
+
+// GrpcFallback is how a bridged route degrades while its gRPC backend is unavailable:
+// dialing fails, the connection is down or the service was closed. The options are tried
+// in order (a cached response, then Handler, then the static payload), and requests none
+// of them answers get 503.
+type GrpcFallback struct {
+	// Cached, when set, keeps successful responses and serves the one for the same request,
+	// if it is younger than Cached
+	Cached time.Duration
+	// CacheEntries bounds the cached responses; zero means 1000
+	CacheEntries int
+	// Handler answers in place of the backend, e.g. from a local replica or with less data
+	Handler gin.HandlerFunc
+	// Status and Payload answer with a fixed JSON response; Status defaults to 200
+	Status  int
+	Payload interface{}
+
+	cache *fallbackCache
+}
+
+// fallbackCache holds the last successful responses of a cached fallback, keyed by method
+// and request
+type fallbackCache struct {
+	entries    map[string]fallbackEntry
+	maxEntries int
+	mutex      sync.Mutex
+}
+
+type fallbackEntry struct {
+	response proto.Message
+	storedAt time.Time
+}
+
+// prepared validates the fallback and returns a copy ready for use
+func (f GrpcFallback) prepared() *GrpcFallback {
+	if f.Cached <= 0 && f.Handler == nil && f.Payload == nil && f.Status == 0 {
+		panic("gRPC fallback needs a cache window, a handler or a static payload")
+	}
+	if f.Cached > 0 {
+		if f.CacheEntries <= 0 {
+			f.CacheEntries = defaultFallbackEntries
+		}
+		f.cache = &fallbackCache{entries: make(map[string]fallbackEntry), maxEntries: f.CacheEntries}
+	}
+	return &f
+}
+
+// kinds lists the fallback's options, for route metadata
+func (f *GrpcFallback) kinds() []string {
+	var kinds []string
+	if f.Cached > 0 {
+		kinds = append(kinds, "cached")
+	}
+	if f.Handler != nil {
+		kinds = append(kinds, "handler")
+	}
+	if f.Payload != nil || f.Status != 0 {
+		kinds = append(kinds, "static")
+	}
+	return kinds
+}
+
+// WithGrpcFallback degrades the route gracefully while its gRPC backend is unavailable,
+// overriding the method's fallback set with SetMethodFallback
+func (rb *RouteBuilder) WithGrpcFallback(fallback GrpcFallback) *RouteBuilder {
+	prepared := fallback.prepared()
+	rb.WithMetadata("grpc_fallback", prepared.kinds())
+	return rb.WithMiddleware(func(c *gin.Context) {
+		c.Set(grpcFallbackKey, prepared)
+		c.Next()
+	})
+}
+
+// SetMethodFallback sets the fallback of every route bridging a method, transcoded and
+// discovered routes included
+func (gb *GrpcBridge) SetMethodFallback(serviceName, methodName string, fallback GrpcFallback) error {
+	service, exists := gb.services[serviceName]
+	if !exists {
+		return fmt.Errorf("gRPC service %s not found", serviceName)
+	}
+	method, exists := service.Methods[methodName]
+	if !exists {
+		return fmt.Errorf("gRPC method %s not found in service %s", methodName, serviceName)
+	}
+
+	method.Fallback = fallback.prepared()
+	return nil
+}
+
+// fallbackFor returns the route's fallback, or the method's
+func (gb *GrpcBridge) fallbackFor(c *gin.Context, method *GrpcMethod) *GrpcFallback {
+	if value, exists := c.Get(grpcFallbackKey); exists {
+		return value.(*GrpcFallback)
+	}
+	return method.Fallback
+}
+
+// backendUnavailable reports whether a call failed because the backend cannot be reached
+func backendUnavailable(err error) bool {
+	return IsErrorCode(err, ErrBackendUnavailable) || status.Code(err) == codes.Unavailable
+}
+
+// remember caches a successful response for the request
+func (f *GrpcFallback) remember(method *GrpcMethod, request, response proto.Message) {
+	if f == nil || f.cache == nil {
+		return
+	}
+	key, ok := fallbackKey(method, request)
+	if !ok {
+		return
+	}
+	f.cache.store(key, proto.Clone(response))
+}
+
+// serve answers a request whose backend is unavailable. Like dispatch, it returns a cached
+// response to render, nil without error when the request was answered, or the cause when
+// no option applies.
+func (f *GrpcFallback) serve(c *gin.Context, method *GrpcMethod, request proto.Message, cause error) (proto.Message, error) {
+	if f.cache != nil {
+		if key, ok := fallbackKey(method, request); ok {
+			if response, fresh := f.cache.load(key, f.Cached); fresh {
+				LoggerFor(c).Warn("gRPC backend unavailable, serving cached response", "method", method.FullName, "error", cause)
+				c.Header(GrpcFallbackHeader, "cached")
+				return response, nil
+			}
+		}
+	}
+
+	if f.Handler != nil {
+		LoggerFor(c).Warn("gRPC backend unavailable, serving fallback handler", "method", method.FullName, "error", cause)
+		c.Header(GrpcFallbackHeader, "handler")
+		f.Handler(c)
+		return nil, nil
+	}
+
+	if f.Payload != nil || f.Status != 0 {
+		LoggerFor(c).Warn("gRPC backend unavailable, serving static fallback", "method", method.FullName, "error", cause)
+		code := f.Status
+		if code == 0 {
+			code = http.StatusOK
+		}
+		c.Header(GrpcFallbackHeader, "static")
+		c.JSON(code, f.Payload)
+		return nil, nil
+	}
+	return nil, cause
+}
+
+// fallbackKey identifies a request by method and deterministically encoded message
+func fallbackKey(method *GrpcMethod, request proto.Message) (string, bool) {
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return "", false
+	}
+	return method.FullName + "\x00" + string(encoded), true
+}
+
+func (fc *fallbackCache) store(key string, response proto.Message) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	if _, exists := fc.entries[key]; !exists && len(fc.entries) >= fc.maxEntries {
+		// Evict the oldest response
+		var oldestKey string
+		var oldest time.Time
+		for k, entry := range fc.entries {
+			if oldestKey == "" || entry.storedAt.Before(oldest) {
+				oldestKey, oldest = k, entry.storedAt
+			}
+		}
+		delete(fc.entries, oldestKey)
+	}
+	fc.entries[key] = fallbackEntry{response: response, storedAt: time.Now()}
+}
+
+// load returns a copy of the response cached for key if it is younger than maxAge
+func (fc *fallbackCache) load(key string, maxAge time.Duration) (proto.Message, bool) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	entry, exists := fc.entries[key]
+	if !exists || time.Since(entry.storedAt) > maxAge {
+		return nil, false
+	}
+	return proto.Clone(entry.response), true
+}
@@ -0,0 +1,44 @@
+package supergin
+
+import (
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+)
+
+// BridgeHandler continues a bridged unary call with the given request, returning the
+// backend's response
+type BridgeHandler func(c *gin.Context, req proto.Message) (proto.Message, error)
+
+// BridgeInterceptor runs around every bridged unary call, including transcoded and
+// gRPC-Web or Connect calls. It receives the bridge's name of the service, the method
+// name, the HTTP context and the converted proto request. It calls next to continue,
+// possibly with a modified or replaced request, and may inspect or replace the response;
+// returning without calling next answers the call itself. A nil response without error
+// from next means the request was answered without a backend call, as a dry run or by a
+// fallback. Errors implementing StatusCoder set the HTTP status of the response.
+type BridgeInterceptor func(c *gin.Context, service, method string, req proto.Message, next BridgeHandler) (proto.Message, error)
+
+// Use adds interceptors to the bridge. They run in the order added, the first outermost.
+// On bridged and transcoded routes they run before proto validation, so validation sees
+// requests as interceptors leave them. Streaming calls are not intercepted.
+func (gb *GrpcBridge) Use(interceptors ...BridgeInterceptor) *GrpcBridge {
+	for _, interceptor := range interceptors {
+		if interceptor == nil {
+			panic("bridge interceptor must not be nil")
+		}
+	}
+	gb.interceptors = append(gb.interceptors, interceptors...)
+	return gb
+}
+
+// intercept runs call behind the bridge's interceptors
+func (gb *GrpcBridge) intercept(c *gin.Context, service *GrpcService, method *GrpcMethod, req proto.Message, call BridgeHandler) (proto.Message, error) {
+	handler := call
+	for i := len(gb.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := gb.interceptors[i], handler
+		handler = func(c *gin.Context, req proto.Message) (proto.Message, error) {
+			return interceptor(c, service.Name, method.Name, req, next)
+		}
+	}
+	return handler(c, req)
+}
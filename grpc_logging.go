@@ -0,0 +1,113 @@
+package supergin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// RedactedValue replaces redacted fields in bridge logs
+const RedactedValue = "[REDACTED]"
+
+// BridgeLogConfig controls logging of bridged gRPC calls
+type BridgeLogConfig struct {
+	// RedactFields lists proto field paths to redact, e.g. "email" or "user.address.street".
+	// Paths use proto field names; repeated fields are traversed element-wise.
+	RedactFields []string
+	// MaxBytes truncates each logged payload; zero means 4096
+	MaxBytes int
+	// LogPayloads includes request/response bodies; otherwise only call outcome is logged
+	LogPayloads bool
+}
+
+// SetLogging enables logging for a bridged method
+func (gb *GrpcBridge) SetLogging(serviceName, methodName string, config BridgeLogConfig) error {
+	service, exists := gb.services[serviceName]
+	if !exists {
+		return fmt.Errorf("gRPC service %s not found", serviceName)
+	}
+	method, exists := service.Methods[methodName]
+	if !exists {
+		return fmt.Errorf("gRPC method %s not found in service %s", methodName, serviceName)
+	}
+
+	method.Logging = &config
+	return nil
+}
+
+// logCall logs a bridged call according to the method's logging config
+func (gb *GrpcBridge) logCall(c *gin.Context, method *GrpcMethod, request, response proto.Message, duration time.Duration, callErr error) {
+	config := method.Logging
+	if config == nil {
+		return
+	}
+
+	status := "ok"
+	if callErr != nil {
+		status = callErr.Error()
+	}
+
+	fields := []interface{}{"grpc_method", method.FullName, "duration", duration, "status", status}
+	if config.LogPayloads {
+		fields = append(fields, "request", renderRedacted(request, config), "response", renderRedacted(response, config))
+	}
+	LoggerFor(c).Info("gRPC bridge call", fields...)
+}
+
+// renderRedacted renders a proto message as JSON with redaction and truncation applied
+func renderRedacted(msg proto.Message, config *BridgeLogConfig) string {
+	if msg == nil {
+		return "null"
+	}
+
+	raw, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return fmt.Sprintf("<unrenderable: %v>", err)
+	}
+
+	if len(config.RedactFields) > 0 {
+		var doc interface{}
+		if err := json.Unmarshal(raw, &doc); err == nil {
+			for _, path := range config.RedactFields {
+				redactPath(doc, strings.Split(path, "."))
+			}
+			if redacted, err := json.Marshal(doc); err == nil {
+				raw = redacted
+			}
+		}
+	}
+
+	maxBytes := config.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = 4096
+	}
+	if len(raw) > maxBytes {
+		return fmt.Sprintf("%s...(truncated %d bytes)", raw[:maxBytes], len(raw)-maxBytes)
+	}
+	return string(raw)
+}
+
+// redactPath replaces the value at a field path within a decoded JSON document
+func redactPath(doc interface{}, path []string) {
+	switch node := doc.(type) {
+	case []interface{}:
+		for _, item := range node {
+			redactPath(item, path)
+		}
+	case map[string]interface{}:
+		value, exists := node[path[0]]
+		if !exists {
+			return
+		}
+		if len(path) == 1 {
+			node[path[0]] = RedactedValue
+			return
+		}
+		redactPath(value, path[1:])
+	}
+}
@@ -0,0 +1,172 @@
+package supergin
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataPolicy controls which HTTP request headers the bridge forwards to gRPC backends
+// as metadata, and which response metadata it returns as HTTP headers. Without a policy
+// nothing is propagated.
+type MetadataPolicy struct {
+	// Allow lists request headers forwarded as metadata, matched case-insensitively; a
+	// trailing "*" matches a prefix, e.g. "X-Tenant-*"
+	Allow []string
+	// Deny lists headers never forwarded, even when Allow matches them
+	Deny []string
+	// Rename maps request headers to different metadata keys, e.g. "X-Tenant" to "tenant-id"
+	Rename map[string]string
+	// Transform rewrites a forwarded value, given its metadata key; returning "" drops it
+	Transform func(key, value string) string
+	// Expose lists response header and trailer metadata returned as HTTP headers, matched
+	// like Allow
+	Expose []string
+	// ExposePrefix is prepended to exposed header names, e.g. "Grpc-Metadata-"
+	ExposePrefix string
+}
+
+// reservedMetadata are headers that describe the HTTP or gRPC transport itself and are
+// never propagated in either direction
+var reservedMetadata = map[string]bool{
+	"connection":        true,
+	"content-length":    true,
+	"content-type":      true,
+	"host":              true,
+	"keep-alive":        true,
+	"proxy-connection":  true,
+	"te":                true,
+	"trailer":           true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+// SetMetadataPolicy sets the metadata policy of services registered without their own
+// GrpcServiceOptions.Metadata
+func (gb *GrpcBridge) SetMetadataPolicy(policy MetadataPolicy) *GrpcBridge {
+	gb.metadataPolicy = &policy
+	return gb
+}
+
+func (gb *GrpcBridge) metadataPolicyFor(service *GrpcService) *MetadataPolicy {
+	if service.MetadataPolicy != nil {
+		return service.MetadataPolicy
+	}
+	return gb.metadataPolicy
+}
+
+// outgoingContext returns the request context carrying the headers the service's policy
+// forwards as outgoing metadata
+func (gb *GrpcBridge) outgoingContext(c *gin.Context, service *GrpcService) context.Context {
+	ctx := c.Request.Context()
+	policy := gb.metadataPolicyFor(service)
+	if policy == nil || len(policy.Allow) == 0 {
+		return ctx
+	}
+
+	md := metadata.MD{}
+	for name, values := range c.Request.Header {
+		header := strings.ToLower(name)
+		if !policy.forwards(header) {
+			continue
+		}
+		key := policy.metadataKey(header)
+		if reservedMetadata[key] || strings.HasPrefix(key, "grpc-") {
+			continue
+		}
+		for _, value := range values {
+			if policy.Transform != nil {
+				value = policy.Transform(key, value)
+			}
+			if value == "" {
+				continue
+			}
+			if strings.HasSuffix(key, "-bin") {
+				decoded, err := decodeBinaryMetadata(value)
+				if err != nil {
+					continue
+				}
+				value = decoded
+			}
+			md.Append(key, value)
+		}
+	}
+	if len(md) == 0 {
+		return ctx
+	}
+	if existing, ok := metadata.FromOutgoingContext(ctx); ok {
+		md = metadata.Join(existing, md)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// exposeMetadata writes the response header and trailer metadata the service's policy
+// exposes as HTTP response headers
+func (gb *GrpcBridge) exposeMetadata(c *gin.Context, service *GrpcService, mds ...metadata.MD) {
+	policy := gb.metadataPolicyFor(service)
+	if policy == nil || len(policy.Expose) == 0 {
+		return
+	}
+
+	for _, md := range mds {
+		for key, values := range md {
+			if reservedMetadata[key] || strings.HasPrefix(key, "grpc-") || strings.HasPrefix(key, ":") {
+				continue
+			}
+			if !matchesHeader(policy.Expose, key) {
+				continue
+			}
+			name := http.CanonicalHeaderKey(policy.ExposePrefix + key)
+			for _, value := range values {
+				if strings.HasSuffix(key, "-bin") {
+					value = base64.StdEncoding.EncodeToString([]byte(value))
+				}
+				c.Writer.Header().Add(name, value)
+			}
+		}
+	}
+}
+
+// forwards reports whether the policy forwards a lowercased request header
+func (p *MetadataPolicy) forwards(header string) bool {
+	return matchesHeader(p.Allow, header) && !matchesHeader(p.Deny, header)
+}
+
+// metadataKey returns the metadata key a lowercased request header is forwarded under
+func (p *MetadataPolicy) metadataKey(header string) string {
+	for from, to := range p.Rename {
+		if strings.EqualFold(from, header) {
+			return strings.ToLower(to)
+		}
+	}
+	return header
+}
+
+// matchesHeader reports whether a lowercased name matches any of the patterns
+func matchesHeader(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if pattern == name {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeBinaryMetadata decodes a base64 HTTP header value for a "-bin" metadata key,
+// padded or not
+func decodeBinaryMetadata(value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		decoded, err = base64.RawStdEncoding.DecodeString(value)
+	}
+	return string(decoded), err
+}
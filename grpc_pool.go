@@ -0,0 +1,153 @@
+package supergin
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// maxHealthCheckTimeout bounds a single health check RPC
+const maxHealthCheckTimeout = 5 * time.Second
+
+// grpcPool spreads calls to a service round-robin across several connections, skipping
+// those in transient failure or reported unhealthy by the health checker
+type grpcPool struct {
+	conns   []*grpc.ClientConn
+	healthy []atomic.Bool
+	next    atomic.Uint64
+	stop    chan struct{}
+	stopped sync.WaitGroup
+}
+
+// newGrpcPool dials size connections to address. Dialing is lazy, so unreachable backends
+// only fail calls.
+func newGrpcPool(address string, size int, dialOptions []grpc.DialOption) (*grpcPool, error) {
+	if size <= 0 {
+		size = 1
+	}
+	pool := &grpcPool{
+		conns:   make([]*grpc.ClientConn, 0, size),
+		healthy: make([]atomic.Bool, size),
+		stop:    make(chan struct{}),
+	}
+	for i := 0; i < size; i++ {
+		conn, err := grpc.Dial(address, dialOptions...)
+		if err != nil {
+			pool.close()
+			return nil, err
+		}
+		pool.conns = append(pool.conns, conn)
+		pool.healthy[i].Store(true)
+	}
+	return pool, nil
+}
+
+// pick returns the next usable connection
+func (p *grpcPool) pick() (*grpc.ClientConn, bool) {
+	start := p.next.Add(1)
+	for i := 0; i < len(p.conns); i++ {
+		index := int((start + uint64(i)) % uint64(len(p.conns)))
+		if !p.healthy[index].Load() {
+			continue
+		}
+		state := p.conns[index].GetState()
+		if state == connectivity.TransientFailure || state == connectivity.Shutdown {
+			continue
+		}
+		return p.conns[index], true
+	}
+	return nil, false
+}
+
+// healthCheck polls every connection with the grpc.health.v1 Check RPC until the pool
+// is closed
+func (p *grpcPool) healthCheck(service *GrpcService, interval time.Duration, logger Logger) {
+	timeout := min(interval, maxHealthCheckTimeout)
+	for i, conn := range p.conns {
+		p.stopped.Add(1)
+		go func(i int, conn *grpc.ClientConn) {
+			defer p.stopped.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				healthy := checkConnHealth(conn, timeout)
+				if p.healthy[i].Swap(healthy) != healthy {
+					logger.Warn("gRPC connection health changed", "service", service.Name, "connection", i, "healthy", healthy)
+				}
+				select {
+				case <-p.stop:
+					return
+				case <-ticker.C:
+				}
+			}
+		}(i, conn)
+	}
+}
+
+// checkConnHealth reports whether the server behind conn is serving. Servers that do not
+// implement the health service count as healthy.
+func checkConnHealth(conn *grpc.ClientConn, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	response, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return true
+	}
+	return err == nil && response.GetStatus() == healthpb.HealthCheckResponse_SERVING
+}
+
+// close stops health checking and closes every connection
+func (p *grpcPool) close() error {
+	close(p.stop)
+	p.stopped.Wait()
+
+	var errs []error
+	for _, conn := range p.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// allowCall checks the service's circuit breaker before a call
+func (s *GrpcService) allowCall() error {
+	if s.Breaker == nil {
+		return nil
+	}
+	return s.Breaker.Allow()
+}
+
+// recordCall reports a call's outcome to the service's circuit breaker. Only failures
+// pointing at the backend count: unavailability and timeouts, not application errors.
+func (s *GrpcService) recordCall(err error) {
+	if s.Breaker == nil {
+		return
+	}
+	failed := err != nil && (backendUnavailable(err) || status.Code(err) == codes.DeadlineExceeded)
+	s.Breaker.Record(!failed)
+}
+
+// conn returns a connection to call the service on, spreading calls across its pool
+func (s *GrpcService) conn() (*grpc.ClientConn, error) {
+	if s.Connection == nil {
+		return nil, NewSuperGinError(ErrBackendUnavailable, "gRPC service %s is closed", s.Name)
+	}
+	if s.pool == nil {
+		return s.Connection, nil
+	}
+	conn, ok := s.pool.pick()
+	if !ok {
+		return nil, NewSuperGinError(ErrBackendUnavailable, "no healthy connection to gRPC service %s", s.Name)
+	}
+	return conn, nil
+}
@@ -0,0 +1,356 @@
+package supergin
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DefaultReflectionTimeout bounds method discovery when GrpcServiceOptions gives no timeout
+const DefaultReflectionTimeout = 10 * time.Second
+
+// GrpcServiceOptions configures how RegisterGrpcService sets up a service
+type GrpcServiceOptions struct {
+	// Reflection discovers the service's methods and message descriptors through the
+	// gRPC server reflection API (grpc.reflection.v1) instead of RegisterGrpcMethod
+	Reflection bool
+	// RoutePrefix, when set with Reflection, registers a POST bridge route per
+	// discovered method at RoutePrefix/<Method>, named <name>_<Method>
+	RoutePrefix string
+	// Timeout bounds discovery; zero means DefaultReflectionTimeout
+	Timeout time.Duration
+
+	// TLS secures the connection. Without TLS, CAFile or CertFile the service is dialed
+	// in plaintext.
+	TLS *tls.Config
+	// CAFile verifies the server against a PEM CA bundle instead of the system roots
+	CAFile string
+	// CertFile and KeyFile present a PEM client certificate for mutual TLS
+	CertFile string
+	KeyFile  string
+	// Authority overrides the :authority header and the name the server's certificate
+	// is verified against, e.g. when dialing an IP or a load balancer
+	Authority string
+	// DialOptions are appended to the bridge's own, e.g. keepalive or interceptors
+	DialOptions []grpc.DialOption
+
+	// Metadata overrides the bridge's metadata policy for this service
+	Metadata *MetadataPolicy
+
+	// PoolSize dials this many connections and spreads calls across them; zero means one
+	PoolSize int
+	// HealthCheckInterval, when set, polls each connection with the grpc.health.v1 Check
+	// RPC and routes calls away from those not serving; with none left, calls fail as
+	// unavailable. Servers without the health service count as healthy.
+	HealthCheckInterval time.Duration
+	// Retry retries failed unary calls; nil makes a single attempt
+	Retry *RetryPolicy
+	// CallTimeout bounds every call to the service unless the route's WithTimeout or the
+	// request's grpc-timeout header sets a shorter deadline
+	CallTimeout time.Duration
+	// CircuitBreaker opens the engine's "grpc:<name>" circuit breaker after consecutive
+	// Unavailable or DeadlineExceeded failures; nil disables it
+	CircuitBreaker *CircuitBreakerOptions
+}
+
+// DiscoverGrpcMethods registers every method of a service using the server reflection
+// API and returns their names. Discovered methods are bridged as protojson with dynamic
+// messages; methods already registered with RegisterGrpcMethod are left untouched.
+func (gb *GrpcBridge) DiscoverGrpcMethods(ctx context.Context, serviceName string) ([]string, error) {
+	service, exists := gb.services[serviceName]
+	if !exists {
+		return nil, fmt.Errorf("gRPC service %s not found", serviceName)
+	}
+	conn, err := service.conn()
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor, err := reflectServiceDescriptor(ctx, conn, service.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover gRPC service %s: %v", service.ServiceName, err)
+	}
+
+	methods := descriptor.Methods()
+	names := make([]string, 0, methods.Len())
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		name := string(md.Name())
+		names = append(names, name)
+		if _, exists := service.Methods[name]; exists {
+			continue
+		}
+		service.Methods[name] = &GrpcMethod{
+			Name:             name,
+			FullName:         fmt.Sprintf("/%s/%s", service.ServiceName, name),
+			StreamingInput:   md.IsStreamingClient(),
+			StreamingOutput:  md.IsStreamingServer(),
+			InputDescriptor:  md.Input(),
+			OutputDescriptor: md.Output(),
+		}
+	}
+	return names, nil
+}
+
+// registerReflectedRoutes registers a bridge route for each discovered method
+func (gb *GrpcBridge) registerReflectedRoutes(serviceName, prefix string, methodNames []string) {
+	service := gb.services[serviceName]
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	for _, name := range methodNames {
+		method := service.Methods[name]
+		rb := gb.engine.Named(fmt.Sprintf("%s_%s", serviceName, name)).
+			POST(prefix+"/"+name).
+			WithDescription(fmt.Sprintf("HTTP to gRPC bridge for %s", method.FullName)).
+			WithTags("grpc", "bridge", "reflection").
+			WithGrpcBridge(serviceName, name)
+		if method.InputDescriptor != nil {
+			rb.WithMetadata("request_schema", ProtoJSONSchema(method.InputDescriptor))
+			rb.WithMetadata("response_schema", ProtoJSONSchema(method.OutputDescriptor))
+		}
+		if method.StreamingInput || method.StreamingOutput {
+			rb.WithMetadata("grpc_streaming", gin.H{"client": method.StreamingInput, "server": method.StreamingOutput})
+		}
+		rb.Handler(func(c *gin.Context) {
+			// Handler is set up by WithGrpcBridge
+		})
+	}
+}
+
+// reflectServiceDescriptor fetches a service's file descriptors over server reflection
+// and resolves the service descriptor from them
+func reflectServiceDescriptor(ctx context.Context, conn *grpc.ClientConn, serviceName string) (protoreflect.ServiceDescriptor, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+
+	protos := make(map[string]*descriptorpb.FileDescriptorProto)
+	pending := []*reflectionpb.ServerReflectionRequest{{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: serviceName},
+	}}
+	for len(pending) > 0 {
+		request := pending[0]
+		pending = pending[1:]
+
+		files, err := reflectionRequest(stream, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			protos[file.GetName()] = file
+		}
+
+		// Servers usually send transitive dependencies; fetch any that are missing
+		for _, file := range files {
+			for _, dep := range file.GetDependency() {
+				if _, exists := protos[dep]; exists {
+					continue
+				}
+				if _, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+					continue
+				}
+				protos[dep] = nil
+				pending = append(pending, &reflectionpb.ServerReflectionRequest{
+					MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+				})
+			}
+		}
+	}
+
+	files := new(protoregistry.Files)
+	for name := range protos {
+		if err := buildReflectedFile(files, protos, name); err != nil {
+			return nil, err
+		}
+	}
+
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, err
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", serviceName)
+	}
+	return service, nil
+}
+
+// reflectionRequest sends one reflection request and returns the files in its response
+func reflectionRequest(stream reflectionpb.ServerReflection_ServerReflectionInfoClient, request *reflectionpb.ServerReflectionRequest) ([]*descriptorpb.FileDescriptorProto, error) {
+	if err := stream.Send(request); err != nil {
+		return nil, err
+	}
+	response, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if errResponse := response.GetErrorResponse(); errResponse != nil {
+		return nil, fmt.Errorf("reflection error %d: %s", errResponse.GetErrorCode(), errResponse.GetErrorMessage())
+	}
+
+	encoded := response.GetFileDescriptorResponse().GetFileDescriptorProto()
+	files := make([]*descriptorpb.FileDescriptorProto, 0, len(encoded))
+	for _, data := range encoded {
+		file := new(descriptorpb.FileDescriptorProto)
+		if err := proto.Unmarshal(data, file); err != nil {
+			return nil, fmt.Errorf("invalid file descriptor: %v", err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// buildReflectedFile registers a file after its dependencies, falling back to the
+// global registry for files the server did not send
+func buildReflectedFile(files *protoregistry.Files, protos map[string]*descriptorpb.FileDescriptorProto, name string) error {
+	if _, err := files.FindFileByPath(name); err == nil {
+		return nil
+	}
+	file := protos[name]
+	if file == nil {
+		if _, err := protoregistry.GlobalFiles.FindFileByPath(name); err == nil {
+			return nil
+		}
+		return fmt.Errorf("missing file descriptor %s", name)
+	}
+
+	for _, dep := range file.GetDependency() {
+		if err := buildReflectedFile(files, protos, dep); err != nil {
+			return err
+		}
+	}
+	descriptor, err := protodesc.NewFile(file, reflectedResolver{files: files})
+	if err != nil {
+		return fmt.Errorf("invalid file descriptor %s: %v", name, err)
+	}
+	return files.RegisterFile(descriptor)
+}
+
+// reflectedResolver resolves descriptors from reflected files, then linked-in ones
+type reflectedResolver struct {
+	files *protoregistry.Files
+}
+
+func (r reflectedResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if file, err := r.files.FindFileByPath(path); err == nil {
+		return file, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r reflectedResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if descriptor, err := r.files.FindDescriptorByName(name); err == nil {
+		return descriptor, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// ProtoJSONSchema builds a JSON Schema for a proto message as encoded by protojson
+func ProtoJSONSchema(descriptor protoreflect.MessageDescriptor) map[string]interface{} {
+	return protoMessageSchema(descriptor, make(map[protoreflect.FullName]bool))
+}
+
+func protoMessageSchema(descriptor protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]bool) map[string]interface{} {
+	// Well-known types have special JSON mappings
+	switch descriptor.FullName() {
+	case "google.protobuf.Timestamp":
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration":
+		return map[string]interface{}{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]+)?s$`}
+	case "google.protobuf.FieldMask":
+		return map[string]interface{}{"type": "string"}
+	case "google.protobuf.Struct":
+		return map[string]interface{}{"type": "object"}
+	case "google.protobuf.ListValue":
+		return map[string]interface{}{"type": "array"}
+	case "google.protobuf.Value":
+		return map[string]interface{}{}
+	case "google.protobuf.Any":
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"@type": map[string]interface{}{"type": "string"}},
+		}
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue", "google.protobuf.Int64Value",
+		"google.protobuf.UInt64Value", "google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		return protoFieldSchema(descriptor.Fields().ByName("value"), visiting)
+	}
+
+	if visiting[descriptor.FullName()] {
+		// Recursive message; stop descending
+		return map[string]interface{}{"type": "object"}
+	}
+	visiting[descriptor.FullName()] = true
+	defer delete(visiting, descriptor.FullName())
+
+	properties := make(map[string]interface{})
+	fields := descriptor.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		var prop map[string]interface{}
+		switch {
+		case fd.IsMap():
+			prop = map[string]interface{}{"type": "object", "additionalProperties": protoFieldSchema(fd.MapValue(), visiting)}
+		case fd.IsList():
+			prop = map[string]interface{}{"type": "array", "items": protoFieldSchema(fd, visiting)}
+		default:
+			prop = protoFieldSchema(fd, visiting)
+		}
+		properties[fd.JSONName()] = prop
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"title":      string(descriptor.FullName()),
+		"properties": properties,
+	}
+}
+
+// protoFieldSchema describes a single (element) value of a field
+func protoFieldSchema(fd protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]bool) map[string]interface{} {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]interface{}{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "uint32", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// protojson encodes 64-bit integers as strings
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]interface{}{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case protoreflect.StringKind:
+		return map[string]interface{}{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]interface{}{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		enum := make([]interface{}, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			enum = append(enum, string(values.Get(i).Name()))
+		}
+		return map[string]interface{}{"type": "string", "enum": enum}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protoMessageSchema(fd.Message(), visiting)
+	default:
+		return map[string]interface{}{}
+	}
+}
@@ -0,0 +1,116 @@
+package supergin
+
+import (
+	"context"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Retry defaults applied when a RetryPolicy leaves them unset
+const (
+	DefaultRetryInitialBackoff = 100 * time.Millisecond
+	DefaultRetryMaxBackoff     = 2 * time.Second
+	DefaultRetryMultiplier     = 2.0
+)
+
+// GrpcTimeoutHeader lets clients bound the backend call of a bridged request, using the
+// gRPC wire format: an integer followed by H, M, S, m (ms), u (µs) or n (ns)
+const GrpcTimeoutHeader = "Grpc-Timeout"
+
+// grpcTimeoutPattern matches grpc-timeout header values such as "500m" or "30S"
+var grpcTimeoutPattern = regexp.MustCompile(`^([0-9]{1,8})([HMSmun])$`)
+
+// grpcTimeoutUnits maps grpc-timeout unit suffixes to durations
+var grpcTimeoutUnits = map[string]time.Duration{
+	"H": time.Hour, "M": time.Minute, "S": time.Second,
+	"m": time.Millisecond, "u": time.Microsecond, "n": time.Nanosecond,
+}
+
+// RetryPolicy retries unary calls to a gRPC service that fail with a retryable code, with
+// exponential backoff and full jitter. Only retry methods that are safe to repeat: a call
+// failing with Unavailable may still have reached the backend.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, the first included
+	MaxAttempts int
+	// InitialBackoff is the upper bound of the first wait; defaults to 100ms
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts; defaults to 2s
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff after each attempt; defaults to 2
+	Multiplier float64
+	// RetryableCodes are the status codes worth retrying; defaults to Unavailable
+	RetryableCodes []codes.Code
+}
+
+// retryable reports whether a failed attempt may be retried
+func (p *RetryPolicy) retryable(err error) bool {
+	if IsErrorCode(err, ErrBackendUnavailable) {
+		return true
+	}
+	retryableCodes := p.RetryableCodes
+	if len(retryableCodes) == 0 {
+		retryableCodes = []codes.Code{codes.Unavailable}
+	}
+	return slices.Contains(retryableCodes, status.Code(err))
+}
+
+// backoff returns the wait before the attempt following attempt (1-based)
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, maxBackoff, multiplier := p.InitialBackoff, p.MaxBackoff, p.Multiplier
+	if initial <= 0 {
+		initial = DefaultRetryInitialBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+	if multiplier < 1 {
+		multiplier = DefaultRetryMultiplier
+	}
+
+	bound := float64(initial)
+	for i := 1; i < attempt && bound < float64(maxBackoff); i++ {
+		bound *= multiplier
+	}
+	bound = min(bound, float64(maxBackoff))
+	return time.Duration(rand.Int64N(int64(bound) + 1))
+}
+
+// callContext returns the context for a backend call: the request context, which carries
+// the route's timeout, with forwarded metadata, bounded by the grpc-timeout header and the
+// service's CallTimeout, whichever ends first
+func (gb *GrpcBridge) callContext(c *gin.Context, service *GrpcService) (context.Context, context.CancelFunc, error) {
+	ctx := gb.outgoingContext(c, service)
+
+	timeout, bounded := service.CallTimeout, service.CallTimeout > 0
+	if value := c.GetHeader(GrpcTimeoutHeader); value != "" {
+		requested, err := parseGrpcTimeout(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !bounded || requested < timeout {
+			timeout, bounded = requested, true
+		}
+	}
+	if !bounded {
+		return ctx, func() {}, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, nil
+}
+
+// parseGrpcTimeout parses a grpc-timeout header value
+func parseGrpcTimeout(value string) (time.Duration, error) {
+	match := grpcTimeoutPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, NewSuperGinError(ErrValidationFailed, "invalid grpc-timeout '%s'", value)
+	}
+	amount, _ := strconv.ParseInt(match[1], 10, 64)
+	return time.Duration(amount) * grpcTimeoutUnits[match[2]], nil
+}
@@ -0,0 +1,371 @@
+package supergin
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// grpcServedMethod is a route exposed as a native gRPC method by ServeGrpc
+type grpcServedMethod struct {
+	route          string
+	grpcInputType  reflect.Type
+	grpcOutputType reflect.Type
+}
+
+// httpStatusCodes maps HTTP statuses of routes served over gRPC to gRPC codes
+var httpStatusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	499:                            codes.Canceled,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// ServeAsGrpc exposes the route as the unary gRPC method /serviceName/methodName on the
+// server started by Engine.ServeGrpc. grpcInputType and grpcOutputType are the method's
+// proto messages, e.g. &pb.GetUserRequest{}; they are converted to and from the route's
+// input and output types with GrpcConverter when implemented, and protojson otherwise.
+func (rb *RouteBuilder) ServeAsGrpc(serviceName, methodName string, grpcInputType, grpcOutputType interface{}) *RouteBuilder {
+	if rb.name == "" {
+		panic("route name is required before ServeAsGrpc")
+	}
+	served := &grpcServedMethod{
+		route:          rb.name,
+		grpcInputType:  reflect.TypeOf(grpcInputType),
+		grpcOutputType: reflect.TypeOf(grpcOutputType),
+	}
+	for _, t := range []reflect.Type{served.grpcInputType, served.grpcOutputType} {
+		if _, err := newProtoMessage(t); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	fullName := fmt.Sprintf("/%s/%s", serviceName, methodName)
+	rb.engine.routesMux.Lock()
+	if rb.engine.grpcMethods == nil {
+		rb.engine.grpcMethods = make(map[string]*grpcServedMethod)
+	}
+	if existing, exists := rb.engine.grpcMethods[fullName]; exists {
+		rb.engine.routesMux.Unlock()
+		panic(fmt.Sprintf("gRPC method %s is already served by route '%s'", fullName, existing.route))
+	}
+	rb.engine.grpcMethods[fullName] = served
+	rb.engine.routesMux.Unlock()
+
+	return rb.WithMetadata("grpc_served_method", fullName)
+}
+
+// ServeGrpc serves the routes exposed with ServeAsGrpc as native gRPC services on addr,
+// next to the HTTP server, until Shutdown is called. Each call runs through the route like
+// an HTTP request, so middleware, auth schemes, policies and validation apply as they do
+// over REST: path parameters are filled from the input's uri fields, GET and DELETE inputs
+// are sent as query parameters and others as a JSON body. Incoming metadata is passed on
+// as request headers and error statuses are mapped to gRPC codes. Only unary calls are
+// supported.
+func (e *Engine) ServeGrpc(addr string, opts ...grpc.ServerOption) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(append(opts, grpc.UnknownServiceHandler(e.serveGrpcCall))...)
+	e.lifecycleMux.Lock()
+	if e.grpcServer != nil || e.shuttingDown {
+		e.lifecycleMux.Unlock()
+		listener.Close()
+		return fmt.Errorf("gRPC server already started")
+	}
+	e.grpcServer = server
+	e.lifecycleMux.Unlock()
+
+	e.routesMux.RLock()
+	for fullName, served := range e.grpcMethods {
+		if _, exists := e.routes[served.route]; !exists {
+			e.logger.Warn("gRPC method not served: route is not registered", "method", fullName, "route", served.route)
+		}
+	}
+	e.routesMux.RUnlock()
+	e.logger.Info("serving gRPC", "address", listener.Addr().String())
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// stopGrpcServer stops the gRPC server gracefully, cutting in-flight calls once ctx is done
+func (e *Engine) stopGrpcServer(ctx context.Context) error {
+	e.lifecycleMux.Lock()
+	server := e.grpcServer
+	e.lifecycleMux.Unlock()
+	if server == nil {
+		return nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return fmt.Errorf("failed to drain gRPC server: %w", ctx.Err())
+	}
+}
+
+// serveGrpcCall handles every call to the gRPC server, dispatching it to its route
+func (e *Engine) serveGrpcCall(_ interface{}, stream grpc.ServerStream) error {
+	fullName, _ := grpc.MethodFromServerStream(stream)
+	e.routesMux.RLock()
+	served, exists := e.grpcMethods[fullName]
+	var route *RouteInfo
+	if exists {
+		route, exists = e.routes[served.route]
+	}
+	e.routesMux.RUnlock()
+	if !exists {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", fullName)
+	}
+
+	input, err := newProtoMessage(served.grpcInputType)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := stream.RecvMsg(input); err != nil {
+		return err
+	}
+
+	req, err := e.grpcRouteRequest(stream.Context(), route, input)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, req)
+
+	if recorder.Code >= http.StatusBadRequest {
+		return grpcRouteError(recorder.Code, recorder.Body.Bytes())
+	}
+	output, err := e.grpcRouteOutput(route, served, recorder.Code, recorder.Body.Bytes())
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return stream.SendMsg(output)
+}
+
+// grpcRouteRequest builds the HTTP request a gRPC call makes to its route
+func (e *Engine) grpcRouteRequest(ctx context.Context, route *RouteInfo, input proto.Message) (*http.Request, error) {
+	var httpInput interface{}
+	if route.InputType != nil {
+		converted, err := e.GrpcBridge().convertFromGrpc(input, route.InputType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert gRPC input: %v", err)
+		}
+		httpInput = converted
+	} else {
+		rendered, err := protojson.Marshal(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal gRPC input: %v", err)
+		}
+		httpInput = json.RawMessage(rendered)
+	}
+
+	path, err := grpcRoutePath(route, httpInput)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	query := url.Values{}
+	if route.Method == "GET" || route.Method == "DELETE" {
+		for name, field := range taggedFields(route.InputType, "form") {
+			for _, value := range inputFieldValues(httpInput, field) {
+				query.Add(name, value)
+			}
+		}
+	} else if body, err = json.Marshal(httpInput); err != nil {
+		return nil, fmt.Errorf("failed to marshal HTTP input: %v", err)
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, route.Method, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Host = route.Host
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for key, values := range md {
+			// Pseudo-headers, transport headers and binary values have no HTTP equivalent
+			if strings.HasPrefix(key, ":") || strings.HasPrefix(key, "grpc-") || strings.HasSuffix(key, "-bin") ||
+				key == "content-type" || key == "te" {
+				continue
+			}
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		req.RemoteAddr = p.Addr.String()
+	}
+	return req, nil
+}
+
+// grpcRoutePath fills the route's path parameters from the input's uri fields
+func grpcRoutePath(route *RouteInfo, httpInput interface{}) (string, error) {
+	params := taggedFields(route.InputType, "uri")
+	segments := strings.Split(route.Path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") && !strings.HasPrefix(segment, "*") {
+			continue
+		}
+		name := segment[1:]
+		field, exists := params[name]
+		var values []string
+		if exists {
+			values = inputFieldValues(httpInput, field)
+		}
+		if len(values) == 0 || values[0] == "" {
+			return "", fmt.Errorf("missing path parameter '%s'", name)
+		}
+		if segment[0] == '*' {
+			// Catch-all parameters span segments and keep their slashes
+			segments[i] = strings.TrimPrefix(values[0], "/")
+			continue
+		}
+		segments[i] = url.PathEscape(values[0])
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// inputFieldValues formats an input field as parameter values, one per slice element
+func inputFieldValues(httpInput interface{}, field reflect.StructField) []string {
+	v := reflect.ValueOf(httpInput)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	fieldValue, err := v.FieldByIndexErr(field.Index)
+	if err != nil {
+		return nil
+	}
+
+	format := func(value reflect.Value) (string, bool) {
+		for value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return "", false
+			}
+			value = value.Elem()
+		}
+		if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+			text, err := marshaler.MarshalText()
+			return string(text), err == nil
+		}
+		return fmt.Sprint(value.Interface()), true
+	}
+
+	if fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Kind() != reflect.Uint8 {
+		values := make([]string, 0, fieldValue.Len())
+		for i := 0; i < fieldValue.Len(); i++ {
+			if value, ok := format(fieldValue.Index(i)); ok {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	if fieldValue.IsZero() {
+		return nil
+	}
+	if value, ok := format(fieldValue); ok {
+		return []string{value}
+	}
+	return nil
+}
+
+// grpcRouteOutput converts a route's response to the gRPC output message. Empty
+// responses, such as 204s, become empty messages.
+func (e *Engine) grpcRouteOutput(route *RouteInfo, served *grpcServedMethod, code int, body []byte) (proto.Message, error) {
+	output, err := newProtoMessage(served.grpcOutputType)
+	if err != nil {
+		return nil, err
+	}
+	if code == http.StatusNoContent || len(bytes.TrimSpace(body)) == 0 {
+		return output, nil
+	}
+
+	if route.OutputType != nil {
+		httpOutput := reflect.New(route.OutputType).Interface()
+		if _, ok := httpOutput.(GrpcConverter); ok {
+			if err := json.Unmarshal(body, httpOutput); err != nil {
+				return nil, fmt.Errorf("failed to decode route response: %v", err)
+			}
+			return e.GrpcBridge().convertToGrpc(httpOutput, output)
+		}
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, output); err != nil {
+		return nil, fmt.Errorf("failed to convert route response to gRPC: %v", err)
+	}
+	return output, nil
+}
+
+// grpcRouteError converts a route's error response to a gRPC status, using its "error"
+// message and "details" when the body has them
+func grpcRouteError(code int, body []byte) error {
+	grpcCode, exists := httpStatusCodes[code]
+	if !exists {
+		grpcCode = codes.Unknown
+		if code >= http.StatusInternalServerError {
+			grpcCode = codes.Internal
+		}
+	}
+
+	message := http.StatusText(code)
+	var payload struct {
+		Error   string      `json:"error"`
+		Details interface{} `json:"details"`
+	}
+	if json.Unmarshal(body, &payload) == nil && payload.Error != "" {
+		message = payload.Error
+		if details, ok := payload.Details.(string); ok && details != "" {
+			message += ": " + details
+		}
+	}
+	return status.Error(grpcCode, message)
+}
@@ -0,0 +1,233 @@
+package supergin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// NDJSONContentType is the media type of newline-delimited JSON streams
+const NDJSONContentType = "application/x-ndjson"
+
+// RegisterGrpcStreamingMethod registers a streaming gRPC method. Client streams are read
+// from an NDJSON request body (one HTTP input per line); server streams are written as
+// NDJSON, or as server-sent events when the client accepts text/event-stream.
+// Routes bridging client-streaming methods should not declare an input type, as route
+// validation would consume the body.
+func (gb *GrpcBridge) RegisterGrpcStreamingMethod(serviceName, methodName string, streamingInput, streamingOutput bool,
+	httpInputType, httpOutputType, grpcInputType, grpcOutputType interface{}) error {
+
+	if err := gb.RegisterGrpcMethod(serviceName, methodName, httpInputType, httpOutputType, grpcInputType, grpcOutputType); err != nil {
+		return err
+	}
+
+	method := gb.services[serviceName].Methods[methodName]
+	method.StreamingInput = streamingInput
+	method.StreamingOutput = streamingOutput
+	return nil
+}
+
+// handleStreaming bridges a client-, server- or bidirectional-streaming call
+func (gb *GrpcBridge) handleStreaming(c *gin.Context, service *GrpcService, method *GrpcMethod) error {
+	if gb.isDryRun(c) {
+		return fmt.Errorf("dry run is not supported for streaming method %s", method.FullName)
+	}
+
+	// Decode and validate all input up front so invalid requests fail before the call
+	var inputs []proto.Message
+	if method.StreamingInput {
+		decoded, err := gb.decodeInputStream(c, method)
+		if err != nil {
+			return err
+		}
+		inputs = decoded
+	} else {
+		grpcInput, err := gb.bindGrpcInput(c, method)
+		if err != nil {
+			return err
+		}
+		if err := gb.validateProto(method, grpcInput); err != nil {
+			return err
+		}
+		inputs = []proto.Message{grpcInput}
+	}
+
+	ctx, cancel, err := gb.callContext(c, service)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	// The circuit breaker sees the outcome of the whole stream
+	if err := service.allowCall(); err != nil {
+		return err
+	}
+	var callErr error
+	defer func() { service.recordCall(callErr) }()
+
+	conn, err := service.conn()
+	if err != nil {
+		callErr = err
+		return err
+	}
+
+	start := time.Now()
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{
+		StreamName:    method.Name,
+		ClientStreams: method.StreamingInput,
+		ServerStreams: method.StreamingOutput,
+	}, method.FullName)
+	if err != nil {
+		callErr = err
+		return fmt.Errorf("gRPC stream failed: %v", err)
+	}
+
+	for _, input := range inputs {
+		if err := stream.SendMsg(input); err != nil && !errors.Is(err, io.EOF) {
+			callErr = err
+			gb.logCall(c, method, input, nil, time.Since(start), err)
+			return fmt.Errorf("gRPC stream send failed: %v", err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		callErr = err
+		return fmt.Errorf("gRPC stream close failed: %v", err)
+	}
+
+	var firstInput proto.Message
+	if len(inputs) > 0 {
+		firstInput = inputs[0]
+	}
+
+	if !method.StreamingOutput {
+		output, err := gb.newGrpcOutput(method)
+		if err != nil {
+			return err
+		}
+		err = stream.RecvMsg(output)
+		callErr = err
+		gb.logCall(c, method, firstInput, output, time.Since(start), err)
+		header, _ := stream.Header()
+		gb.exposeMetadata(c, service, header, stream.Trailer())
+		if err != nil {
+			return fmt.Errorf("gRPC call failed: %v", err)
+		}
+		httpOutput, err := gb.convertFromGrpc(output, method.OutputType)
+		if err != nil {
+			return fmt.Errorf("failed to convert gRPC output to HTTP: %v", err)
+		}
+		c.JSON(http.StatusOK, httpOutput)
+		return nil
+	}
+
+	// Trailers arrive after the body has started, so only header metadata is exposed
+	if header, err := stream.Header(); err == nil {
+		gb.exposeMetadata(c, service, header)
+	}
+	err = gb.writeOutputStream(c, method, stream)
+	callErr = err
+	gb.logCall(c, method, firstInput, nil, time.Since(start), err)
+	return nil
+}
+
+// decodeInputStream reads HTTP inputs from an NDJSON body and converts each to a gRPC request
+func (gb *GrpcBridge) decodeInputStream(c *gin.Context, method *GrpcMethod) ([]proto.Message, error) {
+	var inputs []proto.Message
+	decoder := json.NewDecoder(c.Request.Body)
+	for line := 1; ; line++ {
+		grpcInput, err := gb.newGrpcInput(method)
+		if err != nil {
+			return nil, err
+		}
+
+		// Discovered methods have no HTTP type; each line is a protojson request
+		if method.InputType == nil {
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, NewSuperGinError(ErrValidationFailed, "binding error in message %d: %v", line, err)
+			}
+			if err := protojson.Unmarshal(raw, grpcInput); err != nil {
+				return nil, NewSuperGinError(ErrValidationFailed, "binding error in message %d: %v", line, err)
+			}
+		} else {
+			httpInput := reflect.New(method.InputType).Interface()
+			if err := decoder.Decode(httpInput); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, NewSuperGinError(ErrValidationFailed, "binding error in message %d: %v", line, err)
+			}
+			if err := gb.validateHttpInput(method, httpInput); err != nil {
+				return nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "invalid message %d", line)
+			}
+
+			grpcInput, err = gb.convertToGrpc(httpInput, grpcInput)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert message %d to gRPC: %v", line, err)
+			}
+		}
+		if err := gb.validateProto(method, grpcInput); err != nil {
+			return nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "invalid message %d", line)
+		}
+		inputs = append(inputs, grpcInput)
+	}
+	return inputs, nil
+}
+
+// writeOutputStream relays server-stream messages as NDJSON or server-sent events.
+// Errors after the response has started are written as a final error frame.
+func (gb *GrpcBridge) writeOutputStream(c *gin.Context, method *GrpcMethod, stream grpc.ClientStream) error {
+	sse := strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+	if sse {
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+	} else {
+		c.Header("Content-Type", NDJSONContentType)
+	}
+	c.Status(http.StatusOK)
+
+	write := func(event string, payload interface{}) {
+		data, _ := json.Marshal(payload)
+		if sse {
+			if event != "" {
+				fmt.Fprintf(c.Writer, "event: %s\n", event)
+			}
+			fmt.Fprintf(c.Writer, "data: %s\n\n", data)
+		} else {
+			c.Writer.Write(append(data, '\n'))
+		}
+		c.Writer.Flush()
+	}
+
+	for {
+		output, err := gb.newGrpcOutput(method)
+		if err != nil {
+			write("error", gin.H{"error": err.Error()})
+			return err
+		}
+		if err := stream.RecvMsg(output); err == io.EOF {
+			return nil
+		} else if err != nil {
+			write("error", gin.H{"error": "gRPC stream failed", "details": err.Error()})
+			return err
+		}
+
+		httpOutput, err := gb.convertFromGrpc(output, method.OutputType)
+		if err != nil {
+			write("error", gin.H{"error": "failed to convert gRPC output to HTTP", "details": err.Error()})
+			return err
+		}
+		write("", httpOutput)
+	}
+}
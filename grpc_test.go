@@ -0,0 +1,119 @@
+package supergin
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWriteProtobufNegotiatesGzip(t *testing.T) {
+	data := bytes.Repeat([]byte{0x0a, 0x03, 'a', 'b', 'c'}, 100)
+
+	tests := []struct {
+		acceptEncoding string
+		gzipped        bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"br, gzip;q=0.5", true},
+		{"gzip;q=0", false},
+		{"*;q=1, gzip;q=0", false},
+		{"*", true},
+		{"identity", false},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+		c.Request.Header.Set("Accept-Encoding", tt.acceptEncoding)
+		// Set by CORS earlier in the chain
+		c.Header("Vary", "Origin")
+
+		writeProtobuf(c, http.StatusOK, data)
+
+		if vary := w.Header().Values("Vary"); !slices.Equal(vary, []string{"Origin", "Accept-Encoding"}) {
+			t.Errorf("Accept-Encoding %q: Vary = %v, want [Origin Accept-Encoding]", tt.acceptEncoding, vary)
+		}
+		body := w.Body.Bytes()
+		if gzipped := w.Header().Get("Content-Encoding") == "gzip"; gzipped != tt.gzipped {
+			t.Errorf("Accept-Encoding %q: gzipped = %v, want %v", tt.acceptEncoding, gzipped, tt.gzipped)
+			continue
+		}
+		if tt.gzipped {
+			reader, err := gzip.NewReader(bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("gzip: %v", err)
+			}
+			if body, err = io.ReadAll(reader); err != nil {
+				t.Fatalf("gunzip: %v", err)
+			}
+		}
+		if !bytes.Equal(body, data) {
+			t.Errorf("Accept-Encoding %q: body does not round trip", tt.acceptEncoding)
+		}
+	}
+}
+
+// gzipped compresses data
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadProtobufBodyCapsGzip(t *testing.T) {
+	small := bytes.Repeat([]byte{0x08, 0x01}, 512)
+	// Compresses to a few KiB but inflates just past the default cap
+	bomb := make([]byte, maxDecompressedMessage+1)
+
+	tests := []struct {
+		name      string
+		encoding  string
+		body      []byte
+		bodyLimit int64
+		wantLimit int64
+		wantErr   bool
+	}{
+		{"identity", "", small, 0, 0, false},
+		{"gzip", "gzip", gzipped(t, small), 0, 0, false},
+		{"gzip over the default cap", "gzip", gzipped(t, bomb), 0, maxDecompressedMessage, true},
+		{"gzip over the route's body limit", "gzip", gzipped(t, small), 512, 512, true},
+		{"unsupported encoding", "br", small, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			c.Request.Header.Set("Content-Encoding", tt.encoding)
+			if tt.bodyLimit > 0 {
+				c.Set(bodyLimitKey, tt.bodyLimit)
+			}
+
+			data, err := readProtobufBody(c)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			var tooLarge *http.MaxBytesError
+			if tt.wantLimit > 0 && (!errors.As(err, &tooLarge) || tooLarge.Limit != tt.wantLimit) {
+				t.Errorf("err = %v, want *http.MaxBytesError with limit %d", err, tt.wantLimit)
+			}
+			if !tt.wantErr && !bytes.Equal(data, small) {
+				t.Errorf("read %d bytes, want the %d byte message", len(data), len(small))
+			}
+		})
+	}
+}
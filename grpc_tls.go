@@ -0,0 +1,62 @@
+package supergin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// dialOptions returns the dial options for a service: transport credentials, authority
+// and the caller's own options
+func (o GrpcServiceOptions) dialOptions() ([]grpc.DialOption, error) {
+	creds, err := o.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if o.Authority != "" {
+		dialOptions = append(dialOptions, grpc.WithAuthority(o.Authority))
+	}
+	return append(dialOptions, o.DialOptions...), nil
+}
+
+// transportCredentials builds TLS credentials from the options, or plaintext credentials
+// when none are configured
+func (o GrpcServiceOptions) transportCredentials() (credentials.TransportCredentials, error) {
+	if o.TLS == nil && o.CAFile == "" && o.CertFile == "" && o.KeyFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if o.TLS != nil {
+		config = o.TLS.Clone()
+	}
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", o.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	if o.CertFile != "" || o.KeyFile != "" {
+		if o.CertFile == "" || o.KeyFile == "" {
+			return nil, fmt.Errorf("client certificates need both CertFile and KeyFile")
+		}
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = append(config.Certificates, cert)
+	}
+	return credentials.NewTLS(config), nil
+}
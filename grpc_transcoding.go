@@ -0,0 +1,367 @@
+package supergin
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// HttpRule maps an HTTP verb and path template onto a bridged gRPC method,
+// approximating google.api.http transcoding as implemented by grpc-gateway
+type HttpRule struct {
+	// Method is the HTTP verb: GET, POST, PUT, DELETE or PATCH
+	Method string
+	// Path is a template whose variables bind request fields, e.g. "/v1/users/{user_id}"
+	// or "/v1/shelves/{shelf.id}/books"
+	Path string
+	// Body selects what the request body maps to: "" for no body, "*" for the whole
+	// request message, or the name of a single top-level request field
+	Body string
+	// ResponseBody optionally selects a top-level response field to return instead
+	// of the whole response message
+	ResponseBody string
+}
+
+// transcodingVariable binds a gin path parameter to a request field path
+type transcodingVariable struct {
+	param string
+	field []string
+}
+
+// SetHttpRule validates a transcoding rule against the method's proto types and attaches it
+func (gb *GrpcBridge) SetHttpRule(serviceName, methodName string, rule HttpRule) error {
+	service, exists := gb.services[serviceName]
+	if !exists {
+		return fmt.Errorf("gRPC service %s not found", serviceName)
+	}
+	method, exists := service.Methods[methodName]
+	if !exists {
+		return fmt.Errorf("gRPC method %s not found in service %s", methodName, serviceName)
+	}
+
+	rule.Method = strings.ToUpper(rule.Method)
+	switch rule.Method {
+	case "GET", "DELETE":
+		if rule.Body != "" {
+			return fmt.Errorf("HTTP rule for %s: %s cannot have a body mapping", method.FullName, rule.Method)
+		}
+	case "POST", "PUT", "PATCH":
+	default:
+		return fmt.Errorf("HTTP rule for %s: unsupported HTTP method %s", method.FullName, rule.Method)
+	}
+
+	input, err := gb.newGrpcInput(method)
+	if err != nil {
+		return err
+	}
+	output, err := gb.newGrpcOutput(method)
+	if err != nil {
+		return err
+	}
+
+	_, variables, err := parsePathTemplate(rule.Path)
+	if err != nil {
+		return fmt.Errorf("HTTP rule for %s: %v", method.FullName, err)
+	}
+	for _, variable := range variables {
+		if err := checkFieldPath(input.ProtoReflect().Descriptor(), variable.field, true); err != nil {
+			return fmt.Errorf("HTTP rule for %s: path variable %s: %v", method.FullName, strings.Join(variable.field, "."), err)
+		}
+	}
+	if rule.Body != "" && rule.Body != "*" {
+		if err := checkFieldPath(input.ProtoReflect().Descriptor(), []string{rule.Body}, false); err != nil {
+			return fmt.Errorf("HTTP rule for %s: body: %v", method.FullName, err)
+		}
+	}
+	if rule.ResponseBody != "" {
+		if err := checkFieldPath(output.ProtoReflect().Descriptor(), []string{rule.ResponseBody}, false); err != nil {
+			return fmt.Errorf("HTTP rule for %s: response body: %v", method.FullName, err)
+		}
+	}
+
+	method.HttpRule = &rule
+	return nil
+}
+
+// Transcode attaches a transcoding rule to a bridged method and registers a named route
+// serving it. Requests are bound directly onto the proto request: the body according to
+// rule.Body, then path variables, then (unless the whole body is mapped) query parameters.
+func (gb *GrpcBridge) Transcode(name, serviceName, methodName string, rule HttpRule) error {
+	if err := gb.SetHttpRule(serviceName, methodName, rule); err != nil {
+		return err
+	}
+
+	service := gb.services[serviceName]
+	method := service.Methods[methodName]
+	rule = *method.HttpRule
+	ginPath, variables, _ := parsePathTemplate(rule.Path)
+
+	rb := gb.engine.Named(name).
+		WithDescription(fmt.Sprintf("Transcoded gRPC method %s", method.FullName)).
+		WithTags("grpc", "bridge", "transcoded").
+		WithMetadata("grpc_service", serviceName).
+		WithMetadata("grpc_method", methodName).
+		WithMetadata("http_rule", rule)
+	rb.method = rule.Method
+	rb.path = ginPath
+
+	rb.Handler(func(c *gin.Context) {
+		if err := gb.handleTranscoded(c, service, method, &rule, variables); err != nil {
+			writeBridgeError(c, err)
+		}
+	})
+	return nil
+}
+
+// handleTranscoded binds a request per an HTTP rule and dispatches it
+func (gb *GrpcBridge) handleTranscoded(c *gin.Context, service *GrpcService, method *GrpcMethod, rule *HttpRule, variables []transcodingVariable) error {
+	grpcInput, err := gb.newGrpcInput(method)
+	if err != nil {
+		return err
+	}
+	if err := bindTranscodedRequest(c, rule, variables, grpcInput); err != nil {
+		return err
+	}
+
+	grpcOutput, err := gb.dispatch(c, service, method, grpcInput)
+	if err != nil || grpcOutput == nil {
+		return err
+	}
+
+	rendered, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(grpcOutput)
+	if err != nil {
+		return fmt.Errorf("failed to marshal gRPC response: %v", err)
+	}
+	if rule.ResponseBody != "" {
+		field := findProtoField(grpcOutput.ProtoReflect().Descriptor(), rule.ResponseBody)
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(rendered, &doc); err != nil {
+			return fmt.Errorf("failed to select response body: %v", err)
+		}
+		rendered = doc[field.JSONName()]
+		if rendered == nil {
+			rendered = []byte("null")
+		}
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", rendered)
+	return nil
+}
+
+// bindTranscodedRequest populates a proto request from body, path and query
+func bindTranscodedRequest(c *gin.Context, rule *HttpRule, variables []transcodingVariable, msg proto.Message) error {
+	if rule.Body != "" {
+		body, err := c.GetRawData()
+		if err != nil {
+			return NewSuperGinError(ErrValidationFailed, "failed to read request body: %v", err)
+		}
+		if len(body) > 0 {
+			if rule.Body == "*" {
+				err = protojson.Unmarshal(body, msg)
+			} else {
+				// Wrap the body in its field so protojson handles any field type
+				wrapped := fmt.Appendf(nil, "{%q:%s}", rule.Body, body)
+				partial := msg.ProtoReflect().New().Interface()
+				if err = protojson.Unmarshal(wrapped, partial); err == nil {
+					proto.Merge(msg, partial)
+				}
+			}
+			if err != nil {
+				return NewSuperGinError(ErrValidationFailed, "binding error: %v", err)
+			}
+		}
+	}
+
+	bound := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		if err := setProtoField(msg.ProtoReflect(), variable.field, strings.TrimPrefix(c.Param(variable.param), "/")); err != nil {
+			return NewSuperGinError(ErrValidationFailed, "path variable %s: %v", strings.Join(variable.field, "."), err)
+		}
+		bound[strings.Join(variable.field, ".")] = true
+	}
+
+	if rule.Body == "*" {
+		return nil
+	}
+	descriptor := msg.ProtoReflect().Descriptor()
+	for key, values := range c.Request.URL.Query() {
+		path := strings.Split(key, ".")
+		if bound[key] || (rule.Body != "" && path[0] == rule.Body) {
+			continue
+		}
+		// Unknown query parameters are ignored, as they may be consumed by middleware
+		if checkFieldPath(descriptor, path, false) != nil {
+			continue
+		}
+		for _, value := range values {
+			if err := setProtoField(msg.ProtoReflect(), path, value); err != nil {
+				return NewSuperGinError(ErrValidationFailed, "query parameter %s: %v", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parsePathTemplate converts "/v1/users/{user_id}" into a gin path and its field bindings.
+// Variables may be {field}, {field=*} or, as the last segment, {field=**}.
+func parsePathTemplate(template string) (string, []transcodingVariable, error) {
+	if !strings.HasPrefix(template, "/") {
+		return "", nil, fmt.Errorf("path template %q must start with /", template)
+	}
+
+	segments := strings.Split(template, "/")
+	var variables []transcodingVariable
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") {
+			if strings.ContainsAny(segment, "{}") {
+				return "", nil, fmt.Errorf("path template %q: variables must span a whole segment", template)
+			}
+			if strings.ContainsAny(segment, ":*") {
+				return "", nil, fmt.Errorf("path template %q: custom verbs and wildcard segments are not supported", template)
+			}
+			continue
+		}
+		if !strings.HasSuffix(segment, "}") {
+			return "", nil, fmt.Errorf("path template %q: unterminated variable", template)
+		}
+		field, pattern, _ := strings.Cut(segment[1:len(segment)-1], "=")
+		if field == "" || (pattern != "" && pattern != "*" && pattern != "**") || (pattern == "**" && i != len(segments)-1) {
+			return "", nil, fmt.Errorf("path template %q: unsupported variable %q", template, segment)
+		}
+
+		// {field=**} matches the rest of the path, including slashes
+		param := strings.ReplaceAll(field, ".", "_")
+		if pattern == "**" {
+			segments[i] = "*" + param
+		} else {
+			segments[i] = ":" + param
+		}
+		variables = append(variables, transcodingVariable{param: param, field: strings.Split(field, ".")})
+	}
+	return strings.Join(segments, "/"), variables, nil
+}
+
+// newProtoMessage instantiates a registered gRPC message type
+func newProtoMessage(t reflect.Type) (proto.Message, error) {
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("gRPC type %v must be a pointer to a proto message", t)
+	}
+	msg, ok := reflect.New(t.Elem()).Interface().(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("gRPC type %s does not implement proto.Message", t)
+	}
+	return msg, nil
+}
+
+// findProtoField looks up a field by proto name, falling back to its JSON name
+func findProtoField(descriptor protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := descriptor.Fields()
+	if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return fields.ByJSONName(name)
+}
+
+// checkFieldPath verifies a dotted field path exists; scalar requires a singular scalar leaf
+func checkFieldPath(descriptor protoreflect.MessageDescriptor, path []string, scalar bool) error {
+	for i, name := range path {
+		fd := findProtoField(descriptor, name)
+		if fd == nil {
+			return fmt.Errorf("no field %q in %s", name, descriptor.FullName())
+		}
+		if i == len(path)-1 {
+			if scalar && (fd.IsList() || fd.IsMap() || fd.Message() != nil) {
+				return fmt.Errorf("field %q must be a singular scalar", name)
+			}
+			return nil
+		}
+		if fd.IsList() || fd.IsMap() || fd.Message() == nil {
+			return fmt.Errorf("field %q is not a singular message", name)
+		}
+		descriptor = fd.Message()
+	}
+	return nil
+}
+
+// setProtoField parses a string into the scalar field at path, appending to repeated fields
+func setProtoField(msg protoreflect.Message, path []string, raw string) error {
+	fd := findProtoField(msg.Descriptor(), path[0])
+	if fd == nil {
+		return fmt.Errorf("no field %q in %s", path[0], msg.Descriptor().FullName())
+	}
+	if len(path) > 1 {
+		if fd.IsList() || fd.IsMap() || fd.Message() == nil {
+			return fmt.Errorf("field %q is not a singular message", path[0])
+		}
+		return setProtoField(msg.Mutable(fd).Message(), path[1:], raw)
+	}
+	if fd.IsMap() || fd.Message() != nil {
+		return fmt.Errorf("field %q cannot be bound from a string", path[0])
+	}
+
+	value, err := parseProtoScalar(fd, raw)
+	if err != nil {
+		return err
+	}
+	if fd.IsList() {
+		msg.Mutable(fd).List().Append(value)
+	} else {
+		msg.Set(fd, value)
+	}
+	return nil
+}
+
+// parseProtoScalar converts a string to a proto value of the field's kind
+func parseProtoScalar(fd protoreflect.FieldDescriptor, raw string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(raw), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(raw)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(raw, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(raw, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(raw, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(raw, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(raw, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.BytesKind:
+		v, err := base64.StdEncoding.DecodeString(raw)
+		if err != nil {
+			v, err = base64.URLEncoding.DecodeString(raw)
+		}
+		return protoreflect.ValueOfBytes(v), err
+	case protoreflect.EnumKind:
+		if value := fd.Enum().Values().ByName(protoreflect.Name(raw)); value != nil {
+			return protoreflect.ValueOfEnum(value.Number()), nil
+		}
+		n, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid enum value %q for %s", raw, fd.Enum().FullName())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+	}
+}
@@ -0,0 +1,519 @@
+package supergin
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Web protocols terminated by ServeWebProtocols
+const (
+	GrpcWebProtocol = "grpc-web"
+	ConnectProtocol = "connect"
+)
+
+// Envelope flags of length-prefixed gRPC-Web and Connect messages
+const (
+	envelopeCompressed = 0x01
+	envelopeEndStream  = 0x02
+	envelopeTrailers   = 0x80
+)
+
+// connectCodes maps gRPC codes to Connect error code names and HTTP statuses
+var connectCodes = map[codes.Code]struct {
+	name   string
+	status int
+}{
+	codes.Canceled:           {"canceled", 499},
+	codes.Unknown:            {"unknown", http.StatusInternalServerError},
+	codes.InvalidArgument:    {"invalid_argument", http.StatusBadRequest},
+	codes.DeadlineExceeded:   {"deadline_exceeded", http.StatusGatewayTimeout},
+	codes.NotFound:           {"not_found", http.StatusNotFound},
+	codes.AlreadyExists:      {"already_exists", http.StatusConflict},
+	codes.PermissionDenied:   {"permission_denied", http.StatusForbidden},
+	codes.ResourceExhausted:  {"resource_exhausted", http.StatusTooManyRequests},
+	codes.FailedPrecondition: {"failed_precondition", http.StatusBadRequest},
+	codes.Aborted:            {"aborted", http.StatusConflict},
+	codes.OutOfRange:         {"out_of_range", http.StatusBadRequest},
+	codes.Unimplemented:      {"unimplemented", http.StatusNotImplemented},
+	codes.Internal:           {"internal", http.StatusInternalServerError},
+	codes.Unavailable:        {"unavailable", http.StatusServiceUnavailable},
+	codes.DataLoss:           {"data_loss", http.StatusInternalServerError},
+	codes.Unauthenticated:    {"unauthenticated", http.StatusUnauthorized},
+}
+
+// webRequest describes how a gRPC-Web or Connect request is framed and encoded
+type webRequest struct {
+	protocol    string
+	contentType string
+	// enveloped requests and responses are length-prefixed message streams; Connect unary
+	// calls carry a single bare message
+	enveloped bool
+	// text bodies are base64 encoded, as sent by grpc-web-text clients
+	text bool
+	// json messages are protojson rather than binary protobuf
+	json bool
+}
+
+// ServeWebProtocols registers a POST route per method of a bridged service at
+// prefix + "/package.Service/Method", terminating gRPC-Web and Connect requests and
+// calling the backend over gRPC, so browser clients generated by grpc-web or connect-web
+// need no Envoy in front. The protocol follows the request's Content-Type: application/grpc-web
+// (+proto or +json), application/grpc-web-text, application/connect+proto or +json for
+// streams, and application/proto or application/json for Connect unary calls.
+// Methods registered afterwards are not served; call it once the service is set up.
+// Browsers calling another origin also need a CORS policy allowing these headers.
+func (gb *GrpcBridge) ServeWebProtocols(serviceName, prefix string) error {
+	service, exists := gb.services[serviceName]
+	if !exists {
+		return fmt.Errorf("gRPC service %s not found", serviceName)
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	names := make([]string, 0, len(service.Methods))
+	for name := range service.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		method := service.Methods[name]
+		gb.engine.Named(fmt.Sprintf("%s_%s_web", serviceName, name)).
+			POST(prefix+method.FullName).
+			WithDescription(fmt.Sprintf("gRPC-Web and Connect endpoint for %s", method.FullName)).
+			WithTags("grpc", "bridge", "grpc-web").
+			WithMetadata("grpc_service", serviceName).
+			WithMetadata("grpc_method", name).
+			WithMetadata("grpc_protocols", []string{GrpcWebProtocol, ConnectProtocol}).
+			Handler(func(c *gin.Context) {
+				gb.handleWebRequest(c, service, method)
+			})
+	}
+	return nil
+}
+
+// handleWebRequest decodes a gRPC-Web or Connect request, calls the backend and writes the
+// response in the request's protocol. Failures are reported in-protocol: as trailers for
+// gRPC-Web, as an end-of-stream message for Connect streams and as a JSON error for Connect
+// unary calls.
+func (gb *GrpcBridge) handleWebRequest(c *gin.Context, service *GrpcService, method *GrpcMethod) {
+	req, ok := parseWebRequest(c.ContentType())
+	streaming := method.StreamingInput || method.StreamingOutput
+	if !ok || (streaming && !req.enveloped) {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"error":   "unsupported content type",
+			"details": fmt.Sprintf("%s expects a gRPC-Web or Connect request", method.FullName),
+		})
+		return
+	}
+	w := &webResponseWriter{c: c, req: req}
+
+	// gRPC-Web deadlines come from the grpc-timeout header, like other bridged calls
+	timeout, err := req.connectTimeout(c)
+	if err != nil {
+		w.finish(err)
+		return
+	}
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+	}
+
+	inputs, err := gb.readWebInputs(c, req, method)
+	if err != nil {
+		w.finish(err)
+		return
+	}
+	ctx, cancel, err := gb.callContext(c, service)
+	if err != nil {
+		w.finish(err)
+		return
+	}
+	defer cancel()
+
+	if !streaming {
+		output, err := gb.intercept(c, service, method, inputs[0], func(c *gin.Context, input proto.Message) (proto.Message, error) {
+			var header, trailer metadata.MD
+			start := time.Now()
+			output, err := gb.callGrpcMethod(ctx, service, method, input, grpc.Header(&header), grpc.Trailer(&trailer))
+			gb.logCall(c, method, input, output, time.Since(start), err)
+			gb.exposeMetadata(c, service, header, trailer)
+			return output, err
+		})
+		if err == nil && output == nil {
+			err = status.Errorf(codes.Internal, "no response for %s", method.FullName)
+		}
+		if err == nil {
+			err = w.message(output)
+		}
+		w.finish(err)
+		return
+	}
+
+	var firstInput proto.Message
+	if len(inputs) > 0 {
+		firstInput = inputs[0]
+	}
+	start := time.Now()
+	err = gb.relayWebStream(ctx, c, w, service, method, inputs)
+	gb.logCall(c, method, firstInput, nil, time.Since(start), err)
+	w.finish(err)
+}
+
+// relayWebStream sends the request messages on a new stream and writes every response
+// message to w
+func (gb *GrpcBridge) relayWebStream(ctx context.Context, c *gin.Context, w *webResponseWriter, service *GrpcService, method *GrpcMethod, inputs []proto.Message) (err error) {
+	if err := service.allowCall(); err != nil {
+		return err
+	}
+	defer func() { service.recordCall(err) }()
+
+	conn, err := service.conn()
+	if err != nil {
+		return err
+	}
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{
+		StreamName:    method.Name,
+		ClientStreams: method.StreamingInput,
+		ServerStreams: method.StreamingOutput,
+	}, method.FullName)
+	if err != nil {
+		return err
+	}
+	for _, input := range inputs {
+		if err := stream.SendMsg(input); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	// Trailers arrive after the body has started, so only header metadata is exposed
+	if header, err := stream.Header(); err == nil {
+		gb.exposeMetadata(c, service, header)
+	}
+	for {
+		output, err := gb.newGrpcOutput(method)
+		if err != nil {
+			return err
+		}
+		if err := stream.RecvMsg(output); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := w.message(output); err != nil {
+			return err
+		}
+	}
+}
+
+// readWebInputs decodes and validates the request messages. Only client-streaming methods
+// accept more than one.
+func (gb *GrpcBridge) readWebInputs(c *gin.Context, req *webRequest, method *GrpcMethod) ([]proto.Message, error) {
+	var payloads [][]byte
+	if req.enveloped {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return nil, NewSuperGinError(ErrValidationFailed, "failed to read request body: %v", err)
+		}
+		if req.text {
+			if body, err = decodeWebText(body); err != nil {
+				return nil, NewSuperGinError(ErrValidationFailed, "invalid base64 request body: %v", err)
+			}
+		}
+		if payloads, err = readEnvelopes(body, req.messageEncoding(c), decompressedLimit(c)); err != nil {
+			return nil, err
+		}
+	} else {
+		// Connect unary bodies are compressed with Content-Encoding
+		body, err := readProtobufBody(c)
+		if err != nil {
+			if IsErrorCode(err, ErrUnsupportedEncoding) {
+				return nil, err
+			}
+			return nil, NewSuperGinError(ErrValidationFailed, "failed to read request body: %v", err)
+		}
+		payloads = [][]byte{body}
+	}
+	if !method.StreamingInput && len(payloads) != 1 {
+		return nil, NewSuperGinError(ErrValidationFailed, "%s expects exactly one request message, got %d", method.FullName, len(payloads))
+	}
+
+	inputs := make([]proto.Message, 0, len(payloads))
+	for i, payload := range payloads {
+		input, err := gb.newGrpcInput(method)
+		if err != nil {
+			return nil, err
+		}
+		if err := req.unmarshal(payload, input); err != nil {
+			return nil, NewSuperGinError(ErrValidationFailed, "failed to decode message %d: %v", i+1, err)
+		}
+		if err := gb.validateProto(method, input); err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// parseWebRequest identifies the protocol and codec from a request media type
+func parseWebRequest(contentType string) (*webRequest, bool) {
+	req := &webRequest{contentType: strings.ToLower(contentType)}
+	switch req.contentType {
+	case "application/grpc-web", "application/grpc-web+proto":
+		req.protocol, req.enveloped = GrpcWebProtocol, true
+	case "application/grpc-web+json":
+		req.protocol, req.enveloped, req.json = GrpcWebProtocol, true, true
+	case "application/grpc-web-text", "application/grpc-web-text+proto":
+		req.protocol, req.enveloped, req.text = GrpcWebProtocol, true, true
+	case "application/connect+proto":
+		req.protocol, req.enveloped = ConnectProtocol, true
+	case "application/connect+json":
+		req.protocol, req.enveloped, req.json = ConnectProtocol, true, true
+	case "application/proto":
+		req.protocol = ConnectProtocol
+	case "application/json":
+		req.protocol, req.json = ConnectProtocol, true
+	default:
+		return nil, false
+	}
+	return req, true
+}
+
+// connectTimeout returns the deadline a Connect client set with Connect-Timeout-Ms
+func (r *webRequest) connectTimeout(c *gin.Context) (time.Duration, error) {
+	value := c.GetHeader("Connect-Timeout-Ms")
+	if r.protocol != ConnectProtocol || value == "" {
+		return 0, nil
+	}
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ms < 0 || len(value) > 10 {
+		return 0, NewSuperGinError(ErrValidationFailed, "invalid Connect-Timeout-Ms '%s'", value)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// messageEncoding returns the compression of enveloped messages flagged as compressed
+func (r *webRequest) messageEncoding(c *gin.Context) string {
+	if r.protocol == ConnectProtocol {
+		return strings.ToLower(c.GetHeader("Connect-Content-Encoding"))
+	}
+	return strings.ToLower(c.GetHeader("Grpc-Encoding"))
+}
+
+func (r *webRequest) unmarshal(data []byte, msg proto.Message) error {
+	if r.json {
+		return protojson.Unmarshal(data, msg)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+func (r *webRequest) marshal(msg proto.Message) ([]byte, error) {
+	if r.json {
+		return protojson.Marshal(msg)
+	}
+	return proto.Marshal(msg)
+}
+
+// readEnvelopes splits a body into length-prefixed messages, decompressing flagged ones up
+// to limit bytes each. A trailing Connect end-of-stream envelope is ignored.
+func readEnvelopes(body []byte, encoding string, limit int64) ([][]byte, error) {
+	var messages [][]byte
+	for len(body) > 0 {
+		if len(body) < 5 {
+			return nil, NewSuperGinError(ErrValidationFailed, "truncated message envelope")
+		}
+		flags := body[0]
+		size := binary.BigEndian.Uint32(body[1:5])
+		body = body[5:]
+		if uint64(size) > uint64(len(body)) {
+			return nil, NewSuperGinError(ErrValidationFailed, "message envelope of %d bytes exceeds the request body", size)
+		}
+		data := body[:size]
+		body = body[size:]
+
+		if flags&(envelopeEndStream|envelopeTrailers) != 0 {
+			continue
+		}
+		if flags&envelopeCompressed != 0 {
+			if encoding != "gzip" {
+				return nil, NewSuperGinError(ErrUnsupportedEncoding, "unsupported message encoding '%s'", encoding)
+			}
+			var err error
+			data, err = readGzip(bytes.NewReader(data), limit)
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "compressed message exceeds %d bytes", limit)
+			}
+			if err != nil {
+				return nil, NewSuperGinError(ErrValidationFailed, "invalid compressed message: %v", err)
+			}
+		}
+		messages = append(messages, data)
+	}
+	return messages, nil
+}
+
+// decodeWebText decodes a grpc-web-text body, which may be several padded base64 chunks
+func decodeWebText(body []byte) ([]byte, error) {
+	body = bytes.TrimSpace(body)
+	var decoded []byte
+	for len(body) > 0 {
+		end := len(body)
+		if i := bytes.IndexByte(body, '='); i >= 0 {
+			end = i
+			for end < len(body) && body[end] == '=' {
+				end++
+			}
+		}
+		chunk, err := base64.StdEncoding.DecodeString(string(body[:end]))
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, chunk...)
+		body = body[end:]
+	}
+	return decoded, nil
+}
+
+// webStatus converts a bridge error to the gRPC status reported to web clients
+func webStatus(err error) *status.Status {
+	switch {
+	case err == nil:
+		return status.New(codes.OK, "")
+	case IsErrorCode(err, ErrValidationFailed):
+		return status.New(codes.InvalidArgument, err.Error())
+	case IsErrorCode(err, ErrUnsupportedEncoding):
+		return status.New(codes.Unimplemented, err.Error())
+	case IsErrorCode(err, ErrBackendUnavailable):
+		return status.New(codes.Unavailable, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	return status.New(codes.Internal, err.Error())
+}
+
+// connectCode returns the Connect name and HTTP status of a gRPC code
+func connectCode(code codes.Code) (string, int) {
+	entry, exists := connectCodes[code]
+	if !exists {
+		entry = connectCodes[codes.Unknown]
+	}
+	return entry.name, entry.status
+}
+
+// connectError renders a status as a Connect error object
+func connectError(st *status.Status) gin.H {
+	name, _ := connectCode(st.Code())
+	rendered := gin.H{"code": name}
+	if st.Message() != "" {
+		rendered["message"] = st.Message()
+	}
+	return rendered
+}
+
+// encodeGrpcMessage percent-encodes a grpc-message trailer value
+func encodeGrpcMessage(message string) string {
+	var sb strings.Builder
+	for i := 0; i < len(message); i++ {
+		b := message[i]
+		if b < 0x20 || b > 0x7e || b == '%' {
+			fmt.Fprintf(&sb, "%%%02X", b)
+			continue
+		}
+		sb.WriteByte(b)
+	}
+	return sb.String()
+}
+
+// webResponseWriter writes response messages and the final status in a web protocol
+type webResponseWriter struct {
+	c       *gin.Context
+	req     *webRequest
+	started bool
+	unary   []byte
+}
+
+// message writes a response message, or holds it until finish for Connect unary calls
+func (w *webResponseWriter) message(msg proto.Message) error {
+	data, err := w.req.marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal gRPC response: %v", err)
+	}
+	if !w.req.enveloped {
+		w.unary = data
+		return nil
+	}
+	w.envelope(0, data)
+	return nil
+}
+
+// envelope writes a length-prefixed frame, starting the response if needed
+func (w *webResponseWriter) envelope(flags byte, data []byte) {
+	if !w.started {
+		w.c.Header("Content-Type", w.req.contentType)
+		w.c.Status(http.StatusOK)
+		w.started = true
+	}
+	frame := make([]byte, 5+len(data))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(data)))
+	copy(frame[5:], data)
+	if w.req.text {
+		frame = []byte(base64.StdEncoding.EncodeToString(frame))
+	}
+	w.c.Writer.Write(frame)
+	w.c.Writer.Flush()
+}
+
+// finish ends the response with the call's status
+func (w *webResponseWriter) finish(err error) {
+	st := webStatus(err)
+
+	if !w.req.enveloped {
+		if err != nil {
+			_, code := connectCode(st.Code())
+			w.c.JSON(code, connectError(st))
+			return
+		}
+		w.c.Data(http.StatusOK, w.req.contentType, w.unary)
+		return
+	}
+
+	if w.req.protocol == GrpcWebProtocol {
+		trailers := fmt.Sprintf("grpc-status: %d\r\ngrpc-message: %s\r\n", st.Code(), encodeGrpcMessage(st.Message()))
+		w.envelope(envelopeTrailers, []byte(trailers))
+		return
+	}
+
+	end := gin.H{}
+	if err != nil {
+		end["error"] = connectError(st)
+	}
+	data, _ := json.Marshal(end)
+	w.envelope(envelopeEndStream, data)
+}
@@ -0,0 +1,102 @@
+package supergin
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+// envelope frames data the way gRPC-Web and Connect clients do
+func envelope(flags byte, data []byte) []byte {
+	frame := make([]byte, 5, 5+len(data))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	return append(frame, data...)
+}
+
+func TestReadEnvelopesCapsCompressedMessages(t *testing.T) {
+	message := bytes.Repeat([]byte{0x08, 0x01}, 512)
+	compressed := envelope(envelopeCompressed, gzipped(t, message))
+
+	tests := []struct {
+		name     string
+		body     []byte
+		encoding string
+		limit    int64
+		want     [][]byte
+		wantCode ErrorCode
+		wantCap  bool
+	}{
+		{
+			name:  "uncompressed",
+			body:  envelope(0, message),
+			limit: 512,
+			want:  [][]byte{message},
+		},
+		{
+			name:     "compressed within the limit",
+			body:     compressed,
+			encoding: "gzip",
+			limit:    maxDecompressedMessage,
+			want:     [][]byte{message},
+		},
+		{
+			name:     "compressed over the limit",
+			body:     compressed,
+			encoding: "gzip",
+			limit:    512,
+			wantCode: ErrValidationFailed,
+			wantCap:  true,
+		},
+		{
+			name:     "compressed with an unsupported encoding",
+			body:     compressed,
+			encoding: "br",
+			limit:    maxDecompressedMessage,
+			wantCode: ErrUnsupportedEncoding,
+		},
+		{
+			name:     "trailers and end of stream are skipped",
+			body:     slices.Concat(envelope(0, message), envelope(envelopeTrailers, []byte("grpc-status: 0")), envelope(envelopeEndStream, []byte("{}"))),
+			encoding: "gzip",
+			limit:    maxDecompressedMessage,
+			want:     [][]byte{message},
+		},
+		{
+			name:     "truncated header",
+			body:     []byte{0, 0, 0},
+			limit:    maxDecompressedMessage,
+			wantCode: ErrValidationFailed,
+		},
+		{
+			name:     "size past the end of the body",
+			body:     envelope(0, message)[:100],
+			limit:    maxDecompressedMessage,
+			wantCode: ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, err := readEnvelopes(tt.body, tt.encoding, tt.limit)
+			if tt.wantCode != "" {
+				if !IsErrorCode(err, tt.wantCode) {
+					t.Fatalf("err = %v, want %s", err, tt.wantCode)
+				}
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) != tt.wantCap {
+					t.Errorf("err = %v, want *http.MaxBytesError cause %v", err, tt.wantCap)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readEnvelopes: %v", err)
+			}
+			if !slices.EqualFunc(messages, tt.want, bytes.Equal) {
+				t.Errorf("read %d messages, want %d", len(messages), len(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package supergin
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorHandlingKey stores the engine's error handling in the gin context for HandleError
+const errorHandlingKey = "supergin:error_handling"
+
+// HandlerFuncE is a handler returning its failure instead of writing an error response
+type HandlerFuncE func(c *gin.Context) error
+
+// ErrorHandler writes the response for an error returned by a handler
+type ErrorHandler func(c *gin.Context, err error)
+
+// errorStatus maps errors matching target, per errors.Is, to an HTTP status
+type errorStatus struct {
+	target error
+	status int
+}
+
+// errorHandling holds the engine's error response settings. The status slice is only
+// appended to, so a slice read under the lock stays valid after it is released.
+type errorHandling struct {
+	handler  ErrorHandler
+	statuses []errorStatus
+	mutex    sync.RWMutex
+}
+
+// MapError sets the status WriteError responds with for errors matching target, e.g.
+// app.MapError(sql.ErrNoRows, http.StatusNotFound). Mappings are checked in registration
+// order, after StatusCoder and before the built-in mappings.
+func (e *Engine) MapError(target error, status int) *Engine {
+	if target == nil {
+		panic("error mapping target must not be nil")
+	}
+	e.errorHandling.mutex.Lock()
+	defer e.errorHandling.mutex.Unlock()
+
+	e.errorHandling.statuses = append(e.errorHandling.statuses, errorStatus{target: target, status: status})
+	return e
+}
+
+// errorHandlingMiddleware makes the engine's error handling available to HandleError
+func (e *Engine) errorHandlingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(errorHandlingKey, &e.errorHandling)
+		c.Next()
+	}
+}
+
+// mappedErrorStatus returns the status the engine serving c maps err to with MapError
+func mappedErrorStatus(c *gin.Context, err error) (int, bool) {
+	handling := errorHandlingFor(c)
+	if handling == nil {
+		return 0, false
+	}
+	handling.mutex.RLock()
+	statuses := handling.statuses
+	handling.mutex.RUnlock()
+
+	for _, mapping := range statuses {
+		if errors.Is(err, mapping.target) {
+			return mapping.status, true
+		}
+	}
+	return 0, false
+}
+
+// errorHandlingFor returns the error handling of the engine serving c, if any
+func errorHandlingFor(c *gin.Context) *errorHandling {
+	if value, exists := c.Get(errorHandlingKey); exists {
+		return value.(*errorHandling)
+	}
+	return nil
+}
+
+// HandleError routes an error returned by a handler to the engine's Config.ErrorHandler,
+// or WriteError when none is set. The error is also recorded on the context for logging
+// middleware; if the handler already started its response, nothing more is written.
+func HandleError(c *gin.Context, err error) {
+	c.Error(err)
+	if c.Writer.Written() {
+		LoggerFor(c).Warn("handler returned an error after writing its response", "error", err)
+		return
+	}
+	if handling := errorHandlingFor(c); handling != nil && handling.handler != nil {
+		handling.handler(c, err)
+		return
+	}
+	WriteError(c, err)
+}
+
+// HandleE adapts an error-returning handler into a gin handler whose errors go through
+// HandleError, e.g. for resource member routes or plain gin registration
+func HandleE(handler HandlerFuncE) gin.HandlerFunc {
+	if handler == nil {
+		panic("handler function is required")
+	}
+	return func(c *gin.Context) {
+		if err := handler(c); err != nil {
+			HandleError(c, err)
+		}
+	}
+}
+
+// HandlerE registers the route with a handler returning its errors, which are written by
+// the engine's error handler instead of by each handler:
+//
+//	app.Named("show_user").GET("/users/:id").HandlerE(func(c *gin.Context) error {
+//		user, err := users.Find(c.Param("id"))
+//		if err != nil {
+//			return err
+//		}
+//		c.JSON(http.StatusOK, user)
+//		return nil
+//	})
+func (rb *RouteBuilder) HandlerE(handler HandlerFuncE) *RouteBuilder {
+	return rb.Handler(HandleE(handler))
+}
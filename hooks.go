@@ -0,0 +1,151 @@
+package supergin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// hooksKey stores the engine's hooks in the gin context for validation failure listeners
+const hooksKey = "supergin:hooks"
+
+// RouteListener is notified when a route is registered
+type RouteListener func(route *RouteInfo)
+
+// RequestListener is notified when a request starts, before any route middleware
+type RequestListener func(c *gin.Context)
+
+// RequestEndListener is notified when a request ends, after the response is written
+type RequestEndListener func(c *gin.Context, latency time.Duration)
+
+// ValidationListener is notified when a request fails input validation, with the field
+// errors the 400 response lists
+type ValidationListener func(c *gin.Context, fields FieldErrors, err error)
+
+// engineHooks holds the engine's lifecycle listeners. Listener slices are only appended
+// to, so a slice read under the lock stays valid after it is released.
+type engineHooks struct {
+	routeRegistered  []RouteListener
+	requestStart     []RequestListener
+	requestEnd       []RequestEndListener
+	validationFailed []ValidationListener
+	mutex            sync.RWMutex
+	logger           Logger
+}
+
+// OnRouteRegistered subscribes a listener to routes registered from now on, e.g. to warm
+// a cache or load route-specific policy
+func (e *Engine) OnRouteRegistered(listener RouteListener) *Engine {
+	e.hooks.mutex.Lock()
+	defer e.hooks.mutex.Unlock()
+
+	e.hooks.routeRegistered = append(e.hooks.routeRegistered, listener)
+	return e
+}
+
+// OnRequestStart subscribes a listener to the start of every request. Listeners run in
+// order; one that aborts the context stops the request.
+func (e *Engine) OnRequestStart(listener RequestListener) *Engine {
+	e.hooks.mutex.Lock()
+	defer e.hooks.mutex.Unlock()
+
+	e.hooks.requestStart = append(e.hooks.requestStart, listener)
+	return e
+}
+
+// OnRequestEnd subscribes a listener to the end of every request, including requests that
+// panicked, were aborted or matched no route
+func (e *Engine) OnRequestEnd(listener RequestEndListener) *Engine {
+	e.hooks.mutex.Lock()
+	defer e.hooks.mutex.Unlock()
+
+	e.hooks.requestEnd = append(e.hooks.requestEnd, listener)
+	return e
+}
+
+// OnValidationFailed subscribes a listener to input validation failures of routes, typed
+// handlers, CRUD resources and the gRPC bridge
+func (e *Engine) OnValidationFailed(listener ValidationListener) *Engine {
+	e.hooks.mutex.Lock()
+	defer e.hooks.mutex.Unlock()
+
+	e.hooks.validationFailed = append(e.hooks.validationFailed, listener)
+	return e
+}
+
+// hooksMiddleware notifies request listeners around the rest of the chain. It runs
+// before recovery, so request end listeners see the 500 written for a panic.
+func (e *Engine) hooksMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		e.hooks.mutex.RLock()
+		start, end := e.hooks.requestStart, e.hooks.requestEnd
+		validation := len(e.hooks.validationFailed) > 0
+		e.hooks.mutex.RUnlock()
+
+		if validation {
+			c.Set(hooksKey, &e.hooks)
+		}
+		if len(start) == 0 && len(end) == 0 {
+			c.Next()
+			return
+		}
+
+		began := time.Now()
+		for _, listener := range start {
+			e.hooks.run("request start", func() { listener(c) })
+			if c.IsAborted() {
+				break
+			}
+		}
+		if !c.IsAborted() {
+			c.Next()
+		}
+
+		latency := time.Since(began)
+		for _, listener := range end {
+			e.hooks.run("request end", func() { listener(c, latency) })
+		}
+	}
+}
+
+// notifyRouteRegistered notifies route listeners
+func (h *engineHooks) notifyRouteRegistered(route *RouteInfo) {
+	h.mutex.RLock()
+	listeners := h.routeRegistered
+	h.mutex.RUnlock()
+
+	for _, listener := range listeners {
+		h.run("route registered", func() { listener(route) })
+	}
+}
+
+// notifyValidationFailed notifies validation listeners
+func (h *engineHooks) notifyValidationFailed(c *gin.Context, err error) {
+	h.mutex.RLock()
+	listeners := h.validationFailed
+	h.mutex.RUnlock()
+
+	fields := fieldErrorsOf(err)
+	for _, listener := range listeners {
+		h.run("validation failed", func() { listener(c, fields, err) })
+	}
+}
+
+// run invokes a listener, isolating the request or registration from listener panics
+func (h *engineHooks) run(event string, listener func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.log().Error("engine hook panicked", "event", event, "panic", r)
+		}
+	}()
+	listener()
+}
+
+// log returns the hooks' logger, falling back to the default logger
+func (h *engineHooks) log() Logger {
+	if h.logger == nil {
+		return defaultLogger
+	}
+	return h.logger
+}
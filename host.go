@@ -0,0 +1,99 @@
+package supergin
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithHost binds the route to a hostname, e.g. "api.example.com". Requests for other
+// hosts fall through to routes registered without a host, so the same path may be served
+// differently per domain. Host-bound routes are dispatched by the engine's ServeHTTP.
+func (rb *RouteBuilder) WithHost(host string) *RouteBuilder {
+	rb.host = normalizeHost(host)
+	return rb
+}
+
+// WithHost binds routes created afterwards in the group to a hostname
+func (g *GroupBuilder) WithHost(host string) *GroupBuilder {
+	g.host = normalizeHost(host)
+	return g
+}
+
+// normalizeHost lowercases a host and strips its port and trailing dot
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// hostRouter returns the router serving routes bound to host, creating it on first use.
+// Requests it cannot route fall through to the engine's host-independent routes.
+func (e *Engine) hostRouter(host string) *gin.Engine {
+	e.hostsMux.Lock()
+	defer e.hostsMux.Unlock()
+
+	if router, exists := e.hosts[host]; exists {
+		return router
+	}
+	router := gin.New()
+	router.NoRoute(func(c *gin.Context) {
+		e.Engine.ServeHTTP(c.Writer, c.Request)
+	})
+	e.hosts[host] = router
+	return router
+}
+
+// ServeHTTP dispatches requests to routes bound to the request's host, falling back to
+// host-independent routes
+func (e *Engine) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	e.hostsMux.RLock()
+	router := e.hosts[normalizeHost(req.Host)]
+	e.hostsMux.RUnlock()
+
+	if router != nil {
+		router.ServeHTTP(w, req)
+		return
+	}
+	e.Engine.ServeHTTP(w, req)
+}
+
+// Run serves HTTP on addr, or $PORT or :8080, without graceful shutdown. Unlike gin's Run
+// it serves through the engine's ServeHTTP so host-bound routes are dispatched.
+func (e *Engine) Run(addr ...string) error {
+	address := ":8080"
+	if port := os.Getenv("PORT"); port != "" {
+		address = ":" + port
+	}
+	if len(addr) > 0 {
+		address = addr[0]
+	}
+	if err := e.startServices(); err != nil {
+		return err
+	}
+	if err := e.reportStartup(address); err != nil {
+		return err
+	}
+	return http.ListenAndServe(address, e)
+}
+
+// routeURL prefixes a host-bound route's path with its scheme and host
+func (e *Engine) routeURL(route *RouteInfo, path string) string {
+	if route.Host == "" {
+		return path
+	}
+	return e.hostURL(route.Host) + path
+}
+
+// hostURL is the absolute base URL of a host, using Config.URLScheme or https
+func (e *Engine) hostURL(host string) string {
+	scheme := e.config.URLScheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	return scheme + "://" + host
+}
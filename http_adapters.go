@@ -0,0 +1,128 @@
+package supergin
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPMiddleware is the standard net/http middleware signature, also used by chi
+type HTTPMiddleware func(http.Handler) http.Handler
+
+// WrapHTTPMiddleware adapts net/http middleware for use with WithMiddleware or Use.
+// Requests replaced by the middleware (e.g. via r.WithContext) and wrapped response
+// writers are propagated to the rest of the gin chain. If the middleware does not
+// call the next handler, the chain is aborted.
+func WrapHTTPMiddleware(mw HTTPMiddleware) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			c.Request = r
+			if gw, ok := w.(gin.ResponseWriter); ok {
+				c.Writer = gw
+			} else {
+				c.Writer = &httpResponseWriter{ResponseWriter: w, status: http.StatusOK, size: -1}
+			}
+			c.Next()
+		})
+
+		mw(next).ServeHTTP(c.Writer, c.Request)
+		if !called {
+			c.Abort()
+		}
+	}
+}
+
+// ToHTTPMiddleware adapts gin handlers into net/http middleware, so gin middleware can
+// wrap plain http.Handlers. The next handler runs only if no gin handler aborts, and
+// receives the request as modified by the gin handlers.
+func ToHTTPMiddleware(handlers ...gin.HandlerFunc) HTTPMiddleware {
+	return func(next http.Handler) http.Handler {
+		engine := gin.New()
+		engine.ContextWithFallback = true
+		chain := append(append([]gin.HandlerFunc{}, handlers...), func(c *gin.Context) {
+			next.ServeHTTP(c.Writer, c.Request)
+		})
+		engine.Any("/*path", chain...)
+		return engine
+	}
+}
+
+// httpResponseWriter adapts a plain http.ResponseWriter to gin.ResponseWriter
+type httpResponseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *httpResponseWriter) WriteHeader(code int) {
+	if code > 0 && !w.Written() {
+		w.status = code
+	}
+}
+
+func (w *httpResponseWriter) WriteHeaderNow() {
+	if !w.Written() {
+		w.size = 0
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+}
+
+func (w *httpResponseWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+	n, err := w.ResponseWriter.Write(data)
+	w.size += n
+	return n, err
+}
+
+func (w *httpResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *httpResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *httpResponseWriter) Size() int {
+	return w.size
+}
+
+func (w *httpResponseWriter) Written() bool {
+	return w.size != -1
+}
+
+func (w *httpResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	if w.size < 0 {
+		w.size = 0
+	}
+	return hijacker.Hijack()
+}
+
+func (w *httpResponseWriter) Flush() {
+	w.WriteHeaderNow()
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *httpResponseWriter) CloseNotify() <-chan bool {
+	if notifier, ok := w.ResponseWriter.(http.CloseNotifier); ok {
+		return notifier.CloseNotify()
+	}
+	return make(chan bool)
+}
+
+func (w *httpResponseWriter) Pusher() http.Pusher {
+	if pusher, ok := w.ResponseWriter.(http.Pusher); ok {
+		return pusher
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package supergin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobsPathPrefix is where job status and result routes are mounted
+const JobsPathPrefix = "/jobs"
+
+// DefaultJobRetention is how long a finished job and its result remain available
+const DefaultJobRetention = time.Hour
+
+// JobState is the lifecycle state of a background job
+type JobState string
+
+const (
+	JobPending   JobState = "pending"
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+)
+
+// Job is the status of a background job, as served by GET /jobs/:id
+type Job struct {
+	ID    string   `json:"id"`
+	Route string   `json:"route"`
+	State JobState `json:"state"`
+	// Progress is the completed fraction, from 0 to 1
+	Progress    float64    `json:"progress"`
+	Message     string     `json:"message,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// ExpiresAt is when a finished job and its result are discarded
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	StatusURL string     `json:"status_url"`
+	// ResultURL is set once the job has succeeded
+	ResultURL string `json:"result_url,omitempty"`
+}
+
+// JobResult is the downloadable output of a job
+type JobResult struct {
+	// ContentType defaults to application/octet-stream
+	ContentType string
+	// Filename, when set, is offered to clients via Content-Disposition
+	Filename string
+	Data     []byte
+}
+
+// ExportFunc produces a job's result. It runs in the background after the route has
+// answered 202, so it must use job rather than the request's gin context.
+type ExportFunc func(job *JobContext) (*JobResult, error)
+
+// ExportOptions configures an export route's jobs
+type ExportOptions struct {
+	// Retention is how long finished jobs and results stay available; zero means
+	// DefaultJobRetention
+	Retention time.Duration
+	// Timeout cancels the job's context after this long; zero means no timeout
+	Timeout time.Duration
+}
+
+// JobContext is passed to a running job. Its context is cancelled on timeout and on
+// engine shutdown, and carries a fresh DI request scope.
+type JobContext struct {
+	context.Context
+	id    string
+	input interface{}
+	store *jobStore
+}
+
+// ID returns the job's ID
+func (j *JobContext) ID() string {
+	return j.id
+}
+
+// Input returns the validated input of the request that started the job, if any
+func (j *JobContext) Input() interface{} {
+	return j.input
+}
+
+// Progress reports done out of total units of work
+func (j *JobContext) Progress(done, total int64) {
+	if total <= 0 {
+		return
+	}
+	fraction := math.Min(math.Max(float64(done)/float64(total), 0), 1)
+	j.store.update(j.id, func(job *Job) { job.Progress = fraction })
+}
+
+// SetMessage sets a human-readable status message, e.g. "exporting page 3 of 10"
+func (j *JobContext) SetMessage(message string) {
+	j.store.update(j.id, func(job *Job) { job.Message = message })
+}
+
+// Export makes the route start a background job: it answers 202 with the job's status and
+// a Location header pointing at GET /jobs/:id, which reports progress. Once the job has
+// succeeded, GET /jobs/:id/result downloads its result until the retention expires.
+// Jobs started by an authenticated principal or client are only served to it, so the job
+// routes, tagged "jobs", need the same authentication, e.g. UseForTags("jobs", ...); job
+// IDs are random UUIDs.
+func (rb *RouteBuilder) Export(export ExportFunc, opts ...ExportOptions) *RouteBuilder {
+	options := ExportOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Retention <= 0 {
+		options.Retention = DefaultJobRetention
+	}
+	if rb.outputType == nil {
+		rb.WithOutput(Job{})
+	}
+
+	store := rb.engine.jobStore()
+	name := rb.name
+	return rb.Handler(func(c *gin.Context) {
+		input, _ := GetValidatedInput(c)
+		job := store.start(name, jobOwner(c), input, export, options, LoggerFor(c))
+		c.Header("Location", job.StatusURL)
+		c.JSON(http.StatusAccepted, job)
+	})
+}
+
+// Job returns a job's status
+func (e *Engine) Job(id string) (Job, bool) {
+	return e.jobStore().get(id)
+}
+
+// jobStore returns the engine's job store, registering the job routes on first use
+func (e *Engine) jobStore() *jobStore {
+	e.lifecycleMux.Lock()
+	store := e.jobs
+	if store == nil {
+		store = &jobStore{jobs: make(map[string]*jobEntry)}
+		e.jobs = store
+	}
+	e.lifecycleMux.Unlock()
+
+	store.routesOnce.Do(func() { e.registerJobRoutes(store) })
+	return store
+}
+
+// registerJobRoutes adds the status and result routes shared by all export routes
+func (e *Engine) registerJobRoutes(store *jobStore) {
+	e.Named("job_status").
+		GET(JobsPathPrefix + "/:id").
+		WithDescription("Status and progress of a background job").
+		WithTags("jobs").
+		WithOutput(Job{}).
+		Handler(func(c *gin.Context) {
+			job, _, exists := store.result(c.Param("id"), c)
+			if !exists {
+				c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+				return
+			}
+			c.JSON(http.StatusOK, job)
+		})
+
+	e.Named("job_result").
+		GET(JobsPathPrefix + "/:id/result").
+		WithDescription("Download the result of a finished background job").
+		WithTags("jobs").
+		Handler(func(c *gin.Context) {
+			job, result, exists := store.result(c.Param("id"), c)
+			switch {
+			case !exists:
+				c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+			case job.State == JobFailed:
+				c.JSON(http.StatusGone, gin.H{"error": "job failed", "details": job.Error})
+			case result == nil:
+				c.Header("Location", job.StatusURL)
+				c.JSON(http.StatusConflict, gin.H{"error": "job has not finished", "state": job.State})
+			default:
+				contentType := result.ContentType
+				if contentType == "" {
+					contentType = "application/octet-stream"
+				}
+				if result.Filename != "" {
+					c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": result.Filename}))
+				}
+				c.Data(http.StatusOK, contentType, result.Data)
+			}
+		})
+}
+
+// jobStore holds an engine's jobs until their retention expires
+type jobStore struct {
+	jobs       map[string]*jobEntry
+	mutex      sync.Mutex
+	routesOnce sync.Once
+}
+
+type jobEntry struct {
+	job       Job
+	result    *JobResult
+	retention time.Duration
+	cancel    context.CancelFunc
+	// owner is the principal or client that started the job, empty for anonymous ones
+	owner string
+}
+
+// jobOwner identifies who a request acts for: its principal, else its client
+func jobOwner(c *gin.Context) string {
+	if principal, ok := GetPrincipal(c); ok {
+		return principal.PrincipalID()
+	}
+	return ClientID(c)
+}
+
+// start records a pending job and runs export in the background
+func (s *jobStore) start(route, owner string, input interface{}, export ExportFunc, options ExportOptions, logger Logger) Job {
+	scope := NewRequestScope()
+	ctx := ContextWithScope(context.Background(), scope)
+	var cancel context.CancelFunc
+	if options.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	now := time.Now()
+	id := newUUID()
+	entry := &jobEntry{
+		job: Job{
+			ID:        id,
+			Route:     route,
+			State:     JobPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+			StatusURL: JobsPathPrefix + "/" + id,
+		},
+		owner:     owner,
+		retention: options.Retention,
+		cancel:    cancel,
+	}
+
+	s.mutex.Lock()
+	s.sweepLocked()
+	s.jobs[id] = entry
+	job := entry.job
+	s.mutex.Unlock()
+
+	logger = logger.With("job_id", id)
+	go func() {
+		defer cancel()
+		s.update(id, func(job *Job) { job.State = JobRunning })
+		result, err := runExport(export, &JobContext{Context: ctx, id: id, input: input, store: s})
+		if err == nil && result == nil {
+			result = &JobResult{}
+		}
+		if err != nil {
+			logger.Warn("job failed", "error", err)
+		} else {
+			logger.Info("job succeeded", "bytes", len(result.Data))
+		}
+		s.finish(id, result, err)
+		if err := scope.Close(context.Background()); err != nil {
+			logger.Warn("failed to dispose job services", "error", err)
+		}
+	}()
+
+	return job
+}
+
+// runExport calls export, converting a panic into an error
+func runExport(export ExportFunc, job *JobContext) (result *JobResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return export(job)
+}
+
+// update applies fn to a job's status
+func (s *jobStore) update(id string, fn func(job *Job)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if entry, exists := s.jobs[id]; exists {
+		fn(&entry.job)
+		entry.job.UpdatedAt = time.Now()
+	}
+}
+
+// finish records a job's outcome and starts its retention
+func (s *jobStore) finish(id string, result *JobResult, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, exists := s.jobs[id]
+	if !exists {
+		return
+	}
+	now := time.Now()
+	expiresAt := now.Add(entry.retention)
+	entry.job.UpdatedAt = now
+	entry.job.CompletedAt = &now
+	entry.job.ExpiresAt = &expiresAt
+	entry.cancel = nil
+	if err != nil {
+		entry.job.State = JobFailed
+		entry.job.Error = err.Error()
+		return
+	}
+	entry.job.State = JobSucceeded
+	entry.job.Progress = 1
+	entry.job.ResultURL = entry.job.StatusURL + "/result"
+	entry.result = result
+}
+
+func (s *jobStore) get(id string) (Job, bool) {
+	job, _, exists := s.result(id, nil)
+	return job, exists
+}
+
+// result returns a job's status and result. With a request, jobs another principal or
+// client started are reported missing.
+func (s *jobStore) result(id string, c *gin.Context) (Job, *JobResult, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sweepLocked()
+	entry, exists := s.jobs[id]
+	if !exists || (c != nil && entry.owner != jobOwner(c)) {
+		return Job{}, nil, false
+	}
+	return entry.job, entry.result, true
+}
+
+// cancelAll cancels running jobs, e.g. on shutdown
+func (s *jobStore) cancelAll() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, entry := range s.jobs {
+		if entry.cancel != nil {
+			entry.cancel()
+		}
+	}
+}
+
+// sweepLocked discards finished jobs past their retention
+func (s *jobStore) sweepLocked() {
+	now := time.Now()
+	for id, entry := range s.jobs {
+		if entry.job.ExpiresAt != nil && now.After(*entry.job.ExpiresAt) {
+			delete(s.jobs, id)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package supergin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportBuffer is a request-scoped service that records its disposal
+type exportBuffer struct {
+	closed chan struct{}
+}
+
+func (b *exportBuffer) Close() { close(b.closed) }
+
+func TestExportJobs(t *testing.T) {
+	app := newTestEngine(Config{})
+	app.Use(func(c *gin.Context) {
+		if user := c.GetHeader("X-User"); user != "" {
+			SetPrincipal(c, BasicPrincipal{ID: user})
+		}
+	})
+	closed := make(chan struct{})
+	app.DI().RegisterRequest("export_buffer", func() *exportBuffer {
+		return &exportBuffer{closed: closed}
+	})
+	app.Named("export_orders").POST("/orders/export").Export(func(job *JobContext) (*JobResult, error) {
+		app.DI().GetFromContext(job, "export_buffer")
+		return &JobResult{ContentType: "text/csv", Data: []byte("id\n1\n")}, nil
+	})
+
+	get := func(path, user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if user != "" {
+			req.Header.Set("X-User", user)
+		}
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/orders/export", nil)
+	req.Header.Set("X-User", "alice")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("export = %d, want 202", w.Code)
+	}
+	statusURL := w.Header().Get("Location")
+
+	// The job disposes its scope once it has finished
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the job's request-scoped services were not disposed")
+	}
+	if w := get(statusURL+"/result", "alice"); w.Code != http.StatusOK || w.Body.String() != "id\n1\n" {
+		t.Errorf("alice's result = %d %q, want the export", w.Code, w.Body)
+	}
+	for _, user := range []string{"", "mallory"} {
+		for _, path := range []string{statusURL, statusURL + "/result"} {
+			if w := get(path, user); w.Code != http.StatusNotFound {
+				t.Errorf("GET %s as %q = %d, want 404", path, user, w.Code)
+			}
+		}
+	}
+}
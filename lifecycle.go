@@ -0,0 +1,256 @@
+package supergin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// DefaultDisposeTimeout bounds how long a single service may take to dispose
+const DefaultDisposeTimeout = 5 * time.Second
+
+// DefaultStartTimeout bounds how long a single service may take to start
+const DefaultStartTimeout = 30 * time.Second
+
+// Lifecycle is implemented by services that acquire resources when the engine starts and
+// release them when it shuts down
+type Lifecycle interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// StartOptions configures eager service startup
+type StartOptions struct {
+	// PerServiceTimeout bounds each service's Start; zero means DefaultStartTimeout
+	PerServiceTimeout time.Duration
+}
+
+// ShutdownOptions configures container disposal
+type ShutdownOptions struct {
+	// PerServiceTimeout bounds each service's disposal; zero means DefaultDisposeTimeout
+	PerServiceTimeout time.Duration
+}
+
+// disposeFunc returns a disposal function for instances that hold resources
+func disposeFunc(instance interface{}) func(ctx context.Context) error {
+	switch v := instance.(type) {
+	case interface{ Stop(context.Context) error }:
+		return v.Stop
+	case interface{ Shutdown(context.Context) error }:
+		return v.Shutdown
+	case io.Closer:
+		return func(context.Context) error { return v.Close() }
+	case interface{ Close() }:
+		return func(context.Context) error { v.Close(); return nil }
+	}
+	return nil
+}
+
+// startFunc returns a start function for instances that need one
+func startFunc(instance interface{}) func(ctx context.Context) error {
+	if v, ok := instance.(interface{ Start(context.Context) error }); ok {
+		return v.Start
+	}
+	return nil
+}
+
+var (
+	starterType      = reflect.TypeOf((*interface{ Start(context.Context) error })(nil)).Elem()
+	stopperType      = reflect.TypeOf((*interface{ Stop(context.Context) error })(nil)).Elem()
+	shutdownerType   = reflect.TypeOf((*interface{ Shutdown(context.Context) error })(nil)).Elem()
+	closerType       = reflect.TypeOf((*io.Closer)(nil)).Elem()
+	simpleCloserType = reflect.TypeOf((*interface{ Close() })(nil)).Elem()
+	lifecycleTypes   = []reflect.Type{starterType, stopperType, shutdownerType, closerType, simpleCloserType}
+)
+
+// hasLifecycle reports whether a service's declared type can be started or disposed
+func hasLifecycle(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	for _, lifecycleType := range lifecycleTypes {
+		if t.Implements(lifecycleType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Start eagerly creates, in dependency order, every singleton whose declared type has a
+// lifecycle (Start, Stop, Shutdown or Close) and calls Start on those implementing it, so
+// connection failures surface at startup rather than on the first request. Services whose
+// factory returns a plain interface are only detected if the interface declares the
+// methods. Start runs once; the first failure is returned and later services are skipped.
+func (di *DIContainer) Start(ctx context.Context, opts ...StartOptions) error {
+	options := StartOptions{PerServiceTimeout: DefaultStartTimeout}
+	if len(opts) > 0 && opts[0].PerServiceTimeout > 0 {
+		options.PerServiceTimeout = opts[0].PerServiceTimeout
+	}
+
+	di.mutex.Lock()
+	if di.started || di.disposed {
+		di.mutex.Unlock()
+		return nil
+	}
+	di.started = true
+	var eager []*ServiceDefinition
+	for _, name := range di.dependencyOrder() {
+		service, exists := di.services[name]
+		if exists && service.Scope == ScopeSingleton && hasLifecycle(service.Type) {
+			eager = append(eager, service)
+		}
+	}
+	di.mutex.Unlock()
+
+	for _, service := range eager {
+		instance, err := di.resolve(service.Name, nil, ctx)
+		if err != nil {
+			return err
+		}
+		start := startFunc(instance)
+		if start == nil {
+			continue
+		}
+		if err := runWithTimeout(ctx, start, options.PerServiceTimeout); err != nil {
+			return NewSuperGinErrorWithCause(ErrStartFailed, err, "service '%s' failed to start", service.Name)
+		}
+	}
+	return nil
+}
+
+// Shutdown disposes every created singleton in reverse dependency order, so a service
+// is always disposed before the services it depends on. Each disposal is bounded by a
+// timeout and isolated from panics; all failures are returned joined together.
+func (di *DIContainer) Shutdown(ctx context.Context, opts ...ShutdownOptions) error {
+	options := ShutdownOptions{PerServiceTimeout: DefaultDisposeTimeout}
+	if len(opts) > 0 && opts[0].PerServiceTimeout > 0 {
+		options.PerServiceTimeout = opts[0].PerServiceTimeout
+	}
+
+	di.mutex.Lock()
+	if di.disposed {
+		di.mutex.Unlock()
+		return nil
+	}
+	di.disposed = true
+	order := di.dependencyOrder()
+	instances := make(map[string]interface{}, len(di.singletons))
+	for name, instance := range di.singletons {
+		instances[name] = instance
+	}
+	di.mutex.Unlock()
+
+	var errs []error
+	for i := len(order) - 1; i >= 0; i-- {
+		name := order[i]
+		instance, created := instances[name]
+		if !created {
+			continue
+		}
+		dispose := disposeFunc(instance)
+		if dispose == nil {
+			continue
+		}
+
+		if err := disposeWithTimeout(ctx, name, dispose, options.PerServiceTimeout); err != nil {
+			di.log().Error("failed to dispose service", "service", name, "error", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// disposeWithTimeout runs a disposal function with a deadline and panic recovery
+func disposeWithTimeout(ctx context.Context, name string, dispose func(context.Context) error, timeout time.Duration) error {
+	if err := runWithTimeout(ctx, dispose, timeout); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return NewSuperGinErrorWithCause(ErrDisposeFailed, err, "service '%s' did not dispose in time", name)
+		}
+		return NewSuperGinErrorWithCause(ErrDisposeFailed, err, "service '%s' failed to dispose", name)
+	}
+	return nil
+}
+
+// runWithTimeout runs a lifecycle function with a deadline and panic recovery
+func runWithTimeout(ctx context.Context, fn func(context.Context) error, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close disposes the scope's instances in reverse creation order, so an instance is
+// disposed before the request-scoped services it was built from. Each disposal is bounded
+// by DefaultDisposeTimeout; all failures are returned joined together. The DI middleware
+// closes the scopes it creates when the request ends; scopes made with NewRequestScope
+// for jobs or WebSocket messages should be closed by their creator.
+func (s *RequestScope) Close(ctx context.Context) error {
+	s.mutex.Lock()
+	order, instances := s.order, s.instances
+	s.order, s.instances = nil, make(map[string]interface{})
+	s.mutex.Unlock()
+
+	var errs []error
+	for i := len(order) - 1; i >= 0; i-- {
+		dispose := disposeFunc(instances[order[i]])
+		if dispose == nil {
+			continue
+		}
+		if err := disposeWithTimeout(ctx, order[i], dispose, DefaultDisposeTimeout); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dependencyOrder returns service names ordered so dependencies precede their dependents.
+// Caller must hold the container mutex.
+func (di *DIContainer) dependencyOrder() []string {
+	names := make([]string, 0, len(di.services))
+	for name := range di.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	visited := make(map[string]bool)
+	order := make([]string, 0, len(names))
+
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		if service, exists := di.services[name]; exists {
+			for _, dep := range service.Dependencies {
+				visit(dep)
+			}
+		}
+		order = append(order, name)
+	}
+
+	for _, name := range names {
+		visit(name)
+	}
+	return order
+}
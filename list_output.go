@@ -0,0 +1,83 @@
+package supergin
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ListOptions configures how a collection response is modeled
+type ListOptions struct {
+	// ItemsField names the items array in the envelope; defaults to "items"
+	ItemsField string
+	// Bare models a plain JSON array instead of a pagination envelope
+	Bare bool
+}
+
+// ListOutput describes a route's collection response in route info and docs
+type ListOutput struct {
+	ItemType   reflect.Type           `json:"-"`
+	Item       string                 `json:"item"`
+	Envelope   bool                   `json:"envelope"`
+	ItemsField string                 `json:"items_field,omitempty"`
+	Schema     map[string]interface{} `json:"schema"`
+}
+
+// WithListOutput declares a collection response of item values. By default the response
+// is modeled as a pagination envelope {"items": [...], "total", "page", "per_page"};
+// ListOptions{Bare: true} models a plain array. The route's output type is set to the
+// envelope (or slice) type so docs and schemas show the item structure.
+func (rb *RouteBuilder) WithListOutput(item interface{}, opts ...ListOptions) *RouteBuilder {
+	if item == nil {
+		panic("list output item type is required")
+	}
+	options := ListOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.ItemsField == "" {
+		options.ItemsField = "items"
+	}
+
+	itemType := reflect.TypeOf(item)
+	list := &ListOutput{
+		ItemType: itemType,
+		Item:     itemType.String(),
+		Envelope: !options.Bare,
+	}
+	if options.Bare {
+		rb.outputType = reflect.SliceOf(itemType)
+	} else {
+		list.ItemsField = options.ItemsField
+		rb.outputType = listEnvelopeType(itemType, options.ItemsField)
+	}
+	list.Schema = JSONSchema(rb.outputType)
+
+	rb.listOutput = list
+	return rb
+}
+
+// listEnvelopeType builds the pagination envelope struct for an item type
+func listEnvelopeType(itemType reflect.Type, itemsField string) reflect.Type {
+	return reflect.StructOf([]reflect.StructField{
+		{
+			Name: "Items",
+			Type: reflect.SliceOf(itemType),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s" validate:"required" description:"Items on this page"`, itemsField)),
+		},
+		{
+			Name: "Total",
+			Type: reflect.TypeOf(0),
+			Tag:  `json:"total" description:"Total number of items across all pages"`,
+		},
+		{
+			Name: "Page",
+			Type: reflect.TypeOf(0),
+			Tag:  `json:"page,omitempty" description:"Current page, starting at 1"`,
+		},
+		{
+			Name: "PerPage",
+			Type: reflect.TypeOf(0),
+			Tag:  `json:"per_page,omitempty" description:"Page size"`,
+		},
+	})
+}
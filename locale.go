@@ -0,0 +1,197 @@
+package supergin
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/locales"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultLocale is used when a request's Accept-Language names no registered locale
+const DefaultLocale = "en"
+
+const (
+	// localeKey stores the request's resolved locale in the gin context
+	localeKey = "supergin:locale"
+	// catalogKey stores the engine's validation message catalog in the gin context
+	catalogKey = "supergin:validation_catalog"
+)
+
+// validationCatalog holds validation message translations and templates per locale
+type validationCatalog struct {
+	translators map[string]ut.Translator
+	rules       map[string]map[string]string
+	fields      map[string]map[string]string
+	mutex       sync.RWMutex
+}
+
+func newValidationCatalog() *validationCatalog {
+	return &validationCatalog{
+		translators: make(map[string]ut.Translator),
+		rules:       make(map[string]map[string]string),
+		fields:      make(map[string]map[string]string),
+	}
+}
+
+// RegisterLocale adds a locale for validation messages, using validator's translations, e.g.
+//
+//	app.RegisterLocale(fr.New(), fr_translations.RegisterDefaultTranslations)
+//
+// Requests pick their locale from Accept-Language; DefaultLocale needs no registration.
+func (e *Engine) RegisterLocale(translator locales.Translator, register func(*validator.Validate, ut.Translator) error) error {
+	locale := normalizeLocale(translator.Locale())
+	trans, _ := ut.New(translator, translator).GetTranslator(translator.Locale())
+	if register != nil {
+		if err := register(e.validator, trans); err != nil {
+			return NewSuperGinErrorWithCause(ErrValidationFailed, err, "failed to register locale '%s'", locale)
+		}
+	}
+
+	e.validationCatalog.mutex.Lock()
+	defer e.validationCatalog.mutex.Unlock()
+	e.validationCatalog.translators[locale] = trans
+	return nil
+}
+
+// SetRuleMessage sets the message for a failed rule in a locale. Templates may use
+// {field}, {rule} and {param}, e.g. SetRuleMessage("en", "min", "{field} is too short").
+func (e *Engine) SetRuleMessage(locale, rule, template string) *Engine {
+	e.validationCatalog.setTemplate(e.validationCatalog.rules, locale, rule, template)
+	return e
+}
+
+// SetFieldMessage sets the message for a failed rule on one field, named by JSON path,
+// overriding SetRuleMessage, e.g. SetFieldMessage("en", "password", "min", "use 12+ characters")
+func (e *Engine) SetFieldMessage(locale, field, rule, template string) *Engine {
+	e.validationCatalog.setTemplate(e.validationCatalog.fields, locale, field+"\x00"+rule, template)
+	return e
+}
+
+func (vc *validationCatalog) setTemplate(templates map[string]map[string]string, locale, key, template string) {
+	locale = normalizeLocale(locale)
+	vc.mutex.Lock()
+	defer vc.mutex.Unlock()
+
+	if templates[locale] == nil {
+		templates[locale] = make(map[string]string)
+	}
+	templates[locale][key] = template
+}
+
+// Locale returns the request's validation message locale, resolved from Accept-Language
+func Locale(c *gin.Context) string {
+	if locale := c.GetString(localeKey); locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// localeMiddleware resolves each request's locale against the registered locales
+func (e *Engine) localeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(catalogKey, e.validationCatalog)
+		c.Set(localeKey, e.validationCatalog.match(c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
+
+// match picks the first registered locale in Accept-Language preference order, trying
+// each tag and then its base language
+func (vc *validationCatalog) match(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return DefaultLocale
+	}
+
+	type preference struct {
+		tag string
+		q   float64
+	}
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if tag != "" && tag != "*" && q > 0 {
+			preferences = append(preferences, preference{tag: normalizeLocale(tag), q: q})
+		}
+	}
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].q > preferences[j].q })
+
+	vc.mutex.RLock()
+	defer vc.mutex.RUnlock()
+	for _, pref := range preferences {
+		base, _, _ := strings.Cut(pref.tag, "_")
+		for _, candidate := range []string{pref.tag, base} {
+			if _, exists := vc.translators[candidate]; exists || candidate == DefaultLocale {
+				return candidate
+			}
+		}
+	}
+	return DefaultLocale
+}
+
+// normalizeLocale converts language tags such as "fr-CA" to locale names like "fr_ca"
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "-", "_"))
+}
+
+// message resolves a field error's message: field template, rule template, registered
+// translation, then the default English message
+func (vc *validationCatalog) message(locale string, field FieldError, fe validator.FieldError) string {
+	param := ""
+	if fe != nil {
+		param = fe.Param()
+	}
+
+	vc.mutex.RLock()
+	template, exists := vc.fields[locale][field.Field+"\x00"+field.Rule]
+	if !exists {
+		template, exists = vc.rules[locale][field.Rule]
+	}
+	trans := vc.translators[locale]
+	vc.mutex.RUnlock()
+
+	if exists {
+		return strings.NewReplacer("{field}", field.Field, "{rule}", field.Rule, "{param}", param).Replace(template)
+	}
+	if fe != nil && trans != nil {
+		if translated := fe.Translate(trans); translated != fe.Error() {
+			return translated
+		}
+	}
+	return field.Message
+}
+
+// localizedFieldErrors returns err's field errors with messages in the request's locale
+func localizedFieldErrors(c *gin.Context, err error) FieldErrors {
+	fields := fieldErrorsOf(err)
+	value, exists := c.Get(catalogKey)
+	if len(fields) == 0 || !exists {
+		return fields
+	}
+	catalog := value.(*validationCatalog)
+	locale := Locale(c)
+
+	var structErr *structValidationError
+	fromStruct := errors.As(err, &structErr)
+	localized := make(FieldErrors, len(fields))
+	for i, field := range fields {
+		var fe validator.FieldError
+		if fromStruct && i < len(structErr.errs) {
+			fe = structErr.errs[i]
+		}
+		field.Message = catalog.message(locale, field, fe)
+		localized[i] = field
+	}
+	return localized
+}
@@ -0,0 +1,257 @@
+package supergin
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Logger is the structured logger SuperGin writes through. Fields are alternating
+// key/value pairs, as in log/slog.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+	// With returns a logger that adds the fields to every line
+	With(keysAndValues ...interface{}) Logger
+}
+
+// RequestIDHeader carries the request ID; incoming values are kept, otherwise one is generated
+const RequestIDHeader = "X-Request-ID"
+
+const (
+	// requestIDKey stores the request ID in the gin context
+	requestIDKey = "supergin:request_id"
+	// routeNameKey stores the matched route's name in the gin context
+	routeNameKey = "supergin:route_name"
+	// loggerKey stores the request's logger in the gin context
+	loggerKey = "supergin:logger"
+)
+
+// defaultLogger writes through slog.Default, so slog.SetDefault also redirects SuperGin
+var defaultLogger = NewSlogLogger(nil)
+
+// slogLogger adapts a *slog.Logger; a nil logger resolves slog.Default on every call
+type slogLogger struct {
+	logger *slog.Logger
+	fields []interface{}
+}
+
+// NewSlogLogger adapts a *slog.Logger. With nil, lines go to slog.Default at the time they
+// are written. zap (zapslog) and zerolog (slog-zerolog) handlers also plug in this way.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return &slogLogger{logger: logger}
+}
+
+func (l *slogLogger) log(level slog.Level, msg string, keysAndValues []interface{}) {
+	logger := l.logger
+	if logger == nil {
+		logger = slog.Default().With(l.fields...)
+	}
+	logger.Log(context.Background(), level, msg, keysAndValues...)
+}
+
+func (l *slogLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelDebug, msg, keysAndValues)
+}
+
+func (l *slogLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelInfo, msg, keysAndValues)
+}
+
+func (l *slogLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelWarn, msg, keysAndValues)
+}
+
+func (l *slogLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelError, msg, keysAndValues)
+}
+
+func (l *slogLogger) With(keysAndValues ...interface{}) Logger {
+	if l.logger == nil {
+		return &slogLogger{fields: appendFields(l.fields, keysAndValues)}
+	}
+	return &slogLogger{logger: l.logger.With(keysAndValues...)}
+}
+
+// SugaredLogger is the key/value logging API of *zap.SugaredLogger
+type SugaredLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// sugaredLogger adapts a SugaredLogger, carrying With fields itself
+type sugaredLogger struct {
+	logger SugaredLogger
+	fields []interface{}
+}
+
+// NewSugaredLogger adapts a zap logger, e.g. supergin.NewSugaredLogger(zapLogger.Sugar())
+func NewSugaredLogger(logger SugaredLogger) Logger {
+	return &sugaredLogger{logger: logger}
+}
+
+func (l *sugaredLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.logger.Debugw(msg, appendFields(l.fields, keysAndValues)...)
+}
+
+func (l *sugaredLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.logger.Infow(msg, appendFields(l.fields, keysAndValues)...)
+}
+
+func (l *sugaredLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.logger.Warnw(msg, appendFields(l.fields, keysAndValues)...)
+}
+
+func (l *sugaredLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.logger.Errorw(msg, appendFields(l.fields, keysAndValues)...)
+}
+
+func (l *sugaredLogger) With(keysAndValues ...interface{}) Logger {
+	return &sugaredLogger{logger: l.logger, fields: appendFields(l.fields, keysAndValues)}
+}
+
+// ZerologEvent is the part of *zerolog.Event used to write a line
+type ZerologEvent[E any] interface {
+	Fields(fields interface{}) E
+	Msg(msg string)
+}
+
+// Zerolog is the leveled API of *zerolog.Logger
+type Zerolog[E ZerologEvent[E]] interface {
+	Debug() E
+	Info() E
+	Warn() E
+	Error() E
+}
+
+// zerologLogger adapts a Zerolog, carrying With fields itself
+type zerologLogger[E ZerologEvent[E], L Zerolog[E]] struct {
+	logger L
+	fields []interface{}
+}
+
+// NewZerologLogger adapts a zerolog logger. The event type cannot be inferred, e.g.
+//
+//	supergin.NewZerologLogger[*zerolog.Event](&zl)
+func NewZerologLogger[E ZerologEvent[E], L Zerolog[E]](logger L) Logger {
+	return &zerologLogger[E, L]{logger: logger}
+}
+
+func (l *zerologLogger[E, L]) write(event E, msg string, keysAndValues []interface{}) {
+	if fields := appendFields(l.fields, keysAndValues); len(fields) > 0 {
+		event = event.Fields(fields)
+	}
+	event.Msg(msg)
+}
+
+func (l *zerologLogger[E, L]) Debug(msg string, keysAndValues ...interface{}) {
+	l.write(l.logger.Debug(), msg, keysAndValues)
+}
+
+func (l *zerologLogger[E, L]) Info(msg string, keysAndValues ...interface{}) {
+	l.write(l.logger.Info(), msg, keysAndValues)
+}
+
+func (l *zerologLogger[E, L]) Warn(msg string, keysAndValues ...interface{}) {
+	l.write(l.logger.Warn(), msg, keysAndValues)
+}
+
+func (l *zerologLogger[E, L]) Error(msg string, keysAndValues ...interface{}) {
+	l.write(l.logger.Error(), msg, keysAndValues)
+}
+
+func (l *zerologLogger[E, L]) With(keysAndValues ...interface{}) Logger {
+	return &zerologLogger[E, L]{logger: l.logger, fields: appendFields(l.fields, keysAndValues)}
+}
+
+// appendFields concatenates field lists without sharing the base's backing array
+func appendFields(base, extra []interface{}) []interface{} {
+	if len(base) == 0 {
+		return extra
+	}
+	fields := make([]interface{}, 0, len(base)+len(extra))
+	return append(append(fields, base...), extra...)
+}
+
+// Logger returns the engine's logger
+func (e *Engine) Logger() Logger {
+	return e.logger
+}
+
+// LoggerFor returns the request's logger, annotated with the request ID and, once routed,
+// the route name and ID. Outside SuperGin's middleware it falls back to the default logger.
+func LoggerFor(c *gin.Context) Logger {
+	logger := defaultLogger
+	if value, exists := c.Get(loggerKey); exists {
+		logger = value.(Logger)
+	}
+	if name := c.GetString(routeNameKey); name != "" {
+		logger = logger.With("route", name, "route_id", RouteID(c))
+	}
+	return logger
+}
+
+// RequestID returns the ID assigned to the request by SuperGin's request logger
+func RequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// requestLogger assigns each request an ID and writes one structured line per request
+// with the route, request ID and request-scoped DI services resolved while serving it
+func (e *Engine) requestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = "req_" + newUUID()
+		}
+		c.Set(requestIDKey, id)
+		c.Set(loggerKey, e.logger.With("request_id", id))
+		c.Header(RequestIDHeader, id)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []interface{}{
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", status,
+			"latency", time.Since(start),
+			"client_ip", c.ClientIP(),
+			"size", c.Writer.Size(),
+		}
+		if value, exists := c.Get(requestScopeGinKey); exists {
+			fields = append(fields, "di_scope", value.(*RequestScope).names())
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, "errors", c.Errors.String())
+		}
+
+		logger := LoggerFor(c)
+		if status >= 500 {
+			logger.Error("request", fields...)
+		} else {
+			logger.Info("request", fields...)
+		}
+	}
+}
+
+// names lists the request-scoped services resolved in the scope
+func (s *RequestScope) names() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	names := make([]string, 0, len(s.instances))
+	for name := range s.instances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
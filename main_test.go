@@ -0,0 +1,26 @@
+package supergin
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// quietLogger discards what engines and hubs log during tests
+func quietLogger() Logger {
+	return NewSlogLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// newTestEngine creates an engine that logs nothing
+func newTestEngine(config Config) *Engine {
+	config.Logger = quietLogger()
+	return New(config)
+}
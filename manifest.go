@@ -0,0 +1,243 @@
+package supergin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ManifestPath is where EnableManifestEndpoint mounts the boot manifest
+const ManifestPath = "/_admin/manifest"
+
+// BootManifest is a machine-readable description of the surface an engine serves, so
+// deployment tooling can check that what is running matches what was expected
+type BootManifest struct {
+	Title       string            `json:"title,omitempty"`
+	Version     string            `json:"version,omitempty"`
+	Environment string            `json:"environment"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Listeners   []string          `json:"listeners"`
+	Routes      []ManifestRoute   `json:"routes"`
+	Services    []ManifestService `json:"services"`
+	Hubs        []ManifestHub     `json:"hubs"`
+	GrpcMethods []ManifestMethod  `json:"grpc_methods"`
+}
+
+// ManifestRoute describes a registered route and the handlers in front of it
+type ManifestRoute struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Method       string   `json:"method"`
+	Path         string   `json:"path"`
+	Host         string   `json:"host,omitempty"`
+	Group        string   `json:"group,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Input        string   `json:"input,omitempty"`
+	Output       string   `json:"output,omitempty"`
+	Environments []string `json:"environments,omitempty"`
+	Middleware   []string `json:"middleware"`
+}
+
+// ManifestService describes a service registered in the DI container
+type ManifestService struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type,omitempty"`
+	Scope        DIScope  `json:"scope"`
+	Dependencies []string `json:"dependencies,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
+// ManifestHub describes a WebSocket hub
+type ManifestHub struct {
+	Name      string `json:"name"`
+	Path      string `json:"path,omitempty"`
+	Transport string `json:"transport"`
+}
+
+// ManifestMethod describes a bridged gRPC method and the routes serving it
+type ManifestMethod struct {
+	Service         string   `json:"service"`
+	Address         string   `json:"address"`
+	Method          string   `json:"method"`
+	FullName        string   `json:"full_name"`
+	StreamingInput  bool     `json:"streaming_input,omitempty"`
+	StreamingOutput bool     `json:"streaming_output,omitempty"`
+	Routes          []string `json:"routes,omitempty"`
+}
+
+// Manifest describes the engine's routes with their middleware, DI services with their
+// scopes, WebSocket hubs, bridged gRPC methods and listeners. Lists are sorted by name.
+func (e *Engine) Manifest() BootManifest {
+	manifest := BootManifest{
+		Title:       e.config.Title,
+		Version:     e.config.Version,
+		Environment: e.environment,
+		GeneratedAt: time.Now().UTC(),
+		Listeners:   []string{},
+		Routes:      []ManifestRoute{},
+		Services:    []ManifestService{},
+		Hubs:        []ManifestHub{},
+		GrpcMethods: []ManifestMethod{},
+	}
+
+	routes := e.GetRoutes()
+	hubPaths := make(map[*WebSocketHub]string)
+	methodRoutes := make(map[string][]string)
+	for _, route := range routes {
+		manifest.Routes = append(manifest.Routes, ManifestRoute{
+			ID:           route.ID,
+			Name:         route.Name,
+			Method:       route.Method,
+			Path:         route.Path,
+			Host:         route.Host,
+			Group:        route.Group,
+			Tags:         route.Tags,
+			Input:        typeName(route.InputType),
+			Output:       typeName(route.OutputType),
+			Environments: route.Environments,
+			Middleware:   route.Middleware,
+		})
+		if hub, ok := route.Metadata["websocket_hub"].(*WebSocketHub); ok {
+			hubPaths[hub] = route.Path
+		}
+		service, _ := route.Metadata["grpc_service"].(string)
+		method, _ := route.Metadata["grpc_method"].(string)
+		if service != "" && method != "" {
+			key := service + "/" + method
+			methodRoutes[key] = append(methodRoutes[key], route.Name)
+		}
+	}
+	sort.Slice(manifest.Routes, func(i, j int) bool { return manifest.Routes[i].Name < manifest.Routes[j].Name })
+
+	for _, def := range e.di.ListServices() {
+		manifest.Services = append(manifest.Services, ManifestService{
+			Name:         def.Name,
+			Type:         typeName(def.Type),
+			Scope:        def.Scope,
+			Dependencies: def.Dependencies,
+			Tags:         def.Tags,
+		})
+	}
+	sort.Slice(manifest.Services, func(i, j int) bool { return manifest.Services[i].Name < manifest.Services[j].Name })
+
+	e.lifecycleMux.Lock()
+	manifest.Listeners = append(manifest.Listeners, e.listeners...)
+	hubs := append([]*WebSocketHub(nil), e.hubs...)
+	e.lifecycleMux.Unlock()
+	for _, hub := range hubs {
+		manifest.Hubs = append(manifest.Hubs, ManifestHub{
+			Name:      hub.name,
+			Path:      hubPaths[hub],
+			Transport: fmt.Sprintf("%T", hub.transport),
+		})
+	}
+	sort.Slice(manifest.Hubs, func(i, j int) bool { return manifest.Hubs[i].Name < manifest.Hubs[j].Name })
+
+	if e.di.Has("grpc_bridge") {
+		if bridge, ok := e.di.Get("grpc_bridge").(*GrpcBridge); ok {
+			for serviceName, service := range bridge.services {
+				for methodName, method := range service.Methods {
+					routeNames := methodRoutes[serviceName+"/"+methodName]
+					sort.Strings(routeNames)
+					manifest.GrpcMethods = append(manifest.GrpcMethods, ManifestMethod{
+						Service:         serviceName,
+						Address:         service.Address,
+						Method:          methodName,
+						FullName:        method.FullName,
+						StreamingInput:  method.StreamingInput,
+						StreamingOutput: method.StreamingOutput,
+						Routes:          routeNames,
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(manifest.GrpcMethods, func(i, j int) bool {
+		return manifest.GrpcMethods[i].FullName < manifest.GrpcMethods[j].FullName
+	})
+
+	return manifest
+}
+
+// WriteManifest writes the boot manifest to path as indented JSON
+func (e *Engine) WriteManifest(path string) error {
+	data, err := json.MarshalIndent(e.Manifest(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode boot manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write boot manifest: %w", err)
+	}
+	return nil
+}
+
+// EnableManifestEndpoint registers GET /_admin/manifest, serving the boot manifest behind
+// the given authentication middleware
+func (e *Engine) EnableManifestEndpoint(auth gin.HandlerFunc, middleware ...gin.HandlerFunc) *Engine {
+	if auth == nil {
+		panic("manifest endpoint requires an authentication middleware")
+	}
+
+	e.Named("admin_manifest").
+		GET(ManifestPath).
+		WithDescription("Machine-readable description of the deployed routes, services, hubs and listeners").
+		WithTags("admin").
+		WithMiddleware(append([]gin.HandlerFunc{auth}, middleware...)...).
+		WithOutput(BootManifest{}).
+		Handler(func(c *gin.Context) {
+			c.JSON(http.StatusOK, e.Manifest())
+		})
+
+	return e
+}
+
+// reportStartup records a listener, logs a summary of the served surface and writes the
+// boot manifest when Config.ManifestPath is set
+func (e *Engine) reportStartup(addr string) error {
+	e.lifecycleMux.Lock()
+	e.listeners = append(e.listeners, addr)
+	e.lifecycleMux.Unlock()
+
+	manifest := e.Manifest()
+	e.logger.Info("starting server",
+		"addr", addr,
+		"environment", manifest.Environment,
+		"routes", len(manifest.Routes),
+		"services", len(manifest.Services),
+		"hubs", len(manifest.Hubs),
+		"grpc_methods", len(manifest.GrpcMethods),
+	)
+
+	if e.config.ManifestPath == "" {
+		return nil
+	}
+	if err := e.WriteManifest(e.config.ManifestPath); err != nil {
+		return err
+	}
+	e.logger.Debug("boot manifest written", "path", e.config.ManifestPath)
+	return nil
+}
+
+// handlerNames returns the function names of a handler chain, as gin reports them
+func handlerNames(handlers []gin.HandlerFunc) []string {
+	names := make([]string, len(handlers))
+	for i, handler := range handlers {
+		names[i] = runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+	}
+	return names
+}
+
+// typeName returns a type's name for the manifest, or "" for nil
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
@@ -0,0 +1,306 @@
+package supergin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MemoryAction decides what a hub does when a message would exceed its memory budget
+type MemoryAction int
+
+const (
+	// MemoryBackpressure refuses the message: Send returns ErrMemoryBudget and broadcasts
+	// skip the connection
+	MemoryBackpressure MemoryAction = iota
+	// MemoryEvictLargest also closes the connection holding the most buffered bytes
+	MemoryEvictLargest
+)
+
+// MemoryScope identifies which budget was exceeded
+type MemoryScope string
+
+const (
+	MemoryScopeRequest MemoryScope = "request"
+	MemoryScopeHub     MemoryScope = "hub"
+	MemoryScopeGlobal  MemoryScope = "global"
+)
+
+// ErrMemoryBudget is returned by sends refused by a memory budget
+var ErrMemoryBudget = errors.New("websocket memory budget exceeded")
+
+// MemoryBudget bounds memory held by request bodies and queued WebSocket messages.
+// Zero disables a limit.
+type MemoryBudget struct {
+	// MaxRequestBytes rejects requests whose body is larger with 413
+	MaxRequestBytes int64
+	// MaxInFlightBytes caps the declared Content-Length of concurrent requests; requests
+	// beyond it get 503 with Retry-After
+	MaxInFlightBytes int64
+	// MaxHubBytes caps the messages buffered for a hub's connections
+	MaxHubBytes int64
+	// MaxTotalBytes caps in-flight request bytes plus buffered messages across all hubs
+	MaxTotalBytes int64
+	// HubAction applies when a message would exceed MaxHubBytes or MaxTotalBytes
+	HubAction MemoryAction
+	// OnPressure is called whenever a budget refuses a request or message
+	OnPressure func(event MemoryPressure)
+}
+
+// MemoryPressure describes a request or message refused by a memory budget
+type MemoryPressure struct {
+	Scope MemoryScope
+	// Hub names the hub for hub-scoped and message refusals
+	Hub   string
+	Bytes int64
+	Limit int64
+}
+
+// MemoryStats reports memory budget usage and refusals
+type MemoryStats struct {
+	InFlightBytes      int64            `json:"in_flight_bytes"`
+	BufferedBytes      int64            `json:"buffered_bytes"`
+	HubBytes           map[string]int64 `json:"hub_bytes"`
+	RejectedRequests   uint64           `json:"rejected_requests"`
+	ThrottledRequests  uint64           `json:"throttled_requests"`
+	DroppedMessages    uint64           `json:"dropped_messages"`
+	EvictedConnections uint64           `json:"evicted_connections"`
+}
+
+// memoryGuard enforces an engine's MemoryBudget
+type memoryGuard struct {
+	budget   MemoryBudget
+	inFlight atomic.Int64
+	buffered atomic.Int64
+
+	rejected  atomic.Uint64
+	throttled atomic.Uint64
+	dropped   atomic.Uint64
+	evicted   atomic.Uint64
+}
+
+// SetMemoryBudget enables memory guardrails for requests and the engine's WebSocket hubs
+func (e *Engine) SetMemoryBudget(budget MemoryBudget) *Engine {
+	guard := &memoryGuard{budget: budget}
+
+	e.lifecycleMux.Lock()
+	defer e.lifecycleMux.Unlock()
+	e.memory.Store(guard)
+	for _, hub := range e.hubs {
+		hub.setMemoryGuard(guard)
+	}
+	return e
+}
+
+// MemoryStats reports current usage against the memory budget
+func (e *Engine) MemoryStats() MemoryStats {
+	stats := MemoryStats{HubBytes: make(map[string]int64)}
+	guard := e.memory.Load()
+	if guard == nil {
+		return stats
+	}
+
+	e.lifecycleMux.Lock()
+	for _, hub := range e.hubs {
+		stats.HubBytes[hub.name] += hub.buffered.Load()
+	}
+	e.lifecycleMux.Unlock()
+
+	stats.InFlightBytes = guard.inFlight.Load()
+	stats.BufferedBytes = guard.buffered.Load()
+	stats.RejectedRequests = guard.rejected.Load()
+	stats.ThrottledRequests = guard.throttled.Load()
+	stats.DroppedMessages = guard.dropped.Load()
+	stats.EvictedConnections = guard.evicted.Load()
+	return stats
+}
+
+// memoryMiddleware enforces the request body budgets
+func (e *Engine) memoryMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		guard := e.memory.Load()
+		if guard == nil {
+			c.Next()
+			return
+		}
+		budget := guard.budget
+		size := c.Request.ContentLength
+
+		if budget.MaxRequestBytes > 0 {
+			if size > budget.MaxRequestBytes {
+				guard.rejected.Add(1)
+				guard.pressure(MemoryPressure{Scope: MemoryScopeRequest, Bytes: size, Limit: budget.MaxRequestBytes})
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+					"error": "request body too large",
+					"limit": budget.MaxRequestBytes,
+				})
+				return
+			}
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, budget.MaxRequestBytes)
+		}
+
+		if size <= 0 {
+			c.Next()
+			return
+		}
+		inFlight := guard.inFlight.Add(size)
+		defer guard.inFlight.Add(-size)
+
+		scope, limit := MemoryScope(""), int64(0)
+		if budget.MaxInFlightBytes > 0 && inFlight > budget.MaxInFlightBytes {
+			scope, limit = MemoryScopeRequest, budget.MaxInFlightBytes
+		} else if budget.MaxTotalBytes > 0 && inFlight+guard.buffered.Load() > budget.MaxTotalBytes {
+			scope, limit = MemoryScopeGlobal, budget.MaxTotalBytes
+		}
+		if scope != "" {
+			guard.throttled.Add(1)
+			guard.pressure(MemoryPressure{Scope: scope, Bytes: size, Limit: limit})
+			c.Header("Retry-After", strconv.Itoa(1))
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Server is busy, retry later"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func (g *memoryGuard) pressure(event MemoryPressure) {
+	if g.budget.OnPressure != nil {
+		g.budget.OnPressure(event)
+	}
+}
+
+// hubMemory tracks one hub's buffered bytes
+type hubMemory struct {
+	guard *memoryGuard
+	mutex sync.RWMutex
+}
+
+// setMemoryGuard attaches an engine's memory guard to the hub
+func (h *WebSocketHub) setMemoryGuard(guard *memoryGuard) {
+	h.memory.mutex.Lock()
+	defer h.memory.mutex.Unlock()
+	h.memory.guard = guard
+}
+
+// reserveMemory accounts for a message about to be queued for conn, or refuses it
+func (h *WebSocketHub) reserveMemory(conn *WebSocketConnection, size int) error {
+	h.memory.mutex.RLock()
+	guard := h.memory.guard
+	h.memory.mutex.RUnlock()
+
+	n := int64(size)
+	if guard != nil {
+		budget := guard.budget
+		scope, limit := MemoryScope(""), int64(0)
+		if budget.MaxHubBytes > 0 && h.buffered.Load()+n > budget.MaxHubBytes {
+			scope, limit = MemoryScopeHub, budget.MaxHubBytes
+		} else if budget.MaxTotalBytes > 0 && guard.inFlight.Load()+guard.buffered.Load()+n > budget.MaxTotalBytes {
+			scope, limit = MemoryScopeGlobal, budget.MaxTotalBytes
+		}
+		if scope != "" {
+			guard.dropped.Add(1)
+			guard.pressure(MemoryPressure{Scope: scope, Hub: h.name, Bytes: n, Limit: limit})
+			if budget.HubAction == MemoryEvictLargest {
+				// Callers may hold the hub lock, which eviction needs
+				go h.evictLargestBuffer(guard)
+			}
+			return ErrMemoryBudget
+		}
+	}
+
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	if conn.drained {
+		return ErrMemoryBudget
+	}
+	conn.buffered += n
+	h.buffered.Add(n)
+	if guard != nil {
+		guard.buffered.Add(n)
+	}
+	return nil
+}
+
+// releaseMemory accounts for size bytes written or discarded; a negative size releases
+// everything still buffered and stops accounting for the connection
+func (h *WebSocketHub) releaseMemory(conn *WebSocketConnection, size int) {
+	conn.mutex.Lock()
+	if conn.drained {
+		// Everything was released when the write pump stopped
+		conn.mutex.Unlock()
+		return
+	}
+	n := int64(size)
+	if size < 0 {
+		n = conn.buffered
+		conn.drained = true
+	}
+	conn.buffered -= n
+	conn.mutex.Unlock()
+
+	h.buffered.Add(-n)
+	h.memory.mutex.RLock()
+	if h.memory.guard != nil {
+		h.memory.guard.buffered.Add(-n)
+	}
+	h.memory.mutex.RUnlock()
+}
+
+// evictLargestBuffer closes the connection with the most buffered bytes
+func (h *WebSocketHub) evictLargestBuffer(guard *memoryGuard) {
+	var largest *WebSocketConnection
+	var largestBytes int64
+	for _, conn := range h.GetConnections() {
+		conn.mutex.RLock()
+		buffered := conn.buffered
+		conn.mutex.RUnlock()
+		if buffered > largestBytes {
+			largest, largestBytes = conn, buffered
+		}
+	}
+	if largest == nil {
+		return
+	}
+
+	guard.evicted.Add(1)
+	h.log().Warn("WebSocket connection evicted: memory budget exceeded", "connection_id", largest.ID, "buffered_bytes", largestBytes)
+	closeConnection(largest, CloseTryAgainLater, "memory budget exceeded")
+}
+
+// enqueue queues an encoded message without blocking, within the hub's memory budget
+func (conn *WebSocketConnection) enqueue(message []byte) error {
+	if err := conn.Hub.reserveMemory(conn, len(message)); err != nil {
+		return err
+	}
+	// The connection mutex keeps closeSend from closing the queue mid-send
+	conn.mutex.Lock()
+	sent, closed := false, conn.sendClosed
+	if !closed {
+		select {
+		case conn.send <- message:
+			sent = true
+		default:
+		}
+	}
+	conn.mutex.Unlock()
+	if sent {
+		return nil
+	}
+
+	conn.Hub.releaseMemory(conn, len(message))
+	if closed {
+		return errConnectionClosed
+	}
+	return errSendBufferFull
+}
+
+var (
+	// errSendBufferFull is returned when a connection's send queue is full
+	errSendBufferFull = errors.New("connection send channel is full")
+	// errConnectionClosed is returned when the hub has let go of a connection
+	errConnectionClosed = errors.New("connection is closed")
+)
@@ -0,0 +1,233 @@
+package supergin
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MessageDefinition describes the payload contract for a message type
+type MessageDefinition struct {
+	Type        string                 `json:"type"`
+	Description string                 `json:"description,omitempty"`
+	PayloadType reflect.Type           `json:"-"`
+	Schema      map[string]interface{} `json:"schema"`
+}
+
+// MessageRegistry maps message type strings to payload types. It is the single source
+// of payload contracts for typed WebSocket routing and generated documentation.
+type MessageRegistry struct {
+	messages map[string]*MessageDefinition
+	mutex    sync.RWMutex
+}
+
+// NewMessageRegistry creates an empty message registry
+func NewMessageRegistry() *MessageRegistry {
+	return &MessageRegistry{
+		messages: make(map[string]*MessageDefinition),
+	}
+}
+
+// Register maps a message type to the Go type of its payload
+func (r *MessageRegistry) Register(messageType string, payload interface{}, description ...string) *MessageRegistry {
+	payloadType := reflect.TypeOf(payload)
+	for payloadType != nil && payloadType.Kind() == reflect.Ptr {
+		payloadType = payloadType.Elem()
+	}
+
+	def := &MessageDefinition{
+		Type:        messageType,
+		PayloadType: payloadType,
+		Schema:      JSONSchema(payloadType),
+	}
+	if len(description) > 0 {
+		def.Description = description[0]
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.messages[messageType] = def
+	return r
+}
+
+// Lookup returns the definition for a message type
+func (r *MessageRegistry) Lookup(messageType string) (*MessageDefinition, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	def, exists := r.messages[messageType]
+	return def, exists
+}
+
+// List returns all message definitions ordered by type
+func (r *MessageRegistry) List() []*MessageDefinition {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	defs := make([]*MessageDefinition, 0, len(r.messages))
+	for _, def := range r.messages {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Type < defs[j].Type })
+	return defs
+}
+
+// Decode converts a loosely typed payload (as produced by json.Unmarshal into interface{})
+// into a pointer to the registered payload type
+func (r *MessageRegistry) Decode(messageType string, data interface{}) (interface{}, error) {
+	def, exists := r.Lookup(messageType)
+	if !exists {
+		return nil, NewSuperGinError(ErrUnknownMessageType, "message type '%s' not registered", messageType)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "failed to encode '%s' payload", messageType)
+	}
+
+	payload := reflect.New(def.PayloadType).Interface()
+	if err := json.Unmarshal(raw, payload); err != nil {
+		return nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "invalid '%s' payload", messageType)
+	}
+	return payload, nil
+}
+
+// Messages returns the engine's message registry
+func (e *Engine) Messages() *MessageRegistry {
+	return e.messages
+}
+
+// MessageHandlerFunc handles a decoded, validated message payload
+type MessageHandlerFunc func(conn *WebSocketConnection, payload interface{})
+
+// MessageRouter is a WebSocketHandler that dispatches messages by type, decoding and
+// validating payloads against the registry before invoking the handler
+type MessageRouter struct {
+	DefaultWebSocketHandler
+	registry  *MessageRegistry
+	validator *validator.Validate
+	prepare   func(reflect.Type)
+	handlers  map[string]MessageHandlerFunc
+	emits     []string
+	mutex     sync.RWMutex
+}
+
+// NewMessageRouter creates a typed message router backed by the registry
+func NewMessageRouter(registry *MessageRegistry) *MessageRouter {
+	return &MessageRouter{
+		registry:  registry,
+		validator: validator.New(),
+		handlers:  make(map[string]MessageHandlerFunc),
+	}
+}
+
+// MessageRouter creates a typed message router backed by the engine's registry
+func (e *Engine) MessageRouter() *MessageRouter {
+	router := NewMessageRouter(e.messages)
+	router.validator = e.validator
+	router.prepare = e.prepareValidation
+	return router
+}
+
+// On registers a handler for a message type. The type must be registered in the registry.
+func (mr *MessageRouter) On(messageType string, handler MessageHandlerFunc) *MessageRouter {
+	def, exists := mr.registry.Lookup(messageType)
+	if !exists {
+		panic(fmt.Sprintf("message type '%s' not registered", messageType))
+	}
+	if mr.prepare != nil {
+		mr.prepare(def.PayloadType)
+	}
+
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+	mr.handlers[messageType] = handler
+	return mr
+}
+
+// Emits declares message types the server sends to clients, for the AsyncAPI document.
+// The types must be registered in the registry.
+func (mr *MessageRouter) Emits(messageTypes ...string) *MessageRouter {
+	for _, messageType := range messageTypes {
+		if _, exists := mr.registry.Lookup(messageType); !exists {
+			panic(fmt.Sprintf("message type '%s' not registered", messageType))
+		}
+	}
+
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+	for _, messageType := range messageTypes {
+		if !contains(mr.emits, messageType) {
+			mr.emits = append(mr.emits, messageType)
+		}
+	}
+	return mr
+}
+
+// messageTypes returns the handled and emitted message types, sorted
+func (mr *MessageRouter) messageTypes() (handled, emitted []string) {
+	mr.mutex.RLock()
+	defer mr.mutex.RUnlock()
+
+	for messageType := range mr.handlers {
+		handled = append(handled, messageType)
+	}
+	emitted = append(emitted, mr.emits...)
+	sort.Strings(handled)
+	sort.Strings(emitted)
+	return handled, emitted
+}
+
+// OnMessage implements WebSocketHandler
+func (mr *MessageRouter) OnMessage(conn *WebSocketConnection, messageType string, data interface{}) {
+	mr.mutex.RLock()
+	handler, exists := mr.handlers[messageType]
+	mr.mutex.RUnlock()
+
+	if !exists {
+		if mr.OnMessageFunc != nil {
+			mr.OnMessageFunc(conn, messageType, data)
+			return
+		}
+		conn.Hub.log().Warn("WebSocket message type has no handler", "connection_id", conn.ID, "message_type", messageType)
+		return
+	}
+
+	payload, err := mr.decode(messageType, data)
+	if err != nil {
+		conn.Send("error", map[string]interface{}{
+			"type":    messageType,
+			"error":   "invalid message payload",
+			"details": err.Error(),
+		})
+		mr.OnError(conn, err)
+		return
+	}
+
+	handler(conn, payload)
+}
+
+// decode decodes and validates a message payload against the registry
+func (mr *MessageRouter) decode(messageType string, data interface{}) (interface{}, error) {
+	payload, err := mr.registry.Decode(messageType, data)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.TypeOf(payload).Elem().Kind() == reflect.Struct {
+		if err := validateStruct(mr.validator, payload); err != nil {
+			return nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "invalid '%s' payload", messageType)
+		}
+	}
+	return payload, nil
+}
+
+// OnTyped registers a handler receiving the payload as *T
+func OnTyped[T any](mr *MessageRouter, messageType string, handler func(conn *WebSocketConnection, payload *T)) *MessageRouter {
+	return mr.On(messageType, func(conn *WebSocketConnection, payload interface{}) {
+		handler(conn, payload.(*T))
+	})
+}
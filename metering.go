@@ -0,0 +1,377 @@
+package supergin
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UsagePath is where EnableUsageEndpoints mounts the self-service usage routes
+const UsagePath = "/_usage"
+
+// DefaultRecentErrors is how many failed requests are kept per client
+const DefaultRecentErrors = 20
+
+const (
+	// clientIDKey stores the identified API client in the gin context
+	clientIDKey = "supergin:client_id"
+	// throttledKey marks requests the metering quota rejected
+	throttledKey = "supergin:throttled"
+)
+
+// RateLimit allows Requests per Window
+type RateLimit struct {
+	Requests int           `json:"requests"`
+	Window   time.Duration `json:"window"`
+}
+
+// MeteringOptions configures per-client usage metering
+type MeteringOptions struct {
+	// Identify names the client making a request, e.g. the owner of its API key. It runs
+	// after the route's authentication and middleware, so clients identified by
+	// authentication middleware via SetClientID are metered. Nil uses ClientID.
+	Identify func(c *gin.Context) string
+	// Quota, when set, limits each client's requests across all routes; clients over it
+	// get 429 once authenticated, just before the handler runs
+	Quota *RateLimit
+	// RecentErrors is how many failed requests are kept per client; zero means
+	// DefaultRecentErrors
+	RecentErrors int
+}
+
+// ClientUsage is a client's metered usage
+type ClientUsage struct {
+	Client       string            `json:"client"`
+	Requests     uint64            `json:"requests"`
+	ClientErrors uint64            `json:"client_errors"`
+	ServerErrors uint64            `json:"server_errors"`
+	Throttled    uint64            `json:"throttled"`
+	Routes       map[string]uint64 `json:"routes"`
+	FirstSeen    time.Time         `json:"first_seen"`
+	LastSeen     time.Time         `json:"last_seen"`
+	RateLimit    *RateLimitStatus  `json:"rate_limit,omitempty"`
+}
+
+// RateLimitStatus is a client's standing against its quota
+type RateLimitStatus struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Window    string    `json:"window"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// UsageError is a request of a client that failed with a 4xx or 5xx status
+type UsageError struct {
+	Time      time.Time `json:"time"`
+	Route     string    `json:"route,omitempty"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	RequestID string    `json:"request_id,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// SetClientID identifies the API client making the request, for metering; authentication
+// middleware calls it once the credentials are verified
+func SetClientID(c *gin.Context, client string) {
+	c.Set(clientIDKey, client)
+}
+
+// ClientID returns the API client identified for the request, or ""
+func ClientID(c *gin.Context) string {
+	return c.GetString(clientIDKey)
+}
+
+// meter collects an engine's per-client usage
+type meter struct {
+	options MeteringOptions
+	clients map[string]*clientMeter
+	mutex   sync.Mutex
+}
+
+type clientMeter struct {
+	usage  ClientUsage
+	errors []UsageError
+	window fixedWindow
+}
+
+// fixedWindow counts requests in consecutive windows of a RateLimit
+type fixedWindow struct {
+	start time.Time
+	count int
+}
+
+// allow counts a request, reporting whether it is within the limit
+func (w *fixedWindow) allow(limit RateLimit, now time.Time) bool {
+	if now.Sub(w.start) >= limit.Window {
+		w.start, w.count = now.Truncate(limit.Window), 0
+	}
+	if w.count >= limit.Requests {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// status reports the window's standing against limit
+func (w *fixedWindow) status(limit RateLimit, now time.Time) RateLimitStatus {
+	count := w.count
+	if now.Sub(w.start) >= limit.Window {
+		count = 0
+	}
+	return RateLimitStatus{
+		Limit:     limit.Requests,
+		Remaining: max(limit.Requests-count, 0),
+		Window:    limit.Window.String(),
+		ResetAt:   w.start.Add(limit.Window),
+	}
+}
+
+// EnableMetering starts collecting per-client usage on every route: request counts per
+// route, 4xx and 5xx counts, recent failures and, with a Quota, rate-limit standing.
+func (e *Engine) EnableMetering(opts ...MeteringOptions) *Engine {
+	options := MeteringOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Identify == nil {
+		options.Identify = ClientID
+	}
+	if options.RecentErrors <= 0 {
+		options.RecentErrors = DefaultRecentErrors
+	}
+	if options.Quota != nil && (options.Quota.Requests <= 0 || options.Quota.Window <= 0) {
+		panic("metering quota requires positive Requests and Window")
+	}
+
+	e.meter.Store(&meter{options: options, clients: make(map[string]*clientMeter)})
+	return e
+}
+
+// Usage returns a client's metered usage
+func (e *Engine) Usage(client string) (ClientUsage, bool) {
+	m := e.meter.Load()
+	if m == nil {
+		return ClientUsage{}, false
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	cm, exists := m.clients[client]
+	if !exists {
+		return ClientUsage{}, false
+	}
+	usage := cm.usage
+	usage.Routes = make(map[string]uint64, len(cm.usage.Routes))
+	for route, n := range cm.usage.Routes {
+		usage.Routes[route] = n
+	}
+	if quota := m.options.Quota; quota != nil {
+		status := cm.window.status(*quota, time.Now())
+		usage.RateLimit = &status
+	}
+	return usage, true
+}
+
+// RecentErrors returns a client's most recent failed requests, newest first
+func (e *Engine) RecentErrors(client string) []UsageError {
+	m := e.meter.Load()
+	if m == nil {
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	cm, exists := m.clients[client]
+	if !exists {
+		return []UsageError{}
+	}
+	errs := make([]UsageError, len(cm.errors))
+	for i, ue := range cm.errors {
+		errs[len(errs)-1-i] = ue
+	}
+	return errs
+}
+
+// EnableUsageEndpoints registers GET /_usage, reporting the calling client's usage and
+// rate-limit standing, and GET /_usage/errors, listing its recent failed requests. The
+// auth middleware must identify the client, e.g. with SetClientID; unidentified requests
+// get 401. Metering is enabled with defaults if it is not already.
+func (e *Engine) EnableUsageEndpoints(auth gin.HandlerFunc, middleware ...gin.HandlerFunc) *Engine {
+	if auth == nil {
+		panic("usage endpoints require an authentication middleware")
+	}
+	if e.meter.Load() == nil {
+		e.EnableMetering()
+	}
+	handlers := append([]gin.HandlerFunc{auth}, middleware...)
+
+	e.Named("usage").
+		GET(UsagePath).
+		WithDescription("Usage counters and rate-limit status of the calling API client").
+		WithTags("usage").
+		WithMiddleware(handlers...).
+		WithOutput(ClientUsage{}).
+		Handler(func(c *gin.Context) {
+			client, ok := e.usageClient(c)
+			if !ok {
+				return
+			}
+			usage, exists := e.Usage(client)
+			if !exists {
+				usage = ClientUsage{Client: client, Routes: map[string]uint64{}}
+			}
+			c.JSON(http.StatusOK, usage)
+		})
+
+	e.Named("usage_errors").
+		GET(UsagePath + "/errors").
+		WithDescription("Recent failed requests of the calling API client").
+		WithTags("usage").
+		WithMiddleware(handlers...).
+		WithOutput([]UsageError{}).
+		Handler(func(c *gin.Context) {
+			client, ok := e.usageClient(c)
+			if !ok {
+				return
+			}
+			c.JSON(http.StatusOK, e.RecentErrors(client))
+		})
+
+	return e
+}
+
+// usageClient identifies the client calling a usage endpoint, responding 401 when unknown
+func (e *Engine) usageClient(c *gin.Context) (string, bool) {
+	client := ""
+	if m := e.meter.Load(); m != nil {
+		client = m.options.Identify(c)
+	}
+	if client == "" {
+		client = ClientID(c)
+	}
+	if client == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "API client not identified"})
+		return "", false
+	}
+	return client, true
+}
+
+// meteringMiddleware records each request's usage once it has finished, when route
+// middleware has identified the client
+func (e *Engine) meteringMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		m := e.meter.Load()
+		if m == nil {
+			return
+		}
+		if client := m.options.Identify(c); client != "" {
+			m.record(c, client, c.GetBool(throttledKey))
+		}
+	}
+}
+
+// quotaMiddleware enforces the metering quota. It is part of every route's chain, after
+// authentication and route middleware, so clients are identified and metering can be
+// enabled after routes are registered.
+func (e *Engine) quotaMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		m := e.meter.Load()
+		if m == nil || m.options.Quota == nil {
+			c.Next()
+			return
+		}
+		client := m.options.Identify(c)
+		if client == "" {
+			c.Next()
+			return
+		}
+
+		status, allowed := m.take(client)
+		c.Header("X-RateLimit-Limit", strconv.Itoa(status.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(status.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(status.ResetAt.Unix(), 10))
+		if !allowed {
+			retryAfter := int(time.Until(status.ResetAt).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.Set(throttledKey, true)
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":       "Rate limit exceeded",
+				"retry_after": retryAfter,
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// take counts a request against the client's quota
+func (m *meter) take(client string) (RateLimitStatus, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	cm := m.client(client, now)
+	allowed := cm.window.allow(*m.options.Quota, now)
+	return cm.window.status(*m.options.Quota, now), allowed
+}
+
+// record adds a finished request to the client's usage
+func (m *meter) record(c *gin.Context, client string, throttled bool) {
+	status := c.Writer.Status()
+	route := c.GetString(routeNameKey)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	cm := m.client(client, now)
+	usage := &cm.usage
+	usage.Requests++
+	usage.LastSeen = now
+	if route != "" {
+		usage.Routes[route]++
+	}
+	if throttled {
+		usage.Throttled++
+	}
+	switch {
+	case status >= 500:
+		usage.ServerErrors++
+	case status >= 400:
+		usage.ClientErrors++
+	default:
+		return
+	}
+
+	ue := UsageError{
+		Time:      now,
+		Route:     route,
+		Method:    c.Request.Method,
+		Path:      c.Request.URL.Path,
+		Status:    status,
+		RequestID: RequestID(c),
+	}
+	if err := c.Errors.Last(); err != nil {
+		ue.Error = err.Error()
+	}
+	cm.errors = append(cm.errors, ue)
+	if len(cm.errors) > m.options.RecentErrors {
+		cm.errors = cm.errors[len(cm.errors)-m.options.RecentErrors:]
+	}
+}
+
+// client returns a client's meter, creating it on first use; m.mutex must be held
+func (m *meter) client(client string, now time.Time) *clientMeter {
+	cm, exists := m.clients[client]
+	if !exists {
+		cm = &clientMeter{usage: ClientUsage{Client: client, Routes: make(map[string]uint64), FirstSeen: now}}
+		m.clients[client] = cm
+	}
+	return cm
+}
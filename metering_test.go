@@ -0,0 +1,64 @@
+package supergin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMeteringQuotaAfterRouteAuth(t *testing.T) {
+	app := newTestEngine(Config{})
+	app.RegisterAuthScheme("apikey", APIKeyAuth(APIKeyOptions{Store: StaticAPIKeys{
+		"alice-key": {Client: "alice"},
+		"bob-key":   {Client: "bob"},
+	}}))
+	app.EnableMetering(MeteringOptions{Quota: &RateLimit{Requests: 2, Window: time.Minute}})
+	app.Named("list_invoices").GET("/invoices").WithAuth("apikey").Handler(func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	steps := []struct {
+		apiKey string
+		want   int
+	}{
+		{"", http.StatusUnauthorized},
+		{"alice-key", http.StatusOK},
+		{"alice-key", http.StatusOK},
+		{"alice-key", http.StatusTooManyRequests},
+		{"bob-key", http.StatusOK},
+	}
+	for i, step := range steps {
+		req := httptest.NewRequest(http.MethodGet, "/invoices", nil)
+		if step.apiKey != "" {
+			req.Header.Set(DefaultAPIKeyHeader, step.apiKey)
+		}
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		if w.Code != step.want {
+			t.Errorf("request %d with key %q = %d, want %d", i, step.apiKey, w.Code, step.want)
+		}
+		if step.want == http.StatusOK && w.Header().Get("X-RateLimit-Limit") != "2" {
+			t.Errorf("request %d has no rate limit headers", i)
+		}
+	}
+
+	usage, ok := app.Usage("alice")
+	if !ok {
+		t.Fatal("alice has no usage")
+	}
+	if usage.Requests != 3 || usage.Throttled != 1 || usage.ClientErrors != 1 || usage.Routes["list_invoices"] != 3 {
+		t.Errorf("alice's usage = %+v, want 3 requests, 1 throttled", usage)
+	}
+	if usage.RateLimit == nil || usage.RateLimit.Remaining != 0 {
+		t.Errorf("alice's rate limit = %+v, want none remaining", usage.RateLimit)
+	}
+	if errs := app.RecentErrors("alice"); len(errs) != 1 || errs[0].Status != http.StatusTooManyRequests {
+		t.Errorf("alice's recent errors = %+v, want the 429", errs)
+	}
+	if usage, _ := app.Usage("bob"); usage.Requests != 1 {
+		t.Errorf("bob's usage = %+v, want 1 request", usage)
+	}
+}
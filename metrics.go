@@ -0,0 +1,302 @@
+package supergin
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RouteMetrics holds request counters for a named route
+type RouteMetrics struct {
+	Route        string            `json:"route"`
+	RouteID      string            `json:"route_id,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Requests     uint64            `json:"requests"`
+	Errors       uint64            `json:"errors"`
+	SLOBreaches  uint64            `json:"slo_breaches"`
+	TotalLatency time.Duration     `json:"total_latency"`
+	MaxLatency   time.Duration     `json:"max_latency"`
+	LastSeen     time.Time         `json:"last_seen"`
+	// Stages profiles the request pipeline: time spent in each stage, excluding the
+	// stages it called
+	Stages map[string]StageMetrics `json:"stages,omitempty"`
+	// ValidationFailures counts requests rejected by input validation
+	ValidationFailures uint64 `json:"validation_failures"`
+	// Rejections counts validation failures per input field, with array indices and map
+	// keys collapsed, e.g. "items[].sku"
+	Rejections map[string]FieldRejections `json:"rejections,omitempty"`
+}
+
+// FieldRejections counts the validation failures of one input field
+type FieldRejections struct {
+	Field string `json:"field"`
+	Count uint64 `json:"count"`
+	// Rules counts the failures by rule, e.g. "required" or "email"
+	Rules map[string]uint64 `json:"rules,omitempty"`
+}
+
+// StageMetrics accumulates the time requests spent in one pipeline stage
+type StageMetrics struct {
+	Runs         uint64        `json:"runs"`
+	TotalLatency time.Duration `json:"total_latency"`
+	MaxLatency   time.Duration `json:"max_latency"`
+}
+
+// MetricsRegistry collects per-route request metrics
+type MetricsRegistry struct {
+	routes map[string]*RouteMetrics
+	mutex  sync.RWMutex
+}
+
+// NewMetricsRegistry creates an empty metrics registry
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{
+		routes: make(map[string]*RouteMetrics),
+	}
+}
+
+// SetLabels attaches static labels (e.g. SLO budgets) to a route's metrics
+func (m *MetricsRegistry) SetLabels(route string, labels map[string]string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	metrics := m.getOrCreate(route)
+	if metrics.Labels == nil {
+		metrics.Labels = make(map[string]string)
+	}
+	for k, v := range labels {
+		metrics.Labels[k] = v
+	}
+}
+
+// SetRouteID records the stable ID of a route so metrics can be joined with logs and traces
+func (m *MetricsRegistry) SetRouteID(route, id string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.getOrCreate(route).RouteID = id
+}
+
+// Observe records a completed request for a route
+func (m *MetricsRegistry) Observe(route string, status int, latency time.Duration, sloBreached bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	metrics := m.getOrCreate(route)
+	metrics.Requests++
+	if status >= 500 {
+		metrics.Errors++
+	}
+	if sloBreached {
+		metrics.SLOBreaches++
+	}
+	metrics.TotalLatency += latency
+	if latency > metrics.MaxLatency {
+		metrics.MaxLatency = latency
+	}
+	metrics.LastSeen = time.Now()
+}
+
+// ObserveStages records the time a request spent in each pipeline stage that ran
+func (m *MetricsRegistry) ObserveStages(route string, timings []StageTiming) {
+	if len(timings) == 0 {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	metrics := m.getOrCreate(route)
+	if metrics.Stages == nil {
+		metrics.Stages = make(map[string]StageMetrics)
+	}
+	for _, timing := range timings {
+		stage := metrics.Stages[timing.Stage]
+		stage.Runs++
+		stage.TotalLatency += timing.Duration
+		if timing.Duration > stage.MaxLatency {
+			stage.MaxLatency = timing.Duration
+		}
+		metrics.Stages[timing.Stage] = stage
+	}
+}
+
+// ObserveValidation records a request rejected by input validation and the fields that
+// failed; binding errors carry no fields
+func (m *MetricsRegistry) ObserveValidation(route string, fields FieldErrors) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	metrics := m.getOrCreate(route)
+	metrics.ValidationFailures++
+	if len(fields) == 0 {
+		return
+	}
+	if metrics.Rejections == nil {
+		metrics.Rejections = make(map[string]FieldRejections)
+	}
+	for _, fe := range fields {
+		field := collapseFieldPath(fe.Field)
+		rejections := metrics.Rejections[field]
+		rejections.Field = field
+		rejections.Count++
+		if fe.Rule != "" {
+			if rejections.Rules == nil {
+				rejections.Rules = make(map[string]uint64)
+			}
+			rejections.Rules[fe.Rule]++
+		}
+		metrics.Rejections[field] = rejections
+	}
+}
+
+// Get returns a copy of the metrics for a route
+func (m *MetricsRegistry) Get(route string) (RouteMetrics, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	metrics, exists := m.routes[route]
+	if !exists {
+		return RouteMetrics{}, false
+	}
+	return metrics.copy(), true
+}
+
+// Snapshot returns a copy of all route metrics
+func (m *MetricsRegistry) Snapshot() map[string]RouteMetrics {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	snapshot := make(map[string]RouteMetrics)
+	for k, v := range m.routes {
+		snapshot[k] = v.copy()
+	}
+	return snapshot
+}
+
+// WritePrometheus writes the registry in the Prometheus text exposition format, one series
+// per route labeled by route name and ID
+func (m *MetricsRegistry) WritePrometheus(w io.Writer) error {
+	snapshot := m.Snapshot()
+	names := make([]string, 0, len(snapshot))
+	for name := range snapshot {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	families := []struct {
+		name, kind, help string
+		value            func(RouteMetrics) float64
+	}{
+		{"supergin_route_requests_total", "counter", "Requests handled by the route",
+			func(rm RouteMetrics) float64 { return float64(rm.Requests) }},
+		{"supergin_route_errors_total", "counter", "Requests answered with a 5xx status",
+			func(rm RouteMetrics) float64 { return float64(rm.Errors) }},
+		{"supergin_route_slo_breaches_total", "counter", "Requests that breached the route's SLO",
+			func(rm RouteMetrics) float64 { return float64(rm.SLOBreaches) }},
+		{"supergin_route_latency_seconds_total", "counter", "Total time spent handling requests",
+			func(rm RouteMetrics) float64 { return rm.TotalLatency.Seconds() }},
+		{"supergin_route_latency_seconds_max", "gauge", "Slowest request handled by the route",
+			func(rm RouteMetrics) float64 { return rm.MaxLatency.Seconds() }},
+		{"supergin_route_validation_failures_total", "counter", "Requests rejected by input validation",
+			func(rm RouteMetrics) float64 { return float64(rm.ValidationFailures) }},
+	}
+
+	var b strings.Builder
+	for _, family := range families {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", family.name, family.help, family.name, family.kind)
+		for _, name := range names {
+			rm := snapshot[name]
+			fmt.Fprintf(&b, "%s{route=%s,route_id=%s} %s\n", family.name,
+				strconv.Quote(name), strconv.Quote(rm.RouteID),
+				strconv.FormatFloat(family.value(rm), 'g', -1, 64))
+		}
+	}
+
+	stageFamilies := []struct {
+		name, help string
+		value      func(StageMetrics) float64
+	}{
+		{"supergin_route_stage_runs_total", "Requests that ran the pipeline stage",
+			func(sm StageMetrics) float64 { return float64(sm.Runs) }},
+		{"supergin_route_stage_seconds_total", "Time spent in the pipeline stage, excluding later stages",
+			func(sm StageMetrics) float64 { return sm.TotalLatency.Seconds() }},
+	}
+	for _, family := range stageFamilies {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s counter\n", family.name, family.help, family.name)
+		for _, name := range names {
+			rm := snapshot[name]
+			for _, stage := range DefaultStages {
+				sm, ran := rm.Stages[stage]
+				if !ran {
+					continue
+				}
+				fmt.Fprintf(&b, "%s{route=%s,route_id=%s,stage=%s} %s\n", family.name,
+					strconv.Quote(name), strconv.Quote(rm.RouteID), strconv.Quote(stage),
+					strconv.FormatFloat(family.value(sm), 'g', -1, 64))
+			}
+		}
+	}
+
+	b.WriteString("# HELP supergin_route_field_rejections_total Input field validation failures by rule\n")
+	b.WriteString("# TYPE supergin_route_field_rejections_total counter\n")
+	for _, name := range names {
+		rm := snapshot[name]
+		for _, rejections := range sortedRejections(rm.Rejections) {
+			rules := make([]string, 0, len(rejections.Rules))
+			for rule := range rejections.Rules {
+				rules = append(rules, rule)
+			}
+			sort.Strings(rules)
+			for _, rule := range rules {
+				fmt.Fprintf(&b, "supergin_route_field_rejections_total{route=%s,route_id=%s,field=%s,rule=%s} %d\n",
+					strconv.Quote(name), strconv.Quote(rm.RouteID), strconv.Quote(rejections.Field),
+					strconv.Quote(rule), rejections.Rules[rule])
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (m *MetricsRegistry) getOrCreate(route string) *RouteMetrics {
+	metrics, exists := m.routes[route]
+	if !exists {
+		metrics = &RouteMetrics{Route: route}
+		m.routes[route] = metrics
+	}
+	return metrics
+}
+
+func (rm *RouteMetrics) copy() RouteMetrics {
+	c := *rm
+	if rm.Labels != nil {
+		c.Labels = make(map[string]string)
+		for k, v := range rm.Labels {
+			c.Labels[k] = v
+		}
+	}
+	if rm.Stages != nil {
+		c.Stages = make(map[string]StageMetrics, len(rm.Stages))
+		for k, v := range rm.Stages {
+			c.Stages[k] = v
+		}
+	}
+	if rm.Rejections != nil {
+		c.Rejections = make(map[string]FieldRejections, len(rm.Rejections))
+		for k, v := range rm.Rejections {
+			if v.Rules != nil {
+				rules := make(map[string]uint64, len(v.Rules))
+				for rule, count := range v.Rules {
+					rules[rule] = count
+				}
+				v.Rules = rules
+			}
+			c.Rejections[k] = v
+		}
+	}
+	return c
+}
@@ -0,0 +1,136 @@
+package supergin
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware is implemented by struct-based middleware whose dependencies are
+// injected from the DI container through `inject:"serviceName"` field tags.
+//
+//	type AuditMiddleware struct {
+//		Logger Logger `inject:"logger"`
+//	}
+//
+//	func (m *AuditMiddleware) Handle(c *gin.Context) { ... }
+type Middleware interface {
+	Handle(c *gin.Context)
+}
+
+// MiddlewareHandler adapts struct-based middleware into a gin.HandlerFunc.
+// Middleware that depends only on singletons is injected once; middleware that depends
+// on request-scoped or transient services is copied and injected on every request.
+func (di *DIContainer) MiddlewareHandler(mw Middleware) gin.HandlerFunc {
+	mwValue := reflect.ValueOf(mw)
+	if mwValue.Kind() != reflect.Ptr || mwValue.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("struct middleware must be a pointer to a struct, got %T", mw))
+	}
+	structType := mwValue.Elem().Type()
+
+	if !di.needsRequestScope(structType) {
+		if err := di.Inject(mw); err != nil {
+			panic(fmt.Sprintf("failed to inject middleware %s: %v", structType, err))
+		}
+		return mw.Handle
+	}
+
+	return func(c *gin.Context) {
+		// Copy the configured middleware so per-request injection doesn't race
+		instance := reflect.New(structType)
+		instance.Elem().Set(mwValue.Elem())
+
+		if err := di.InjectFromContext(c, instance.Interface()); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error":   "Middleware injection failed",
+				"details": err.Error(),
+			})
+			return
+		}
+		instance.Interface().(Middleware).Handle(c)
+	}
+}
+
+// UseMiddleware attaches struct-based middleware globally
+func (e *Engine) UseMiddleware(middleware ...Middleware) *Engine {
+	for _, mw := range middleware {
+		e.Use(e.di.MiddlewareHandler(mw))
+	}
+	return e
+}
+
+// WithMiddlewareStruct adds struct-based middleware to the route
+func (rb *RouteBuilder) WithMiddlewareStruct(middleware ...Middleware) *RouteBuilder {
+	for _, mw := range middleware {
+		rb.middleware = append(rb.middleware, rb.engine.di.MiddlewareHandler(mw))
+	}
+	return rb
+}
+
+// MiddlewarePredicate decides per request whether conditional middleware runs
+type MiddlewarePredicate func(c *gin.Context) bool
+
+// When wraps middleware so it only runs when the predicate matches
+func When(predicate MiddlewarePredicate, middleware gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if predicate(c) {
+			middleware(c)
+		}
+	}
+}
+
+// UseIf attaches global middleware that only runs when the predicate matches
+func (e *Engine) UseIf(predicate MiddlewarePredicate, middleware ...gin.HandlerFunc) *Engine {
+	for _, mw := range middleware {
+		e.Use(When(predicate, mw))
+	}
+	return e
+}
+
+// UseForTags attaches middleware to every route carrying the tag. Routes take their
+// middleware when registered, so it must be called before the first route with the tag;
+// calling it later panics instead of leaving those routes without it.
+func (e *Engine) UseForTags(tag string, middleware ...gin.HandlerFunc) *Engine {
+	e.routesMux.RLock()
+	for _, route := range e.routes {
+		if slices.Contains(route.Tags, tag) {
+			e.routesMux.RUnlock()
+			panic(fmt.Sprintf("UseForTags(%q) must be called before route '%s' with the tag is registered", tag, route.Name))
+		}
+	}
+	e.routesMux.RUnlock()
+
+	e.tagMiddlewareMux.Lock()
+	defer e.tagMiddlewareMux.Unlock()
+
+	e.tagMiddleware[tag] = append(e.tagMiddleware[tag], middleware...)
+	return e
+}
+
+// middlewareForTags returns the tag-bound middleware for a route, in tag order
+func (e *Engine) middlewareForTags(tags []string) []gin.HandlerFunc {
+	e.tagMiddlewareMux.RLock()
+	defer e.tagMiddlewareMux.RUnlock()
+
+	var middleware []gin.HandlerFunc
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		middleware = append(middleware, e.tagMiddleware[tag]...)
+	}
+	return middleware
+}
+
+// WithMiddlewareIf adds middleware to the route that only runs when the predicate matches
+func (rb *RouteBuilder) WithMiddlewareIf(predicate MiddlewarePredicate, middleware ...gin.HandlerFunc) *RouteBuilder {
+	for _, mw := range middleware {
+		rb.middleware = append(rb.middleware, When(predicate, mw))
+	}
+	return rb
+}
@@ -0,0 +1,39 @@
+package supergin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestUseForTags(t *testing.T) {
+	app := newTestEngine(Config{})
+	app.UseForTags("admin", func(c *gin.Context) {
+		if c.GetHeader("X-Admin") == "" {
+			c.AbortWithStatus(http.StatusForbidden)
+		}
+	})
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	app.Named("list_users").GET("/admin/users").WithTags("admin").Handler(ok)
+	app.Named("health").GET("/health").Handler(ok)
+
+	for path, want := range map[string]int{"/admin/users": http.StatusForbidden, "/health": http.StatusOK} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != want {
+			t.Errorf("GET %s = %d, want %d", path, w.Code, want)
+		}
+	}
+
+	// Tags without routes yet can still get middleware
+	app.UseForTags("reports", ok)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("UseForTags after a tagged route was registered did not panic")
+		}
+	}()
+	app.UseForTags("admin", ok)
+}
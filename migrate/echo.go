@@ -0,0 +1,75 @@
+package migrate
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivikasavnish/supergin"
+)
+
+// EchoContext mirrors the commonly used subset of echo.Context
+type EchoContext interface {
+	Request() *http.Request
+	Response() http.ResponseWriter
+	Param(name string) string
+	QueryParam(name string) string
+	FormValue(name string) string
+	Get(key string) interface{}
+	Set(key string, value interface{})
+	Bind(i interface{}) error
+	Validate(i interface{}) error
+	JSON(code int, i interface{}) error
+	String(code int, s string) error
+	Blob(code int, contentType string, b []byte) error
+	NoContent(code int) error
+	Redirect(code int, url string) error
+	// Gin exposes the underlying gin context
+	Gin() *gin.Context
+}
+
+// EchoHandler is the Echo handler signature
+type EchoHandler func(c EchoContext) error
+
+// Echo adapts an Echo-style handler to a gin handler
+func Echo(handler EchoHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		handleError(c, handler(&echoContext{c: c}))
+	}
+}
+
+// EchoTyped adapts an Echo-style handler taking a bound request onto SuperGin's typed
+// handlers, so the route gets input validation and documented input/output types
+func EchoTyped[Req, Resp any](handler func(c EchoContext, req *Req) (*Resp, error)) supergin.TypedHandlerFunc {
+	return supergin.Handle(func(c *gin.Context, req *Req) (*Resp, error) {
+		return handler(&echoContext{c: c}, req)
+	})
+}
+
+// echoContext implements EchoContext on a gin context
+type echoContext struct {
+	c *gin.Context
+}
+
+func (e *echoContext) Request() *http.Request              { return e.c.Request }
+func (e *echoContext) Response() http.ResponseWriter       { return e.c.Writer }
+func (e *echoContext) Param(name string) string            { return e.c.Param(name) }
+func (e *echoContext) QueryParam(name string) string       { return e.c.Query(name) }
+func (e *echoContext) FormValue(name string) string        { return e.c.PostForm(name) }
+func (e *echoContext) Set(key string, value interface{})   { e.c.Set(key, value) }
+func (e *echoContext) Bind(i interface{}) error            { return bind(e.c, i) }
+func (e *echoContext) Validate(i interface{}) error        { return validate.Struct(i) }
+func (e *echoContext) Gin() *gin.Context                   { return e.c }
+func (e *echoContext) JSON(code int, i interface{}) error  { e.c.JSON(code, i); return nil }
+func (e *echoContext) String(code int, s string) error     { e.c.String(code, "%s", s); return nil }
+func (e *echoContext) NoContent(code int) error            { e.c.Status(code); return nil }
+func (e *echoContext) Redirect(code int, url string) error { e.c.Redirect(code, url); return nil }
+
+func (e *echoContext) Get(key string) interface{} {
+	value, _ := e.c.Get(key)
+	return value
+}
+
+func (e *echoContext) Blob(code int, contentType string, b []byte) error {
+	e.c.Data(code, contentType, b)
+	return nil
+}
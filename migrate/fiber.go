@@ -0,0 +1,154 @@
+package migrate
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ivikasavnish/supergin"
+)
+
+// FiberCtx mirrors the commonly used subset of *fiber.Ctx
+type FiberCtx struct {
+	c      *gin.Context
+	status int
+}
+
+// FiberHandler is the Fiber handler signature
+type FiberHandler func(c *FiberCtx) error
+
+// Fiber adapts a Fiber-style handler to a gin handler. Calling Next continues the gin chain.
+func Fiber(handler FiberHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		handleError(c, handler(newFiberCtx(c)))
+	}
+}
+
+// FiberTyped adapts a Fiber-style handler taking a bound request onto SuperGin's typed
+// handlers, so the route gets input validation and documented input/output types
+func FiberTyped[Req, Resp any](handler func(c *FiberCtx, req *Req) (*Resp, error)) supergin.TypedHandlerFunc {
+	return supergin.Handle(func(c *gin.Context, req *Req) (*Resp, error) {
+		return handler(newFiberCtx(c), req)
+	})
+}
+
+func newFiberCtx(c *gin.Context) *FiberCtx {
+	return &FiberCtx{c: c, status: 200}
+}
+
+// Gin exposes the underlying gin context
+func (f *FiberCtx) Gin() *gin.Context { return f.c }
+
+// Method returns the request method
+func (f *FiberCtx) Method() string { return f.c.Request.Method }
+
+// Path returns the request path
+func (f *FiberCtx) Path() string { return f.c.Request.URL.Path }
+
+// Params returns a path parameter, or defaultValue when it is empty
+func (f *FiberCtx) Params(key string, defaultValue ...string) string {
+	return orDefault(f.c.Param(key), defaultValue)
+}
+
+// Query returns a query parameter, or defaultValue when it is empty
+func (f *FiberCtx) Query(key string, defaultValue ...string) string {
+	return orDefault(f.c.Query(key), defaultValue)
+}
+
+// FormValue returns a form value, or defaultValue when it is empty
+func (f *FiberCtx) FormValue(key string, defaultValue ...string) string {
+	return orDefault(f.c.PostForm(key), defaultValue)
+}
+
+// Get returns a request header, or defaultValue when it is empty
+func (f *FiberCtx) Get(key string, defaultValue ...string) string {
+	return orDefault(f.c.GetHeader(key), defaultValue)
+}
+
+// Set sets a response header
+func (f *FiberCtx) Set(key, value string) { f.c.Header(key, value) }
+
+// Locals gets, or with a value sets, a request-scoped value
+func (f *FiberCtx) Locals(key string, value ...interface{}) interface{} {
+	if len(value) > 0 {
+		f.c.Set(key, value[0])
+		return value[0]
+	}
+	v, _ := f.c.Get(key)
+	return v
+}
+
+// Body returns the raw request body
+func (f *FiberCtx) Body() []byte {
+	body, _ := f.c.GetRawData()
+	return body
+}
+
+// BodyParser binds the request body into out
+func (f *FiberCtx) BodyParser(out interface{}) error {
+	if err := f.c.ShouldBind(out); err != nil {
+		return NewHTTPError(400, err.Error())
+	}
+	return nil
+}
+
+// QueryParser binds the query string into out
+func (f *FiberCtx) QueryParser(out interface{}) error {
+	if err := f.c.ShouldBindQuery(out); err != nil {
+		return NewHTTPError(400, err.Error())
+	}
+	return nil
+}
+
+// Validate validates a struct with its validate tags
+func (f *FiberCtx) Validate(out interface{}) error { return validate.Struct(out) }
+
+// Status sets the status used by the next response
+func (f *FiberCtx) Status(code int) *FiberCtx {
+	f.status = code
+	return f
+}
+
+// JSON writes a JSON response
+func (f *FiberCtx) JSON(data interface{}) error {
+	f.c.JSON(f.status, data)
+	return nil
+}
+
+// SendString writes a plain text response
+func (f *FiberCtx) SendString(body string) error {
+	f.c.String(f.status, "%s", body)
+	return nil
+}
+
+// Send writes a raw response
+func (f *FiberCtx) Send(body []byte) error {
+	f.c.Data(f.status, "application/octet-stream", body)
+	return nil
+}
+
+// SendStatus writes a bodiless response
+func (f *FiberCtx) SendStatus(code int) error {
+	f.c.Status(code)
+	return nil
+}
+
+// Redirect redirects with status 302 unless another status is given
+func (f *FiberCtx) Redirect(location string, status ...int) error {
+	code := 302
+	if len(status) > 0 {
+		code = status[0]
+	}
+	f.c.Redirect(code, location)
+	return nil
+}
+
+// Next continues the gin handler chain
+func (f *FiberCtx) Next() error {
+	f.c.Next()
+	return nil
+}
+
+func orDefault(value string, defaultValue []string) string {
+	if value == "" && len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return value
+}
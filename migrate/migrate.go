@@ -0,0 +1,83 @@
+// Package migrate provides shims that run Echo- and Fiber-style handlers on SuperGin,
+// so existing handler code can be registered as named routes without a rewrite.
+//
+// The shims mirror the commonly used subset of echo.Context and *fiber.Ctx without
+// importing either framework; porting a handler is usually a matter of changing its
+// parameter type:
+//
+//	app.Named("show_user").GET("/users/:id").Handler(migrate.Echo(showUser))
+//	app.Named("create_user").POST("/users").TypedHandler(migrate.EchoTyped(createUser))
+package migrate
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/ivikasavnish/supergin"
+)
+
+// HTTPError is an error carrying an HTTP status, like echo.HTTPError and fiber.Error
+type HTTPError struct {
+	Code    int
+	Message interface{}
+}
+
+// NewHTTPError creates an HTTPError; the message defaults to the status text
+func NewHTTPError(code int, message ...interface{}) *HTTPError {
+	err := &HTTPError{Code: code, Message: http.StatusText(code)}
+	if len(message) > 0 {
+		err.Message = message[0]
+	}
+	return err
+}
+
+// Error implements the error interface
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("code=%d, message=%v", e.Code, e.Message)
+}
+
+// StatusCode implements supergin.StatusCoder
+func (e *HTTPError) StatusCode() int {
+	return e.Code
+}
+
+// validate backs the shims' Validate helpers
+var validate = validator.New()
+
+// bind binds path parameters, then the query string (GET/DELETE) or body
+func bind(c *gin.Context, target interface{}) error {
+	if len(c.Params) > 0 {
+		if err := c.ShouldBindUri(target); err != nil {
+			return NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	var err error
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodDelete, http.MethodHead:
+		err = c.ShouldBindQuery(target)
+	default:
+		if c.Request.ContentLength == 0 {
+			return nil
+		}
+		err = c.ShouldBind(target)
+	}
+	if err != nil {
+		return NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return nil
+}
+
+// handleError writes a handler error unless a response was already written
+func handleError(c *gin.Context, err error) {
+	if err == nil || c.Writer.Written() {
+		return
+	}
+	if httpErr, ok := err.(*HTTPError); ok {
+		c.JSON(httpErr.Code, gin.H{"error": httpErr.Message})
+		return
+	}
+	supergin.WriteError(c, err)
+}
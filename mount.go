@@ -0,0 +1,75 @@
+package supergin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MountGin registers every route of an existing gin engine under prefix so legacy routes
+// appear in the route registry, docs, metrics and URLFor. Requests are delegated to the
+// existing engine with the prefix stripped, preserving its middleware chain. Route names
+// are generated from method and path, e.g. GET /legacy/users/:id becomes get_legacy_users_id.
+// It returns the generated names in registration order.
+func (e *Engine) MountGin(prefix string, existing *gin.Engine) []string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	delegate := func(c *gin.Context) {
+		c.Request.URL.Path = stripPrefix(c.Request.URL.Path, prefix)
+		if c.Request.URL.RawPath != "" {
+			c.Request.URL.RawPath = stripPrefix(c.Request.URL.RawPath, prefix)
+		}
+		existing.ServeHTTP(c.Writer, c.Request)
+	}
+
+	var names []string
+	for _, route := range existing.Routes() {
+		switch route.Method {
+		case "GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS":
+		default:
+			e.logger.Warn("MountGin: skipping route with unsupported method", "method", route.Method, "path", route.Path)
+			continue
+		}
+
+		name := e.mountedRouteName(route.Method, prefix+route.Path)
+		rb := e.Named(name).
+			WithDescription(fmt.Sprintf("Mounted gin route %s %s", route.Method, route.Path)).
+			WithTags("mounted").
+			WithMetadata("mounted_handler", route.Handler)
+		rb.method = route.Method
+		rb.path = joinPaths("/", prefix+route.Path)
+		rb.Handler(delegate)
+
+		names = append(names, name)
+	}
+	return names
+}
+
+// mountedRouteName derives a unique route name from method and path
+func (e *Engine) mountedRouteName(method, path string) string {
+	parts := []string{strings.ToLower(method)}
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.TrimLeft(segment, ":*")
+		if segment != "" {
+			parts = append(parts, strings.NewReplacer("-", "_", ".", "_").Replace(segment))
+		}
+	}
+	base := strings.Join(parts, "_")
+
+	name := base
+	for i := 2; ; i++ {
+		if _, exists := e.GetRoute(name); !exists {
+			return name
+		}
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+}
+
+// stripPrefix removes a mount prefix from a request path
+func stripPrefix(path, prefix string) string {
+	stripped := strings.TrimPrefix(path, prefix)
+	if !strings.HasPrefix(stripped, "/") {
+		stripped = "/" + stripped
+	}
+	return stripped
+}
@@ -0,0 +1,108 @@
+package supergin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/protobuf/proto"
+)
+
+// Renderer writes a response value in one media type
+type Renderer func(c *gin.Context, status int, value interface{})
+
+var (
+	binders         = make(map[string]binding.BindingBody)
+	renderers       = make(map[string]Renderer)
+	rendererOrder   []string
+	negotiationMu   sync.RWMutex
+	defaultRenderer = binding.MIMEJSON
+)
+
+func init() {
+	RegisterBinder(binding.JSON, binding.MIMEJSON)
+	RegisterBinder(binding.XML, binding.MIMEXML, binding.MIMEXML2)
+	RegisterBinder(binding.YAML, binding.MIMEYAML, binding.MIMEYAML2, "text/yaml")
+	RegisterBinder(binding.TOML, binding.MIMETOML)
+	RegisterBinder(binding.ProtoBuf, binding.MIMEPROTOBUF, "application/protobuf")
+
+	RegisterRenderer(func(c *gin.Context, status int, value interface{}) {
+		c.JSON(status, value)
+	}, binding.MIMEJSON)
+	RegisterRenderer(func(c *gin.Context, status int, value interface{}) {
+		c.XML(status, value)
+	}, binding.MIMEXML, binding.MIMEXML2)
+	RegisterRenderer(func(c *gin.Context, status int, value interface{}) {
+		c.YAML(status, value)
+	}, binding.MIMEYAML, binding.MIMEYAML2)
+	RegisterRenderer(func(c *gin.Context, status int, value interface{}) {
+		c.TOML(status, value)
+	}, binding.MIMETOML)
+	RegisterRenderer(func(c *gin.Context, status int, value interface{}) {
+		message, ok := value.(proto.Message)
+		if !ok {
+			c.JSON(http.StatusNotAcceptable, gin.H{
+				"error": fmt.Sprintf("%T cannot be rendered as protobuf", value),
+			})
+			return
+		}
+		c.ProtoBuf(status, message)
+	}, binding.MIMEPROTOBUF, "application/protobuf")
+}
+
+// RegisterBinder decodes request bodies of the given content types with a gin binding,
+// e.g. supergin.RegisterBinder(csvBinding{}, "text/csv"). JSON, XML, YAML, TOML, MsgPack
+// and protobuf are registered by default; form bodies always use form binding.
+func RegisterBinder(binder binding.BindingBody, contentTypes ...string) {
+	negotiationMu.Lock()
+	defer negotiationMu.Unlock()
+
+	for _, contentType := range contentTypes {
+		binders[strings.ToLower(contentType)] = binder
+	}
+}
+
+// RegisterRenderer writes responses for the given media types when Respond negotiates
+// them from the Accept header
+func RegisterRenderer(renderer Renderer, mediaTypes ...string) {
+	negotiationMu.Lock()
+	defer negotiationMu.Unlock()
+
+	for _, mediaType := range mediaTypes {
+		mediaType = strings.ToLower(mediaType)
+		if _, exists := renderers[mediaType]; !exists {
+			rendererOrder = append(rendererOrder, mediaType)
+		}
+		renderers[mediaType] = renderer
+	}
+}
+
+// lookupBinder returns the binder registered for a content type without parameters
+func lookupBinder(contentType string) (binding.BindingBody, bool) {
+	negotiationMu.RLock()
+	defer negotiationMu.RUnlock()
+
+	binder, exists := binders[strings.ToLower(contentType)]
+	return binder, exists
+}
+
+// Respond writes value in the media type negotiated from the Accept header, falling back
+// to JSON when the client accepts nothing registered
+func Respond(c *gin.Context, status int, value interface{}) {
+	negotiationMu.RLock()
+	offered := append([]string(nil), rendererOrder...)
+	negotiationMu.RUnlock()
+
+	mediaType := c.NegotiateFormat(offered...)
+	negotiationMu.RLock()
+	renderer, exists := renderers[mediaType]
+	if !exists {
+		renderer = renderers[defaultRenderer]
+	}
+	negotiationMu.RUnlock()
+
+	renderer(c, status, value)
+}
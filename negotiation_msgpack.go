@@ -0,0 +1,16 @@
+//go:build !nomsgpack
+
+package supergin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+)
+
+func init() {
+	RegisterBinder(binding.MsgPack, binding.MIMEMSGPACK, binding.MIMEMSGPACK2)
+	RegisterRenderer(func(c *gin.Context, status int, value interface{}) {
+		c.Render(status, render.MsgPack{Data: value})
+	}, binding.MIMEMSGPACK, binding.MIMEMSGPACK2)
+}
@@ -0,0 +1,314 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivikasavnish/supergin"
+)
+
+const (
+	// ClaimsService is the request-scoped DI service holding the request's verified claims
+	ClaimsService = "oidc_claims"
+
+	// claimsKey stores the verified claims in the gin context
+	claimsKey = "supergin:oidc_claims"
+	// flowTTL bounds how long a sign-in may take between login and callback
+	flowTTL = 10 * time.Minute
+)
+
+// ErrNoClaims is returned when resolving ClaimsService in a request Middleware did not
+// authenticate
+var ErrNoClaims = errors.New("oidc: request has no verified claims")
+
+// Register adds the sign-in flow under prefix as named routes and registers ClaimsService
+// in the engine's container:
+//
+//   - oidc_login (GET prefix/login) redirects to the provider with state, nonce and PKCE;
+//     a return_to query parameter names the local path to come back to
+//   - oidc_callback (GET prefix/callback) exchanges the code, verifies the ID token and
+//     keeps it in the session cookie; RedirectURL must point here
+//   - oidc_logout (GET prefix/logout) clears the cookie and signs out at the provider when
+//     it has an end session endpoint
+//
+// Sessions last as long as the ID token; users sign in again once it expires.
+func (p *Provider) Register(app *supergin.Engine, prefix string) *Provider {
+	if p.config.RedirectURL == "" {
+		panic("oidc sign-in routes require Config.RedirectURL")
+	}
+	prefix = "/" + strings.Trim(prefix, "/")
+	if prefix == "/" {
+		prefix = ""
+	}
+
+	app.DI().RegisterRequest(ClaimsService, func() (*Claims, error) {
+		return nil, ErrNoClaims
+	})
+
+	app.Named("oidc_login").
+		GET(prefix+"/login").
+		WithDescription("Sign in with OpenID Connect").
+		WithTags("auth", "oidc").
+		Handler(p.login)
+	app.Named("oidc_callback").
+		GET(prefix+"/callback").
+		WithDescription("OpenID Connect sign-in callback").
+		WithTags("auth", "oidc").
+		Handler(p.callback)
+	app.Named("oidc_logout").
+		GET(prefix+"/logout").
+		WithDescription("Sign out").
+		WithTags("auth", "oidc").
+		Handler(p.logout)
+	return p
+}
+
+// Middleware authenticates requests with a bearer token or the session cookie. Verified
+// requests get the claims as their principal, so RequireRoles and RequirePermissions work,
+// and as ClaimsService in their DI scope; others get 401 as application/problem+json, or
+// 503 when the provider cannot be reached. Register it as a scheme:
+//
+//	app.RegisterAuthScheme("oidc", provider.Middleware())
+func (p *Provider) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := ""
+		if scheme, credentials, found := strings.Cut(c.GetHeader("Authorization"), " "); found && strings.EqualFold(scheme, "Bearer") {
+			token = strings.TrimSpace(credentials)
+		} else if cookie, err := c.Cookie(p.config.CookieName); err == nil {
+			token = cookie
+		}
+		if token == "" {
+			c.Header("WWW-Authenticate", "Bearer")
+			supergin.WriteProblem(c, http.StatusUnauthorized, "authentication required")
+			return
+		}
+
+		claims, err := p.Verify(c.Request.Context(), token)
+		if errors.Is(err, ErrInvalidToken) {
+			supergin.LoggerFor(c).Info("token rejected", "error", err)
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			supergin.WriteProblem(c, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		if err != nil {
+			supergin.LoggerFor(c).Error("token verification failed", "error", err)
+			supergin.WriteProblem(c, http.StatusServiceUnavailable, "token verification unavailable")
+			return
+		}
+
+		c.Set(claimsKey, claims)
+		supergin.SetPrincipal(c, claims)
+		if claims.ClientID != "" {
+			supergin.SetClientID(c, claims.ClientID)
+		}
+		if scope, ok := supergin.ScopeFromContext(c); ok {
+			scope.Provide(ClaimsService, claims)
+		}
+	}
+}
+
+// ClaimsFrom returns the claims Middleware verified for the request
+func ClaimsFrom(c *gin.Context) (*Claims, bool) {
+	value, exists := c.Get(claimsKey)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*Claims)
+	return claims, ok
+}
+
+// login starts the authorization code flow
+func (p *Provider) login(c *gin.Context) {
+	state, nonce, verifier := randomToken(), randomToken(), randomToken()
+	returnTo := c.Query("return_to")
+	if !localPath(returnTo) {
+		returnTo = p.config.PostLoginURL
+	}
+	p.setCookie(c, p.flowCookie(), strings.Join([]string{state, nonce, verifier,
+		base64.RawURLEncoding.EncodeToString([]byte(returnTo))}, "."), flowTTL)
+
+	challenge := sha256.Sum256([]byte(verifier))
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	c.Redirect(http.StatusFound, withQuery(p.metadata.AuthorizationEndpoint, query))
+}
+
+// callback completes the flow started by login
+func (p *Provider) callback(c *gin.Context) {
+	if providerError := c.Query("error"); providerError != "" {
+		detail := providerError
+		if description := c.Query("error_description"); description != "" {
+			detail += ": " + description
+		}
+		supergin.WriteProblem(c, http.StatusUnauthorized, detail)
+		return
+	}
+
+	flow, err := c.Cookie(p.flowCookie())
+	parts := strings.Split(flow, ".")
+	p.setCookie(c, p.flowCookie(), "", -1)
+	if err != nil || len(parts) != 4 || subtle.ConstantTimeCompare([]byte(parts[0]), []byte(c.Query("state"))) != 1 {
+		supergin.WriteProblem(c, http.StatusBadRequest, "sign-in state mismatch; start again")
+		return
+	}
+	nonce, verifier := parts[1], parts[2]
+	returnTo := p.config.PostLoginURL
+	if decoded, err := base64.RawURLEncoding.DecodeString(parts[3]); err == nil && localPath(string(decoded)) {
+		returnTo = string(decoded)
+	}
+
+	idToken, err := p.exchange(c.Request.Context(), c.Query("code"), verifier)
+	if err != nil {
+		supergin.LoggerFor(c).Error("oidc code exchange failed", "error", err)
+		supergin.WriteProblem(c, http.StatusBadGateway, "sign-in could not be completed")
+		return
+	}
+	claims, err := p.verify(c.Request.Context(), idToken, nonce)
+	if err != nil {
+		supergin.LoggerFor(c).Info("ID token rejected", "error", err)
+		supergin.WriteProblem(c, http.StatusUnauthorized, "invalid ID token")
+		return
+	}
+
+	p.setCookie(c, p.config.CookieName, idToken, time.Until(time.Unix(claims.ExpiresAt, 0)))
+	c.Redirect(http.StatusFound, returnTo)
+}
+
+// logout ends the session, at the provider too when it supports RP-initiated logout
+func (p *Provider) logout(c *gin.Context) {
+	idToken, _ := c.Cookie(p.config.CookieName)
+	p.setCookie(c, p.config.CookieName, "", -1)
+
+	if p.metadata.EndSessionEndpoint == "" {
+		c.Redirect(http.StatusFound, p.config.PostLogoutURL)
+		return
+	}
+	query := url.Values{
+		"client_id":                {p.config.ClientID},
+		"post_logout_redirect_uri": {p.absoluteURL(p.config.PostLogoutURL)},
+	}
+	if idToken != "" {
+		query.Set("id_token_hint", idToken)
+	}
+	c.Redirect(http.StatusFound, withQuery(p.metadata.EndSessionEndpoint, query))
+}
+
+// exchange trades an authorization code for the ID token
+func (p *Provider) exchange(ctx context.Context, code, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"code_verifier": {verifier},
+	}
+	if p.config.ClientSecret == "" {
+		form.Set("client_id", p.config.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	p.authenticate(req)
+
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := decodeResponse(resp, &tokens); err != nil {
+		return "", err
+	}
+	if tokens.IDToken == "" {
+		return "", errors.New("token response has no id_token")
+	}
+	return tokens.IDToken, nil
+}
+
+// flowCookie names the cookie carrying a sign-in's state between login and callback
+func (p *Provider) flowCookie() string {
+	return p.config.CookieName + "_flow"
+}
+
+// setCookie sets an HttpOnly cookie for the whole site, deleting it for negative maxAge
+func (p *Provider) setCookie(c *gin.Context, name, value string, maxAge time.Duration) {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   !strings.HasPrefix(p.config.RedirectURL, "http://"),
+		SameSite: http.SameSiteLaxMode,
+	}
+	if maxAge < 0 {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(c.Writer, cookie)
+}
+
+// absoluteURL resolves a path against the redirect URL, since providers need absolute
+// post-logout URLs
+func (p *Provider) absoluteURL(target string) string {
+	base, err := url.Parse(p.config.RedirectURL)
+	if err != nil {
+		return target
+	}
+	resolved, err := base.Parse(target)
+	if err != nil {
+		return target
+	}
+	return resolved.String()
+}
+
+// localPath reports whether a return path stays on this site, so the callback cannot be
+// used as an open redirect. Backslashes and control characters are refused anywhere, since
+// browsers follow /\evil.com, and /<tab>/evil.com once the tab is stripped, to evil.com.
+func localPath(path string) bool {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") {
+		return false
+	}
+	for _, r := range path {
+		if r < 0x20 || r == 0x7f || r == '\\' {
+			return false
+		}
+	}
+	parsed, err := url.Parse(path)
+	return err == nil && parsed.Scheme == "" && parsed.Host == ""
+}
+
+// withQuery appends query parameters to an endpoint that may already have some
+func withQuery(endpoint string, query url.Values) string {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+	return endpoint + separator + query.Encode()
+}
+
+// randomToken returns 32 random bytes, base64url encoded
+func randomToken() string {
+	data := make([]byte, 32)
+	rand.Read(data)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
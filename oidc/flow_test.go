@@ -0,0 +1,118 @@
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ivikasavnish/supergin"
+)
+
+func TestLocalPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/", true},
+		{"/orders/42?tab=items#top", true},
+		{"/%09/evil.com", true},
+		{"", false},
+		{"orders", false},
+		{"//evil.com", false},
+		{"/\\evil.com", false},
+		{"/\t/evil.com", false},
+		{"/\n/evil.com", false},
+		{"/a\r\nSet-Cookie: x=y", false},
+		{"/\x7f/evil.com", false},
+		{"https://evil.com", false},
+	}
+	for _, tt := range tests {
+		if got := localPath(tt.path); got != tt.want {
+			t.Errorf("localPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+// newTestProvider discovers a provider served by an httptest server
+func newTestProvider(t *testing.T) *Provider {
+	t.Helper()
+	var issuer *httptest.Server
+	issuer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != discoveryPath {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(Metadata{
+			Issuer:                issuer.URL,
+			AuthorizationEndpoint: issuer.URL + "/authorize",
+			TokenEndpoint:         issuer.URL + "/token",
+			JWKSURI:               issuer.URL + "/jwks",
+		})
+	}))
+	t.Cleanup(issuer.Close)
+
+	provider, err := New(context.Background(), Config{
+		Issuer:       issuer.URL,
+		ClientID:     "app",
+		RedirectURL:  "http://app.test/auth/callback",
+		PostLoginURL: "/home",
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return provider
+}
+
+func TestLoginReturnTo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := supergin.New(supergin.Config{
+		Logger: supergin.NewSlogLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	})
+	provider := newTestProvider(t).Register(app, "/auth")
+
+	tests := []struct {
+		returnTo string
+		want     string
+	}{
+		{"/orders?page=2", "/orders?page=2"},
+		{"", "/home"},
+		{"//evil.com", "/home"},
+		{"/\\evil.com", "/home"},
+		{"/\t/evil.com", "/home"},
+		{"https://evil.com/", "/home"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/auth/login?return_to="+url.QueryEscape(tt.returnTo), nil)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		if w.Code != http.StatusFound {
+			t.Fatalf("login with return_to %q = %d, want 302", tt.returnTo, w.Code)
+		}
+
+		var flow string
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == provider.flowCookie() {
+				flow = cookie.Value
+			}
+		}
+		parts := strings.Split(flow, ".")
+		if len(parts) != 4 {
+			t.Fatalf("flow cookie %q has %d parts, want 4", flow, len(parts))
+		}
+		stored, err := base64.RawURLEncoding.DecodeString(parts[3])
+		if err != nil {
+			t.Fatalf("decoding return path: %v", err)
+		}
+		if string(stored) != tt.want {
+			t.Errorf("return_to %q stored as %q, want %q", tt.returnTo, stored, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Package oidc signs users in with an OpenID Connect provider and authenticates API
+// requests with its tokens.
+//
+// New fetches the provider's discovery document. Register then adds the authorization
+// code flow as named routes, and Middleware verifies bearer tokens or the session cookie
+// the callback sets:
+//
+//	provider, err := oidc.New(ctx, oidc.Config{
+//		Issuer:       "https://accounts.example.com",
+//		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+//		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+//		RedirectURL:  "https://app.example.com/auth/callback",
+//	})
+//	provider.Register(app, "/auth")
+//	app.RegisterAuthScheme("oidc", provider.Middleware())
+//
+// Handlers read the verified claims with ClaimsFrom, or resolve them from the request's
+// DI scope as oidc.ClaimsService.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ivikasavnish/supergin"
+)
+
+const (
+	// DefaultCookieName is the session cookie holding the ID token after sign-in
+	DefaultCookieName = "supergin_oidc"
+	// DefaultClockSkew is how far token times may be off from the local clock
+	DefaultClockSkew = time.Minute
+	// discoveryPath is appended to the issuer to find its discovery document
+	discoveryPath = "/.well-known/openid-configuration"
+)
+
+// Config configures a Provider
+type Config struct {
+	// Issuer is the provider's issuer URL, which must match its discovery document
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the absolute URL of the callback route, as registered with the
+	// provider
+	RedirectURL string
+	// Scopes are requested at sign-in; default openid, profile and email
+	Scopes []string
+	// Audience is the aud tokens must carry; default ClientID
+	Audience string
+	// PostLoginURL is where the callback sends users without a return path; default "/"
+	PostLoginURL string
+	// PostLogoutURL is where users land after signing out; default "/"
+	PostLogoutURL string
+	// CookieName names the session cookie; default DefaultCookieName. Cookies are Secure
+	// unless RedirectURL is plain http.
+	CookieName string
+	// ClockSkew tolerates clock drift in exp, nbf and iat; default DefaultClockSkew
+	ClockSkew time.Duration
+	// HTTPClient fetches discovery, keys and tokens; default http.DefaultClient with a 10s
+	// timeout
+	HTTPClient *http.Client
+}
+
+// Metadata is the part of the provider's discovery document the package uses
+type Metadata struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint,omitempty"`
+	JWKSURI               string   `json:"jwks_uri"`
+	IntrospectionEndpoint string   `json:"introspection_endpoint,omitempty"`
+	EndSessionEndpoint    string   `json:"end_session_endpoint,omitempty"`
+	SigningAlgorithms     []string `json:"id_token_signing_alg_values_supported,omitempty"`
+}
+
+// Provider verifies tokens from one OpenID Connect provider and runs its sign-in flow
+type Provider struct {
+	config   Config
+	metadata Metadata
+	keys     *keySet
+}
+
+// New fetches the issuer's discovery document and returns a Provider for it
+func New(ctx context.Context, config Config) (*Provider, error) {
+	if config.Issuer == "" || config.ClientID == "" {
+		return nil, supergin.NewSuperGinError(supergin.ErrMisconfiguration, "oidc requires an issuer and a client ID")
+	}
+	config.Issuer = strings.TrimSuffix(config.Issuer, "/")
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "profile", "email"}
+	}
+	if config.Audience == "" {
+		config.Audience = config.ClientID
+	}
+	if config.PostLoginURL == "" {
+		config.PostLoginURL = "/"
+	}
+	if config.PostLogoutURL == "" {
+		config.PostLogoutURL = "/"
+	}
+	if config.CookieName == "" {
+		config.CookieName = DefaultCookieName
+	}
+	if config.ClockSkew <= 0 {
+		config.ClockSkew = DefaultClockSkew
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	var metadata Metadata
+	if err := getJSON(ctx, config.HTTPClient, config.Issuer+discoveryPath, &metadata); err != nil {
+		return nil, supergin.NewSuperGinErrorWithCause(supergin.ErrBackendUnavailable, err, "failed to fetch oidc discovery document")
+	}
+	// The issuer must be the one the document was fetched from, so another issuer's
+	// tokens are never accepted
+	if strings.TrimSuffix(metadata.Issuer, "/") != config.Issuer {
+		return nil, supergin.NewSuperGinError(supergin.ErrMisconfiguration,
+			"oidc discovery document is for issuer %q, not %q", metadata.Issuer, config.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, supergin.NewSuperGinError(supergin.ErrMisconfiguration, "oidc discovery document lacks required endpoints")
+	}
+
+	return &Provider{
+		config:   config,
+		metadata: metadata,
+		keys:     newKeySet(config.HTTPClient, metadata.JWKSURI),
+	}, nil
+}
+
+// Metadata returns the provider's discovery document
+func (p *Provider) Metadata() Metadata {
+	return p.metadata
+}
+
+// getJSON fetches url and decodes its JSON body into target
+func getJSON(ctx context.Context, client *http.Client, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, target)
+}
+
+// decodeResponse decodes a successful JSON response, closing its body
+func decodeResponse(resp *http.Response, target interface{}) error {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(target)
+}
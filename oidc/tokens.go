@@ -0,0 +1,429 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // SHA-256 for RS256, PS256 and ES256
+	_ "crypto/sha512" // SHA-384 and SHA-512 for the other algorithms
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ivikasavnish/supergin"
+)
+
+// keyRefreshInterval bounds how often an unknown key ID refetches the provider's keys
+const keyRefreshInterval = time.Minute
+
+// ErrInvalidToken is wrapped by errors for tokens that fail verification, as opposed to
+// the provider being unreachable
+var ErrInvalidToken = errors.New("oidc: invalid token")
+
+// Claims are the verified claims of an ID or access token. They implement
+// supergin.Principal: roles come from the roles and groups claims and permissions from
+// the space-separated scope.
+type Claims struct {
+	Subject           string   `json:"sub"`
+	Issuer            string   `json:"iss"`
+	Audience          []string `json:"aud,omitempty"`
+	ExpiresAt         int64    `json:"exp,omitempty"`
+	IssuedAt          int64    `json:"iat,omitempty"`
+	NotBefore         int64    `json:"nbf,omitempty"`
+	Nonce             string   `json:"nonce,omitempty"`
+	ClientID          string   `json:"client_id,omitempty"`
+	Email             string   `json:"email,omitempty"`
+	EmailVerified     bool     `json:"email_verified,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	PreferredUsername string   `json:"preferred_username,omitempty"`
+	Scope             string   `json:"scope,omitempty"`
+	Roles             []string `json:"roles,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	// Raw holds every claim, including provider-specific ones
+	Raw map[string]interface{} `json:"-"`
+}
+
+// PrincipalID implements supergin.Principal
+func (c *Claims) PrincipalID() string { return c.Subject }
+
+// HasRole implements supergin.Principal
+func (c *Claims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role) || slices.Contains(c.Groups, role)
+}
+
+// HasPermission implements supergin.Principal
+func (c *Claims) HasPermission(permission string) bool {
+	return slices.Contains(strings.Fields(c.Scope), permission)
+}
+
+// Verify checks a token and returns its claims. JWTs are verified against the provider's
+// signing keys; other tokens are introspected at the provider's introspection endpoint
+// (RFC 7662) when it has one. Failed checks wrap ErrInvalidToken.
+func (p *Provider) Verify(ctx context.Context, token string) (*Claims, error) {
+	return p.verify(ctx, token, "")
+}
+
+// verify checks a token, and its nonce when one is expected
+func (p *Provider) verify(ctx context.Context, token, nonce string) (*Claims, error) {
+	if strings.Count(token, ".") != 2 {
+		if p.metadata.IntrospectionEndpoint == "" {
+			return nil, fmt.Errorf("%w: not a JWT and the provider has no introspection endpoint", ErrInvalidToken)
+		}
+		return p.introspect(ctx, token)
+	}
+
+	parts := strings.Split(token, ".")
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidToken)
+	}
+	key, err := p.keys.key(ctx, header.KeyID, header.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	if err := verifySignature(header.Algorithm, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	claims, err := parseClaims(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	if err := p.validate(claims, nonce); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// validate checks the issuer, audience, validity period and nonce
+func (p *Provider) validate(claims *Claims, nonce string) error {
+	now := time.Now()
+	skew := p.config.ClockSkew
+	switch {
+	case strings.TrimSuffix(claims.Issuer, "/") != p.config.Issuer:
+		return fmt.Errorf("%w: issued by %q", ErrInvalidToken, claims.Issuer)
+	case !slices.Contains(claims.Audience, p.config.Audience):
+		return fmt.Errorf("%w: not issued for %q", ErrInvalidToken, p.config.Audience)
+	case claims.ExpiresAt == 0 || now.Add(-skew).After(time.Unix(claims.ExpiresAt, 0)):
+		return fmt.Errorf("%w: expired", ErrInvalidToken)
+	case claims.NotBefore != 0 && now.Add(skew).Before(time.Unix(claims.NotBefore, 0)):
+		return fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	case nonce != "" && subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1:
+		return fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+	return nil
+}
+
+// introspect asks the provider whether an opaque token is active
+func (p *Provider) introspect(ctx context.Context, token string) (*Claims, error) {
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.metadata.IntrospectionEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	p.authenticate(req)
+
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, supergin.NewSuperGinErrorWithCause(supergin.ErrBackendUnavailable, err, "token introspection failed")
+	}
+	var raw map[string]interface{}
+	if err := decodeResponse(resp, &raw); err != nil {
+		return nil, supergin.NewSuperGinErrorWithCause(supergin.ErrBackendUnavailable, err, "token introspection failed")
+	}
+	if active, _ := raw["active"].(bool); !active {
+		return nil, fmt.Errorf("%w: inactive", ErrInvalidToken)
+	}
+
+	claims := claimsFrom(raw)
+	// Introspection responses may omit iss, aud and exp; those present must match
+	if claims.Issuer != "" && strings.TrimSuffix(claims.Issuer, "/") != p.config.Issuer {
+		return nil, fmt.Errorf("%w: issued by %q", ErrInvalidToken, claims.Issuer)
+	}
+	if len(claims.Audience) > 0 && !slices.Contains(claims.Audience, p.config.Audience) {
+		return nil, fmt.Errorf("%w: not issued for %q", ErrInvalidToken, p.config.Audience)
+	}
+	if claims.ExpiresAt != 0 && time.Now().Add(-p.config.ClockSkew).After(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+// authenticate adds the client's credentials to a token or introspection request
+func (p *Provider) authenticate(req *http.Request) {
+	if p.config.ClientSecret != "" {
+		// RFC 6749 section 2.3.1 form-encodes the credentials before Basic encoding them
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+}
+
+// parseClaims decodes a JWT payload
+func parseClaims(segment string) (*Claims, error) {
+	var raw map[string]interface{}
+	if err := decodeSegment(segment, &raw); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+	return claimsFrom(raw), nil
+}
+
+// claimsFrom reads the standard claims leniently, since providers differ in types such as
+// a string or array aud, or email_verified sent as a string
+func claimsFrom(raw map[string]interface{}) *Claims {
+	text := func(name string) string {
+		value, _ := raw[name].(string)
+		return value
+	}
+	number := func(name string) int64 {
+		value, _ := raw[name].(float64)
+		return int64(value)
+	}
+	list := func(name string) []string {
+		switch value := raw[name].(type) {
+		case string:
+			return []string{value}
+		case []interface{}:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				if text, ok := item.(string); ok {
+					items = append(items, text)
+				}
+			}
+			return items
+		}
+		return nil
+	}
+
+	verified, isBool := raw["email_verified"].(bool)
+	if !isBool {
+		verified = text("email_verified") == "true"
+	}
+	return &Claims{
+		Subject:           text("sub"),
+		Issuer:            text("iss"),
+		Audience:          list("aud"),
+		ExpiresAt:         number("exp"),
+		IssuedAt:          number("iat"),
+		NotBefore:         number("nbf"),
+		Nonce:             text("nonce"),
+		ClientID:          text("client_id"),
+		Email:             text("email"),
+		EmailVerified:     verified,
+		Name:              text("name"),
+		PreferredUsername: text("preferred_username"),
+		Scope:             text("scope"),
+		Roles:             list("roles"),
+		Groups:            list("groups"),
+		Raw:               raw,
+	}
+}
+
+// decodeSegment decodes a base64url JWT segment holding JSON
+func decodeSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// verifySignature checks a JWS signature made with an RSA or ECDSA algorithm
+func verifySignature(algorithm string, key crypto.PublicKey, signed, signature []byte) error {
+	if len(algorithm) != 5 {
+		return fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+	var hash crypto.Hash
+	switch algorithm[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+	digester := hash.New()
+	digester.Write(signed)
+	digest := digester.Sum(nil)
+
+	switch algorithm[:2] {
+	case "RS", "PS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s needs an RSA key", algorithm)
+		}
+		if algorithm[0] == 'P' {
+			return rsa.VerifyPSS(rsaKey, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		return rsa.VerifyPKCS1v15(rsaKey, hash, digest, signature)
+	case "ES":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s needs an EC key", algorithm)
+		}
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("malformed ECDSA signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported algorithm %q", algorithm)
+}
+
+// keySet caches the provider's signing keys, refetching them when a token names a key
+// it does not know, as after a key rotation
+type keySet struct {
+	client  *http.Client
+	uri     string
+	keys    []signingKey
+	fetched time.Time
+	mutex   sync.Mutex
+}
+
+// signingKey is a parsed JSON Web Key
+type signingKey struct {
+	id        string
+	algorithm string
+	key       crypto.PublicKey
+}
+
+// jsonWebKey is a JWK as published in a JWKS document
+type jsonWebKey struct {
+	Type      string `json:"kty"`
+	ID        string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+}
+
+func newKeySet(client *http.Client, uri string) *keySet {
+	return &keySet{client: client, uri: uri}
+}
+
+// key returns the key a token's header names
+func (s *keySet) key(ctx context.Context, id, algorithm string) (crypto.PublicKey, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if key, found := s.lookup(id, algorithm); found {
+		return key, nil
+	}
+	if s.fetched.IsZero() || time.Since(s.fetched) >= keyRefreshInterval {
+		if err := s.refresh(ctx); err != nil {
+			return nil, err
+		}
+		if key, found := s.lookup(id, algorithm); found {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, id)
+}
+
+// lookup finds a key by ID, or the only key for tokens without one
+func (s *keySet) lookup(id, algorithm string) (crypto.PublicKey, bool) {
+	for _, key := range s.keys {
+		if (key.id == id || (id == "" && len(s.keys) == 1)) &&
+			(key.algorithm == "" || key.algorithm == algorithm) {
+			return key.key, true
+		}
+	}
+	return nil, false
+}
+
+// refresh refetches the provider's keys
+func (s *keySet) refresh(ctx context.Context) error {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := getJSON(ctx, s.client, s.uri, &document); err != nil {
+		return supergin.NewSuperGinErrorWithCause(supergin.ErrBackendUnavailable, err, "failed to fetch oidc signing keys")
+	}
+
+	keys := make([]signingKey, 0, len(document.Keys))
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key, err := jwk.publicKey(); err == nil {
+			keys = append(keys, signingKey{id: jwk.ID, algorithm: jwk.Algorithm, key: key})
+		}
+	}
+	s.keys = keys
+	s.fetched = time.Now()
+	return nil
+}
+
+// publicKey parses an RSA or EC key; other key types are skipped
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	decode := func(value string) (*big.Int, error) {
+		data, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil || len(data) == 0 {
+			return nil, fmt.Errorf("malformed key %q", k.ID)
+		}
+		return new(big.Int).SetBytes(data), nil
+	}
+
+	switch k.Type {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil || !e.IsInt64() {
+			return nil, fmt.Errorf("malformed key %q", k.ID)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Type)
+}
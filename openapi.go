@@ -0,0 +1,388 @@
+package supergin
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIVersion is the OpenAPI specification version emitted by the generator
+const OpenAPIVersion = "3.1.0"
+
+// OpenAPI generates an OpenAPI 3.1 document from the route registry
+func (e *Engine) OpenAPI() map[string]interface{} {
+	components := make(map[string]interface{})
+	sb := newSchemaBuilder(components)
+
+	routes := e.GetRoutes()
+	names := make([]string, 0, len(routes))
+	for name := range routes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	paths := make(map[string]interface{})
+	tagSet := make(map[string]bool)
+	for _, name := range names {
+		route := routes[name]
+		path := openAPIPath(route.Path)
+
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = e.openAPIOperation(route, sb)
+
+		for _, tag := range route.Tags {
+			tagSet[tag] = true
+		}
+	}
+
+	tags := make([]map[string]interface{}, 0, len(tagSet))
+	for tag := range tagSet {
+		tags = append(tags, map[string]interface{}{"name": tag})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i]["name"].(string) < tags[j]["name"].(string) })
+
+	title := e.config.Title
+	if title == "" {
+		title = "SuperGin API"
+	}
+	version := e.config.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+
+	return map[string]interface{}{
+		"openapi": OpenAPIVersion,
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": version,
+		},
+		"paths": paths,
+		"tags":  tags,
+		"components": map[string]interface{}{
+			"schemas": components,
+		},
+	}
+}
+
+// openAPIOperation builds the operation object for a route
+func (e *Engine) openAPIOperation(route *RouteInfo, sb *schemaBuilder) map[string]interface{} {
+	op := map[string]interface{}{
+		"operationId": route.Name,
+	}
+	if route.Description != "" {
+		op["summary"] = route.Description
+	}
+	if len(route.Tags) > 0 {
+		op["tags"] = route.Tags
+	}
+	if deprecated, _ := route.Metadata["deprecated"].(bool); deprecated {
+		op["deprecated"] = true
+	}
+	if route.SLO != nil {
+		op["x-slo"] = route.SLO
+	}
+	if len(route.Environments) > 0 {
+		op["x-environments"] = route.Environments
+	}
+	required := routeRequirements(route.Metadata)
+	if len(required.Roles) > 0 {
+		op["x-required-roles"] = required.Roles
+	}
+	if len(required.Permissions) > 0 {
+		op["x-required-permissions"] = required.Permissions
+	}
+	if route.Host != "" {
+		op["servers"] = []map[string]interface{}{{"url": e.hostURL(route.Host)}}
+	}
+
+	parameters := pathParameters(route.Path, route.InputType, sb)
+	if route.InputType != nil {
+		parameters = append(parameters, headerParameters(route.InputType, sb)...)
+	}
+	if raw, _ := route.Metadata["raw_body"].(bool); raw {
+		op["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"*/*": map[string]interface{}{
+					"schema": map[string]interface{}{"type": "string", "format": "binary"},
+				},
+			},
+		}
+	} else if schema, ok := route.Metadata["request_schema"].(map[string]interface{}); ok && route.InputType == nil {
+		op["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schema},
+			},
+		}
+	} else if route.InputType != nil {
+		if route.Method == "GET" || route.Method == "DELETE" {
+			parameters = append(parameters, queryParameters(route.InputType, sb)...)
+		} else {
+			schema := sb.build(route.InputType)
+			content := map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schema},
+			}
+			if hasTag(route.InputType, "form") {
+				content["application/x-www-form-urlencoded"] = map[string]interface{}{"schema": schema}
+			}
+			op["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  content,
+			}
+		}
+	}
+	pagination, paginated := route.Metadata["pagination"].(PaginationOptions)
+	if paginated {
+		// Pagination owns its query parameters even when the input declares them too
+		kept := parameters[:0]
+		for _, param := range parameters {
+			p := param.(map[string]interface{})
+			if p["in"] != "query" || !pageQueryNames[p["name"].(string)] {
+				kept = append(kept, param)
+			}
+		}
+		parameters = append(kept, paginationParameters(pagination)...)
+	}
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+
+	success := map[string]interface{}{"description": "Successful response"}
+	if route.OutputType != nil {
+		success["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": sb.build(route.OutputType)},
+		}
+	} else if schema, ok := route.Metadata["response_schema"].(map[string]interface{}); ok {
+		success["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		}
+	}
+	if len(route.Headers) > 0 {
+		success["headers"] = openAPIHeaders(route.Headers)
+	}
+	if paginated {
+		headers, _ := success["headers"].(map[string]interface{})
+		if headers == nil {
+			headers = make(map[string]interface{})
+		}
+		headers["Link"] = map[string]interface{}{
+			"description": "URLs of the first, prev, next and last pages",
+			"schema":      map[string]interface{}{"type": "string"},
+		}
+		headers["X-Total-Count"] = map[string]interface{}{
+			"description": "Total number of items across all pages",
+			"schema":      map[string]interface{}{"type": "integer"},
+		}
+		success["headers"] = headers
+	}
+	responses := map[string]interface{}{"200": success}
+	if raw, _ := route.Metadata["raw_body"].(bool); raw {
+		responses["413"] = map[string]interface{}{"description": "Request body too large"}
+	} else if route.InputType != nil {
+		responses["400"] = map[string]interface{}{"description": "Input validation failed"}
+	}
+	if !required.empty() {
+		responses["401"] = map[string]interface{}{"description": "Authentication required"}
+		responses["403"] = map[string]interface{}{"description": "Missing required roles or permissions"}
+	}
+	op["responses"] = responses
+
+	return op
+}
+
+// openAPIPath converts gin path syntax (/users/:id, /files/*path) to OpenAPI templates
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathParameters describes the parameters in a gin path, typed by the input's uri-tagged fields
+func pathParameters(path string, input reflect.Type, sb *schemaBuilder) []interface{} {
+	fields := uriFields(input)
+
+	var params []interface{}
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			schema := map[string]interface{}{"type": "string"}
+			param := map[string]interface{}{
+				"name":     segment[1:],
+				"in":       "path",
+				"required": true,
+			}
+			if field, ok := fields[segment[1:]]; ok {
+				schema = paramSchema(field, sb)
+				applyValidateTag(schema, field.Type, validationRules(field))
+				if desc := field.Tag.Get("description"); desc != "" {
+					param["description"] = desc
+				}
+			}
+			param["schema"] = schema
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// uriFields indexes an input struct's uri-tagged fields by parameter name
+func uriFields(t reflect.Type) map[string]reflect.StructField {
+	return taggedFields(t, "uri")
+}
+
+// taggedFields indexes a struct's fields by their name under the tag key, including
+// fields of embedded structs
+func taggedFields(t reflect.Type, key string) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	if t == nil {
+		return fields
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Tag.Get(key) == "" {
+			for name, nested := range taggedFields(field.Type, key) {
+				fields[name] = nested
+			}
+			continue
+		}
+		if name := strings.Split(field.Tag.Get(key), ",")[0]; name != "" && name != "-" {
+			fields[name] = field
+		}
+	}
+	return fields
+}
+
+// queryParameters describes input struct fields bound from the query string
+func queryParameters(t reflect.Type, sb *schemaBuilder) []interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []interface{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("form") == "" {
+			params = append(params, queryParameters(field.Type, sb)...)
+			continue
+		}
+		if isParamField(field) {
+			// Bound from the path or headers
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			jsonName, skip := jsonFieldName(field)
+			if skip {
+				continue
+			}
+			name = jsonName
+		}
+
+		schema := paramSchema(field, sb)
+		required := applyValidateTag(schema, field.Type, validationRules(field))
+		param := map[string]interface{}{
+			"name":   name,
+			"in":     "query",
+			"schema": schema,
+		}
+		if required {
+			param["required"] = true
+		}
+		if desc := field.Tag.Get("description"); desc != "" {
+			param["description"] = desc
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// headerParameters describes input struct fields bound from request headers
+func headerParameters(t reflect.Type, sb *schemaBuilder) []interface{} {
+	fields := taggedFields(t, "header")
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		field := fields[name]
+		schema := paramSchema(field, sb)
+		param := map[string]interface{}{
+			"name":   name,
+			"in":     "header",
+			"schema": schema,
+		}
+		if applyValidateTag(schema, field.Type, validationRules(field)) {
+			param["required"] = true
+		}
+		if desc := field.Tag.Get("description"); desc != "" {
+			param["description"] = desc
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// isParamField reports whether a field is bound from the path or a header rather than
+// the body or query string
+func isParamField(field reflect.StructField) bool {
+	_, uri := field.Tag.Lookup("uri")
+	_, header := field.Tag.Lookup("header")
+	return uri || header
+}
+
+// hasTag reports whether any field of a struct type carries the tag key
+func hasTag(t reflect.Type, key string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// setupOpenAPIEndpoint serves the generated OpenAPI document under the docs path and at /openapi.json
+func (e *Engine) setupOpenAPIEndpoint() {
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, e.OpenAPI())
+	}
+
+	docsSpec := strings.TrimSuffix(e.config.DocsPath, "/") + "/openapi.json"
+	e.Engine.GET(docsSpec, e.docsHandlers(handler)...)
+	if docsSpec != "/openapi.json" {
+		e.Engine.GET("/openapi.json", e.docsHandlers(handler)...)
+	}
+}
@@ -0,0 +1,255 @@
+package supergin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OutputValidationMode controls what happens when a response fails output validation
+type OutputValidationMode int
+
+const (
+	// OutputValidationLog logs mismatches and sends the response unchanged
+	OutputValidationLog OutputValidationMode = iota
+	// OutputValidationReject replaces mismatching responses with a 500 error
+	OutputValidationReject
+)
+
+// capturingWriter buffers a handler's response so it can be validated before sending
+type capturingWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func newCapturingWriter(w gin.ResponseWriter) *capturingWriter {
+	return &capturingWriter{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (w *capturingWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *capturingWriter) WriteHeaderNow() {}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *capturingWriter) Status() int {
+	return w.status
+}
+
+func (w *capturingWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *capturingWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+// Flush is deferred until the buffered response is validated
+func (w *capturingWriter) Flush() {}
+
+// flushOutput checks a response buffered by the handler stage and writes it, or a 500 when
+// a check fails in reject mode, to the real writer. Handler panics never reach it, so their
+// buffer is discarded and the recovery middleware responds.
+func (rb *RouteBuilder) flushOutput(c *gin.Context, capture *capturingWriter) {
+	original := capture.ResponseWriter
+	c.Writer = original
+	status, body := capture.status, capture.body.Bytes()
+	config := rb.engine.config
+	var rejection error
+	if err := rb.checkResponseHeaders(status, capture.Header()); err != nil {
+		LoggerFor(c).Warn("response header check failed", "error", err)
+		if config.ResponseHeaderMode == OutputValidationReject {
+			rejection = err
+		}
+	}
+	if config.ValidateOutput && rb.outputType != nil {
+		if err := rb.validateOutput(capture.Header().Get("Content-Type"), status, body); err != nil {
+			LoggerFor(c).Warn("output validation failed", "error", err)
+			if config.OutputValidationMode == OutputValidationReject && rejection == nil {
+				rejection = err
+			}
+		}
+	}
+	if rejection != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Output validation failed",
+			"details": rejection.Error(),
+		})
+		return
+	}
+
+	original.WriteHeader(status)
+	if len(body) > 0 {
+		original.Write(body)
+	} else {
+		original.WriteHeaderNow()
+	}
+}
+
+// validateOutput checks a successful JSON response body against the route's output type
+func (rb *RouteBuilder) validateOutput(contentType string, status int, body []byte) error {
+	if status < 200 || status >= 300 || len(body) == 0 || !strings.Contains(contentType, "json") {
+		return nil
+	}
+
+	// Contract-only validation checks just the fields clients depend on
+	if rb.engine.config.OutputContractOnly {
+		var output interface{}
+		if err := json.Unmarshal(body, &output); err != nil {
+			return NewSuperGinErrorWithCause(ErrValidationFailed, err, "invalid JSON response")
+		}
+		if err := checkContract(output, contractFields(rb.outputType), ""); err != nil {
+			return NewSuperGinErrorWithCause(ErrValidationFailed, err, "response breaks contract")
+		}
+		return nil
+	}
+
+	var output interface{}
+	if union, exists := LookupUnion(rb.outputType); exists {
+		decoded, err := union.Decode(body)
+		if err != nil {
+			return NewSuperGinErrorWithCause(ErrValidationFailed, err, "response does not match %s", union.Name)
+		}
+		output = decoded
+	} else {
+		value := reflect.New(rb.outputType)
+		if err := json.Unmarshal(body, value.Interface()); err != nil {
+			return NewSuperGinErrorWithCause(ErrValidationFailed, err, "response does not match %s", rb.outputType)
+		}
+		output = value.Interface()
+	}
+
+	if err := rb.validateOutputValue(reflect.ValueOf(output)); err != nil {
+		return NewSuperGinErrorWithCause(ErrValidationFailed, err, "invalid response")
+	}
+	return nil
+}
+
+// validateOutputValue validates structs directly and collections element-wise
+func (rb *RouteBuilder) validateOutputValue(v reflect.Value) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if !v.CanAddr() {
+			copied := reflect.New(v.Type())
+			copied.Elem().Set(v)
+			v = copied.Elem()
+		}
+		return validateStruct(rb.engine.validator, v.Addr().Interface())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := rb.validateOutputValue(v.Index(i)); err != nil {
+				return fmt.Errorf("item %d: %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// contractField is a response field tagged `contract:"required"`, or a field leading to one
+type contractField struct {
+	name     string
+	required bool
+	nested   []contractField
+}
+
+// contractCache holds the contract fields computed per output type
+var contractCache sync.Map
+
+// contractFields returns the contract-critical fields of a response type
+func contractFields(t reflect.Type) []contractField {
+	if cached, ok := contractCache.Load(t); ok {
+		return cached.([]contractField)
+	}
+	fields := buildContractFields(t, make(map[reflect.Type]bool))
+	contractCache.Store(t, fields)
+	return fields
+}
+
+func buildContractFields(t reflect.Type, visiting map[reflect.Type]bool) []contractField {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType || visiting[t] {
+		return nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var fields []contractField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			fields = append(fields, buildContractFields(field.Type, visiting)...)
+			continue
+		}
+
+		cf := contractField{
+			name:     name,
+			required: field.Tag.Get("contract") == "required",
+			nested:   buildContractFields(field.Type, visiting),
+		}
+		if cf.required || len(cf.nested) > 0 {
+			fields = append(fields, cf)
+		}
+	}
+	return fields
+}
+
+// checkContract verifies contract fields are present and non-null in a decoded JSON value.
+// Lists are checked element-wise; absent optional parents are not descended into.
+func checkContract(value interface{}, fields []contractField, path string) error {
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			if err := checkContract(item, fields, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for _, field := range fields {
+			fieldPath := strings.TrimPrefix(path+"."+field.name, ".")
+			fieldValue, exists := v[field.name]
+			if !exists || fieldValue == nil {
+				if field.required {
+					return fmt.Errorf("missing contract field '%s'", fieldPath)
+				}
+				continue
+			}
+			if err := checkContract(fieldValue, field.nested, fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package supergin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPerPage is the page size when a request gives no per_page
+	DefaultPerPage = 20
+	// DefaultMaxPerPage caps per_page
+	DefaultMaxPerPage = 100
+)
+
+// pageKey stores the request's parsed pagination parameters in the gin context
+const pageKey = "supergin:page"
+
+// PaginationOptions configures the page, per_page, sort and order query parameters
+type PaginationOptions struct {
+	// DefaultPerPage is used without per_page; zero means DefaultPerPage
+	DefaultPerPage int `json:"default_per_page"`
+	// MaxPerPage caps per_page; zero means DefaultMaxPerPage
+	MaxPerPage int `json:"max_per_page"`
+	// SortFields are the values sort accepts; empty means any plain identifier
+	SortFields []string `json:"sort_fields,omitempty"`
+	// DefaultSort and DefaultOrder apply without sort and order; order defaults to asc
+	DefaultSort  string `json:"default_sort,omitempty"`
+	DefaultOrder string `json:"default_order,omitempty"`
+}
+
+// PageRequest is a request's parsed pagination parameters
+type PageRequest struct {
+	Page    int    `json:"page"`
+	PerPage int    `json:"per_page"`
+	Sort    string `json:"sort,omitempty"`
+	Order   string `json:"order"`
+}
+
+// Offset is the index of the page's first item
+func (p PageRequest) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// OrderBy returns an ORDER BY expression such as "created_at DESC", or "" without sort.
+// Sort is validated against SortFields or as a plain identifier, so it is safe in SQL.
+func (p PageRequest) OrderBy() string {
+	if p.Sort == "" {
+		return ""
+	}
+	return p.Sort + " " + strings.ToUpper(p.Order)
+}
+
+// Paginated is the response envelope of a paginated collection, as documented by
+// WithListOutput
+type Paginated[T any] struct {
+	Items   []T `json:"items"`
+	Total   int `json:"total"`
+	Page    int `json:"page,omitempty"`
+	PerPage int `json:"per_page,omitempty"`
+}
+
+// WithPagination parses page, per_page, sort and order for the route, rejecting invalid
+// values with 400, and documents them; handlers read them with Pagination and respond
+// with WritePage. Pair it with WithListOutput for the envelope schema.
+func (rb *RouteBuilder) WithPagination(opts ...PaginationOptions) *RouteBuilder {
+	options := PaginationOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.DefaultPerPage <= 0 {
+		options.DefaultPerPage = DefaultPerPage
+	}
+	if options.MaxPerPage <= 0 {
+		options.MaxPerPage = DefaultMaxPerPage
+	}
+	if options.DefaultPerPage > options.MaxPerPage {
+		options.DefaultPerPage = options.MaxPerPage
+	}
+	if options.DefaultOrder == "" {
+		options.DefaultOrder = "asc"
+	}
+
+	rb.metadata["pagination"] = options
+	rb.middleware = append(rb.middleware, func(c *gin.Context) {
+		page, err := parsePageRequest(c, options)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination", "details": err.Error()})
+			c.Abort()
+			return
+		}
+		c.Set(pageKey, page)
+		c.Next()
+	})
+	return rb
+}
+
+// WithPagination paginates the resource's list and search routes: their responses are
+// documented as a Paginated envelope and page, per_page, sort and order are parsed
+func (rb *ResourceBuilder) WithPagination(opts ...PaginationOptions) *ResourceBuilder {
+	options := PaginationOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	rb.pagination = &options
+	return rb
+}
+
+// Pagination returns the request's pagination parameters; without WithPagination the
+// defaults (page 1, DefaultPerPage) are parsed from the query
+func Pagination(c *gin.Context) PageRequest {
+	if value, exists := c.Get(pageKey); exists {
+		return value.(PageRequest)
+	}
+	page, err := parsePageRequest(c, PaginationOptions{
+		DefaultPerPage: DefaultPerPage,
+		MaxPerPage:     DefaultMaxPerPage,
+		DefaultOrder:   "asc",
+	})
+	if err != nil {
+		return PageRequest{Page: 1, PerPage: DefaultPerPage, Order: "asc"}
+	}
+	return page
+}
+
+// WritePage responds with a Paginated envelope of items, setting X-Total-Count and a Link
+// header with first, prev, next and last page URLs
+func WritePage[T any](c *gin.Context, items []T, total int) {
+	page := Pagination(c)
+	if items == nil {
+		items = []T{}
+	}
+
+	lastPage := 1
+	if total > 0 {
+		lastPage = (total + page.PerPage - 1) / page.PerPage
+	}
+	links := []string{pageLink(c, 1, "first")}
+	if page.Page > 1 {
+		links = append(links, pageLink(c, min(page.Page-1, lastPage), "prev"))
+	}
+	if page.Page < lastPage {
+		links = append(links, pageLink(c, page.Page+1, "next"))
+	}
+	links = append(links, pageLink(c, lastPage, "last"))
+
+	c.Header("Link", strings.Join(links, ", "))
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	Respond(c, http.StatusOK, Paginated[T]{
+		Items:   items,
+		Total:   total,
+		Page:    page.Page,
+		PerPage: page.PerPage,
+	})
+}
+
+// pageLink formats a Link header entry for the current URL at another page
+func pageLink(c *gin.Context, page int, rel string) string {
+	u := *c.Request.URL
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	u.RawQuery = query.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.RequestURI(), rel)
+}
+
+// parsePageRequest reads and validates pagination query parameters
+func parsePageRequest(c *gin.Context, options PaginationOptions) (PageRequest, error) {
+	page := PageRequest{Page: 1, PerPage: options.DefaultPerPage, Sort: options.DefaultSort, Order: options.DefaultOrder}
+
+	if raw := c.Query("page"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			return page, fmt.Errorf("page must be a positive integer")
+		}
+		page.Page = n
+	}
+	if raw := c.Query("per_page"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > options.MaxPerPage {
+			return page, fmt.Errorf("per_page must be between 1 and %d", options.MaxPerPage)
+		}
+		page.PerPage = n
+	}
+	if raw := c.Query("sort"); raw != "" {
+		if len(options.SortFields) > 0 && !contains(options.SortFields, raw) {
+			return page, fmt.Errorf("sort must be one of: %s", strings.Join(options.SortFields, ", "))
+		}
+		if !columnPattern.MatchString(raw) {
+			return page, fmt.Errorf("invalid sort field '%s'", raw)
+		}
+		page.Sort = raw
+	}
+	if raw := c.Query("order"); raw != "" {
+		order := strings.ToLower(raw)
+		if order != "asc" && order != "desc" {
+			return page, fmt.Errorf("order must be asc or desc")
+		}
+		page.Order = order
+	}
+	return page, nil
+}
+
+// paginationParameters documents the pagination query parameters of a route
+func paginationParameters(options PaginationOptions) []interface{} {
+	sort := map[string]interface{}{"type": "string"}
+	if len(options.SortFields) > 0 {
+		sort["enum"] = options.SortFields
+	}
+	if options.DefaultSort != "" {
+		sort["default"] = options.DefaultSort
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"name": "page", "in": "query", "description": "Page number, starting at 1",
+			"schema": map[string]interface{}{"type": "integer", "minimum": 1, "default": 1},
+		},
+		map[string]interface{}{
+			"name": "per_page", "in": "query", "description": "Page size",
+			"schema": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": options.MaxPerPage, "default": options.DefaultPerPage},
+		},
+		map[string]interface{}{
+			"name": "sort", "in": "query", "description": "Field to sort by",
+			"schema": sort,
+		},
+		map[string]interface{}{
+			"name": "order", "in": "query", "description": "Sort direction",
+			"schema": map[string]interface{}{"type": "string", "enum": []string{"asc", "desc"}, "default": options.DefaultOrder},
+		},
+	}
+}
+
+// pageQueryNames are the query parameters owned by pagination
+var pageQueryNames = map[string]bool{"page": true, "per_page": true, "sort": true, "order": true}
@@ -0,0 +1,256 @@
+package supergin
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Request pipeline stages, in their default order
+const (
+	StageBind      = "bind"
+	StageSanitize  = "sanitize"
+	StageValidate  = "validate"
+	StageAuthorize = "authorize"
+	StageHandler   = "handler"
+	StageTransform = "transform"
+	StageSerialize = "serialize"
+)
+
+// DefaultStages is the default order of a route's request pipeline. Sanitize, authorize
+// and transform do nothing until replaced; policies and auth schemes still run as
+// middleware before the pipeline.
+var DefaultStages = []string{
+	StageBind, StageSanitize, StageValidate, StageAuthorize, StageHandler, StageTransform, StageSerialize,
+}
+
+// boundInputKey stores the input bound by the bind stage until it is validated
+const boundInputKey = "supergin:bound_input"
+
+// StageFunc runs one stage of a route's request pipeline. It calls next to continue with
+// the following stages, or writes a response and returns without calling it to stop.
+type StageFunc func(c *gin.Context, next func())
+
+// Stage is a named step of a route's request pipeline
+type Stage struct {
+	Name string
+	// Run is nil for stages that only pass the request on
+	Run StageFunc
+}
+
+// StageTiming is the time a request spent in one stage, excluding the stages it called
+type StageTiming struct {
+	Stage    string
+	Duration time.Duration
+}
+
+// ReplaceStage replaces a stage of the route's pipeline, e.g. a sanitize stage trimming
+// input strings or an authorize stage checking ownership of the bound input. A nil fn
+// turns the stage into a pass-through. Custom transform and serialize stages receive the
+// handler's response buffered (see ResponseBuffer); it is sent once they return.
+func (rb *RouteBuilder) ReplaceStage(name string, fn StageFunc) *RouteBuilder {
+	stages := rb.pipeline()
+	for i := range stages {
+		if stages[i].Name == name {
+			stages[i].Run = fn
+			if name == StageTransform || name == StageSerialize {
+				rb.bufferResponse = true
+			}
+			return rb
+		}
+	}
+	panic(fmt.Sprintf("route '%s' has no pipeline stage '%s'", rb.name, name))
+}
+
+// StageOrder reorders the route's pipeline, e.g. to authorize before binding. Every stage
+// must be listed once. Stages reading the response must follow the handler.
+func (rb *RouteBuilder) StageOrder(names ...string) *RouteBuilder {
+	stages := rb.pipeline()
+	if len(names) != len(stages) {
+		panic(fmt.Sprintf("route '%s' pipeline has %d stages, got %d", rb.name, len(stages), len(names)))
+	}
+
+	ordered := make([]Stage, 0, len(stages))
+	for _, name := range names {
+		found := false
+		for _, stage := range stages {
+			if stage.Name == name {
+				ordered = append(ordered, stage)
+				found = true
+				break
+			}
+		}
+		if !found {
+			panic(fmt.Sprintf("route '%s' has no pipeline stage '%s'", rb.name, name))
+		}
+	}
+	for _, stage := range stages {
+		if !containsStage(ordered, stage.Name) {
+			panic(fmt.Sprintf("route '%s' stage order is missing '%s'", rb.name, stage.Name))
+		}
+	}
+	rb.stages = ordered
+	return rb
+}
+
+func containsStage(stages []Stage, name string) bool {
+	for _, stage := range stages {
+		if stage.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// pipeline returns the route's stages, creating the default pipeline on first use
+func (rb *RouteBuilder) pipeline() []Stage {
+	if rb.stages == nil {
+		rb.stages = []Stage{
+			{Name: StageBind, Run: rb.bindStage},
+			{Name: StageSanitize},
+			{Name: StageValidate, Run: rb.validateStage},
+			{Name: StageAuthorize},
+			{Name: StageHandler, Run: rb.handlerStage},
+			{Name: StageTransform},
+			{Name: StageSerialize, Run: rb.serializeStage},
+		}
+	}
+	return rb.stages
+}
+
+// stageNames lists the route's stages in order
+func (rb *RouteBuilder) stageNames() []string {
+	stages := rb.pipeline()
+	names := make([]string, len(stages))
+	for i, stage := range stages {
+		names[i] = stage.Name
+	}
+	return names
+}
+
+// runPipeline runs the stages and records the time spent in each one that ran
+func (rb *RouteBuilder) runPipeline(c *gin.Context, stages []Stage) {
+	timings := make([]StageTiming, 0, len(stages))
+
+	var run func(i int)
+	run = func(i int) {
+		if i == len(stages) {
+			return
+		}
+		stage := stages[i]
+		if stage.Run == nil {
+			run(i + 1)
+			return
+		}
+
+		start := time.Now()
+		var downstream time.Duration
+		stage.Run(c, func() {
+			began := time.Now()
+			run(i + 1)
+			downstream += time.Since(began)
+		})
+		timings = append(timings, StageTiming{Stage: stage.Name, Duration: time.Since(start) - downstream})
+	}
+	run(0)
+
+	rb.engine.metrics.ObserveStages(rb.name, timings)
+}
+
+// bindStage binds the request into the route's input type, or limits raw bodies
+func (rb *RouteBuilder) bindStage(c *gin.Context, next func()) {
+	if rb.rawBodyLimit > 0 {
+		if !limitBody(c, rb.rawBodyLimit) {
+			return
+		}
+	} else if rb.engine.config.ValidateInput && rb.inputType != nil {
+		input, err := rb.engine.decodeInput(c, rb.inputType)
+		if err != nil {
+			writeValidationError(c, err)
+			return
+		}
+		c.Set(boundInputKey, input)
+	}
+	next()
+}
+
+// validateStage validates the bound input, then runs validators that need I/O
+func (rb *RouteBuilder) validateStage(c *gin.Context, next func()) {
+	if input, bound := BoundInput(c); bound {
+		if err := rb.engine.checkInput(input, rb.inputType); err != nil {
+			writeValidationError(c, err)
+			return
+		}
+		// Store validated input in context for handler use
+		c.Set("validated_input", input)
+
+		if err := rb.runAsyncValidators(c); err != nil {
+			if IsErrorCode(err, ErrValidationFailed) {
+				writeValidationError(c, err)
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error":   "Input validation unavailable",
+					"details": err.Error(),
+				})
+			}
+			return
+		}
+	}
+	next()
+}
+
+// handlerStage calls the route handler, buffering its response when later stages check
+// or rewrite it
+func (rb *RouteBuilder) handlerStage(c *gin.Context, next func()) {
+	config := rb.engine.config
+	if rb.bufferResponse || (config.ValidateOutput && rb.outputType != nil) ||
+		(config.ResponseHeaderMode == OutputValidationReject && rb.hasRequiredHeaders()) {
+		original := c.Writer
+		capture := newCapturingWriter(original)
+		c.Writer = capture
+		// Restore the writer even if the handler panics
+		defer func() { c.Writer = original }()
+
+		rb.handler(c)
+		next()
+		// Send what a replaced or skipped serialize stage left buffered
+		if c.Writer == capture {
+			rb.flushOutput(c, capture)
+		}
+		return
+	}
+
+	rb.handler(c)
+	next()
+}
+
+// serializeStage checks a buffered response and sends it, or checks the response headers
+// of an unbuffered one
+func (rb *RouteBuilder) serializeStage(c *gin.Context, next func()) {
+	if capture, buffered := c.Writer.(*capturingWriter); buffered {
+		rb.flushOutput(c, capture)
+	} else if rb.hasRequiredHeaders() {
+		if err := rb.checkResponseHeaders(c.Writer.Status(), c.Writer.Header()); err != nil {
+			LoggerFor(c).Warn("response header check failed", "error", err)
+		}
+	}
+	next()
+}
+
+// BoundInput returns the input bound by the bind stage, e.g. for a sanitize stage to
+// normalize before validation
+func BoundInput(c *gin.Context) (interface{}, bool) {
+	return c.Get(boundInputKey)
+}
+
+// ResponseBuffer returns the handler's buffered response body, for transform and
+// serialize stages to read or rewrite before it is sent
+func ResponseBuffer(c *gin.Context) (*bytes.Buffer, bool) {
+	if capture, buffered := c.Writer.(*capturingWriter); buffered {
+		return &capture.body, true
+	}
+	return nil, false
+}
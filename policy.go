@@ -0,0 +1,327 @@
+package supergin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyFile is a set of route policies, loaded from YAML or JSON, e.g.
+//
+//	policies:
+//	  - tags: [admin]
+//	    auth: jwt
+//	    rate_limit: {requests: 100, window: 1m}
+//	  - routes: [list_products]
+//	    cors: {allow_origins: ["https://shop.example.com"], max_age: 10m}
+type PolicyFile struct {
+	Policies []Policy `json:"policies" yaml:"policies"`
+}
+
+// Policy applies CORS, authentication and rate limiting to the routes it matches by name
+// or tag. For each route, policies matched by tag apply first and then those matched by
+// name, each in file order; later policies override the settings they declare.
+type Policy struct {
+	Routes []string `json:"routes,omitempty" yaml:"routes,omitempty"`
+	Tags   []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Auth names a scheme registered with RegisterAuthScheme; "none" removes one set by
+	// an earlier policy
+	Auth      string           `json:"auth,omitempty" yaml:"auth,omitempty"`
+	CORS      *CORSPolicy      `json:"cors,omitempty" yaml:"cors,omitempty"`
+	RateLimit *RateLimitPolicy `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+}
+
+// CORSPolicy configures cross-origin access to a route
+type CORSPolicy struct {
+	// AllowOrigins lists allowed origins; "*" allows any
+	AllowOrigins     []string `json:"allow_origins" yaml:"allow_origins"`
+	AllowMethods     []string `json:"allow_methods,omitempty" yaml:"allow_methods,omitempty"`
+	AllowHeaders     []string `json:"allow_headers,omitempty" yaml:"allow_headers,omitempty"`
+	ExposeHeaders    []string `json:"expose_headers,omitempty" yaml:"expose_headers,omitempty"`
+	AllowCredentials bool     `json:"allow_credentials,omitempty" yaml:"allow_credentials,omitempty"`
+	// MaxAge is how long preflight results may be cached, e.g. "10m"
+	MaxAge string `json:"max_age,omitempty" yaml:"max_age,omitempty"`
+}
+
+// RateLimitPolicy limits requests to a route per client
+type RateLimitPolicy struct {
+	Requests int `json:"requests" yaml:"requests"`
+	// Window is a duration such as "1m"
+	Window string `json:"window" yaml:"window"`
+	// Key is "client" (ClientID, falling back to the client IP, the default) or "ip"
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+}
+
+// routePolicy is the effective policy of one route
+type routePolicy struct {
+	auth     gin.HandlerFunc
+	cors     *CORSPolicy
+	limit    *RateLimit
+	limitKey string
+	windows  map[string]*fixedWindow
+	// swept is when expired windows were last dropped
+	swept time.Time
+	mutex sync.Mutex
+}
+
+// policySet maps route names to their effective policies
+type policySet map[string]*routePolicy
+
+// RegisterAuthScheme names authentication middleware so policy files can refer to it
+func (e *Engine) RegisterAuthScheme(name string, middleware gin.HandlerFunc) *Engine {
+	e.authSchemesMux.Lock()
+	defer e.authSchemesMux.Unlock()
+
+	e.authSchemes[name] = middleware
+	return e
+}
+
+// authScheme returns the middleware registered for a scheme
+func (e *Engine) authScheme(name string) (gin.HandlerFunc, bool) {
+	e.authSchemesMux.RLock()
+	defer e.authSchemesMux.RUnlock()
+
+	middleware, exists := e.authSchemes[name]
+	return middleware, exists
+}
+
+// ApplyPolicyFile loads policies from a .json, .yaml or .yml file and applies them; see
+// ApplyPolicies
+func (e *Engine) ApplyPolicyFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewSuperGinErrorWithCause(ErrInvalidPolicy, err, "failed to read policy file %s", path)
+	}
+
+	var file PolicyFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return NewSuperGinErrorWithCause(ErrInvalidPolicy, err, "failed to parse policy file %s", path)
+	}
+	return e.ApplyPolicies(file)
+}
+
+// ApplyPolicies validates policies against the route registry and applies them, replacing
+// previously applied policies. Call it once routes are registered: naming an unknown
+// route, tag or auth scheme is an error, and no policy is applied. Routes given a CORS
+// policy answer OPTIONS preflight requests.
+func (e *Engine) ApplyPolicies(file PolicyFile) error {
+	routes := e.GetRoutes()
+	tags := make(map[string]bool)
+	for _, route := range routes {
+		for _, tag := range route.Tags {
+			tags[tag] = true
+		}
+	}
+
+	var errs []error
+	for i, policy := range file.Policies {
+		errs = append(errs, e.validatePolicy(i, policy, routes, tags)...)
+	}
+	if len(errs) > 0 {
+		return NewSuperGinErrorWithCause(ErrInvalidPolicy, errors.Join(errs...), "invalid policies")
+	}
+
+	set := make(policySet)
+	for name, route := range routes {
+		var matched []Policy
+		for _, policy := range file.Policies {
+			if hasAnyTag(route.Tags, policy.Tags) {
+				matched = append(matched, policy)
+			}
+		}
+		for _, policy := range file.Policies {
+			if slices.Contains(policy.Routes, name) {
+				matched = append(matched, policy)
+			}
+		}
+		if len(matched) > 0 {
+			set[name] = e.compilePolicies(matched)
+		}
+	}
+
+	for name, rp := range set {
+		if rp.cors != nil {
+			e.registerPreflight(routes[name])
+		}
+	}
+	e.policies.Store(&set)
+	e.logger.Info("route policies applied", "policies", len(file.Policies), "routes", len(set))
+	return nil
+}
+
+// validatePolicy checks one policy against the registry
+func (e *Engine) validatePolicy(i int, policy Policy, routes map[string]*RouteInfo, tags map[string]bool) []error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("policy %d: %s", i, fmt.Sprintf(format, args...)))
+	}
+
+	if len(policy.Routes) == 0 && len(policy.Tags) == 0 {
+		fail("matches no routes or tags")
+	}
+	for _, name := range policy.Routes {
+		if _, exists := routes[name]; !exists {
+			fail("unknown route %q", name)
+		}
+	}
+	for _, tag := range policy.Tags {
+		if !tags[tag] {
+			fail("no route is tagged %q", tag)
+		}
+	}
+	if policy.Auth != "" && policy.Auth != "none" {
+		if _, exists := e.authScheme(policy.Auth); !exists {
+			fail("unknown auth scheme %q", policy.Auth)
+		}
+	}
+	if cors := policy.CORS; cors != nil {
+		for _, problem := range cors.problems() {
+			fail("%s", problem)
+		}
+	}
+	if limit := policy.RateLimit; limit != nil {
+		if limit.Requests <= 0 {
+			fail("rate_limit needs positive requests")
+		}
+		if window, err := time.ParseDuration(limit.Window); err != nil || window <= 0 {
+			fail("invalid rate_limit window %q", limit.Window)
+		}
+		if limit.Key != "" && limit.Key != "client" && limit.Key != "ip" {
+			fail("invalid rate_limit key %q", limit.Key)
+		}
+	}
+	return errs
+}
+
+// compilePolicies merges a route's matched policies, later ones overriding
+func (e *Engine) compilePolicies(policies []Policy) *routePolicy {
+	rp := &routePolicy{windows: make(map[string]*fixedWindow)}
+	for _, policy := range policies {
+		switch policy.Auth {
+		case "":
+		case "none":
+			rp.auth = nil
+		default:
+			rp.auth, _ = e.authScheme(policy.Auth)
+		}
+		if policy.CORS != nil {
+			rp.cors = policy.CORS
+		}
+		if limit := policy.RateLimit; limit != nil {
+			window, _ := time.ParseDuration(limit.Window)
+			rp.limit = &RateLimit{Requests: limit.Requests, Window: window}
+			rp.limitKey = limit.Key
+		}
+	}
+	return rp
+}
+
+// currentPolicy returns the route's policy from the applied policy file, if any
+func (e *Engine) currentPolicy(name string) *routePolicy {
+	if set := e.policies.Load(); set != nil {
+		return (*set)[name]
+	}
+	return nil
+}
+
+// policyMiddleware applies the route's current CORS and auth policy, whose CORS settings
+// override the route's declared CORS policy; it is part of every route's chain so
+// policies can be applied after routes are registered
+func (e *Engine) policyMiddleware(name string, declared *CORSPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rp := e.currentPolicy(name)
+		cors := declared
+		if rp != nil && rp.cors != nil {
+			cors = rp.cors
+		}
+		if cors != nil && !applyCORS(c, cors) {
+			return
+		}
+		if rp != nil && rp.auth != nil {
+			rp.auth(c)
+			if c.IsAborted() {
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// policyLimitMiddleware applies the route's current rate limit. It follows the route's
+// authentication, so requests are counted per authenticated client and rejected ones do
+// not spend the quota.
+func (e *Engine) policyLimitMiddleware(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if rp := e.currentPolicy(name); rp != nil && rp.limit != nil && !rp.allow(c) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// allow counts the request against the route's rate limit, responding 429 when over it
+func (rp *routePolicy) allow(c *gin.Context) bool {
+	key := c.ClientIP()
+	if client := ClientID(c); client != "" && rp.limitKey != "ip" {
+		key = client
+	}
+
+	now := time.Now()
+	rp.mutex.Lock()
+	if now.Sub(rp.swept) >= rp.limit.Window {
+		// Drop windows that have expired, so rotating clients do not grow the map
+		for windowKey, window := range rp.windows {
+			if now.Sub(window.start) >= rp.limit.Window {
+				delete(rp.windows, windowKey)
+			}
+		}
+		rp.swept = now
+	}
+	window, exists := rp.windows[key]
+	if !exists {
+		window = &fixedWindow{}
+		rp.windows[key] = window
+	}
+	allowed := window.allow(*rp.limit, now)
+	status := window.status(*rp.limit, now)
+	rp.mutex.Unlock()
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(status.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(status.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(status.ResetAt.Unix(), 10))
+	if !allowed {
+		retryAfter := int(time.Until(status.ResetAt).Seconds()) + 1
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error":       "Rate limit exceeded",
+			"retry_after": retryAfter,
+		})
+	}
+	return allowed
+}
+
+// hasAnyTag reports whether tags contains any of wanted
+func hasAnyTag(tags, wanted []string) bool {
+	for _, tag := range wanted {
+		if slices.Contains(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
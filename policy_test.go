@@ -0,0 +1,94 @@
+package supergin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRateLimitedEngine serves GET /orders as "list_orders", limited by policy to one request
+// a minute per API key client
+func newRateLimitedEngine(t *testing.T) *Engine {
+	t.Helper()
+	app := newTestEngine(Config{})
+	app.RegisterAuthScheme("apikey", APIKeyAuth(APIKeyOptions{Store: StaticAPIKeys{
+		"alice-key": {Client: "alice"},
+		"bob-key":   {Client: "bob"},
+	}}))
+	app.Named("list_orders").GET("/orders").Handler(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"client": ClientID(c)})
+	})
+	err := app.ApplyPolicies(PolicyFile{Policies: []Policy{{
+		Routes:    []string{"list_orders"},
+		Auth:      "apikey",
+		RateLimit: &RateLimitPolicy{Requests: 1, Window: "1m"},
+	}}})
+	if err != nil {
+		t.Fatalf("ApplyPolicies: %v", err)
+	}
+	return app
+}
+
+func getOrders(app *Engine, apiKey string) int {
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	if apiKey != "" {
+		req.Header.Set(DefaultAPIKeyHeader, apiKey)
+	}
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestPolicyRateLimitPerClient(t *testing.T) {
+	app := newRateLimitedEngine(t)
+
+	steps := []struct {
+		apiKey string
+		want   int
+	}{
+		// Rejected requests do not spend anyone's quota
+		{"", http.StatusUnauthorized},
+		{"wrong-key", http.StatusUnauthorized},
+		{"alice-key", http.StatusOK},
+		// Clients sharing an IP have their own windows
+		{"bob-key", http.StatusOK},
+		{"alice-key", http.StatusTooManyRequests},
+		{"bob-key", http.StatusTooManyRequests},
+	}
+	for i, step := range steps {
+		if got := getOrders(app, step.apiKey); got != step.want {
+			t.Errorf("request %d with key %q = %d, want %d", i, step.apiKey, got, step.want)
+		}
+	}
+}
+
+func TestPolicyRateLimitDropsExpiredWindows(t *testing.T) {
+	app := newRateLimitedEngine(t)
+	getOrders(app, "alice-key")
+	getOrders(app, "bob-key")
+
+	rp := app.currentPolicy("list_orders")
+	rp.mutex.Lock()
+	if len(rp.windows) != 2 {
+		t.Fatalf("%d windows, want 2", len(rp.windows))
+	}
+	// Let the windows and the last sweep expire
+	past := time.Now().Add(-2 * time.Minute)
+	for _, window := range rp.windows {
+		window.start = past
+	}
+	rp.swept = past
+	rp.mutex.Unlock()
+
+	if got := getOrders(app, "alice-key"); got != http.StatusOK {
+		t.Fatalf("alice after her window expired = %d, want 200", got)
+	}
+	rp.mutex.Lock()
+	defer rp.mutex.Unlock()
+	if _, kept := rp.windows["bob"]; kept || len(rp.windows) != 1 {
+		t.Errorf("windows after expiry = %v, want only alice's", rp.windows)
+	}
+}
@@ -0,0 +1,30 @@
+package supergin
+
+import (
+	"io"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultRawBodyLimit caps raw request bodies when WithRawBody is given no limit
+const DefaultRawBodyLimit int64 = 10 << 20
+
+// WithRawBody marks the route as receiving arbitrary payloads, e.g. webhooks or file
+// proxies: input binding and validation are skipped and the body is capped at maxBytes
+// (DefaultRawBodyLimit when omitted). Read it with RawBody.
+func (rb *RouteBuilder) WithRawBody(maxBytes ...int64) *RouteBuilder {
+	limit := DefaultRawBodyLimit
+	if len(maxBytes) > 0 && maxBytes[0] > 0 {
+		limit = maxBytes[0]
+	}
+	rb.rawBodyLimit = limit
+	rb.WithMetadata("raw_body", true)
+	rb.WithMetadata("raw_body_limit", limit)
+	return rb
+}
+
+// RawBody returns the request body reader. On WithRawBody routes reads fail with
+// *http.MaxBytesError once the route's limit is exceeded.
+func RawBody(c *gin.Context) io.ReadCloser {
+	return c.Request.Body
+}
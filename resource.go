@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 // CRUDController interface for REST operations
@@ -18,6 +19,36 @@ type CRUDController interface {
 	Search(c *gin.Context)
 }
 
+// CRUDControllerE is a CRUDController whose actions return their errors. Adapt it with
+// ControllerE to pass it to Resource.
+type CRUDControllerE interface {
+	Create(c *gin.Context) error
+	Read(c *gin.Context) error
+	Update(c *gin.Context) error
+	Delete(c *gin.Context) error
+	List(c *gin.Context) error
+	Search(c *gin.Context) error
+}
+
+// ControllerE adapts an error-returning controller, writing its errors with HandleError:
+//
+//	app.Resource("User", supergin.ControllerE(&UserController{})).Build()
+func ControllerE(controller CRUDControllerE) CRUDController {
+	return errorController{controller}
+}
+
+// errorController routes the errors of a CRUDControllerE through HandleError
+type errorController struct {
+	controller CRUDControllerE
+}
+
+func (ec errorController) Create(c *gin.Context) { HandleE(ec.controller.Create)(c) }
+func (ec errorController) Read(c *gin.Context)   { HandleE(ec.controller.Read)(c) }
+func (ec errorController) Update(c *gin.Context) { HandleE(ec.controller.Update)(c) }
+func (ec errorController) Delete(c *gin.Context) { HandleE(ec.controller.Delete)(c) }
+func (ec errorController) List(c *gin.Context)   { HandleE(ec.controller.List)(c) }
+func (ec errorController) Search(c *gin.Context) { HandleE(ec.controller.Search)(c) }
+
 // ModelInfo holds information about a model for route generation
 type ModelInfo struct {
 	Name         string
@@ -31,6 +62,7 @@ type ModelInfo struct {
 	Tags         []string
 	Metadata     map[string]interface{}
 	CustomRoutes map[string]CustomRoute
+	Environments []string
 }
 
 // CustomRoute defines additional routes for a model
@@ -59,6 +91,8 @@ type ResourceBuilder struct {
 	engine     *Engine
 	modelInfo  *ModelInfo
 	restRoutes *RestRoutes
+	seed       *SeedOptions
+	pagination *PaginationOptions
 }
 
 // Resource creates a new resource builder for a model
@@ -77,6 +111,23 @@ func (e *Engine) Resource(name string, controller CRUDController) *ResourceBuild
 		CustomRoutes: make(map[string]CustomRoute),
 	}
 
+	if typed, ok := controller.(interface {
+		modelTypes() (input, output, search reflect.Type)
+	}); ok {
+		// Typed controllers such as CRUD adapters declare their own model types
+		modelInfo.InputType, modelInfo.OutputType, modelInfo.SearchType = typed.modelTypes()
+	}
+	if validated, ok := controller.(interface {
+		useValidator(validate *validator.Validate)
+	}); ok {
+		// Typed controllers validate unvalidated input with the engine's rules
+		validated.useValidator(e.validator)
+	}
+
+	e.routesMux.Lock()
+	e.resources = append(e.resources, modelInfo)
+	e.routesMux.Unlock()
+
 	return &ResourceBuilder{
 		engine:    e,
 		modelInfo: modelInfo,
@@ -117,6 +168,12 @@ func (rb *ResourceBuilder) WithTags(tags ...string) *ResourceBuilder {
 	return rb
 }
 
+// WithEnvironments limits all resource routes to the named environments
+func (rb *ResourceBuilder) WithEnvironments(envs ...string) *ResourceBuilder {
+	rb.modelInfo.Environments = append(rb.modelInfo.Environments, envs...)
+	return rb
+}
+
 // WithBasePath sets a custom base path for the resource
 func (rb *ResourceBuilder) WithBasePath(path string) *ResourceBuilder {
 	rb.modelInfo.BasePath = path
@@ -159,6 +216,16 @@ func (rb *ResourceBuilder) Collection(name, method, path string, handler gin.Han
 	return rb
 }
 
+// MemberE adds a custom member route whose handler returns its errors (see HandlerE)
+func (rb *ResourceBuilder) MemberE(name, method, path string, handler HandlerFuncE) *ResourceBuilder {
+	return rb.Member(name, method, path, HandleE(handler))
+}
+
+// CollectionE adds a custom collection route whose handler returns its errors (see HandlerE)
+func (rb *ResourceBuilder) CollectionE(name, method, path string, handler HandlerFuncE) *ResourceBuilder {
+	return rb.Collection(name, method, path, HandleE(handler))
+}
+
 // Only restricts which REST actions to generate
 func (rb *ResourceBuilder) Only(actions ...string) *ResourceBuilder {
 	// Store which actions to generate
@@ -213,6 +280,10 @@ func (rb *ResourceBuilder) Build() *RestRoutes {
 		rb.generateCustomRoute(customRoute)
 	}
 
+	if rb.seed != nil {
+		rb.generateSeedRoute()
+	}
+
 	return rb.restRoutes
 }
 
@@ -222,12 +293,15 @@ func (rb *ResourceBuilder) generateListRoute() {
 		GET(rb.modelInfo.BasePath).
 		WithDescription(fmt.Sprintf("List all %s", rb.modelInfo.PluralName)).
 		WithTags(rb.modelInfo.Tags...).
-		WithMiddleware(rb.modelInfo.Middleware...)
+		WithMiddleware(rb.modelInfo.Middleware...).
+		WithEnvironments(rb.modelInfo.Environments...)
 
 	if rb.modelInfo.OutputType != nil {
-		// For list, we expect a slice of the output type
-		sliceType := reflect.SliceOf(rb.modelInfo.OutputType)
-		builder.WithOutput(reflect.New(sliceType).Elem().Interface())
+		// For list, we expect an array of the output type, or a page of them
+		builder.WithListOutput(reflect.New(rb.modelInfo.OutputType).Elem().Interface(), ListOptions{Bare: rb.pagination == nil})
+	}
+	if rb.pagination != nil {
+		builder.WithPagination(*rb.pagination)
 	}
 
 	for k, v := range rb.modelInfo.Metadata {
@@ -242,7 +316,8 @@ func (rb *ResourceBuilder) generateCreateRoute() {
 		POST(rb.modelInfo.BasePath).
 		WithDescription(fmt.Sprintf("Create a new %s", rb.modelInfo.Name)).
 		WithTags(rb.modelInfo.Tags...).
-		WithMiddleware(rb.modelInfo.Middleware...)
+		WithMiddleware(rb.modelInfo.Middleware...).
+		WithEnvironments(rb.modelInfo.Environments...)
 
 	if rb.modelInfo.InputType != nil && rb.modelInfo.OutputType != nil {
 		builder.WithIO(
@@ -263,7 +338,8 @@ func (rb *ResourceBuilder) generateReadRoute() {
 		GET(rb.modelInfo.BasePath + "/:id").
 		WithDescription(fmt.Sprintf("Get %s by ID", rb.modelInfo.Name)).
 		WithTags(rb.modelInfo.Tags...).
-		WithMiddleware(rb.modelInfo.Middleware...)
+		WithMiddleware(rb.modelInfo.Middleware...).
+		WithEnvironments(rb.modelInfo.Environments...)
 
 	if rb.modelInfo.OutputType != nil {
 		builder.WithOutput(reflect.New(rb.modelInfo.OutputType).Elem().Interface())
@@ -281,7 +357,8 @@ func (rb *ResourceBuilder) generateUpdateRoute() {
 		PUT(rb.modelInfo.BasePath + "/:id").
 		WithDescription(fmt.Sprintf("Update %s by ID", rb.modelInfo.Name)).
 		WithTags(rb.modelInfo.Tags...).
-		WithMiddleware(rb.modelInfo.Middleware...)
+		WithMiddleware(rb.modelInfo.Middleware...).
+		WithEnvironments(rb.modelInfo.Environments...)
 
 	if rb.modelInfo.InputType != nil && rb.modelInfo.OutputType != nil {
 		builder.WithIO(
@@ -302,7 +379,8 @@ func (rb *ResourceBuilder) generateDeleteRoute() {
 		DELETE(rb.modelInfo.BasePath + "/:id").
 		WithDescription(fmt.Sprintf("Delete %s by ID", rb.modelInfo.Name)).
 		WithTags(rb.modelInfo.Tags...).
-		WithMiddleware(rb.modelInfo.Middleware...)
+		WithMiddleware(rb.modelInfo.Middleware...).
+		WithEnvironments(rb.modelInfo.Environments...)
 
 	for k, v := range rb.modelInfo.Metadata {
 		builder.WithMetadata(k, v)
@@ -316,14 +394,15 @@ func (rb *ResourceBuilder) generateSearchRoute() {
 		GET(rb.modelInfo.BasePath + "/search").
 		WithDescription(fmt.Sprintf("Search %s", rb.modelInfo.PluralName)).
 		WithTags(rb.modelInfo.Tags...).
-		WithMiddleware(rb.modelInfo.Middleware...)
+		WithMiddleware(rb.modelInfo.Middleware...).
+		WithEnvironments(rb.modelInfo.Environments...)
 
 	if rb.modelInfo.SearchType != nil && rb.modelInfo.OutputType != nil {
-		sliceType := reflect.SliceOf(rb.modelInfo.OutputType)
-		builder.WithIO(
-			reflect.New(rb.modelInfo.SearchType).Elem().Interface(),
-			reflect.New(sliceType).Elem().Interface(),
-		)
+		builder.WithInput(reflect.New(rb.modelInfo.SearchType).Elem().Interface())
+		builder.WithListOutput(reflect.New(rb.modelInfo.OutputType).Elem().Interface(), ListOptions{Bare: rb.pagination == nil})
+	}
+	if rb.pagination != nil {
+		builder.WithPagination(*rb.pagination)
 	}
 
 	for k, v := range rb.modelInfo.Metadata {
@@ -351,7 +430,8 @@ func (rb *ResourceBuilder) generateCustomRoute(customRoute CustomRoute) {
 
 	builder.WithDescription(customRoute.Description).
 		WithTags(rb.modelInfo.Tags...).
-		WithMiddleware(rb.modelInfo.Middleware...)
+		WithMiddleware(rb.modelInfo.Middleware...).
+		WithEnvironments(rb.modelInfo.Environments...)
 
 	if customRoute.InputType != nil && customRoute.OutputType != nil {
 		builder.WithIO(
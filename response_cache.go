@@ -0,0 +1,337 @@
+package supergin
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// CacheStatusHeader reports whether a cached route was served from the cache: HIT or MISS
+	CacheStatusHeader = "X-Cache"
+
+	// cacheKeyPrefix starts every response cache key
+	cacheKeyPrefix = "supergin:cache:"
+
+	// DefaultMemoryCacheEntries bounds the default in-memory response cache
+	DefaultMemoryCacheEntries = 10000
+
+	// cacheStoreTimeout bounds each cache store operation during a request
+	cacheStoreTimeout = time.Second
+)
+
+// CacheStore holds cached responses for WithCache routes: NewMemoryCache keeps them in
+// process, RedisCache shares them between instances
+type CacheStore interface {
+	// Get returns the value stored under key, or false when absent or expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix removes every key starting with prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// CacheOptions configures a route's response cache
+type CacheOptions struct {
+	// VaryHeaders are request headers whose values are part of the cache key, e.g.
+	// "Accept-Language"; they are also listed in the Vary response header
+	VaryHeaders []string
+	// Public marks responses cacheable by shared caches such as CDNs; they are private
+	// otherwise
+	Public bool
+	// CacheControl replaces the emitted Cache-Control header; "-" omits it
+	CacheControl string
+}
+
+// cachedResponse is a response as stored in a CacheStore
+type cachedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// uncachedHeaders are response headers never stored with a cached response. The body is
+// recorded before compression, so its encoding is negotiated again on every hit, and CORS
+// headers are set for each request's origin.
+var uncachedHeaders = map[string]bool{
+	"Set-Cookie":                       true,
+	"Date":                             true,
+	"Content-Encoding":                 true,
+	"Content-Length":                   true,
+	"Access-Control-Allow-Origin":      true,
+	"Access-Control-Allow-Credentials": true,
+	"Access-Control-Expose-Headers":    true,
+	CacheStatusHeader:                  true,
+}
+
+// SetCacheStore replaces the in-memory store of WithCache routes, e.g. with RedisCache
+// to share cached responses between instances
+func (e *Engine) SetCacheStore(store CacheStore) *Engine {
+	if store == nil {
+		panic("cache store must not be nil")
+	}
+	e.cacheStore.Store(&store)
+	return e
+}
+
+// currentCacheStore returns the store set with SetCacheStore, creating the default
+// in-memory store on first use
+func (e *Engine) currentCacheStore() CacheStore {
+	for {
+		if store := e.cacheStore.Load(); store != nil {
+			return *store
+		}
+		var store CacheStore = NewMemoryCache(DefaultMemoryCacheEntries)
+		if e.cacheStore.CompareAndSwap(nil, &store) {
+			return store
+		}
+	}
+}
+
+// InvalidateCache drops every cached response of a named route, on all instances when the
+// store is shared, e.g. app.InvalidateCache("list_users") after creating a user
+func (e *Engine) InvalidateCache(route string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cacheStoreTimeout)
+	defer cancel()
+
+	if err := e.currentCacheStore().DeletePrefix(ctx, cacheKeyPrefix+route+":"); err != nil {
+		return fmt.Errorf("failed to invalidate cache of route %s: %w", route, err)
+	}
+	return nil
+}
+
+// WithCache caches the route's successful GET responses (status, headers and body) for ttl.
+// Entries are keyed by route name, path and query parameters, the VaryHeaders and the
+// authenticated principal, so one caller's responses are never served to another.
+// Responses carry X-Cache: HIT or MISS and a Cache-Control max-age.
+func (rb *RouteBuilder) WithCache(ttl time.Duration, opts ...CacheOptions) *RouteBuilder {
+	if ttl <= 0 {
+		panic("cache TTL must be positive")
+	}
+	options := CacheOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	varyHeaders := make([]string, len(options.VaryHeaders))
+	for i, header := range options.VaryHeaders {
+		varyHeaders[i] = http.CanonicalHeaderKey(header)
+	}
+	sort.Strings(varyHeaders)
+
+	cacheControl := options.CacheControl
+	if cacheControl == "" {
+		visibility := "private"
+		if options.Public {
+			visibility = "public"
+		}
+		cacheControl = fmt.Sprintf("%s, max-age=%d", visibility, int(ttl.Seconds()))
+	}
+
+	rb.WithMetadata("cache_ttl", ttl.String())
+	name := rb.name
+	engine := rb.engine
+	return rb.WithMiddleware(func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+		if cacheControl != "-" {
+			c.Header("Cache-Control", cacheControl)
+		}
+		if len(varyHeaders) > 0 {
+			// Added to, not replacing, the Vary values of CORS and compression
+			c.Writer.Header().Add("Vary", strings.Join(varyHeaders, ", "))
+		}
+
+		store := engine.currentCacheStore()
+		key := cacheKey(c, name, varyHeaders)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), cacheStoreTimeout)
+		value, hit, err := store.Get(ctx, key)
+		cancel()
+		if err != nil {
+			LoggerFor(c).Warn("response cache lookup failed", "route", name, "error", err)
+		}
+		var cached cachedResponse
+		if hit && json.Unmarshal(value, &cached) == nil {
+			for header, values := range cached.Header {
+				if header == "Vary" {
+					addVary(c.Writer.Header(), values)
+					continue
+				}
+				c.Writer.Header()[header] = values
+			}
+			c.Header(CacheStatusHeader, "HIT")
+			c.Data(cached.Status, c.Writer.Header().Get("Content-Type"), cached.Body)
+			c.Abort()
+			return
+		}
+
+		c.Header(CacheStatusHeader, "MISS")
+		recorder := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		c.Writer = recorder.ResponseWriter
+
+		if recorder.Status() != http.StatusOK || c.IsAborted() {
+			return
+		}
+		header := make(http.Header)
+		for name, values := range recorder.Header() {
+			if !uncachedHeaders[name] {
+				header[name] = values
+			}
+		}
+		value, err = json.Marshal(cachedResponse{Status: recorder.Status(), Header: header, Body: recorder.body.Bytes()})
+		if err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), cacheStoreTimeout)
+			err = store.Set(ctx, key, value, ttl)
+			cancel()
+		}
+		if err != nil {
+			LoggerFor(c).Warn("failed to cache response", "route", name, "error", err)
+		}
+	})
+}
+
+// addVary adds Vary values the response does not list yet
+func addVary(header http.Header, values []string) {
+	for _, value := range values {
+		if !slices.Contains(header.Values("Vary"), value) {
+			header.Add("Vary", value)
+		}
+	}
+}
+
+// cacheKey derives a request's cache key from the route name, path and query parameters,
+// vary headers and principal
+func cacheKey(c *gin.Context, route string, varyHeaders []string) string {
+	hash := sha256.New()
+	params := append(gin.Params(nil), c.Params...)
+	sort.Slice(params, func(i, j int) bool { return params[i].Key < params[j].Key })
+	for _, param := range params {
+		fmt.Fprintf(hash, "p:%s=%s\x00", param.Key, param.Value)
+	}
+	// Encode sorts the query by key
+	fmt.Fprintf(hash, "q:%s\x00", c.Request.URL.Query().Encode())
+	for _, header := range varyHeaders {
+		fmt.Fprintf(hash, "h:%s=%s\x00", header, strings.Join(c.Request.Header.Values(header), ","))
+	}
+	if principal, ok := GetPrincipal(c); ok {
+		fmt.Fprintf(hash, "u:%s\x00", principal.PrincipalID())
+	}
+	return cacheKeyPrefix + route + ":" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// recordingWriter records a response body while writing it through
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// MemoryCache is an in-process CacheStore evicting the least recently used entries
+type MemoryCache struct {
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+	mutex      sync.Mutex
+}
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates an LRU cache holding up to maxEntries responses
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryCacheEntries
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get implements CacheStore
+func (m *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	element, exists := m.entries[key]
+	if !exists {
+		return nil, false, nil
+	}
+	entry := element.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		m.order.Remove(element)
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	m.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set implements CacheStore
+func (m *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	entry := &memoryCacheEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+	if element, exists := m.entries[key]; exists {
+		element.Value = entry
+		m.order.MoveToFront(element)
+		return nil
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	for m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+	return nil
+}
+
+// DeletePrefix implements CacheStore
+func (m *MemoryCache) DeletePrefix(ctx context.Context, prefix string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for key, element := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			m.order.Remove(element)
+			delete(m.entries, key)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of cached entries, expired ones included until they are read
+func (m *MemoryCache) Len() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.order.Len()
+}
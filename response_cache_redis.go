@@ -0,0 +1,141 @@
+package supergin
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redisCacheScanCount is the COUNT hint of each SCAN during DeletePrefix
+const redisCacheScanCount = "500"
+
+// redisCache stores cached responses in Redis, speaking RESP directly
+type redisCache struct {
+	addr    string
+	options RedisOptions
+
+	conn   net.Conn
+	reader *bufio.Reader
+	mutex  sync.Mutex
+}
+
+// RedisCache stores cached responses in Redis at addr, e.g. "localhost:6379", so instances
+// share them and InvalidateCache reaches all of them. Commands share one connection.
+func RedisCache(addr string, opts ...RedisOptions) CacheStore {
+	options := RedisOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.DialTimeout <= 0 {
+		options.DialTimeout = DefaultAdapterDialTimeout
+	}
+	return &redisCache{addr: addr, options: options}
+}
+
+// Get implements CacheStore
+func (rc *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := rc.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+	value, ok := reply.([]byte)
+	return value, ok, nil
+}
+
+// Set implements CacheStore
+func (rc *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	ms := ttl.Milliseconds()
+	if ms < 1 {
+		ms = 1
+	}
+	_, err := rc.do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(ms, 10))
+	return err
+}
+
+// DeletePrefix implements CacheStore, scanning for the prefix and deleting each batch found
+func (rc *redisCache) DeletePrefix(ctx context.Context, prefix string) error {
+	pattern := redisGlobEscaper.Replace(prefix) + "*"
+	cursor := "0"
+	for {
+		reply, err := rc.do(ctx, "SCAN", cursor, "MATCH", pattern, "COUNT", redisCacheScanCount)
+		if err != nil {
+			return err
+		}
+		// SCAN replies [next cursor, [keys...]]
+		page, ok := reply.([]interface{})
+		if !ok || len(page) != 2 {
+			return fmt.Errorf("redis: unexpected SCAN reply")
+		}
+		next, _ := page[0].([]byte)
+		keys, _ := page[1].([]interface{})
+		if len(keys) > 0 {
+			args := make([]string, 0, len(keys)+1)
+			args = append(args, "DEL")
+			for _, key := range keys {
+				if key, ok := key.([]byte); ok {
+					args = append(args, string(key))
+				}
+			}
+			if _, err := rc.do(ctx, args...); err != nil {
+				return err
+			}
+		}
+		cursor = string(next)
+		if cursor == "0" || cursor == "" {
+			return nil
+		}
+	}
+}
+
+// Close closes the shared connection
+func (rc *redisCache) Close() error {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	if rc.conn == nil {
+		return nil
+	}
+	err := rc.conn.Close()
+	rc.conn, rc.reader = nil, nil
+	return err
+}
+
+// do runs one command on the shared connection, redialing once if it has gone stale
+func (rc *redisCache) do(ctx context.Context, args ...string) (interface{}, error) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if rc.conn == nil {
+			if rc.conn, rc.reader, err = dialRedis(ctx, rc.addr, rc.options); err != nil {
+				return nil, err
+			}
+		}
+		deadline, _ := ctx.Deadline()
+		rc.conn.SetDeadline(deadline)
+		var reply interface{}
+		if err = writeRESP(rc.conn, args...); err == nil {
+			reply, err = readRESP(rc.reader)
+		}
+		if err == nil {
+			return reply, nil
+		}
+		var replyErr redisError
+		if errors.As(err, &replyErr) {
+			return nil, err
+		}
+		rc.conn.Close()
+		rc.conn, rc.reader = nil, nil
+	}
+	return nil, fmt.Errorf("redis %s failed: %w", args[0], err)
+}
+
+// redisGlobEscaper escapes the glob characters of SCAN MATCH patterns
+var redisGlobEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
@@ -0,0 +1,81 @@
+package supergin
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCacheWithCompression(t *testing.T) {
+	app := newTestEngine(Config{
+		Compression: &CompressionOptions{},
+		CORS:        &CORSPolicy{AllowOrigins: []string{"https://a.example"}},
+	})
+	body := strings.Repeat("cached ", 1000)
+	app.Named("report").GET("/report").
+		WithCache(time.Minute, CacheOptions{VaryHeaders: []string{"Accept-Language"}}).
+		Handler(func(c *gin.Context) { c.String(http.StatusOK, body) })
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		origin         string
+		cache          string
+		encoding       string
+		allowOrigin    string
+	}{
+		{"miss, gzip", "gzip", "https://a.example", "MISS", "gzip", "https://a.example"},
+		{"hit without Accept-Encoding", "", "https://a.example", "HIT", "", "https://a.example"},
+		{"hit, gzip", "gzip", "", "HIT", "gzip", ""},
+		{"hit from another origin", "", "https://b.example", "HIT", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/report", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+
+			if got := w.Header().Get(CacheStatusHeader); got != tt.cache {
+				t.Errorf("%s = %q, want %q", CacheStatusHeader, got, tt.cache)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != tt.encoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.encoding)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.allowOrigin)
+			}
+			vary := w.Header().Values("Vary")
+			for _, want := range []string{"Accept-Encoding", "Accept-Language"} {
+				if !slices.Contains(vary, want) {
+					t.Errorf("Vary = %v, missing %s", vary, want)
+				}
+			}
+
+			var reader io.Reader = w.Body
+			if tt.encoding == "gzip" {
+				gz, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatalf("gzip: %v", err)
+				}
+				reader = gz
+			}
+			got, err := io.ReadAll(reader)
+			if err != nil || string(got) != body {
+				t.Errorf("body is %d bytes (%v), want the %d byte original", len(got), err, len(body))
+			}
+		})
+	}
+}
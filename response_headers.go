@@ -0,0 +1,75 @@
+package supergin
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ResponseHeader declares a header a route sends on successful responses
+type ResponseHeader struct {
+	Name        string `json:"name"`
+	Required    bool   `json:"required"`
+	Description string `json:"description,omitempty"`
+}
+
+// WithResponseHeader declares a response header for docs and verification, e.g.
+// WithResponseHeader("X-Total-Count", true, "Total number of items"). Required headers
+// missing from a 2xx response are logged, or turned into a 500 when
+// Config.ResponseHeaderMode is OutputValidationReject.
+func (rb *RouteBuilder) WithResponseHeader(name string, required bool, description ...string) *RouteBuilder {
+	header := ResponseHeader{
+		Name:     http.CanonicalHeaderKey(name),
+		Required: required,
+	}
+	if len(description) > 0 {
+		header.Description = description[0]
+	}
+	rb.responseHeaders = append(rb.responseHeaders, header)
+	return rb
+}
+
+// hasRequiredHeaders reports whether the route declares any required response header
+func (rb *RouteBuilder) hasRequiredHeaders() bool {
+	for _, header := range rb.responseHeaders {
+		if header.Required {
+			return true
+		}
+	}
+	return false
+}
+
+// checkResponseHeaders reports required headers missing from a successful response
+func (rb *RouteBuilder) checkResponseHeaders(status int, headers http.Header) error {
+	if status < 200 || status >= 300 {
+		return nil
+	}
+
+	var missing []string
+	for _, header := range rb.responseHeaders {
+		if header.Required && headers.Get(header.Name) == "" {
+			missing = append(missing, header.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return NewSuperGinError(ErrValidationFailed, "missing required response headers: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// openAPIHeaders describes declared response headers
+func openAPIHeaders(headers []ResponseHeader) map[string]interface{} {
+	described := make(map[string]interface{}, len(headers))
+	for _, header := range headers {
+		item := map[string]interface{}{
+			"schema": map[string]interface{}{"type": "string"},
+		}
+		if header.Required {
+			item["required"] = true
+		}
+		if header.Description != "" {
+			item["description"] = header.Description
+		}
+		described[header.Name] = item
+	}
+	return described
+}
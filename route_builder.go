@@ -2,7 +2,6 @@ package supergin
 
 import (
 	"fmt"
-	"net/http"
 	"reflect"
 	"time"
 
@@ -22,6 +21,24 @@ type RouteBuilder struct {
 	description string
 	tags        []string
 	middleware  []gin.HandlerFunc
+	slo         *SLO
+	router      *gin.RouterGroup
+	group       string
+	host        string
+	envs        []string
+	groupEnvs   [][]string
+
+	rawBodyLimit    int64
+	maxBodyBytes    int64
+	compression     *CompressionOptions
+	cors            *CORSPolicy
+	corsSet         bool
+	handlerWrappers []func(gin.HandlerFunc) gin.HandlerFunc
+	listOutput      *ListOutput
+	responseHeaders []ResponseHeader
+	asyncValidators []AsyncValidator
+	stages          []Stage
+	bufferResponse  bool
 }
 
 // Named creates a new route builder with a name
@@ -69,6 +86,13 @@ func (rb *RouteBuilder) PATCH(path string) *RouteBuilder {
 	return rb
 }
 
+// HEAD sets the HTTP method to HEAD
+func (rb *RouteBuilder) HEAD(path string) *RouteBuilder {
+	rb.method = "HEAD"
+	rb.path = path
+	return rb
+}
+
 // WithIO sets input and output types for validation
 func (rb *RouteBuilder) WithIO(input, output interface{}) *RouteBuilder {
 	if input != nil {
@@ -146,107 +170,154 @@ func (rb *RouteBuilder) register() {
 	if rb.handler == nil {
 		panic("handler function is required")
 	}
+	envs := environmentSets(rb.groupEnvs, rb.envs)
+	if !rb.engine.inEnvironments(envs) {
+		rb.engine.logger.Debug("route skipped for environment", "route", rb.name, "environment", rb.engine.environment)
+		return
+	}
+
+	// Apply wrappers registered by builder extensions such as WithGrpcBridge
+	for _, wrap := range rb.handlerWrappers {
+		rb.handler = wrap(rb.handler)
+	}
+
+	cors := rb.corsPolicy()
+	if cors != nil {
+		rb.WithMetadata("cors", cors)
+	}
 
 	// Create enhanced handler with validation
 	enhancedHandler := rb.createEnhancedHandler()
 
-	// Combine middleware with enhanced handler
-	handlers := append(rb.middleware, enhancedHandler)
+	// Register with gin, inside the route's group when one is set
+	router := &rb.engine.Engine.RouterGroup
+	if rb.router != nil {
+		router = rb.router
+	}
+	fullPath := joinPaths(router.BasePath(), rb.path)
+	id := routeID(rb.name, rb.method, fullPath)
+
+	// Combine route ID, the body limit, metrics, CORS and auth policies, compression, the
+	// route's auth scheme, policy rate limits, tag-bound middleware, route middleware, role
+	// and permission checks, the metering quota and enhanced handler
+	handlers := []gin.HandlerFunc{routeIDMiddleware(rb.name, id)}
+	if limit := rb.bodyLimit(); limit > 0 {
+		handlers = append(handlers, bodyLimitMiddleware(limit))
+	}
+	handlers = append(handlers, rb.metricsMiddleware(), rb.engine.policyMiddleware(rb.name, cors))
+	if compression := rb.compressionOptions(); compression != nil {
+		handlers = append(handlers, compressionMiddleware(*compression))
+	}
+	if auth := rb.engine.routeAuth(rb.name, rb.metadata); auth != nil {
+		handlers = append(handlers, auth)
+	}
+	handlers = append(handlers, rb.engine.policyLimitMiddleware(rb.name))
+	handlers = append(handlers, rb.engine.middlewareForTags(rb.tags)...)
+	handlers = append(handlers, rb.middleware...)
+	if required := routeRequirements(rb.metadata); !required.empty() {
+		handlers = append(handlers, rb.engine.authorizationMiddleware(required))
+	}
+	handlers = append(handlers, rb.engine.quotaMiddleware(), enhancedHandler)
 
-	// Register with gin
 	switch rb.method {
-	case "GET":
-		rb.engine.Engine.GET(rb.path, handlers...)
-	case "POST":
-		rb.engine.Engine.POST(rb.path, handlers...)
-	case "PUT":
-		rb.engine.Engine.PUT(rb.path, handlers...)
-	case "DELETE":
-		rb.engine.Engine.DELETE(rb.path, handlers...)
-	case "PATCH":
-		rb.engine.Engine.PATCH(rb.path, handlers...)
+	case "GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS":
 	default:
 		panic(fmt.Sprintf("unsupported HTTP method: %s", rb.method))
 	}
+	chain := append(append(gin.HandlersChain{}, router.Handlers...), handlers...)
+	if rb.host != "" {
+		// Host-bound routes live in the host's router, behind the group's gin middleware
+		rb.engine.hostRouter(rb.host).Handle(rb.method, fullPath, chain...)
+	} else {
+		router.Handle(rb.method, rb.path, handlers...)
+	}
+
+	rb.engine.prepareValidation(rb.inputType)
 
 	// Store route info
-	rb.engine.routesMux.Lock()
-	rb.engine.routes[rb.name] = &RouteInfo{
-		Name:        rb.name,
-		Method:      rb.method,
-		Path:        rb.path,
-		Handler:     rb.handler,
-		InputType:   rb.inputType,
-		OutputType:  rb.outputType,
-		Metadata:    rb.metadata,
-		Description: rb.description,
-		Tags:        rb.tags,
-		CreatedAt:   time.Now(),
+	route := &RouteInfo{
+		ID:           id,
+		Name:         rb.name,
+		Method:       rb.method,
+		Path:         fullPath,
+		Handler:      rb.handler,
+		InputType:    rb.inputType,
+		OutputType:   rb.outputType,
+		Metadata:     rb.metadata,
+		Description:  rb.description,
+		Tags:         rb.tags,
+		Group:        rb.group,
+		Host:         rb.host,
+		Environments: intersectEnvironments(envs),
+		Middleware:   handlerNames(chain[:len(chain)-1]),
+		List:         rb.listOutput,
+		Headers:      rb.responseHeaders,
+		SLO:          rb.slo,
+		Stages:       rb.stageNames(),
+		CreatedAt:    time.Now(),
 	}
+	rb.engine.routesMux.Lock()
+	rb.engine.routes[rb.name] = route
 	rb.engine.routesMux.Unlock()
+
+	if cors != nil {
+		rb.engine.registerPreflight(route)
+	}
+
+	rb.engine.metrics.SetRouteID(rb.name, id)
+	if rb.slo != nil {
+		rb.engine.metrics.SetLabels(rb.name, rb.slo.labels())
+	}
+
+	rb.engine.logger.Debug("route registered", "route", rb.name, "route_id", id, "method", rb.method, "path", fullPath, "host", rb.host)
+	rb.engine.hooks.notifyRouteRegistered(route)
 }
 
-// createEnhancedHandler wraps the original handler with validation
+// createEnhancedHandler runs the original handler within the route's request pipeline
 func (rb *RouteBuilder) createEnhancedHandler() gin.HandlerFunc {
+	stages := append([]Stage(nil), rb.pipeline()...)
 	return func(c *gin.Context) {
-		// Input validation
-		if rb.engine.config.ValidateInput && rb.inputType != nil {
-			if err := rb.validateInput(c); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Input validation failed",
-					"details": err.Error(),
-				})
-				return
-			}
-		}
-
-		// Call original handler
-		rb.handler(c)
+		rb.runPipeline(c, stages)
+	}
+}
 
-		// Output validation (if enabled and response is JSON)
-		if rb.engine.config.ValidateOutput && rb.outputType != nil {
-			rb.validateOutput(c)
-		}
+// bindInput binds the request into a new value of inputType and validates it
+func (e *Engine) bindInput(c *gin.Context, inputType reflect.Type) (interface{}, error) {
+	input, err := e.decodeInput(c, inputType)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.checkInput(input, inputType); err != nil {
+		return nil, err
 	}
+	return input, nil
 }
 
-// validateInput validates the request input
-func (rb *RouteBuilder) validateInput(c *gin.Context) error {
+// decodeInput binds the request into a new value of inputType. Unions are validated as
+// they are decoded, since their variant is only known then.
+func (e *Engine) decodeInput(c *gin.Context, inputType reflect.Type) (interface{}, error) {
+	if union, exists := LookupUnion(inputType); exists {
+		return bindUnion(c, union, e.validator)
+	}
+
 	// Create new instance of input type
-	inputValue := reflect.New(rb.inputType).Interface()
-
-	// Bind request data based on content type and method
-	var err error
-	contentType := c.GetHeader("Content-Type")
-
-	if rb.method == "GET" || rb.method == "DELETE" {
-		// For GET/DELETE, bind query parameters
-		err = c.ShouldBindQuery(inputValue)
-	} else if contentType == "application/x-www-form-urlencoded" || contentType == "multipart/form-data" {
-		// For form data
-		err = c.ShouldBind(inputValue)
-	} else {
-		// Default to JSON binding
-		err = c.ShouldBindJSON(inputValue)
+	inputValue := reflect.New(inputType).Interface()
+
+	if err := bindRequest(c, inputValue); err != nil {
+		return nil, bindingError(err)
 	}
+	return inputValue, nil
+}
 
-	if err != nil {
-		return NewSuperGinError(ErrValidationFailed, "binding error: %v", err)
+// checkInput validates input decoded by decodeInput
+func (e *Engine) checkInput(input interface{}, inputType reflect.Type) error {
+	if _, exists := LookupUnion(inputType); exists {
+		return nil
 	}
 
 	// Validate using validator
-	if err := rb.engine.validator.Struct(inputValue); err != nil {
-		return NewSuperGinError(ErrValidationFailed, "validation error: %v", err)
+	if err := validateStruct(e.validator, input); err != nil {
+		return NewSuperGinErrorWithCause(ErrValidationFailed, err, "validation error")
 	}
-
-	// Store validated input in context for handler use
-	c.Set("validated_input", inputValue)
 	return nil
 }
-
-// validateOutput validates the response output (basic implementation)
-func (rb *RouteBuilder) validateOutput(c *gin.Context) {
-	// This would require intercepting the response writer
-	// Implementation depends on specific requirements
-	// For now, we'll skip actual validation but log the capability
-}
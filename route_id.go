@@ -0,0 +1,114 @@
+package supergin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteIDHeader carries the matched route's stable ID on responses
+const RouteIDHeader = "X-Route-ID"
+
+// routeIDKey stores the matched route's ID in the gin and request contexts
+const routeIDKey = "supergin:route_id"
+
+type routeIDContextKey struct{}
+
+// routeID derives a stable identifier from a route's name, method and full path. It does
+// not depend on registration order or process, so logs, metrics and traces from different
+// instances and systems can be joined on it.
+func routeID(name, method, path string) string {
+	sum := sha256.Sum256([]byte(name + "\x00" + method + "\x00" + path))
+	return "rt_" + hex.EncodeToString(sum[:8])
+}
+
+// routeIDMiddleware exposes the route ID to handlers and loggers, tracing via the request
+// context, and clients via the X-Route-ID response header
+func routeIDMiddleware(name, id string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(routeNameKey, name)
+		c.Set(routeIDKey, id)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), routeIDContextKey{}, id))
+		c.Header(RouteIDHeader, id)
+		c.Next()
+	}
+}
+
+// RouteID returns the stable ID of the route handling the request
+func RouteID(c *gin.Context) string {
+	return c.GetString(routeIDKey)
+}
+
+// RouteIDFromContext returns the stable route ID from a request context, e.g. in tracing
+func RouteIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(routeIDContextKey{}).(string)
+	return id
+}
+
+// RouteByID returns route information by stable ID
+func (e *Engine) RouteByID(id string) (*RouteInfo, bool) {
+	e.routesMux.RLock()
+	defer e.routesMux.RUnlock()
+
+	for _, route := range e.routes {
+		if route.ID == id {
+			return route, true
+		}
+	}
+	return nil, false
+}
+
+// RouteFor finds the route serving a method and path. The path may be a route template
+// ("/users/:id") or a concrete request path ("/users/42"); when several templates match,
+// the one with the most static segments wins, as in gin's router.
+func (e *Engine) RouteFor(method, path string) (*RouteInfo, bool) {
+	e.routesMux.RLock()
+	defer e.routesMux.RUnlock()
+
+	method = strings.ToUpper(method)
+	var best *RouteInfo
+	bestScore := -1
+	for _, route := range e.routes {
+		if route.Method != method {
+			continue
+		}
+		if route.Path == path {
+			return route, true
+		}
+		if score, ok := matchRoutePath(route.Path, path); ok && score > bestScore {
+			best, bestScore = route, score
+		}
+	}
+	return best, best != nil
+}
+
+// matchRoutePath matches a concrete path against a gin template, returning the number of
+// static segments matched
+func matchRoutePath(template, path string) (int, bool) {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	score := 0
+	for i, segment := range templateSegments {
+		if strings.HasPrefix(segment, "*") {
+			return score, true
+		}
+		if i >= len(pathSegments) {
+			return 0, false
+		}
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			if pathSegments[i] == "" {
+				return 0, false
+			}
+		case segment == pathSegments[i]:
+			score++
+		default:
+			return 0, false
+		}
+	}
+	return score, len(templateSegments) == len(pathSegments)
+}
@@ -0,0 +1,339 @@
+package supergin
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// JSONSchema builds a self-contained JSON Schema for a Go type from its json and validate tags
+func JSONSchema(t reflect.Type) map[string]interface{} {
+	return newSchemaBuilder(nil).build(t)
+}
+
+// schemaBuilder converts Go types to JSON Schema. When components is non-nil, named
+// struct types are emitted once into components and referenced via $ref.
+type schemaBuilder struct {
+	visiting   map[reflect.Type]bool
+	components map[string]interface{}
+	names      map[reflect.Type]string
+	refPrefix  string
+}
+
+func newSchemaBuilder(components map[string]interface{}) *schemaBuilder {
+	return &schemaBuilder{
+		visiting:   make(map[reflect.Type]bool),
+		components: components,
+		names:      make(map[reflect.Type]string),
+		refPrefix:  "#/components/schemas/",
+	}
+}
+
+func (sb *schemaBuilder) build(t reflect.Type) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	if def, exists := LookupEnum(t); exists {
+		schemaType := "integer"
+		if t.Kind() == reflect.String {
+			schemaType = "string"
+		}
+		return map[string]interface{}{"type": schemaType, "enum": def.Values}
+	}
+	if t == durationType {
+		// encoding/json renders durations as integer nanoseconds
+		return map[string]interface{}{"type": "integer", "format": "int64", "description": "Duration in nanoseconds"}
+	}
+	if isUUIDType(t) {
+		return map[string]interface{}{"type": "string", "format": "uuid"}
+	}
+	if isTextType(t) {
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": sb.build(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": sb.build(t.Elem())}
+	case reflect.Interface:
+		if union, exists := LookupUnion(t); exists {
+			return sb.unionSchema(union)
+		}
+		return map[string]interface{}{}
+	case reflect.Struct:
+		if sb.components != nil && t.Name() != "" {
+			return map[string]interface{}{"$ref": sb.refPrefix + sb.component(t)}
+		}
+		if sb.visiting[t] {
+			// Recursive type; stop descending
+			return map[string]interface{}{"type": "object"}
+		}
+		sb.visiting[t] = true
+		defer delete(sb.visiting, t)
+		return sb.structSchema(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// component registers a named struct type in components and returns its key
+func (sb *schemaBuilder) component(t reflect.Type) string {
+	if name, exists := sb.names[t]; exists {
+		return name
+	}
+
+	name := t.Name()
+	for i := 2; ; i++ {
+		if _, taken := sb.components[name]; !taken {
+			break
+		}
+		name = fmt.Sprintf("%s%d", t.Name(), i)
+	}
+
+	// Reserve the name before descending so recursive types terminate
+	sb.names[t] = name
+	sb.components[name] = map[string]interface{}{}
+	sb.components[name] = sb.structSchema(t)
+	return name
+}
+
+func (sb *schemaBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, skip := jsonFieldName(field)
+		if skip || isParamField(field) {
+			// Path and header fields are documented as parameters
+			continue
+		}
+
+		// Flatten embedded structs without an explicit json name
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				nested := sb.structSchema(embedded)
+				if props, ok := nested["properties"].(map[string]interface{}); ok {
+					for k, v := range props {
+						properties[k] = v
+					}
+				}
+				if req, ok := nested["required"].([]string); ok {
+					required = append(required, req...)
+				}
+				continue
+			}
+		}
+
+		prop := sb.build(field.Type)
+		if applyValidateTag(prop, field.Type, validationRules(field)) {
+			required = append(required, name)
+		}
+		if desc := field.Tag.Get("description"); desc != "" {
+			prop["description"] = desc
+		}
+		if example := field.Tag.Get("example"); example != "" {
+			prop["example"] = example
+		}
+		if field.Tag.Get("contract") == "required" {
+			prop["x-contract"] = "required"
+		}
+		properties[name] = prop
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// isUUIDType reports whether t is a 16-byte UUID type such as github.com/google/uuid.UUID
+func isUUIDType(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8 &&
+		t.Name() == "UUID" && isTextType(t)
+}
+
+// isTextType reports whether t is encoded as a JSON string through encoding.TextMarshaler
+func isTextType(t reflect.Type) bool {
+	ptr := reflect.PointerTo(t)
+	return ptr.Implements(textMarshalerType) && !ptr.Implements(jsonMarshalerType)
+}
+
+// paramSchema describes a field bound from a query, form or path value. Unlike JSON,
+// these use gin's string encodings: time_format layouts and Go duration strings.
+func paramSchema(field reflect.StructField, sb *schemaBuilder) map[string]interface{} {
+	t := field.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case durationType:
+		return map[string]interface{}{"type": "string", "format": "duration", "example": "1m30s"}
+	case timeType:
+		schema := map[string]interface{}{"type": "string", "format": "date-time"}
+		switch layout := field.Tag.Get("time_format"); layout {
+		case "", time.RFC3339, time.RFC3339Nano:
+		case "unix", "unixnano":
+			schema["type"] = "integer"
+			schema["format"] = "int64"
+		case time.DateOnly:
+			schema["format"] = "date"
+		case time.TimeOnly:
+			schema["format"] = "time"
+		default:
+			delete(schema, "format")
+			schema["example"] = time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC).Format(layout)
+		}
+		return schema
+	}
+	return sb.build(field.Type)
+}
+
+// jsonFieldName returns the JSON name for a field and whether it is skipped
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		name = field.Name
+	}
+	return name, false
+}
+
+// applyValidateTag maps validator rules onto schema keywords and reports whether the field is required
+func applyValidateTag(schema map[string]interface{}, t reflect.Type, tag string) bool {
+	if tag == "" {
+		return false
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			required = true
+		case "email":
+			schema["format"] = "email"
+		case "url", "uri":
+			schema["format"] = "uri"
+		case "uuid", "uuid4":
+			schema["format"] = "uuid"
+		case "datetime":
+			schema["format"] = "date-time"
+		case "oneof":
+			schema["enum"] = enumValues(t, strings.Fields(value))
+		case "min", "gte":
+			setBound(schema, t, value, "minLength", "minimum", "minItems")
+		case "max", "lte":
+			setBound(schema, t, value, "maxLength", "maximum", "maxItems")
+		case "gt":
+			setBound(schema, t, value, "", "exclusiveMinimum", "")
+		case "lt":
+			setBound(schema, t, value, "", "exclusiveMaximum", "")
+		case "len":
+			setBound(schema, t, value, "minLength", "", "minItems")
+			setBound(schema, t, value, "maxLength", "", "maxItems")
+		default:
+			if format, exists := validationFormat(key); exists {
+				schema["format"] = format
+			}
+		}
+	}
+	return required
+}
+
+// setBound sets the schema keyword appropriate for the field kind
+func setBound(schema map[string]interface{}, t reflect.Type, value, stringKey, numberKey, arrayKey string) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+
+	key := numberKey
+	switch t.Kind() {
+	case reflect.String:
+		key = stringKey
+	case reflect.Slice, reflect.Array, reflect.Map:
+		key = arrayKey
+	}
+	if key == "" {
+		return
+	}
+	if n == float64(int64(n)) {
+		schema[key] = int64(n)
+	} else {
+		schema[key] = n
+	}
+}
+
+// enumValues converts oneof values to the field's JSON type
+func enumValues(t reflect.Type, values []string) []interface{} {
+	enum := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				enum = append(enum, n)
+				continue
+			}
+		case reflect.Float32, reflect.Float64:
+			if n, err := strconv.ParseFloat(v, 64); err == nil {
+				enum = append(enum, n)
+				continue
+			}
+		}
+		enum = append(enum, v)
+	}
+	return enum
+}
@@ -0,0 +1,169 @@
+package supergin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SeedPathPrefix is where seeding routes are mounted
+const SeedPathPrefix = "/_dev/seed"
+
+const (
+	// DefaultSeedCount is how many records a seed request creates without ?count
+	DefaultSeedCount = 10
+	// DefaultMaxSeedCount caps ?count on seed requests
+	DefaultMaxSeedCount = 1000
+)
+
+// SeedOptions configures a resource's seeding route
+type SeedOptions struct {
+	// Count is the default number of records per request; zero means DefaultSeedCount
+	Count int
+	// MaxCount caps the count a request may ask for; zero means DefaultMaxSeedCount
+	MaxCount int
+	// Environments the route exists in; empty means only DefaultEnvironment
+	Environments []string
+	// Generate builds the i-th record's create payload; nil fakes the resource's input type
+	Generate func(i int) interface{}
+}
+
+// SeedResult is the response of a seeding route
+type SeedResult struct {
+	Resource  string            `json:"resource"`
+	Requested int               `json:"requested"`
+	Created   int               `json:"created"`
+	Records   []json.RawMessage `json:"records"`
+	Failures  []SeedFailure     `json:"failures,omitempty"`
+}
+
+// SeedFailure describes a record the create route rejected
+type SeedFailure struct {
+	Index  int             `json:"index"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// WithSeeding adds a POST /_dev/seed/<resources>?count=N route that creates N fake records
+// through the resource's create route, so validation, middleware and the controller run as
+// for real requests. It is only registered in development unless Environments says otherwise.
+func (rb *ResourceBuilder) WithSeeding(opts ...SeedOptions) *ResourceBuilder {
+	options := SeedOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	rb.seed = &options
+	return rb
+}
+
+// generateSeedRoute registers the seeding route once the create route exists
+func (rb *ResourceBuilder) generateSeedRoute() {
+	options := *rb.seed
+	if options.Count <= 0 {
+		options.Count = DefaultSeedCount
+	}
+	if options.MaxCount <= 0 {
+		options.MaxCount = DefaultMaxSeedCount
+	}
+	if len(options.Environments) == 0 {
+		options.Environments = []string{DefaultEnvironment}
+	}
+
+	envs := environmentSets([][]string{options.Environments}, rb.modelInfo.Environments)
+	if !rb.engine.inEnvironments(envs) {
+		return
+	}
+
+	create, exists := rb.engine.GetRoute(rb.restRoutes.Create)
+	if !exists {
+		rb.engine.logger.Warn("seeding skipped: resource has no create route", "resource", rb.modelInfo.Name)
+		return
+	}
+	generate := options.Generate
+	if generate == nil {
+		if rb.modelInfo.InputType == nil {
+			rb.engine.logger.Warn("seeding skipped: resource has no input type", "resource", rb.modelInfo.Name)
+			return
+		}
+		inputType := rb.modelInfo.InputType
+		generate = func(int) interface{} { return fakeValue(inputType) }
+	}
+
+	plural := strings.ToLower(rb.modelInfo.PluralName)
+	rb.engine.Named("seed_"+plural).
+		POST(SeedPathPrefix+"/"+plural).
+		WithDescription(fmt.Sprintf("Seed fake %s (development only)", rb.modelInfo.PluralName)).
+		WithTags("dev", "seed").
+		WithEnvironments(options.Environments...).
+		WithEnvironments(rb.modelInfo.Environments...).
+		WithOutput(SeedResult{}).
+		Handler(func(c *gin.Context) {
+			count := options.Count
+			if raw := c.Query("count"); raw != "" {
+				n, err := strconv.Atoi(raw)
+				if err != nil || n < 1 || n > options.MaxCount {
+					c.JSON(http.StatusBadRequest, gin.H{
+						"error": fmt.Sprintf("count must be between 1 and %d", options.MaxCount),
+					})
+					return
+				}
+				count = n
+			}
+
+			result := SeedResult{Resource: rb.modelInfo.Name, Requested: count, Records: []json.RawMessage{}}
+			for i := 0; i < count; i++ {
+				status, body, err := rb.engine.seedRecord(c, create, generate(i))
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+					return
+				}
+				if status < 200 || status >= 300 {
+					result.Failures = append(result.Failures, SeedFailure{Index: i, Status: status, Body: rawJSON(body)})
+					continue
+				}
+				result.Created++
+				result.Records = append(result.Records, rawJSON(body))
+			}
+			c.JSON(http.StatusCreated, result)
+		})
+}
+
+// seedRecord posts one payload to the create route through the engine
+func (e *Engine) seedRecord(c *gin.Context, create *RouteInfo, payload interface{}) (int, []byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "failed to encode seed record")
+	}
+
+	req, err := http.NewRequestWithContext(c.Request.Context(), create.Method, create.Path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Host = c.Request.Host
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(RequestIDHeader, RequestID(c))
+	for _, header := range []string{"Authorization", "Cookie"} {
+		if value := c.GetHeader(header); value != "" {
+			req.Header.Set(header, value)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, req)
+	return recorder.Code, recorder.Body.Bytes(), nil
+}
+
+// rawJSON keeps JSON bodies as-is and wraps anything else as a JSON string
+func rawJSON(body []byte) json.RawMessage {
+	if json.Valid(body) {
+		return body
+	}
+	encoded, _ := json.Marshal(string(body))
+	return encoded
+}
@@ -0,0 +1,177 @@
+package supergin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// DefaultDrainTimeout bounds how long Start waits for in-flight requests after a signal
+const DefaultDrainTimeout = 30 * time.Second
+
+// Start serves HTTP on addr until SIGINT/SIGTERM is received or Shutdown is called,
+// then shuts the engine down gracefully within Config.DrainTimeout
+func (e *Engine) Start(addr string) error {
+	server := &http.Server{Addr: addr, Handler: e}
+
+	e.lifecycleMux.Lock()
+	if e.server != nil || e.shuttingDown {
+		e.lifecycleMux.Unlock()
+		return fmt.Errorf("engine already started")
+	}
+	e.server = server
+	e.lifecycleMux.Unlock()
+
+	if err := e.startServices(); err != nil {
+		return err
+	}
+	if err := e.reportStartup(addr); err != nil {
+		return err
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		// Shutdown was called directly; wait for it to finish
+		<-e.shutdownDone
+		return nil
+	case sig := <-signals:
+		e.logger.Info("received signal, shutting down", "signal", sig.String())
+	}
+
+	drainTimeout := e.config.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = DefaultDrainTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	return e.Shutdown(ctx)
+}
+
+// startServices checks the configuration when strict and validates the DI service graph,
+// then eagerly starts singletons with a lifecycle. If one fails, the services already created are disposed and the error is returned.
+func (e *Engine) startServices() error {
+	if err := e.checkStrict(); err != nil {
+		return err
+	}
+	if err := e.di.Validate(); err != nil {
+		e.logger.Error("invalid service graph", "error", err)
+		return err
+	}
+	err := e.di.Start(context.Background())
+	if err == nil {
+		return nil
+	}
+	e.logger.Error("failed to start services", "error", err)
+	if disposeErr := e.di.Shutdown(context.Background()); disposeErr != nil {
+		e.logger.Error("failed to dispose services after startup failure", "error", disposeErr)
+	}
+	return err
+}
+
+// Shutdown stops the engine: WebSocket hubs flush queued messages and send their close
+// frames, the HTTP and gRPC servers drain in-flight requests, running jobs are cancelled,
+// gRPC bridge connections are closed and DI services are disposed. It is safe to call more
+// than once.
+func (e *Engine) Shutdown(ctx context.Context) error {
+	e.lifecycleMux.Lock()
+	if e.shuttingDown {
+		e.lifecycleMux.Unlock()
+		<-e.shutdownDone
+		return nil
+	}
+	e.shuttingDown = true
+	server := e.server
+	hubs := append([]*WebSocketHub(nil), e.hubs...)
+	jobs := e.jobs
+	e.lifecycleMux.Unlock()
+	defer close(e.shutdownDone)
+
+	var errs []error
+
+	// Hijacked WebSocket connections are not drained by http.Server, so close them first
+	for _, hub := range hubs {
+		if err := hub.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if server != nil {
+		if err := server.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to drain HTTP server: %w", err))
+		}
+	}
+
+	if err := e.stopGrpcServer(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	if jobs != nil {
+		jobs.cancelAll()
+	}
+
+	if e.di.Has("grpc_bridge") {
+		if bridge, ok := e.di.Get("grpc_bridge").(*GrpcBridge); ok {
+			if err := bridge.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if err := e.di.Shutdown(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// trackHub records a hub so it is closed on shutdown, drained and memory-budgeted with
+// the engine, and gives it the engine's logger unless one was set with WithLogger. It
+// must be called before the hub runs.
+func (e *Engine) trackHub(hub *WebSocketHub, name string) {
+	e.lifecycleMux.Lock()
+	if hub.logger == nil {
+		hub.logger = e.logger.With("hub", name)
+	}
+	if guard := e.memory.Load(); guard != nil {
+		hub.setMemoryGuard(guard)
+	}
+	hub.name = name
+	e.hubs = append(e.hubs, hub)
+	e.lifecycleMux.Unlock()
+
+	// A hub tracked after Drain drains too; Drain may also have reached it, which is a no-op
+	if state := e.drain.Load(); state != nil {
+		hub.drain(state.options)
+	}
+}
+
+// closeAll sends a close frame to every connection; their read pumps then unregister them
+func (h *WebSocketHub) closeAll(code int, reason string) {
+	h.mutex.RLock()
+	connections := make([]*WebSocketConnection, 0, len(h.connections))
+	for _, conn := range h.connections {
+		connections = append(connections, conn)
+	}
+	h.mutex.RUnlock()
+
+	for _, conn := range connections {
+		closeConnection(conn, code, reason)
+	}
+}
@@ -0,0 +1,61 @@
+package supergin
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SLO declares the latency and size budget a route is expected to meet
+type SLO struct {
+	P99     time.Duration `json:"p99,omitempty"`
+	MaxBody int64         `json:"max_body,omitempty"`
+}
+
+// labels returns the SLO as metric labels
+func (s SLO) labels() map[string]string {
+	labels := make(map[string]string)
+	if s.P99 > 0 {
+		labels["slo_p99"] = s.P99.String()
+	}
+	if s.MaxBody > 0 {
+		labels["slo_max_body"] = strconv.FormatInt(s.MaxBody, 10)
+	}
+	return labels
+}
+
+// WithSLO declares the route's latency and request size budget
+func (rb *RouteBuilder) WithSLO(slo SLO) *RouteBuilder {
+	rb.slo = &slo
+	return rb
+}
+
+// metricsMiddleware records request metrics for a route and logs SLO breaches
+func (rb *RouteBuilder) metricsMiddleware() gin.HandlerFunc {
+	name := rb.name
+	slo := rb.slo
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		latency := time.Since(start)
+
+		breached := false
+		if slo != nil {
+			if slo.P99 > 0 && latency > slo.P99 {
+				breached = true
+				LoggerFor(c).Warn("SLO breach: latency exceeds p99 budget", "latency", latency, "budget", slo.P99)
+			}
+			if slo.MaxBody > 0 && c.Request.ContentLength > slo.MaxBody {
+				breached = true
+				LoggerFor(c).Warn("SLO breach: body exceeds size budget", "bytes", c.Request.ContentLength, "budget", slo.MaxBody)
+			}
+		}
+
+		rb.engine.metrics.Observe(name, c.Writer.Status(), latency, breached)
+		if value, rejected := c.Get(validationFailureKey); rejected {
+			rb.engine.metrics.ObserveValidation(name, value.(FieldErrors))
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package supergin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultStaticCacheControl = "public, max-age=3600"
+	defaultIndexCacheControl  = "no-cache"
+	staticIndexFile           = "index.html"
+)
+
+// StaticOptions configures routes registered by Static and SPA
+type StaticOptions struct {
+	// CacheControl is sent with files; defaults to "public, max-age=3600"
+	CacheControl string
+	// IndexCacheControl is sent with index.html documents, including the SPA fallback, so
+	// new deployments are picked up; defaults to "no-cache"
+	IndexCacheControl string
+	// Tags are added to the routes' "static" tag
+	Tags []string
+}
+
+// staticMount serves files from a file system for a Static or SPA route
+type staticMount struct {
+	fsys     fs.FS
+	fallback string
+	options  StaticOptions
+	etags    sync.Map // file name -> staticETag
+}
+
+// staticETag caches a file's content hash for as long as its size and modification time hold
+type staticETag struct {
+	modTime time.Time
+	size    int64
+	tag     string
+}
+
+// Static serves fsys, such as an embed.FS narrowed with fs.Sub, under prefix as the named
+// route name, so the files appear in the docs and URLFor(name, "filepath", "css/app.css")
+// builds their URLs. Directories serve their index.html. Responses carry a content-hash
+// ETag, answer If-None-Match with 304 and send StaticOptions' Cache-Control. A HEAD route
+// is registered as name_head. At prefix "/" only the root is a route, since a catch-all
+// there would conflict with every other route; other paths are served for GET and HEAD
+// requests no route matches, outside route middleware.
+func (e *Engine) Static(name, prefix string, fsys fs.FS, opts ...StaticOptions) *RouteBuilder {
+	return e.mountStatic(name, prefix, fsys, "", opts, "Static files")
+}
+
+// SPA serves a single-page app like Static, answering paths without a file extension that
+// match no file with indexFallback (index.html when empty) so client-side routes load the
+// app. Paths with an extension, such as missing assets, still get a 404.
+func (e *Engine) SPA(name, prefix string, fsys fs.FS, indexFallback string, opts ...StaticOptions) *RouteBuilder {
+	if indexFallback == "" {
+		indexFallback = staticIndexFile
+	}
+	return e.mountStatic(name, prefix, fsys, indexFallback, opts, "Single-page app", "spa")
+}
+
+// mountStatic registers the GET and HEAD routes of a static mount
+func (e *Engine) mountStatic(name, prefix string, fsys fs.FS, fallback string, opts []StaticOptions, description string, tags ...string) *RouteBuilder {
+	if fsys == nil {
+		panic("static file system is required")
+	}
+	mount := &staticMount{fsys: fsys, fallback: strings.TrimPrefix(path.Clean("/"+fallback), "/")}
+	if len(opts) > 0 {
+		mount.options = opts[0]
+	}
+	if mount.options.CacheControl == "" {
+		mount.options.CacheControl = defaultStaticCacheControl
+	}
+	if mount.options.IndexCacheControl == "" {
+		mount.options.IndexCacheControl = defaultIndexCacheControl
+	}
+
+	prefix = "/" + strings.Trim(prefix, "/")
+	routePath := strings.TrimSuffix(prefix, "/") + "/*filepath"
+	handler := func(c *gin.Context) {
+		if !mount.serve(c, c.Param("filepath")) {
+			writeProblem(c, http.StatusNotFound, "file not found")
+		}
+	}
+	if prefix == "/" {
+		if e.staticRoot != nil {
+			panic("a static mount at \"/\" is already registered")
+		}
+		e.staticRoot = mount
+		routePath = "/"
+		handler = func(c *gin.Context) {
+			if !mount.serve(c, "/") {
+				writeProblem(c, http.StatusNotFound, "file not found")
+			}
+		}
+		e.Engine.NoRoute(func(c *gin.Context) {
+			if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+				mount.serve(c, c.Request.URL.Path)
+			}
+		})
+	}
+
+	tags = append(append([]string{"static"}, tags...), mount.options.Tags...)
+	e.Named(name+"_head").
+		HEAD(routePath).
+		WithDescription(description).
+		WithTags(tags...).
+		WithMetadata("cache_control", mount.options.CacheControl).
+		Handler(handler)
+	return e.Named(name).
+		GET(routePath).
+		WithDescription(description).
+		WithTags(tags...).
+		WithMetadata("cache_control", mount.options.CacheControl).
+		Handler(handler)
+}
+
+// serve writes the file for a request path and reports whether one was found
+func (m *staticMount) serve(c *gin.Context, requested string) bool {
+	name := strings.TrimPrefix(path.Clean("/"+requested), "/")
+	if name == "" {
+		name = "."
+	}
+	extension := path.Ext(name)
+	name, info, found := m.lookup(name)
+	if !found && m.fallback != "" && extension == "" {
+		name, info, found = m.lookup(m.fallback)
+	}
+	if !found {
+		return false
+	}
+
+	file, err := m.fsys.Open(name)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	content, seekable := file.(io.ReadSeeker)
+	if !seekable {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			writeProblem(c, http.StatusInternalServerError, "failed to read file")
+			return true
+		}
+		content = bytes.NewReader(data)
+	}
+	tag, err := m.etag(name, info, content)
+	if err != nil {
+		writeProblem(c, http.StatusInternalServerError, "failed to read file")
+		return true
+	}
+
+	cacheControl := m.options.CacheControl
+	if path.Base(name) == staticIndexFile || name == m.fallback {
+		cacheControl = m.options.IndexCacheControl
+	}
+	c.Header("Cache-Control", cacheControl)
+	c.Header("ETag", tag)
+	// ServeContent answers If-None-Match, ranges and HEAD, and sets the Content-Type
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), content)
+	return true
+}
+
+// lookup resolves a file name, serving directories through their index.html
+func (m *staticMount) lookup(name string) (string, fs.FileInfo, bool) {
+	info, err := fs.Stat(m.fsys, name)
+	if err != nil {
+		return name, nil, false
+	}
+	if info.IsDir() {
+		name = path.Join(name, staticIndexFile)
+		if info, err = fs.Stat(m.fsys, name); err != nil || info.IsDir() {
+			return name, nil, false
+		}
+	}
+	return name, info, true
+}
+
+// etag returns the file's strong ETag, hashing content only when the file has changed
+func (m *staticMount) etag(name string, info fs.FileInfo, content io.ReadSeeker) (string, error) {
+	if cached, ok := m.etags.Load(name); ok {
+		entry := cached.(staticETag)
+		if entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) {
+			return entry.tag, nil
+		}
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	tag := `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+	m.etags.Store(name, staticETag{modTime: info.ModTime(), size: info.Size(), tag: tag})
+	return tag, nil
+}
@@ -0,0 +1,202 @@
+package supergin
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// inputFieldTags are the tags binding an input field from the body, query, path or headers
+var inputFieldTags = []string{"json", "form", "uri", "header"}
+
+// outputFieldTags are the tags naming an output field
+var outputFieldTags = []string{"json"}
+
+// CheckConfiguration looks for common mistakes that otherwise only show up in production:
+// route input and output fields without json tags, validation rules naming unknown
+// validators, resources with nil controllers, WebSocket endpoints accepting any origin in
+// the production environment and docs served without authentication. Every problem found
+// is returned, joined. With Config.Strict, Start and Run call it and refuse to serve.
+func (e *Engine) CheckConfiguration() error {
+	var errs []error
+
+	if e.config.EnableDocs && e.config.DocsAuth == nil {
+		errs = append(errs, NewSuperGinError(ErrMisconfiguration,
+			"docs are enabled without authentication; set Config.DocsAuth or disable EnableDocs"))
+	}
+
+	routes := e.GetRoutes()
+	names := make([]string, 0, len(routes))
+	for name := range routes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		route := routes[name]
+		if route.InputType != nil {
+			if fields := untaggedFields(route.InputType, inputFieldTags); len(fields) > 0 {
+				errs = append(errs, NewSuperGinError(ErrMisconfiguration,
+					"route '%s' input has fields without json tags: %s", name, strings.Join(fields, ", ")))
+			}
+			if err := e.checkValidationRules(route.InputType); err != nil {
+				errs = append(errs, NewSuperGinError(ErrMisconfiguration,
+					"route '%s' input has invalid validation rules: %v", name, err))
+			}
+		}
+		if route.OutputType != nil {
+			if fields := untaggedFields(route.OutputType, outputFieldTags); len(fields) > 0 {
+				errs = append(errs, NewSuperGinError(ErrMisconfiguration,
+					"route '%s' output has fields without json tags: %s", name, strings.Join(fields, ", ")))
+			}
+			if e.config.ValidateOutput {
+				if err := e.checkValidationRules(route.OutputType); err != nil {
+					errs = append(errs, NewSuperGinError(ErrMisconfiguration,
+						"route '%s' output has invalid validation rules: %v", name, err))
+				}
+			}
+		}
+	}
+
+	e.routesMux.RLock()
+	resources := append([]*ModelInfo(nil), e.resources...)
+	e.routesMux.RUnlock()
+	for _, resource := range resources {
+		if isNilValue(resource.Controller) {
+			errs = append(errs, NewSuperGinError(ErrMisconfiguration,
+				"resource '%s' has a nil controller", resource.Name))
+		}
+	}
+
+	if e.environment == ProductionEnvironment {
+		e.lifecycleMux.Lock()
+		hubs := append([]*WebSocketHub(nil), e.hubs...)
+		e.lifecycleMux.Unlock()
+		for _, hub := range hubs {
+			if !hub.checksOrigin() && e.routeCORS(hub.name) == nil {
+				errs = append(errs, NewSuperGinError(ErrMisconfiguration,
+					"WebSocket endpoint '%s' accepts connections from any origin; set WebSocketConfig.CheckOrigin on its transport or give the route a CORS policy", hub.name))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkStrict runs CheckConfiguration when the engine is configured Strict
+func (e *Engine) checkStrict() error {
+	if !e.config.Strict {
+		return nil
+	}
+	if err := e.CheckConfiguration(); err != nil {
+		e.logger.Error("engine misconfigured", "error", err)
+		return err
+	}
+	return nil
+}
+
+// untaggedFields lists the exported fields reachable from t that carry none of the given
+// tags, as Type.Field. Embedded structs without a tag are flattened like encoding/json does.
+func untaggedFields(t reflect.Type, tags []string) []string {
+	var fields []string
+	visited := make(map[reflect.Type]bool)
+
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if union, exists := LookupUnion(t); exists {
+			for _, variant := range union.Variants {
+				walk(variant)
+			}
+			return
+		}
+		if t.Kind() != reflect.Struct || visited[t] || t == timeType || encodesItself(t) {
+			return
+		}
+		visited[t] = true
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+			tagged := false
+			for _, tag := range tags {
+				if _, ok := field.Tag.Lookup(tag); ok {
+					tagged = true
+					break
+				}
+			}
+			if !tagged && !field.Anonymous {
+				fields = append(fields, fmt.Sprintf("%s.%s", t.Name(), field.Name))
+			}
+			walk(field.Type)
+		}
+	}
+	walk(t)
+	return fields
+}
+
+// encodesItself reports whether t controls its own JSON or text encoding
+func encodesItself(t reflect.Type) bool {
+	ptr := reflect.PointerTo(t)
+	return ptr.Implements(jsonMarshalerType) || ptr.Implements(textMarshalerType)
+}
+
+// checkValidationRules validates a zero value of every struct reachable from t. The
+// validator panics on rules it cannot parse, such as unknown validator names, which would
+// otherwise surface on the first request.
+func (e *Engine) checkValidationRules(t reflect.Type) error {
+	var problems []string
+	visited := make(map[reflect.Type]bool)
+
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if union, exists := LookupUnion(t); exists {
+			for _, variant := range union.Variants {
+				walk(variant)
+			}
+			return
+		}
+		if t.Kind() != reflect.Struct || visited[t] || t == timeType {
+			return
+		}
+		visited[t] = true
+
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					problems = append(problems, fmt.Sprintf("%s: %v", t.Name(), r))
+				}
+			}()
+			e.validator.Struct(reflect.New(t).Interface())
+		}()
+		for i := 0; i < t.NumField(); i++ {
+			walk(t.Field(i).Type)
+		}
+	}
+	walk(t)
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(problems, "; "))
+}
+
+// isNilValue reports whether v is nil or a typed nil such as a nil *Controller
+func isNilValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
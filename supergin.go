@@ -5,22 +5,66 @@ package supergin
 import (
 	"net/http"
 	"reflect"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc"
 )
 
 // Engine wraps gin.Engine with enhanced capabilities
 type Engine struct {
 	*gin.Engine
-	routes    map[string]*RouteInfo
-	routesMux sync.RWMutex
-	validator *validator.Validate
-	config    Config
-	di        *DIContainer
+	routes      map[string]*RouteInfo
+	routesMux   sync.RWMutex
+	validator   *validator.Validate
+	config      Config
+	logger      Logger
+	environment string
+	di          *DIContainer
+	metrics     *MetricsRegistry
+	messages    *MessageRegistry
+	groups      map[string]*GroupBuilder
+	resources   []*ModelInfo
+
+	validationCatalog *validationCatalog
+	validationTypes   map[reflect.Type]bool
+	validationTypesMu sync.Mutex
+
+	hooks         engineHooks
+	errorHandling errorHandling
+
+	tagMiddleware    map[string][]gin.HandlerFunc
+	tagMiddlewareMux sync.RWMutex
+
+	authSchemes    map[string]gin.HandlerFunc
+	authSchemesMux sync.RWMutex
+	policies       atomic.Pointer[policySet]
+	authorizer     atomic.Pointer[Authorizer]
+
+	hosts    map[string]*gin.Engine
+	hostsMux sync.RWMutex
+
+	// staticRoot serves GET requests no route matched for a Static or SPA mount at "/"
+	staticRoot *staticMount
+
+	server       *http.Server
+	grpcServer   *grpc.Server
+	grpcMethods  map[string]*grpcServedMethod
+	hubs         []*WebSocketHub
+	listeners    []string
+	drain        atomic.Pointer[drainState]
+	jobs         *jobStore
+	memory       atomic.Pointer[memoryGuard]
+	meter        atomic.Pointer[meter]
+	cacheStore   atomic.Pointer[CacheStore]
+	circuits     map[string]*CircuitBreaker
+	circuitsMux  sync.Mutex
+	shuttingDown bool
+	shutdownDone chan struct{}
+	lifecycleMux sync.Mutex
 }
 
 // Config holds configuration for SuperGin
@@ -29,20 +73,85 @@ type Config struct {
 	ValidateInput  bool
 	ValidateOutput bool
 	DocsPath       string
+	// OutputValidationMode decides whether invalid responses are logged or replaced with a 500
+	OutputValidationMode OutputValidationMode
+	// OutputContractOnly limits output validation to presence of `contract:"required"` fields
+	OutputContractOnly bool
+	// ResponseHeaderMode decides whether missing required response headers are logged or
+	// replaced with a 500
+	ResponseHeaderMode OutputValidationMode
+	// Title and Version describe the API in generated OpenAPI documents
+	Title   string
+	Version string
+	// DrainTimeout bounds graceful shutdown after a signal; zero means DefaultDrainTimeout
+	DrainTimeout time.Duration
+	// Environment names the deployment, e.g. "production"; empty reads SUPERGIN_ENV and
+	// defaults to "development". Routes declaring other environments are not registered.
+	Environment string
+	// URLScheme is the scheme of absolute URLs generated for host-bound routes; default https
+	URLScheme string
+	// BaseURL is where AbsoluteURLFor places routes not bound to a host, e.g.
+	// "https://api.example.com" or, behind a proxy that adds a prefix,
+	// "https://example.com/api"
+	BaseURL string
+	// Logger receives request, route, DI, WebSocket and gRPC bridge logs; nil logs via slog.Default
+	Logger Logger
+	// ManifestPath, when set, is where Start and Run write the boot manifest as JSON
+	ManifestPath string
+	// ValidationTags are the struct tags validation rules are read from, in precedence
+	// order; nil means "validate". Include "binding" to validate gin models unchanged, e.g.
+	// []string{"validate", "binding"}.
+	ValidationTags []string
+	// ErrorHandler writes the responses for errors returned by HandlerE handlers, typed
+	// handlers and CRUD services; nil uses WriteError
+	ErrorHandler ErrorHandler
+	// DocsValidationStats adds per-field validation rejection stats to the docs endpoint.
+	// Off by default since docs are usually public; EnableValidationStatsEndpoint serves
+	// them behind authentication.
+	DocsValidationStats bool
+	// DocsAuth, when set, guards the docs and OpenAPI endpoints, e.g. an API key or admin
+	// session check
+	DocsAuth gin.HandlerFunc
+	// MaxBodyBytes caps request bodies of every route, answering larger ones 413; zero means
+	// no limit. WithMaxBody overrides it per route, and multipart forms keep at most this
+	// much in memory.
+	MaxBodyBytes int64
+	// Compression, when set, compresses every route's responses in the encoding negotiated
+	// from Accept-Encoding; WithCompression overrides it per route
+	Compression *CompressionOptions
+	// CORS is the CORS policy of every route that does not set its own with WithCORS or
+	// through its group; such routes answer OPTIONS preflight requests
+	CORS *CORSPolicy
+	// EnableDebug mounts pprof, expvar, runtime stats and route and DI introspection under
+	// /debug, guarded by DebugAuth, which is then required
+	EnableDebug bool
+	DebugAuth   gin.HandlerFunc
+	// Strict makes Start and Run fail on the misconfigurations CheckConfiguration detects,
+	// instead of serving with them
+	Strict bool
 }
 
 // RouteInfo holds metadata about a route
 type RouteInfo struct {
-	Name        string                 `json:"name"`
-	Method      string                 `json:"method"`
-	Path        string                 `json:"path"`
-	Handler     gin.HandlerFunc        `json:"-"`
-	InputType   reflect.Type           `json:"-"`
-	OutputType  reflect.Type           `json:"-"`
-	Metadata    map[string]interface{} `json:"metadata"`
-	Description string                 `json:"description"`
-	Tags        []string               `json:"tags"`
-	CreatedAt   time.Time              `json:"created_at"`
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	Method       string                 `json:"method"`
+	Path         string                 `json:"path"`
+	Handler      gin.HandlerFunc        `json:"-"`
+	InputType    reflect.Type           `json:"-"`
+	OutputType   reflect.Type           `json:"-"`
+	Metadata     map[string]interface{} `json:"metadata"`
+	Description  string                 `json:"description"`
+	Tags         []string               `json:"tags"`
+	Group        string                 `json:"group,omitempty"`
+	Host         string                 `json:"host,omitempty"`
+	Environments []string               `json:"environments,omitempty"`
+	Middleware   []string               `json:"middleware,omitempty"`
+	List         *ListOutput            `json:"list,omitempty"`
+	Headers      []ResponseHeader       `json:"response_headers,omitempty"`
+	SLO          *SLO                   `json:"slo,omitempty"`
+	Stages       []string               `json:"stages,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
 }
 
 // InputOutput defines the container for request/response validation
@@ -62,25 +171,63 @@ func New(config ...Config) *Engine {
 	if len(config) > 0 {
 		cfg = config[0]
 	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = defaultLogger
+	}
 
 	engine := &Engine{
-		Engine:    gin.New(),
-		routes:    make(map[string]*RouteInfo),
-		validator: validator.New(),
-		config:    cfg,
-		di:        GetDI(),
+		Engine:      gin.New(),
+		routes:      make(map[string]*RouteInfo),
+		validator:   validator.New(),
+		config:      cfg,
+		logger:      logger,
+		environment: resolveEnvironment(cfg.Environment),
+		di:          GetDI(),
+		metrics:     NewMetricsRegistry(),
+		messages:    NewMessageRegistry(),
+		groups:      make(map[string]*GroupBuilder),
+
+		validationCatalog: newValidationCatalog(),
+
+		tagMiddleware: make(map[string][]gin.HandlerFunc),
+		authSchemes:   make(map[string]gin.HandlerFunc),
+		hosts:         make(map[string]*gin.Engine),
+		shutdownDone:  make(chan struct{}),
+	}
+
+	mustValidCORS(cfg.CORS)
+	if cfg.MaxBodyBytes > 0 && cfg.MaxBodyBytes < engine.Engine.MaxMultipartMemory {
+		engine.Engine.MaxMultipartMemory = cfg.MaxBodyBytes
+	}
+	engine.configureValidationTags()
+	engine.hooks.logger = logger
+	engine.errorHandling.handler = cfg.ErrorHandler
+	if cfg.Logger != nil {
+		engine.di.SetLogger(cfg.Logger)
 	}
 
 	// Add built-in middleware
-	engine.Use(gin.Logger())
+	engine.Use(engine.requestLogger())
+	engine.Use(engine.hooksMiddleware())
+	engine.Use(engine.errorHandlingMiddleware())
 	engine.Use(gin.Recovery())
-	
+	engine.Use(engine.localeMiddleware())
+	engine.Use(engine.drainMiddleware())
+	engine.Use(engine.memoryMiddleware())
+	engine.Use(engine.meteringMiddleware())
+
 	// Add DI middleware
-	engine.Use(engine.di.Middleware())
+	engine.Use(engine.diMiddleware())
 
 	// Setup docs endpoint if enabled
 	if cfg.EnableDocs {
 		engine.setupDocsEndpoint()
+		engine.setupOpenAPIEndpoint()
+		engine.setupAsyncAPIEndpoint()
+	}
+	if cfg.EnableDebug {
+		engine.setupDebugEndpoints()
 	}
 
 	return engine
@@ -91,6 +238,11 @@ func (e *Engine) DI() *DIContainer {
 	return e.di
 }
 
+// Metrics returns the per-route metrics registry
+func (e *Engine) Metrics() *MetricsRegistry {
+	return e.metrics
+}
+
 // GetRoute returns route information by name
 func (e *Engine) GetRoute(name string) (*RouteInfo, bool) {
 	e.routesMux.RLock()
@@ -103,7 +255,7 @@ func (e *Engine) GetRoute(name string) (*RouteInfo, bool) {
 func (e *Engine) GetRoutes() map[string]*RouteInfo {
 	e.routesMux.RLock()
 	defer e.routesMux.RUnlock()
-	
+
 	// Create a copy to avoid race conditions
 	routes := make(map[string]*RouteInfo)
 	for k, v := range e.routes {
@@ -116,7 +268,7 @@ func (e *Engine) GetRoutes() map[string]*RouteInfo {
 func (e *Engine) GetRoutesByTag(tag string) []*RouteInfo {
 	e.routesMux.RLock()
 	defer e.routesMux.RUnlock()
-	
+
 	var routes []*RouteInfo
 	for _, route := range e.routes {
 		for _, t := range route.Tags {
@@ -129,46 +281,39 @@ func (e *Engine) GetRoutesByTag(tag string) []*RouteInfo {
 	return routes
 }
 
-// URLFor generates URL for a named route with parameters
-func (e *Engine) URLFor(name string, params ...interface{}) (string, error) {
-	route, exists := e.GetRoute(name)
-	if !exists {
-		return "", NewSuperGinError(ErrRouteNotFound, "route '%s' not found", name)
-	}
-
-	url := route.Path
-	
-	// Simple parameter replacement (basic implementation)
-	for i := 0; i < len(params); i += 2 {
-		if i+1 < len(params) {
-			key := ":" + params[i].(string)
-			value := params[i+1].(string)
-			url = strings.Replace(url, key, value, 1)
-		}
-	}
-	
-	return url, nil
-}
-
 // setupDocsEndpoint creates an endpoint for API documentation
 func (e *Engine) setupDocsEndpoint() {
-	e.Engine.GET(e.config.DocsPath, func(c *gin.Context) {
+	e.Engine.GET(e.config.DocsPath, e.docsHandlers(func(c *gin.Context) {
 		routes := e.GetRoutes()
-		
+
 		// Convert to JSON-serializable format
 		docs := map[string]interface{}{
 			"routes":       routes,
 			"generated_at": time.Now(),
 			"total_routes": len(routes),
+			"environment":  e.environment,
+			"groups":       e.groupDocs(routes),
 			"di_services":  e.di.ListServices(),
+			"messages":     e.messages.List(),
 		}
-		
+		if e.config.DocsValidationStats {
+			docs["validation_stats"] = e.ValidationStats()
+		}
+
 		c.JSON(http.StatusOK, docs)
-	})
+	})...)
+}
+
+// docsHandlers puts Config.DocsAuth, when set, in front of a docs handler
+func (e *Engine) docsHandlers(handler gin.HandlerFunc) []gin.HandlerFunc {
+	if e.config.DocsAuth == nil {
+		return []gin.HandlerFunc{handler}
+	}
+	return []gin.HandlerFunc{e.config.DocsAuth, handler}
 }
 
 // GetValidatedInput retrieves validated input from context
 func GetValidatedInput(c *gin.Context) (interface{}, bool) {
 	input, exists := c.Get("validated_input")
 	return input, exists
-}
\ No newline at end of file
+}
@@ -0,0 +1,263 @@
+package supergin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithTimeout bounds the route's handling time. The request context gets the deadline, so
+// bridged gRPC calls, *http.Client services resolved from DI with the request context and
+// handlers honouring c.Request.Context() stop when it passes; bridged calls still get a
+// shorter grpc-timeout when the client asks for one. A request whose deadline passes before
+// its response has started is answered 504 as application/problem+json right away, even
+// while the handler is still running, and whatever the handler writes afterwards is
+// discarded.
+func (rb *RouteBuilder) WithTimeout(timeout time.Duration) *RouteBuilder {
+	if timeout <= 0 {
+		panic("route timeout must be positive")
+	}
+	rb.WithMetadata("timeout", timeout.String())
+	return rb.WithMiddleware(func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		original := c.Writer
+		writer := &timeoutWriter{ResponseWriter: original, ctx: ctx, timeout: timeout, header: original.Header().Clone()}
+		c.Writer = writer
+		// Restore the writer even if the handler panics
+		defer func() { c.Writer = original }()
+
+		// The handler keeps running on this goroutine, where request-bound DI resolution
+		// works; the watcher answers 504 as soon as the deadline passes
+		stop, stopped := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(stopped)
+			select {
+			case <-ctx.Done():
+				writer.expire()
+			case <-stop:
+			}
+		}()
+		defer func() {
+			close(stop)
+			<-stopped
+		}()
+
+		c.Next()
+		if writer.expire() {
+			c.Abort()
+			return
+		}
+		writer.finish()
+	})
+}
+
+// timeoutWriter replaces a response that had not started when the route's deadline passed
+// with a 504. The handler's headers are kept apart until its response starts, as with
+// http.TimeoutHandler, so the 504 can be written from another goroutine.
+type timeoutWriter struct {
+	gin.ResponseWriter
+	ctx     context.Context
+	timeout time.Duration
+	header  http.Header
+	expired bool
+	mutex   sync.Mutex
+}
+
+// expire writes the 504 once the deadline has passed, unless the response has started,
+// and reports whether the handler's response is to be discarded
+func (w *timeoutWriter) expire() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.expireLocked()
+}
+
+func (w *timeoutWriter) expireLocked() bool {
+	if w.expired {
+		return true
+	}
+	if w.ResponseWriter.Written() || !errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+	w.expired = true
+
+	body, _ := json.Marshal(problemDetails(http.StatusGatewayTimeout,
+		fmt.Sprintf("request exceeded the route timeout of %s", w.timeout)))
+	header := w.ResponseWriter.Header()
+	for _, key := range []string{"Content-Length", "Content-Encoding", "Content-Disposition"} {
+		header.Del(key)
+	}
+	header.Set("Content-Type", "application/problem+json")
+	// With its length declared, clients have the whole 504 before the handler returns; the
+	// connection stays busy until then, so clients are told not to reuse it
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	header.Set("Connection", "close")
+	w.ResponseWriter.WriteHeader(http.StatusGatewayTimeout)
+	w.ResponseWriter.Write(body)
+	w.ResponseWriter.Flush()
+	return true
+}
+
+// syncHeaderLocked copies the handler's headers to the response until it has started
+func (w *timeoutWriter) syncHeaderLocked() {
+	if w.ResponseWriter.Written() {
+		return
+	}
+	header := w.ResponseWriter.Header()
+	for key := range header {
+		if _, kept := w.header[key]; !kept {
+			delete(header, key)
+		}
+	}
+	for key, values := range w.header {
+		header[key] = values
+	}
+}
+
+// finish hands the handler's headers to the response once the handler has returned in time
+func (w *timeoutWriter) finish() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.syncHeaderLocked()
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if !w.expireLocked() {
+		w.syncHeaderLocked()
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if !w.expireLocked() {
+		w.syncHeaderLocked()
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.expireLocked() {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.syncHeaderLocked()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.expireLocked() {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.syncHeaderLocked()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *timeoutWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if !w.expireLocked() {
+		w.syncHeaderLocked()
+		w.ResponseWriter.Flush()
+	}
+}
+
+func (w *timeoutWriter) Status() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.ResponseWriter.Status()
+}
+
+func (w *timeoutWriter) Size() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.ResponseWriter.Size()
+}
+
+func (w *timeoutWriter) Written() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.ResponseWriter.Written()
+}
+
+// boundToDeadline gives an *http.Client resolved with a request context that carries a
+// deadline, such as a route timeout, a transport bounding each of its requests by that
+// deadline. Other services are returned unchanged.
+func boundToDeadline(ctx context.Context, instance interface{}) interface{} {
+	client, ok := instance.(*http.Client)
+	if !ok || client == nil || ctx == nil {
+		return instance
+	}
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		if ginCtx.Request == nil {
+			return instance
+		}
+		ctx = ginCtx.Request.Context()
+	}
+	deadline, bounded := ctx.Deadline()
+	if !bounded {
+		return instance
+	}
+
+	bound := *client
+	bound.Transport = &deadlineTransport{base: client.Transport, deadline: deadline}
+	return &bound
+}
+
+// deadlineTransport bounds requests by a deadline unless their context ends sooner
+type deadlineTransport struct {
+	base     http.RoundTripper
+	deadline time.Time
+}
+
+func (t *deadlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if deadline, bounded := req.Context().Deadline(); bounded && !deadline.After(t.deadline) {
+		return base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithDeadline(req.Context(), t.deadline)
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The deadline keeps bounding the body until it is closed
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose cancels a response's context once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
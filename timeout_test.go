@@ -0,0 +1,85 @@
+package supergin
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRouteTimeout(t *testing.T) {
+	app := newTestEngine(Config{})
+	release := make(chan struct{})
+	handlerErr := make(chan error, 1)
+
+	app.Named("fast").GET("/fast").WithTimeout(time.Second).Handler(func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	app.Named("stuck").GET("/stuck").WithTimeout(50 * time.Millisecond).Handler(func(c *gin.Context) {
+		c.Header("X-Handler", "stuck")
+		<-release
+		c.String(http.StatusOK, "late")
+	})
+	app.Named("cooperative").GET("/cooperative").WithTimeout(50 * time.Millisecond).Handler(func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		handlerErr <- c.Request.Context().Err()
+	})
+
+	server := httptest.NewServer(app)
+	defer server.Close()
+	// Runs before the server closes, which waits for the stuck handler
+	defer close(release)
+	client := &http.Client{Timeout: 2 * time.Second}
+
+	get := func(t *testing.T, path string) (*http.Response, string) {
+		t.Helper()
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("reading %s: %v", path, err)
+		}
+		return resp, string(body)
+	}
+
+	t.Run("fast route", func(t *testing.T) {
+		resp, body := get(t, "/fast")
+		if resp.StatusCode != http.StatusOK || body != "ok" {
+			t.Errorf("got %d %q, want 200 \"ok\"", resp.StatusCode, body)
+		}
+	})
+
+	t.Run("handler ignoring the deadline", func(t *testing.T) {
+		// The handler is still blocked when the 504 has to arrive
+		resp, body := get(t, "/stuck")
+		if resp.StatusCode != http.StatusGatewayTimeout {
+			t.Fatalf("status = %d, want 504", resp.StatusCode)
+		}
+		if got := resp.Header.Get("Content-Type"); got != "application/problem+json" {
+			t.Errorf("Content-Type = %q, want application/problem+json", got)
+		}
+		if got := resp.Header.Get("X-Handler"); got != "" {
+			t.Errorf("X-Handler = %q, want the handler's headers left out of the 504", got)
+		}
+		if body == "late" {
+			t.Error("body is the handler's late response")
+		}
+	})
+
+	t.Run("handler honouring the deadline", func(t *testing.T) {
+		resp, _ := get(t, "/cooperative")
+		if resp.StatusCode != http.StatusGatewayTimeout {
+			t.Errorf("status = %d, want 504", resp.StatusCode)
+		}
+		if err := <-handlerErr; err != context.DeadlineExceeded {
+			t.Errorf("handler context error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+}
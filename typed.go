@@ -0,0 +1,145 @@
+package supergin
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// TypedHandlerFunc is a handler with statically known request and response types,
+// created with Handle and registered with RouteBuilder.TypedHandler
+type TypedHandlerFunc struct {
+	inputType  reflect.Type
+	outputType reflect.Type
+	serve      func(c *gin.Context, validate *validator.Validate)
+}
+
+// StatusCoder is implemented by errors that carry an HTTP status
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// defaultValidator validates typed handlers used outside an engine route
+var defaultValidator = validator.New()
+
+// Handle wraps a typed handler. The handler receives the route's validated input as *Req,
+// or the request bound and validated into a new Req when the route did not validate it.
+// A non-nil response is written with status 200 in the format negotiated from Accept
+// (see Respond) and a nil response as 204; errors are written with HandleError.
+func Handle[Req, Resp any](fn func(c *gin.Context, req *Req) (*Resp, error)) TypedHandlerFunc {
+	inputType := reflect.TypeOf((*Req)(nil)).Elem()
+
+	return TypedHandlerFunc{
+		inputType:  inputType,
+		outputType: reflect.TypeOf((*Resp)(nil)).Elem(),
+		serve: func(c *gin.Context, validate *validator.Validate) {
+			req, err := typedInput[Req](c, inputType, validate)
+			if err != nil {
+				writeValidationError(c, err)
+				return
+			}
+
+			resp, err := fn(c, req)
+			if err != nil {
+				HandleError(c, err)
+				return
+			}
+			if resp == nil {
+				c.Status(http.StatusNoContent)
+				return
+			}
+			Respond(c, http.StatusOK, resp)
+		},
+	}
+}
+
+// WriteError writes a handler error as JSON. Errors implementing StatusCoder use their
+// status, then errors mapped with Engine.MapError; ErrNotFound maps to 404, errors with
+// code ErrValidationFailed or carrying FieldErrors map to 400 and other errors to 500.
+// Field errors are listed under "fields".
+func WriteError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	fields := localizedFieldErrors(c, err)
+	var coded StatusCoder
+	if errors.As(err, &coded) {
+		status = coded.StatusCode()
+	} else if mapped, exists := mappedErrorStatus(c, err); exists {
+		status = mapped
+	} else if errors.Is(err, ErrNotFound) {
+		status = http.StatusNotFound
+	} else if IsErrorCode(err, ErrValidationFailed) || len(fields) > 0 {
+		status = http.StatusBadRequest
+	}
+	body := gin.H{"error": err.Error()}
+	if len(fields) > 0 {
+		body["fields"] = fields
+	}
+	c.JSON(status, body)
+}
+
+// typedInput returns the validated input when it has type *Req, otherwise binds and validates
+func typedInput[Req any](c *gin.Context, inputType reflect.Type, validate *validator.Validate) (*Req, error) {
+	if input, exists := GetValidatedInput(c); exists {
+		if req, ok := input.(*Req); ok {
+			return req, nil
+		}
+	}
+
+	req := new(Req)
+	if union, exists := LookupUnion(inputType); exists {
+		// Union inputs are validated as their concrete variant, e.g. Event holding *UserCreated
+		if input, exists := GetValidatedInput(c); exists {
+			if variant, ok := input.(Req); ok {
+				*req = variant
+				return req, nil
+			}
+		}
+		payload, err := bindUnion(c, union, validate)
+		if err != nil {
+			return nil, err
+		}
+		*req = payload.(Req)
+		return req, nil
+	}
+	if inputType.Kind() == reflect.Struct && inputType.NumField() == 0 {
+		return req, nil
+	}
+	if err := bindRequest(c, req); err != nil {
+		return nil, bindingError(err)
+	}
+	if inputType.Kind() == reflect.Struct {
+		if err := validateStruct(validate, req); err != nil {
+			return nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "validation error")
+		}
+	}
+	return req, nil
+}
+
+// HandlerFunc adapts the typed handler for plain gin registration
+func (h TypedHandlerFunc) HandlerFunc() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.serve(c, defaultValidator)
+	}
+}
+
+// TypedHandler sets the route's input/output types from a typed handler and registers the route
+func (rb *RouteBuilder) TypedHandler(handler TypedHandlerFunc) *RouteBuilder {
+	if handler.serve == nil {
+		panic("typed handler must be created with Handle")
+	}
+
+	if rb.inputType == nil && !(handler.inputType.Kind() == reflect.Struct && handler.inputType.NumField() == 0) {
+		rb.inputType = handler.inputType
+	}
+	if rb.outputType == nil {
+		rb.outputType = handler.outputType
+	}
+
+	validate := rb.engine.validator
+	return rb.Handler(func(c *gin.Context) {
+		handler.serve(c, validate)
+	})
+}
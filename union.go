@@ -0,0 +1,225 @@
+package supergin
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// UnionDefinition describes a discriminated union: an interface type whose concrete
+// payload is selected by a string discriminator field
+type UnionDefinition struct {
+	Type          reflect.Type            `json:"-"`
+	Name          string                  `json:"name"`
+	Discriminator string                  `json:"discriminator"`
+	Variants      map[string]reflect.Type `json:"-"`
+}
+
+var (
+	unions   = make(map[reflect.Type]*UnionDefinition)
+	unionsMu sync.RWMutex
+)
+
+// RegisterUnion declares the concrete types of interface T by discriminator value, e.g.
+//
+//	supergin.RegisterUnion[Event]("type", map[string]Event{
+//		"user.created": &UserCreated{},
+//		"user.deleted": &UserDeleted{},
+//	})
+//
+// Routes using T as input bind the variant named by the payload's discriminator field,
+// and schemas document T as a oneOf with an OpenAPI discriminator mapping.
+func RegisterUnion[T any](discriminator string, variants map[string]T) *UnionDefinition {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("union type %s must be an interface", t))
+	}
+	if discriminator == "" || len(variants) == 0 {
+		panic(fmt.Sprintf("union %s requires a discriminator and at least one variant", t))
+	}
+
+	def := &UnionDefinition{
+		Type:          t,
+		Name:          t.String(),
+		Discriminator: discriminator,
+		Variants:      make(map[string]reflect.Type, len(variants)),
+	}
+	for value, variant := range variants {
+		variantType := reflect.TypeOf(variant)
+		if variantType == nil {
+			panic(fmt.Sprintf("union %s variant '%s' must not be nil", t, value))
+		}
+		def.Variants[value] = variantType
+	}
+
+	unionsMu.Lock()
+	defer unionsMu.Unlock()
+	unions[t] = def
+	return def
+}
+
+// LookupUnion returns the registered definition for a union interface type
+func LookupUnion(t reflect.Type) (*UnionDefinition, bool) {
+	unionsMu.RLock()
+	defer unionsMu.RUnlock()
+
+	def, exists := unions[t]
+	return def, exists
+}
+
+// Values returns the discriminator values in sorted order
+func (u *UnionDefinition) Values() []string {
+	values := make([]string, 0, len(u.Variants))
+	for value := range u.Variants {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// Decode unmarshals data into the variant named by its discriminator field. The result
+// has the registered variant's type (e.g. *UserCreated) and implements the union interface.
+func (u *UnionDefinition) Decode(data []byte) (interface{}, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	var value string
+	raw, exists := fields[u.Discriminator]
+	if !exists {
+		return nil, fmt.Errorf("missing discriminator field '%s'", u.Discriminator)
+	}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("discriminator field '%s' must be a string", u.Discriminator)
+	}
+	variantType, exists := u.Variants[value]
+	if !exists {
+		return nil, fmt.Errorf("unknown %s '%s', expected one of: %s", u.Discriminator, value, strings.Join(u.Values(), ", "))
+	}
+
+	if variantType.Kind() == reflect.Ptr {
+		variant := reflect.New(variantType.Elem())
+		if err := json.Unmarshal(data, variant.Interface()); err != nil {
+			return nil, err
+		}
+		return variant.Interface(), nil
+	}
+	variant := reflect.New(variantType)
+	if err := json.Unmarshal(data, variant.Interface()); err != nil {
+		return nil, err
+	}
+	return variant.Elem().Interface(), nil
+}
+
+// UnmarshalUnion decodes a payload of union type T, for use in UnmarshalJSON methods of
+// structs embedding union fields
+func UnmarshalUnion[T any](data []byte) (T, error) {
+	var zero T
+	def, exists := LookupUnion(reflect.TypeOf((*T)(nil)).Elem())
+	if !exists {
+		return zero, fmt.Errorf("union %T not registered", zero)
+	}
+	decoded, err := def.Decode(data)
+	if err != nil {
+		return zero, err
+	}
+	return decoded.(T), nil
+}
+
+// WithUnionInput sets a registered union as the route's input type
+func (rb *RouteBuilder) WithUnionInput(union *UnionDefinition) *RouteBuilder {
+	rb.inputType = union.Type
+	return rb
+}
+
+// WithUnionOutput sets a registered union as the route's output type
+func (rb *RouteBuilder) WithUnionOutput(union *UnionDefinition) *RouteBuilder {
+	rb.outputType = union.Type
+	return rb
+}
+
+// bindUnion decodes and validates a union request body
+func bindUnion(c *gin.Context, union *UnionDefinition, validate *validator.Validate) (interface{}, error) {
+	body, err := c.GetRawData()
+	if err != nil {
+		return nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "failed to read request body")
+	}
+	payload, err := union.Decode(body)
+	if err != nil {
+		return nil, NewSuperGinError(ErrValidationFailed, "binding error: %v", err)
+	}
+
+	variant := reflect.ValueOf(payload)
+	for variant.Kind() == reflect.Ptr {
+		variant = variant.Elem()
+	}
+	if variant.Kind() == reflect.Struct {
+		if err := validateStruct(validate, payload); err != nil {
+			return nil, NewSuperGinErrorWithCause(ErrValidationFailed, err, "validation error")
+		}
+	}
+	return payload, nil
+}
+
+// unionSchema describes a union as oneOf its variants, each pinned to its discriminator value
+func (sb *schemaBuilder) unionSchema(union *UnionDefinition) map[string]interface{} {
+	values := union.Values()
+	oneOf := make([]interface{}, 0, len(values))
+	mapping := make(map[string]interface{}, len(values))
+
+	for _, value := range values {
+		variant := sb.build(union.Variants[value])
+		if ref, ok := variant["$ref"].(string); ok {
+			mapping[value] = ref
+			variant = map[string]interface{}{
+				"allOf": []interface{}{
+					variant,
+					discriminatorSchema(union.Discriminator, value),
+				},
+			}
+		} else {
+			variant = mergeDiscriminator(variant, union.Discriminator, value)
+		}
+		oneOf = append(oneOf, variant)
+	}
+
+	schema := map[string]interface{}{"oneOf": oneOf}
+	discriminator := map[string]interface{}{"propertyName": union.Discriminator}
+	if len(mapping) == len(values) {
+		discriminator["mapping"] = mapping
+	}
+	schema["discriminator"] = discriminator
+	return schema
+}
+
+// discriminatorSchema requires the discriminator property to equal value
+func discriminatorSchema(property, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{property: map[string]interface{}{"type": "string", "const": value}},
+		"required":   []string{property},
+	}
+}
+
+// mergeDiscriminator adds the pinned discriminator property to an inline variant schema
+func mergeDiscriminator(variant map[string]interface{}, property, value string) map[string]interface{} {
+	properties, _ := variant["properties"].(map[string]interface{})
+	if properties == nil {
+		properties = make(map[string]interface{})
+		variant["properties"] = properties
+	}
+	properties[property] = map[string]interface{}{"type": "string", "const": value}
+
+	required, _ := variant["required"].([]string)
+	if !contains(required, property) {
+		variant["required"] = append(required, property)
+	}
+	return variant
+}
@@ -0,0 +1,217 @@
+package supergin
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// URLFor generates the URL of a named route. Params are key/value pairs, a map such as
+// map[string]string, or a struct whose `uri` fields fill path parameters and `form`
+// fields the query, as the route binds them. Params the path does not use are appended as
+// a query string, with zero struct fields left out. A path parameter without a value is
+// an ErrInvalidURLParams error; catch-all parameters such as a static route's *filepath
+// may be empty. Routes bound to a host get an absolute URL, e.g.
+// https://api.example.com/users/42.
+//
+//	app.URLFor("show_user", "id", 42)                                     // /users/42
+//	app.URLFor("list_posts", map[string]interface{}{"id": 42, "page": 2}) // /users/42/posts?page=2
+//	app.URLFor("list_posts", ListPostsRequest{UserID: 42, Page: 2})       // the same, from its input
+func (e *Engine) URLFor(name string, params ...interface{}) (string, error) {
+	route, exists := e.GetRoute(name)
+	if !exists {
+		return "", NewSuperGinError(ErrRouteNotFound, "route '%s' not found", name)
+	}
+	path, err := buildRoutePath(route, params)
+	if err != nil {
+		return "", err
+	}
+	return e.routeURL(route, path), nil
+}
+
+// AbsoluteURLFor is URLFor returning an absolute URL, e.g. for emails and webhooks.
+// Routes not bound to a host are placed under Config.BaseURL, which is then required.
+func (e *Engine) AbsoluteURLFor(name string, params ...interface{}) (string, error) {
+	route, exists := e.GetRoute(name)
+	if !exists {
+		return "", NewSuperGinError(ErrRouteNotFound, "route '%s' not found", name)
+	}
+	path, err := buildRoutePath(route, params)
+	if err != nil {
+		return "", err
+	}
+	if route.Host != "" {
+		return e.routeURL(route, path), nil
+	}
+	if e.config.BaseURL == "" {
+		return "", NewSuperGinError(ErrMisconfiguration, "absolute URL for route '%s' requires Config.BaseURL", name)
+	}
+	return strings.TrimSuffix(e.config.BaseURL, "/") + path, nil
+}
+
+// buildRoutePath fills a route's path parameters from params and appends the rest as a
+// query string
+func buildRoutePath(route *RouteInfo, params []interface{}) (string, error) {
+	values, omitted, err := urlParams(params)
+	if err != nil {
+		return "", NewSuperGinErrorWithCause(ErrInvalidURLParams, err, "invalid params for route '%s'", route.Name)
+	}
+
+	segments := strings.Split(route.Path, "/")
+	for i, segment := range segments {
+		if len(segment) < 2 || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		key := segment[1:]
+		value := values[key]
+		delete(values, key)
+		if len(value) > 1 {
+			return "", NewSuperGinError(ErrInvalidURLParams, "route '%s' path parameter '%s' has %d values", route.Name, key, len(value))
+		}
+
+		if segment[0] == '*' {
+			// Catch-all parameters span segments, so only the segments are escaped
+			rest := ""
+			if len(value) == 1 {
+				parts := strings.Split(strings.TrimPrefix(value[0], "/"), "/")
+				for j, part := range parts {
+					parts[j] = url.PathEscape(part)
+				}
+				rest = strings.Join(parts, "/")
+			}
+			segments[i] = rest
+			continue
+		}
+		if len(value) == 0 || value[0] == "" {
+			return "", NewSuperGinError(ErrInvalidURLParams, "route '%s' needs path parameter '%s'", route.Name, key)
+		}
+		segments[i] = url.PathEscape(value[0])
+	}
+
+	path := strings.Join(segments, "/")
+	for key := range omitted {
+		delete(values, key)
+	}
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+	return path, nil
+}
+
+// urlParams collects URLFor params into values, reporting the keys of zero struct fields,
+// which fill path parameters but are left out of the query
+func urlParams(params []interface{}) (url.Values, map[string]bool, error) {
+	values := url.Values{}
+	omitted := make(map[string]bool)
+	if len(params) == 1 {
+		v := reflect.ValueOf(params[0])
+		for v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return nil, nil, fmt.Errorf("map params need string keys, got %s", v.Type())
+			}
+			iter := v.MapRange()
+			for iter.Next() {
+				addURLParam(values, iter.Key().String(), iter.Value())
+			}
+			return values, omitted, nil
+		case reflect.Struct:
+			structURLParams(v, values, omitted)
+			return values, omitted, nil
+		}
+	}
+
+	if len(params)%2 != 0 {
+		return nil, nil, fmt.Errorf("params must be key/value pairs, got %d values", len(params))
+	}
+	for i := 0; i < len(params); i += 2 {
+		key, ok := params[i].(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("param key %v is a %T, not a string", params[i], params[i])
+		}
+		addURLParam(values, key, reflect.ValueOf(params[i+1]))
+	}
+	return values, omitted, nil
+}
+
+// structURLParams adds a struct's fields, named by their uri, form or json tag or else
+// their field name; untagged embedded structs are flattened
+func structURLParams(v reflect.Value, values url.Values, omitted map[string]bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := ""
+		for _, tag := range []string{"uri", "form", "json"} {
+			if name = strings.Split(field.Tag.Get(tag), ",")[0]; name != "" {
+				break
+			}
+		}
+		if name == "-" {
+			continue
+		}
+
+		fieldValue := v.Field(i)
+		if field.Anonymous && name == "" {
+			for fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct {
+				structURLParams(fieldValue, values, omitted)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if fieldValue.IsZero() {
+			omitted[name] = true
+		}
+		addURLParam(values, name, fieldValue)
+	}
+}
+
+// addURLParam formats a value, adding each element of slices; nil values are skipped
+func addURLParam(values url.Values, key string, v reflect.Value) {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return
+		}
+		if _, ok := v.Interface().(encoding.TextMarshaler); ok {
+			break
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return
+	}
+	if (v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8) || v.Kind() == reflect.Array {
+		if _, ok := v.Interface().(encoding.TextMarshaler); !ok {
+			for i := 0; i < v.Len(); i++ {
+				addURLParam(values, key, v.Index(i))
+			}
+			return
+		}
+	}
+
+	switch value := v.Interface().(type) {
+	case string:
+		values.Add(key, value)
+	case encoding.TextMarshaler:
+		text, err := value.MarshalText()
+		if err != nil {
+			values.Add(key, fmt.Sprint(value))
+			return
+		}
+		values.Add(key, string(text))
+	default:
+		values.Add(key, fmt.Sprint(value))
+	}
+}
@@ -0,0 +1,56 @@
+package supergin
+
+import (
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	validationFormats   = make(map[string]string)
+	validationFormatsMu sync.RWMutex
+)
+
+// Validator returns the engine's validator, used for route input, gRPC bridge and
+// WebSocket message validation
+func (e *Engine) Validator() *validator.Validate {
+	return e.validator
+}
+
+// RegisterValidation adds a custom validate tag, e.g.
+//
+//	app.RegisterValidation("username", func(fl validator.FieldLevel) bool {
+//		return usernamePattern.MatchString(fl.Field().String())
+//	})
+//
+// Fields using the tag are documented with the given schema format, or the tag name.
+func (e *Engine) RegisterValidation(tag string, fn validator.Func, format ...string) error {
+	if err := e.validator.RegisterValidation(tag, fn); err != nil {
+		return NewSuperGinErrorWithCause(ErrValidationFailed, err, "failed to register validation '%s'", tag)
+	}
+
+	schemaFormat := tag
+	if len(format) > 0 && format[0] != "" {
+		schemaFormat = format[0]
+	}
+	validationFormatsMu.Lock()
+	defer validationFormatsMu.Unlock()
+	validationFormats[tag] = schemaFormat
+	return nil
+}
+
+// RegisterStructValidation adds a struct-level rule for the given types, for checks that
+// span fields, e.g. requiring an end date after the start date. Report failures with
+// sl.ReportError.
+func (e *Engine) RegisterStructValidation(fn validator.StructLevelFunc, types ...interface{}) {
+	e.validator.RegisterStructValidation(fn, types...)
+}
+
+// validationFormat returns the schema format documented for a custom validate tag
+func validationFormat(tag string) (string, bool) {
+	validationFormatsMu.RLock()
+	defer validationFormatsMu.RUnlock()
+
+	format, exists := validationFormats[tag]
+	return format, exists
+}
@@ -0,0 +1,106 @@
+package supergin
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ValidationStatsPath is where EnableValidationStatsEndpoint mounts the rejection stats
+const ValidationStatsPath = "/_admin/validation"
+
+// fieldIndexPattern matches array indices and map keys in field paths
+var fieldIndexPattern = regexp.MustCompile(`\[[^\]]*\]`)
+
+// collapseFieldPath drops array indices and map keys from a field path, so "items[0].sku"
+// and "items[3].sku" are counted together as "items[].sku"
+func collapseFieldPath(field string) string {
+	return fieldIndexPattern.ReplaceAllString(field, "[]")
+}
+
+// RouteValidationStats ranks the input fields of a route by how often they fail validation
+type RouteValidationStats struct {
+	Route    string `json:"route"`
+	RouteID  string `json:"route_id,omitempty"`
+	Requests uint64 `json:"requests"`
+	Failures uint64 `json:"validation_failures"`
+	// FailureRate is the share of requests rejected by validation
+	FailureRate float64 `json:"failure_rate"`
+	// Fields are sorted by failure count, most rejected first
+	Fields []FieldRejections `json:"fields"`
+}
+
+// ValidationStats returns the routes that rejected input since startup, most rejections
+// first, with their most rejected fields: a guide to rules clients keep tripping over
+func (e *Engine) ValidationStats() []RouteValidationStats {
+	stats := []RouteValidationStats{}
+	for name, rm := range e.metrics.Snapshot() {
+		if rm.ValidationFailures == 0 {
+			continue
+		}
+		route := RouteValidationStats{
+			Route:    name,
+			RouteID:  rm.RouteID,
+			Requests: rm.Requests,
+			Failures: rm.ValidationFailures,
+			Fields:   sortedRejections(rm.Rejections),
+		}
+		if rm.Requests > 0 {
+			route.FailureRate = float64(rm.ValidationFailures) / float64(rm.Requests)
+		}
+		stats = append(stats, route)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Failures != stats[j].Failures {
+			return stats[i].Failures > stats[j].Failures
+		}
+		return stats[i].Route < stats[j].Route
+	})
+	return stats
+}
+
+// sortedRejections orders field rejections by count, then field
+func sortedRejections(rejections map[string]FieldRejections) []FieldRejections {
+	fields := make([]FieldRejections, 0, len(rejections))
+	for _, field := range rejections {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Count != fields[j].Count {
+			return fields[i].Count > fields[j].Count
+		}
+		return fields[i].Field < fields[j].Field
+	})
+	return fields
+}
+
+// EnableValidationStatsEndpoint registers GET /_admin/validation, serving ValidationStats
+// behind the given authentication middleware. ?route= narrows it to one route.
+func (e *Engine) EnableValidationStatsEndpoint(auth gin.HandlerFunc, middleware ...gin.HandlerFunc) *Engine {
+	if auth == nil {
+		panic("validation stats endpoint requires an authentication middleware")
+	}
+
+	e.Named("admin_validation_stats").
+		GET(ValidationStatsPath).
+		WithDescription("Input validation failures per route and field, most rejected first").
+		WithTags("admin").
+		WithMiddleware(append([]gin.HandlerFunc{auth}, middleware...)...).
+		Handler(func(c *gin.Context) {
+			stats := e.ValidationStats()
+			if route := c.Query("route"); route != "" {
+				filtered := []RouteValidationStats{}
+				for _, rs := range stats {
+					if rs.Route == route {
+						filtered = append(filtered, rs)
+					}
+				}
+				stats = filtered
+			}
+			c.JSON(http.StatusOK, gin.H{"routes": stats})
+		})
+
+	return e
+}
@@ -0,0 +1,137 @@
+package supergin
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// DefaultValidationTag is the struct tag the engine reads validation rules from
+const DefaultValidationTag = "validate"
+
+// bindingDeferredTypes are input types whose `binding` rules an engine enforces itself,
+// so gin's binders skip validating them and failures are reported once, field by field
+var (
+	bindingDeferredTypes        sync.Map
+	installBindingValidatorOnce sync.Once
+)
+
+// deferringStructValidator is gin's binding validator, skipping types validated by an engine
+type deferringStructValidator struct {
+	binding.StructValidator
+}
+
+// ValidateStruct implements binding.StructValidator
+func (v deferringStructValidator) ValidateStruct(obj interface{}) error {
+	t := reflect.TypeOf(obj)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if _, deferred := bindingDeferredTypes.Load(t); deferred {
+		return nil
+	}
+	return v.StructValidator.ValidateStruct(obj)
+}
+
+// validationTags returns the configured tag namespaces in precedence order
+func (e *Engine) validationTags() []string {
+	if len(e.config.ValidationTags) == 0 {
+		return []string{DefaultValidationTag}
+	}
+	return e.config.ValidationTags
+}
+
+// configureValidationTags points the engine's validator at the first configured namespace
+func (e *Engine) configureValidationTags() {
+	tags := e.validationTags()
+	for _, tag := range tags {
+		if tag == "" {
+			panic("validation tag names must not be empty")
+		}
+	}
+	if tags[0] != DefaultValidationTag {
+		e.validator.SetTagName(tags[0])
+	}
+}
+
+// prepareValidation registers, for t and every struct reachable from it, the rules of
+// fields that only carry a lower-precedence tag, e.g. `binding:"required"` on a model
+// without `validate` tags. The validator is not safe for registration while validating,
+// so this runs when routes and handlers are registered.
+func (e *Engine) prepareValidation(t reflect.Type) {
+	tags := e.validationTags()
+	if t == nil || (len(tags) == 1 && tags[0] == DefaultValidationTag) {
+		return
+	}
+
+	e.validationTypesMu.Lock()
+	defer e.validationTypesMu.Unlock()
+	if e.validationTypes == nil {
+		e.validationTypes = make(map[reflect.Type]bool)
+	}
+
+	for _, tag := range tags {
+		if tag == "binding" {
+			// Defer gin's own `binding` validation to the engine for this input
+			installBindingValidatorOnce.Do(func() {
+				if binding.Validator != nil {
+					binding.Validator = deferringStructValidator{binding.Validator}
+				}
+			})
+			base := t
+			for base.Kind() == reflect.Ptr {
+				base = base.Elem()
+			}
+			bindingDeferredTypes.Store(base, true)
+		}
+	}
+	e.registerTagRules(t, tags)
+}
+
+// registerTagRules walks t, registering fallback rules for each struct once. Caller must
+// hold validationTypesMu.
+func (e *Engine) registerTagRules(t reflect.Type, tags []string) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || e.validationTypes[t] {
+		return
+	}
+	e.validationTypes[t] = true
+
+	if union, exists := LookupUnion(t); exists {
+		for _, variant := range union.Variants {
+			e.registerTagRules(variant, tags)
+		}
+	}
+
+	rules := make(map[string]string)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		if field.Tag.Get(tags[0]) == "" {
+			for _, tag := range tags[1:] {
+				if rule := field.Tag.Get(tag); rule != "" {
+					rules[field.Name] = rule
+					break
+				}
+			}
+		}
+		e.registerTagRules(field.Type, tags)
+	}
+	if len(rules) > 0 {
+		e.validator.RegisterStructValidationMapRules(rules, reflect.New(t).Interface())
+	}
+}
+
+// validationRules returns a field's rules for documentation and generated data: its
+// `validate` tag, or its `binding` tag, which gin's binders enforce with the same syntax
+func validationRules(field reflect.StructField) string {
+	if rules := field.Tag.Get(DefaultValidationTag); rules != "" {
+		return rules
+	}
+	return field.Tag.Get("binding")
+}
@@ -0,0 +1,79 @@
+package supergin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Warning codes from RFC 7234 section 5.5
+const (
+	WarnResponseIsStale        = 110
+	WarnMiscellaneous          = 199
+	WarnTransformationApplied  = 214
+	WarnMiscellaneousPersisted = 299
+)
+
+// WarningHeader is the custom header carrying machine-readable warnings
+const WarningHeader = "X-SuperGin-Warning"
+
+const warningsKey = "supergin:warnings"
+
+// Warning represents a soft failure communicated alongside a successful response
+type Warning struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// AddWarning appends a warning to the response headers and the request's warning list.
+// It must be called before the response body is written.
+func AddWarning(c *gin.Context, code int, message string) {
+	warning := Warning{Code: code, Message: message}
+
+	warnings := GetWarnings(c)
+	c.Set(warningsKey, append(warnings, warning))
+
+	c.Writer.Header().Add("Warning", fmt.Sprintf("%d - %q", code, sanitizeWarningText(message)))
+	c.Writer.Header().Add(WarningHeader, sanitizeWarningText(message))
+}
+
+// Warn appends a miscellaneous persistent (299) warning
+func Warn(c *gin.Context, format string, args ...interface{}) {
+	AddWarning(c, WarnMiscellaneousPersisted, fmt.Sprintf(format, args...))
+}
+
+// GetWarnings returns the warnings recorded for the current request
+func GetWarnings(c *gin.Context) []Warning {
+	if value, exists := c.Get(warningsKey); exists {
+		if warnings, ok := value.([]Warning); ok {
+			return warnings
+		}
+	}
+	return nil
+}
+
+// JSONWithWarnings writes data wrapped in an envelope that includes the request's warnings
+func JSONWithWarnings(c *gin.Context, status int, data interface{}) {
+	body := gin.H{"data": data}
+	if warnings := GetWarnings(c); len(warnings) > 0 {
+		body["warnings"] = warnings
+	}
+	c.JSON(status, body)
+}
+
+// Deprecated marks the route as deprecated and warns clients on every response
+func (rb *RouteBuilder) Deprecated(message string) *RouteBuilder {
+	rb.WithMetadata("deprecated", true)
+	rb.WithMetadata("deprecation_message", message)
+	rb.middleware = append([]gin.HandlerFunc{func(c *gin.Context) {
+		AddWarning(c, WarnMiscellaneousPersisted, message)
+		c.Next()
+	}}, rb.middleware...)
+	return rb
+}
+
+// sanitizeWarningText strips characters that are not allowed in header values
+func sanitizeWarningText(text string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(text)
+}
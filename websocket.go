@@ -1,15 +1,17 @@
 package supergin
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/gorilla/websocket"
 )
 
 // WebSocketHandler defines the interface for WebSocket event handlers
@@ -23,12 +25,28 @@ type WebSocketHandler interface {
 // WebSocketConnection represents a WebSocket connection with metadata
 type WebSocketConnection struct {
 	ID       string
-	Conn     *websocket.Conn
-	Send     chan []byte
+	Conn     WebSocketConn
+	send     chan []byte
 	Hub      *WebSocketHub
 	User     interface{} // User context/session data
 	Metadata map[string]interface{}
-	mutex    sync.RWMutex
+	// ConnectedAt is when the connection was upgraded
+	ConnectedAt time.Time
+	// rooms maps joined rooms to when they were joined
+	rooms       map[string]time.Time
+	sessionID   string
+	resumeToken string
+	buffered    int64
+	drained     bool
+	// sendClosed is set under the connection mutex once send is closed
+	sendClosed  bool
+	closeCode   int
+	closeReason string
+	// unregistered is set under the hub mutex once the hub has let go of the connection
+	unregistered bool
+	// writeDone is closed once the write side has stopped
+	writeDone chan struct{}
+	mutex     sync.RWMutex
 }
 
 // WebSocketHub manages all WebSocket connections
@@ -36,9 +54,68 @@ type WebSocketHub struct {
 	connections map[string]*WebSocketConnection
 	register    chan *WebSocketConnection
 	unregister  chan *WebSocketConnection
-	broadcast   chan []byte
+	broadcast   chan *outgoingMessage
 	handler     WebSocketHandler
 	mutex       sync.RWMutex
+	idGenerator ConnectionIDGenerator
+	reservedIDs map[string]bool
+	// admitted holds connections that passed admit but are not registered yet, so limits
+	// count concurrent upgrades
+	admitted map[string]*WebSocketConnection
+
+	rooms       map[string]map[string]*WebSocketConnection
+	limits      WebSocketLimits
+	presence    PresenceOptions
+	sendFilters []SendFilter
+	filtersMux  sync.RWMutex
+	resume      *resumeState
+
+	registerListeners   []ConnectionListener
+	unregisterListeners []ConnectionListener
+	listenersMux        sync.RWMutex
+
+	transport    WebSocketTransport
+	config       WebSocketConfig
+	authenticate WebSocketAuthenticator
+	middleware   []gin.HandlerFunc
+	adapter      *hubAdapter
+	draining     *DrainOptions
+	memory       hubMemory
+	buffered     atomic.Int64
+	name         string
+	logger       Logger
+	clock        Clock
+	// closing is set by Shutdown, done closed once it has finished
+	closing        bool
+	done           chan struct{}
+	shutdownCode   int
+	shutdownReason string
+	// harness drives the hub synchronously in place of Run and the connection pumps
+	harness *WebSocketTestHub
+}
+
+// ConnectionListener observes connection lifecycle events on a hub
+type ConnectionListener func(conn *WebSocketConnection)
+
+// ConnectionIDGenerator produces connection IDs, e.g. to embed user or tenant info
+type ConnectionIDGenerator func(c *gin.Context) string
+
+// maxIDAttempts bounds how often a colliding generator is retried
+const maxIDAttempts = 5
+
+// Defaults for unset WebSocketConfig fields
+const (
+	// wsPongWait is how long a connection may stay silent, pongs included, before it is dropped
+	wsPongWait = 60 * time.Second
+	// wsWriteWait bounds each write
+	wsWriteWait = 10 * time.Second
+	// wsMaxMessageSize is the read limit for incoming messages
+	wsMaxMessageSize = 512
+)
+
+// DefaultConnectionID generates a random UUIDv4-based connection ID
+func DefaultConnectionID(c *gin.Context) string {
+	return "ws_" + newUUID()
 }
 
 // WebSocketMessage represents a structured WebSocket message
@@ -49,95 +126,340 @@ type WebSocketMessage struct {
 	ID        string      `json:"id,omitempty"`
 }
 
-// WebSocketConfig holds WebSocket configuration
+// WebSocketConfig holds WebSocket configuration. Transports read the upgrade settings;
+// hubs configured WithWebSocketConfig also apply the connection limits and timeouts.
 type WebSocketConfig struct {
-	ReadBufferSize    int
-	WriteBufferSize   int
-	CheckOrigin       func(r *http.Request) bool
+	ReadBufferSize  int
+	WriteBufferSize int
+	// CheckOrigin decides whether an upgrade request's origin is allowed; it takes
+	// precedence over AllowedOrigins. With neither set, any origin is allowed.
+	CheckOrigin func(r *http.Request) bool
+	// AllowedOrigins lists the Origin header values allowed to connect, e.g.
+	// "https://app.example.com"; requests without an Origin header, from non-browser
+	// clients, are allowed
+	AllowedOrigins    []string
 	EnableCompression bool
 	HandshakeTimeout  time.Duration
-	ReadTimeout       time.Duration
-	WriteTimeout      time.Duration
-	PingInterval      time.Duration
+	// ReadTimeout is how long a connection may stay silent, pongs included, before it is
+	// dropped; default 60s
+	ReadTimeout time.Duration
+	// WriteTimeout bounds each write; default 10s
+	WriteTimeout time.Duration
+	// PingInterval is how often connections are pinged and must be shorter than
+	// ReadTimeout; default 90% of ReadTimeout
+	PingInterval time.Duration
+	// MaxMessageSize is the largest incoming message in bytes; larger ones close the
+	// connection. Default 512.
+	MaxMessageSize int64
+}
+
+// HubOption configures a WebSocketHub
+type HubOption func(h *WebSocketHub)
+
+// WithIDGenerator sets the hub's connection ID generator
+func WithIDGenerator(generator ConnectionIDGenerator) HubOption {
+	return func(h *WebSocketHub) {
+		if generator != nil {
+			h.idGenerator = generator
+		}
+	}
+}
+
+// WithLimits sets the hub's connection caps
+func WithLimits(limits WebSocketLimits) HubOption {
+	return func(h *WebSocketHub) {
+		h.limits = limits
+	}
+}
+
+// WithSendFilter adds per-connection filters applied to every outgoing message
+func WithSendFilter(filters ...SendFilter) HubOption {
+	return func(h *WebSocketHub) {
+		h.sendFilters = append(h.sendFilters, filters...)
+	}
+}
+
+// log returns the hub's logger, falling back to the default logger
+func (h *WebSocketHub) log() Logger {
+	if h == nil || h.logger == nil {
+		return defaultLogger
+	}
+	return h.logger
 }
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true // Allow all origins in development
-	},
+// WithLogger sets the hub's logger; hubs registered on an engine default to the engine's
+func WithLogger(logger Logger) HubOption {
+	return func(h *WebSocketHub) {
+		h.logger = logger
+	}
 }
 
 // NewWebSocketHub creates a new WebSocket hub
-func NewWebSocketHub(handler WebSocketHandler) *WebSocketHub {
-	return &WebSocketHub{
+func NewWebSocketHub(handler WebSocketHandler, opts ...HubOption) *WebSocketHub {
+	hub := &WebSocketHub{
 		connections: make(map[string]*WebSocketConnection),
 		register:    make(chan *WebSocketConnection),
 		unregister:  make(chan *WebSocketConnection),
-		broadcast:   make(chan []byte),
+		broadcast:   make(chan *outgoingMessage),
+		done:        make(chan struct{}),
 		handler:     handler,
+		idGenerator: DefaultConnectionID,
+		reservedIDs: make(map[string]bool),
+		admitted:    make(map[string]*WebSocketConnection),
+		rooms:       make(map[string]map[string]*WebSocketConnection),
+		clock:       systemClock{},
+	}
+	for _, opt := range opts {
+		opt(hub)
+	}
+	if hub.transport == nil {
+		hub.transport = defaultWebSocketTransport(hub.config)
+	}
+	hub.config = webSocketConfig(hub.config)
+	return hub
+}
+
+// OnRegister subscribes a listener to connection registrations
+func (h *WebSocketHub) OnRegister(listener ConnectionListener) *WebSocketHub {
+	h.listenersMux.Lock()
+	defer h.listenersMux.Unlock()
+
+	h.registerListeners = append(h.registerListeners, listener)
+	return h
+}
+
+// OnUnregister subscribes a listener to connection deregistrations
+func (h *WebSocketHub) OnUnregister(listener ConnectionListener) *WebSocketHub {
+	h.listenersMux.Lock()
+	defer h.listenersMux.Unlock()
+
+	h.unregisterListeners = append(h.unregisterListeners, listener)
+	return h
+}
+
+// notify invokes listeners, isolating the hub loop from listener panics
+func (h *WebSocketHub) notify(listeners *[]ConnectionListener, conn *WebSocketConnection) {
+	h.listenersMux.RLock()
+	snapshot := make([]ConnectionListener, len(*listeners))
+	copy(snapshot, *listeners)
+	h.listenersMux.RUnlock()
+
+	for _, listener := range snapshot {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					h.log().Error("WebSocket hub listener panicked", "connection_id", conn.ID, "panic", r)
+				}
+			}()
+			listener(conn)
+		}()
 	}
 }
 
-// Run starts the WebSocket hub
+// SetIDGenerator replaces the hub's connection ID generator
+func (h *WebSocketHub) SetIDGenerator(generator ConnectionIDGenerator) *WebSocketHub {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if generator == nil {
+		generator = DefaultConnectionID
+	}
+	h.idGenerator = generator
+	return h
+}
+
+// allocateID generates a connection ID that is unique within the hub and reserves it
+// until the connection is registered
+func (h *WebSocketHub) allocateID(c *gin.Context) string {
+	h.mutex.RLock()
+	generator := h.idGenerator
+	h.mutex.RUnlock()
+
+	for attempt := 0; attempt < maxIDAttempts; attempt++ {
+		id := generator(c)
+		if id != "" && h.reserveID(id) {
+			return id
+		}
+	}
+
+	// The custom generator keeps colliding; fall back to a random suffix
+	for {
+		id := fmt.Sprintf("%s_%s", generator(c), newUUID())
+		if h.reserveID(id) {
+			return id
+		}
+	}
+}
+
+// reserveID marks an ID as taken, reporting false if it is already in use
+func (h *WebSocketHub) reserveID(id string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if _, exists := h.connections[id]; exists || h.reservedIDs[id] {
+		return false
+	}
+	h.reservedIDs[id] = true
+	return true
+}
+
+// Run starts the WebSocket hub, returning once Shutdown has finished
 func (h *WebSocketHub) Run() {
+	h.startAdapter()
 	for {
 		select {
 		case conn := <-h.register:
-			h.mutex.Lock()
-			h.connections[conn.ID] = conn
-			h.mutex.Unlock()
+			h.handleRegister(conn)
+		case conn := <-h.unregister:
+			h.handleUnregister(conn)
+		case message := <-h.broadcast:
+			h.handleBroadcast(message)
+		case <-h.done:
+			return
+		}
+	}
+}
 
-			if h.handler != nil {
-				h.handler.OnConnect(conn)
-			}
+// registerConnection hands a connection to the hub loop, or registers it right away under
+// a test harness
+func (h *WebSocketHub) registerConnection(conn *WebSocketConnection) {
+	if h.harness != nil {
+		h.handleRegister(conn)
+		return
+	}
+	select {
+	case h.register <- conn:
+	case <-h.done:
+		conn.Conn.Close()
+	}
+}
 
-			log.Printf("WebSocket client connected: %s (total: %d)", conn.ID, len(h.connections))
+// unregisterConnection hands a closed connection to the hub loop, or unregisters it right
+// away under a test harness
+func (h *WebSocketHub) unregisterConnection(conn *WebSocketConnection) {
+	if h.harness != nil {
+		h.handleUnregister(conn)
+		return
+	}
+	select {
+	case h.unregister <- conn:
+	case <-h.done:
+	}
+}
 
-		case conn := <-h.unregister:
-			h.mutex.Lock()
-			if _, ok := h.connections[conn.ID]; ok {
-				delete(h.connections, conn.ID)
-				close(conn.Send)
-			}
-			h.mutex.Unlock()
+// broadcastMessage hands a broadcast to the hub loop, or delivers it right away under a
+// test harness
+func (h *WebSocketHub) broadcastMessage(message *outgoingMessage) {
+	if h.harness != nil {
+		h.handleBroadcast(message)
+		return
+	}
+	select {
+	case h.broadcast <- message:
+	case <-h.done:
+	}
+}
 
-			if h.handler != nil {
-				h.handler.OnDisconnect(conn)
-			}
+// handleRegister adds a connection to the hub and announces it
+func (h *WebSocketHub) handleRegister(conn *WebSocketConnection) {
+	h.mutex.Lock()
+	if h.closing {
+		// Upgraded while the hub was shutting down; close it the same way
+		delete(h.reservedIDs, conn.ID)
+		delete(h.admitted, conn.ID)
+		h.mutex.Unlock()
+		code, reason := h.shutdownCode, h.shutdownReason
+		if code == 0 {
+			code, reason = CloseGoingAway, DefaultHubCloseReason
+		}
+		conn.setCloseFrame(code, reason)
+		conn.closeSend()
+		return
+	}
+	h.connections[conn.ID] = conn
+	delete(h.reservedIDs, conn.ID)
+	delete(h.admitted, conn.ID)
+	user, online, _ := h.presenceChangesLocked(conn, nil)
+	h.mutex.Unlock()
 
-			log.Printf("WebSocket client disconnected: %s (total: %d)", conn.ID, len(h.connections))
+	h.attachSession(conn)
 
-		case message := <-h.broadcast:
-			h.mutex.RLock()
-			for _, conn := range h.connections {
-				select {
-				case conn.Send <- message:
-				default:
-					close(conn.Send)
-					delete(h.connections, conn.ID)
-				}
-			}
-			h.mutex.RUnlock()
+	if h.handler != nil {
+		h.handler.OnConnect(conn)
+	}
+	h.notify(&h.registerListeners, conn)
+	h.announcePresence(conn, PresenceJoin, user, online, nil)
+
+	h.log().Info("WebSocket client connected", "connection_id", conn.ID, "total", len(h.connections))
+}
+
+// handleUnregister removes a connection from the hub and its rooms and announces it
+func (h *WebSocketHub) handleUnregister(conn *WebSocketConnection) {
+	h.mutex.Lock()
+	if conn.unregistered {
+		// Already unregistered, e.g. by Shutdown
+		h.mutex.Unlock()
+		return
+	}
+	conn.unregistered = true
+	user, offline, leftRooms := h.presenceChangesLocked(conn, conn.Rooms())
+	// Evicted connections have already left the connections map
+	delete(h.connections, conn.ID)
+	conn.closeSend()
+	h.removeFromRoomsLocked(conn)
+	h.mutex.Unlock()
+
+	h.detachSession(conn)
+
+	if h.handler != nil {
+		h.handler.OnDisconnect(conn)
+	}
+	h.notify(&h.unregisterListeners, conn)
+	h.announcePresence(conn, PresenceLeave, user, offline, leftRooms)
+
+	h.log().Info("WebSocket client disconnected", "connection_id", conn.ID, "total", len(h.connections))
+}
+
+// handleBroadcast queues a message for every connection, dropping slow consumers
+func (h *WebSocketHub) handleBroadcast(message *outgoingMessage) {
+	h.bufferForSessions(message, "")
+
+	var slow []*WebSocketConnection
+	h.mutex.RLock()
+	for _, conn := range h.connections {
+		msgBytes, ok := h.encodeFor(conn, message)
+		if !ok {
+			continue
+		}
+		if err := conn.enqueue(msgBytes); errors.Is(err, errSendBufferFull) {
+			slow = append(slow, conn)
 		}
 	}
+	h.mutex.RUnlock()
+
+	// Drop slow consumers the way closed connections leave, rooms and presence included
+	for _, conn := range slow {
+		conn.setCloseFrame(CloseTryAgainLater, "slow consumer")
+		h.handleUnregister(conn)
+	}
 }
 
-// Broadcast sends a message to all connected clients
+// Broadcast sends a message to all connected clients, on every instance when the hub has
+// an adapter
 func (h *WebSocketHub) Broadcast(messageType string, data interface{}) error {
 	message := WebSocketMessage{
 		Type:      messageType,
 		Data:      data,
-		Timestamp: time.Now(),
+		Timestamp: h.now(),
 	}
 
-	msgBytes, err := json.Marshal(message)
+	outgoing, err := newOutgoingMessage(message)
 	if err != nil {
 		return err
 	}
 
-	h.broadcast <- msgBytes
+	h.broadcastMessage(outgoing)
+	h.relay("", outgoing)
 	return nil
 }
 
@@ -171,20 +493,20 @@ func (conn *WebSocketConnection) Send(messageType string, data interface{}) erro
 	message := WebSocketMessage{
 		Type:      messageType,
 		Data:      data,
-		Timestamp: time.Now(),
+		Timestamp: conn.Hub.now(),
 	}
 
-	msgBytes, err := json.Marshal(message)
+	outgoing, err := newOutgoingMessage(message)
 	if err != nil {
 		return err
 	}
 
-	select {
-	case conn.Send <- msgBytes:
+	msgBytes, ok := conn.Hub.encodeFor(conn, outgoing)
+	if !ok {
 		return nil
-	default:
-		return fmt.Errorf("connection send channel is full")
 	}
+
+	return conn.enqueue(msgBytes)
 }
 
 // SetMetadata sets metadata for this connection
@@ -210,20 +532,33 @@ func (conn *WebSocketConnection) GetMetadata(key string) (interface{}, bool) {
 	return value, exists
 }
 
+// closeSend closes the send queue once, so the write pump flushes it and sends the close
+// frame; later enqueues are refused
+func (conn *WebSocketConnection) closeSend() {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	if !conn.sendClosed {
+		conn.sendClosed = true
+		close(conn.send)
+	}
+}
+
 // Close closes the WebSocket connection
 func (conn *WebSocketConnection) Close() {
 	conn.Conn.Close()
 }
 
 // WebSocket route builder extension
-func (rb *RouteBuilder) WebSocket(path string, handler WebSocketHandler) *RouteBuilder {
-	hub := NewWebSocketHub(handler)
+func (rb *RouteBuilder) WebSocket(path string, handler WebSocketHandler, opts ...HubOption) *RouteBuilder {
+	hub := NewWebSocketHub(handler, opts...)
+	rb.engine.trackHub(hub, rb.name)
 
 	// Start the hub in a goroutine
 	go hub.Run()
 
 	// Store hub in route metadata for access
 	rb.WithMetadata("websocket_hub", hub)
+	rb.WithMiddleware(hub.middleware...)
 
 	rb.GET(path).Handler(func(c *gin.Context) {
 		handleWebSocketUpgrade(c, hub)
@@ -233,8 +568,9 @@ func (rb *RouteBuilder) WebSocket(path string, handler WebSocketHandler) *RouteB
 }
 
 // Engine extension for WebSocket support
-func (e *Engine) WebSocket(name, path string, handler WebSocketHandler) *WebSocketHub {
-	hub := NewWebSocketHub(handler)
+func (e *Engine) WebSocket(name, path string, handler WebSocketHandler, opts ...HubOption) *WebSocketHub {
+	hub := NewWebSocketHub(handler, opts...)
+	e.trackHub(hub, name)
 	go hub.Run()
 
 	e.Named(name).
@@ -242,6 +578,7 @@ func (e *Engine) WebSocket(name, path string, handler WebSocketHandler) *WebSock
 		WithDescription(fmt.Sprintf("WebSocket endpoint: %s", name)).
 		WithTags("websocket").
 		WithMetadata("websocket_hub", hub).
+		WithMiddleware(hub.middleware...).
 		Handler(func(c *gin.Context) {
 			handleWebSocketUpgrade(c, hub)
 		})
@@ -251,25 +588,57 @@ func (e *Engine) WebSocket(name, path string, handler WebSocketHandler) *WebSock
 
 // handleWebSocketUpgrade handles the WebSocket upgrade
 func handleWebSocketUpgrade(c *gin.Context, hub *WebSocketHub) {
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if retryAfter, draining := hub.drainingRetryAfter(); draining {
+		c.Header("Retry-After", strconv.Itoa(retryAfterSeconds(retryAfter)))
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":       "Server is draining",
+			"retry_after": retryAfterSeconds(retryAfter),
+		})
+		return
+	}
+	if hub.isClosing() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Server is shutting down"})
+		return
+	}
+
+	user, ok := hub.authenticateUpgrade(c)
+	if !ok {
+		return
+	}
+
+	conn, err := hub.transport.Upgrade(c.Writer, c.Request)
 	if err != nil {
-		log.Printf("WebSocket upgrade failed: %v", err)
+		LoggerFor(c).Warn("WebSocket upgrade failed", "error", err)
 		return
 	}
 
-	// Generate unique connection ID
-	connID := fmt.Sprintf("ws_%d", time.Now().UnixNano())
+	// Generate connection ID unique within the hub
+	connID := hub.allocateID(c)
 
 	wsConn := &WebSocketConnection{
-		ID:       connID,
-		Conn:     conn,
-		Send:     make(chan []byte, 256),
-		Hub:      hub,
-		Metadata: make(map[string]interface{}),
+		ID:          connID,
+		Conn:        conn,
+		send:        make(chan []byte, 256),
+		Hub:         hub,
+		User:        user,
+		Metadata:    make(map[string]interface{}),
+		ConnectedAt: hub.now(),
+		rooms:       make(map[string]time.Time),
+		resumeToken: resumeTokenFromRequest(c),
+		writeDone:   make(chan struct{}),
+	}
+
+	// Enforce hub and per-user connection limits
+	if !hub.admit(wsConn) {
+		return
 	}
 
 	// Register connection
-	hub.register <- wsConn
+	hub.registerConnection(wsConn)
+	if hub.harness != nil {
+		hub.harness.attach(wsConn)
+		return
+	}
 
 	// Start goroutines for reading and writing
 	go wsConn.writePump()
@@ -278,85 +647,117 @@ func handleWebSocketUpgrade(c *gin.Context, hub *WebSocketHub) {
 
 // readPump pumps messages from the WebSocket connection to the hub
 func (conn *WebSocketConnection) readPump() {
-	defer func() {
-		conn.Hub.unregister <- conn
-		conn.Conn.Close()
-	}()
+	defer conn.stopReading()
 
-	conn.Conn.SetReadLimit(512)
-	conn.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-	conn.Conn.SetPongHandler(func(string) error {
-		conn.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-		return nil
+	conn.startReading()
+	for conn.readNext() {
+	}
+}
+
+// startReading sets the read limit and the read deadline each pong extends
+func (conn *WebSocketConnection) startReading() {
+	conn.Conn.SetReadLimit(conn.Hub.config.MaxMessageSize)
+	conn.Conn.SetReadDeadline(conn.Hub.now().Add(conn.Hub.config.ReadTimeout))
+	conn.Conn.SetPongHandler(func() {
+		conn.Conn.SetReadDeadline(conn.Hub.now().Add(conn.Hub.config.ReadTimeout))
 	})
+}
 
-	for {
-		_, messageBytes, err := conn.Conn.ReadMessage()
-		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				log.Printf("WebSocket error: %v", err)
-				if conn.Hub.handler != nil {
-					conn.Hub.handler.OnError(conn, err)
-				}
+// readNext reads a message and passes it to the handler, reporting false once the
+// connection can no longer be read
+func (conn *WebSocketConnection) readNext() bool {
+	messageBytes, err := conn.Conn.ReadMessage()
+	if err != nil {
+		var closeErr *WebSocketCloseError
+		if errors.As(err, &closeErr) && closeErr.Code != CloseGoingAway && closeErr.Code != CloseAbnormalClosure {
+			conn.Hub.log().Warn("WebSocket read failed", "connection_id", conn.ID, "error", err)
+			if conn.Hub.handler != nil {
+				conn.Hub.handler.OnError(conn, err)
 			}
-			break
 		}
+		return false
+	}
 
-		// Parse message
-		var msg WebSocketMessage
-		if err := json.Unmarshal(messageBytes, &msg); err != nil {
-			log.Printf("Failed to parse WebSocket message: %v", err)
-			continue
-		}
+	// Parse message
+	var msg WebSocketMessage
+	if err := json.Unmarshal(messageBytes, &msg); err != nil {
+		conn.Hub.log().Warn("failed to parse WebSocket message", "connection_id", conn.ID, "error", err)
+		return true
+	}
 
-		// Handle message
-		if conn.Hub.handler != nil {
-			conn.Hub.handler.OnMessage(conn, msg.Type, msg.Data)
-		}
+	// Handle message
+	if conn.Hub.handler != nil {
+		conn.Hub.handler.OnMessage(conn, msg.Type, msg.Data)
 	}
+	return true
+}
+
+// stopReading unregisters the connection and closes its socket
+func (conn *WebSocketConnection) stopReading() {
+	conn.Hub.unregisterConnection(conn)
+	conn.Conn.Close()
 }
 
 // writePump pumps messages from the hub to the WebSocket connection
 func (conn *WebSocketConnection) writePump() {
-	ticker := time.NewTicker(54 * time.Second)
-	defer func() {
-		ticker.Stop()
-		conn.Conn.Close()
-	}()
+	ticker := conn.Hub.clock.NewTicker(conn.Hub.config.PingInterval)
+	defer conn.stopWriting(ticker)
 
 	for {
 		select {
-		case message, ok := <-conn.Send:
-			conn.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if !ok {
-				conn.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+		case message, ok := <-conn.send:
+			if !conn.writeQueued(message, ok) {
 				return
 			}
-
-			w, err := conn.Conn.NextWriter(websocket.TextMessage)
-			if err != nil {
+		case <-ticker.C():
+			if !conn.writePing() {
 				return
 			}
-			w.Write(message)
-
-			// Add queued messages to the current WebSocket message
-			n := len(conn.Send)
-			for i := 0; i < n; i++ {
-				w.Write([]byte{'\n'})
-				w.Write(<-conn.Send)
-			}
+		}
+	}
+}
 
-			if err := w.Close(); err != nil {
-				return
-			}
+// writeQueued writes a message taken from the send queue together with those queued
+// behind it, or a close frame once the queue is closed, reporting false when writing stops
+func (conn *WebSocketConnection) writeQueued(message []byte, open bool) bool {
+	conn.Conn.SetWriteDeadline(conn.Hub.now().Add(conn.Hub.config.WriteTimeout))
+	if !open {
+		conn.Conn.WriteClose(conn.closeFrame())
+		return false
+	}
 
-		case <-ticker.C:
-			conn.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := conn.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return
-			}
+	// Add queued messages to the current WebSocket message
+	queued := len(message)
+	n := len(conn.send)
+	if n > 0 {
+		var buf bytes.Buffer
+		buf.Write(message)
+		for i := 0; i < n; i++ {
+			next := <-conn.send
+			queued += len(next)
+			buf.WriteByte('\n')
+			buf.Write(next)
 		}
+		message = buf.Bytes()
 	}
+
+	err := conn.Conn.WriteMessage(message)
+	conn.Hub.releaseMemory(conn, queued)
+	return err == nil
+}
+
+// writePing pings the peer, reporting false when writing stops
+func (conn *WebSocketConnection) writePing() bool {
+	conn.Conn.SetWriteDeadline(conn.Hub.now().Add(conn.Hub.config.WriteTimeout))
+	return conn.Conn.WritePing() == nil
+}
+
+// stopWriting stops pinging, closes the socket and releases the connection's buffers
+func (conn *WebSocketConnection) stopWriting(ticker ClockTicker) {
+	ticker.Stop()
+	conn.Conn.Close()
+	conn.Hub.releaseMemory(conn, -1)
+	close(conn.writeDone)
 }
 
 // Default WebSocket handler implementation
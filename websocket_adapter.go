@@ -0,0 +1,144 @@
+package supergin
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// HubAdapter relays hub broadcasts between instances behind a load balancer, e.g. over
+// Redis Pub/Sub (RedisAdapter) or NATS (NATSAdapter)
+type HubAdapter interface {
+	// Publish sends payload to every instance subscribed to channel
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe delivers payloads published to channel, blocking until ctx is cancelled
+	// or the subscription fails; the hub resubscribes after failures
+	Subscribe(ctx context.Context, channel string, deliver func(payload []byte)) error
+	// Close releases the adapter's connections
+	Close() error
+}
+
+const (
+	// adapterPublishTimeout bounds how long a broadcast waits on the adapter
+	adapterPublishTimeout = 5 * time.Second
+	// adapterMaxBackoff caps the delay between resubscription attempts
+	adapterMaxBackoff = 5 * time.Second
+)
+
+// hubAdapter is a hub's adapter with its channel and subscription
+type hubAdapter struct {
+	adapter  HubAdapter
+	channel  string
+	instance string
+	cancel   context.CancelFunc
+}
+
+// adapterEnvelope is a broadcast as relayed between instances
+type adapterEnvelope struct {
+	Origin  string          `json:"origin"`
+	Room    string          `json:"room,omitempty"`
+	Message json.RawMessage `json:"message"`
+}
+
+// WithAdapter makes Broadcast and BroadcastToRoom reach connections on every instance
+// sharing the adapter's channel. The channel defaults to "supergin.hub.<hub name>".
+func WithAdapter(adapter HubAdapter, channel ...string) HubOption {
+	return func(h *WebSocketHub) {
+		if adapter == nil {
+			return
+		}
+		h.adapter = &hubAdapter{adapter: adapter, instance: newUUID()}
+		if len(channel) > 0 {
+			h.adapter.channel = channel[0]
+		}
+	}
+}
+
+// startAdapter subscribes the hub to its adapter's channel; it runs when the hub starts
+func (h *WebSocketHub) startAdapter() {
+	a := h.adapter
+	if a == nil {
+		return
+	}
+	if a.channel == "" {
+		a.channel = "supergin.hub"
+		if h.name != "" {
+			a.channel += "." + h.name
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	go func() {
+		backoff := 100 * time.Millisecond
+		for {
+			err := a.adapter.Subscribe(ctx, a.channel, h.receiveRelayed)
+			if ctx.Err() != nil {
+				return
+			}
+			h.log().Warn("WebSocket hub adapter subscription failed", "channel", a.channel, "error", err, "retry_in", backoff)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, adapterMaxBackoff)
+		}
+	}()
+}
+
+// stopAdapter ends the hub's subscription and closes its adapter
+func (h *WebSocketHub) stopAdapter() error {
+	a := h.adapter
+	if a == nil {
+		return nil
+	}
+	if a.cancel != nil {
+		a.cancel()
+	}
+	return a.adapter.Close()
+}
+
+// relay publishes a broadcast to the other instances. Local delivery does not depend on
+// it, so failures are logged rather than returned.
+func (h *WebSocketHub) relay(room string, outgoing *outgoingMessage) {
+	a := h.adapter
+	if a == nil {
+		return
+	}
+	payload, err := json.Marshal(adapterEnvelope{Origin: a.instance, Room: room, Message: outgoing.encoded})
+	if err != nil {
+		h.log().Warn("failed to encode relayed WebSocket message", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), adapterPublishTimeout)
+	defer cancel()
+	if err := a.adapter.Publish(ctx, a.channel, payload); err != nil {
+		h.log().Warn("failed to relay WebSocket broadcast", "channel", a.channel, "room", room, "error", err)
+	}
+}
+
+// receiveRelayed delivers a broadcast published by another instance to local connections
+func (h *WebSocketHub) receiveRelayed(payload []byte) {
+	var envelope adapterEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		h.log().Warn("failed to decode relayed WebSocket message", "error", err)
+		return
+	}
+	if envelope.Origin == h.adapter.instance {
+		return
+	}
+
+	var message WebSocketMessage
+	if err := json.Unmarshal(envelope.Message, &message); err != nil {
+		h.log().Warn("failed to decode relayed WebSocket message", "error", err)
+		return
+	}
+	outgoing := &outgoingMessage{message: message, encoded: envelope.Message}
+	if envelope.Room != "" {
+		h.deliverToRoom(envelope.Room, outgoing)
+		return
+	}
+	h.broadcastMessage(outgoing)
+}
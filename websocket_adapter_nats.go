@@ -0,0 +1,260 @@
+package supergin
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NATSOptions configures NATSAdapter
+type NATSOptions struct {
+	// Token, or Username and Password, authenticate the connection; credentials in the
+	// URL are used when these are empty
+	Token    string
+	Username string
+	Password string
+	// TLS, when set, upgrades connections to TLS; tls:// URLs use a default config
+	TLS *tls.Config
+	// Name identifies the connection in NATS monitoring
+	Name string
+	// DialTimeout defaults to DefaultAdapterDialTimeout
+	DialTimeout time.Duration
+}
+
+// natsAdapter relays broadcasts over NATS core subjects, speaking the client protocol
+// directly
+type natsAdapter struct {
+	addr    string
+	options NATSOptions
+
+	conn  net.Conn
+	mutex sync.Mutex
+}
+
+// NATSAdapter relays hub broadcasts over NATS at url, e.g. "nats://localhost:4222".
+// Publishing shares one connection; each subscription uses its own.
+func NATSAdapter(rawURL string, opts ...NATSOptions) HubAdapter {
+	options := NATSOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.DialTimeout <= 0 {
+		options.DialTimeout = DefaultAdapterDialTimeout
+	}
+
+	addr := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		addr = u.Host
+		if u.Port() == "" {
+			addr = net.JoinHostPort(u.Hostname(), "4222")
+		}
+		if u.Scheme == "tls" && options.TLS == nil {
+			options.TLS = &tls.Config{ServerName: u.Hostname()}
+		}
+		if u.User != nil && options.Token == "" && options.Username == "" {
+			if password, ok := u.User.Password(); ok {
+				options.Username, options.Password = u.User.Username(), password
+			} else {
+				options.Token = u.User.Username()
+			}
+		}
+	}
+	return &natsAdapter{addr: addr, options: options}
+}
+
+// Publish implements HubAdapter, redialing once if the shared connection has gone stale
+func (a *natsAdapter) Publish(ctx context.Context, channel string, payload []byte) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	frame := make([]byte, 0, len(channel)+len(payload)+32)
+	frame = append(frame, "PUB "+channel+" "+strconv.Itoa(len(payload))+"\r\n"...)
+	frame = append(frame, payload...)
+	frame = append(frame, '\r', '\n')
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if a.conn == nil {
+			conn, reader, dialErr := a.dial(ctx)
+			if dialErr != nil {
+				return dialErr
+			}
+			a.conn = conn
+			go a.serve(conn, reader)
+		}
+		deadline, _ := ctx.Deadline()
+		a.conn.SetWriteDeadline(deadline)
+		if _, err = a.conn.Write(frame); err == nil {
+			return nil
+		}
+		a.conn.Close()
+		a.conn = nil
+	}
+	return fmt.Errorf("nats publish failed: %w", err)
+}
+
+// serve answers server pings on the publishing connection until it fails
+func (a *natsAdapter) serve(conn net.Conn, reader *bufio.Reader) {
+	defer func() {
+		a.mutex.Lock()
+		if a.conn == conn {
+			a.conn = nil
+		}
+		a.mutex.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		line, err := readNATSLine(reader)
+		if err != nil {
+			return
+		}
+		switch {
+		case line == "PING":
+			a.mutex.Lock()
+			_, err = io.WriteString(conn, "PONG\r\n")
+			a.mutex.Unlock()
+			if err != nil {
+				return
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return
+		}
+	}
+}
+
+// Subscribe implements HubAdapter
+func (a *natsAdapter) Subscribe(ctx context.Context, channel string, deliver func(payload []byte)) error {
+	conn, reader, err := a.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if _, err := io.WriteString(conn, "SUB "+channel+" 1\r\n"); err != nil {
+		return err
+	}
+	for {
+		line, err := readNATSLine(reader)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("nats subscription failed: %w", err)
+		}
+		switch {
+		case line == "PING":
+			if _, err := io.WriteString(conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("nats: " + strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(line)
+			n, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil || n < 0 {
+				return fmt.Errorf("nats: malformed message header %q", line)
+			}
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return err
+			}
+			deliver(payload[:n])
+		}
+	}
+}
+
+// Close implements HubAdapter
+func (a *natsAdapter) Close() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.conn == nil {
+		return nil
+	}
+	err := a.conn.Close()
+	a.conn = nil
+	return err
+}
+
+// dial connects, upgrades to TLS when configured and completes the CONNECT handshake
+func (a *natsAdapter) dial(ctx context.Context) (net.Conn, *bufio.Reader, error) {
+	dialer := &net.Dialer{Timeout: a.options.DialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", a.addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to nats at %s: %w", a.addr, err)
+	}
+	conn.SetDeadline(time.Now().Add(a.options.DialTimeout))
+
+	fail := func(err error) (net.Conn, *bufio.Reader, error) {
+		conn.Close()
+		return nil, nil, fmt.Errorf("nats handshake failed: %w", err)
+	}
+
+	// The server greets with INFO before anything else, TLS included
+	reader := bufio.NewReader(conn)
+	line, err := readNATSLine(reader)
+	if err != nil {
+		return fail(err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fail(fmt.Errorf("unexpected greeting %q", line))
+	}
+	if a.options.TLS != nil {
+		tlsConn := tls.Client(conn, a.options.TLS)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return fail(err)
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	connect, _ := json.Marshal(map[string]interface{}{
+		"verbose":    false,
+		"pedantic":   false,
+		"lang":       "go",
+		"name":       a.options.Name,
+		"auth_token": a.options.Token,
+		"user":       a.options.Username,
+		"pass":       a.options.Password,
+	})
+	if _, err := io.WriteString(conn, "CONNECT "+string(connect)+"\r\nPING\r\n"); err != nil {
+		return fail(err)
+	}
+	for {
+		line, err := readNATSLine(reader)
+		if err != nil {
+			return fail(err)
+		}
+		if line == "PONG" {
+			break
+		}
+		if strings.HasPrefix(line, "-ERR") {
+			return fail(errors.New(strings.TrimSpace(strings.TrimPrefix(line, "-ERR"))))
+		}
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, reader, nil
+}
+
+// readNATSLine reads one protocol line without its CRLF
+func readNATSLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,229 @@
+package supergin
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultAdapterDialTimeout bounds connecting to a hub adapter's broker
+const DefaultAdapterDialTimeout = 5 * time.Second
+
+// RedisOptions configures RedisAdapter
+type RedisOptions struct {
+	// Username and Password authenticate with AUTH; Username needs Redis 6 ACLs
+	Username string
+	Password string
+	// TLS, when set, connects over TLS
+	TLS *tls.Config
+	// DialTimeout defaults to DefaultAdapterDialTimeout
+	DialTimeout time.Duration
+}
+
+// redisAdapter relays broadcasts over Redis Pub/Sub, speaking RESP directly
+type redisAdapter struct {
+	addr    string
+	options RedisOptions
+
+	conn   net.Conn
+	reader *bufio.Reader
+	mutex  sync.Mutex
+}
+
+// RedisAdapter relays hub broadcasts over Redis Pub/Sub at addr, e.g. "localhost:6379".
+// Publishing shares one connection; each subscription uses its own.
+func RedisAdapter(addr string, opts ...RedisOptions) HubAdapter {
+	options := RedisOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.DialTimeout <= 0 {
+		options.DialTimeout = DefaultAdapterDialTimeout
+	}
+	return &redisAdapter{addr: addr, options: options}
+}
+
+// Publish implements HubAdapter, redialing once if the shared connection has gone stale
+func (a *redisAdapter) Publish(ctx context.Context, channel string, payload []byte) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if a.conn == nil {
+			if a.conn, a.reader, err = a.dial(ctx); err != nil {
+				return err
+			}
+		}
+		deadline, _ := ctx.Deadline()
+		a.conn.SetDeadline(deadline)
+		if err = writeRESP(a.conn, "PUBLISH", channel, string(payload)); err == nil {
+			_, err = readRESP(a.reader)
+		}
+		if err == nil {
+			return nil
+		}
+		a.conn.Close()
+		a.conn, a.reader = nil, nil
+	}
+	return fmt.Errorf("redis publish failed: %w", err)
+}
+
+// Subscribe implements HubAdapter
+func (a *redisAdapter) Subscribe(ctx context.Context, channel string, deliver func(payload []byte)) error {
+	conn, reader, err := a.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if err := writeRESP(conn, "SUBSCRIBE", channel); err != nil {
+		return err
+	}
+	for {
+		reply, err := readRESP(reader)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("redis subscription failed: %w", err)
+		}
+		// Pushed messages are ["message", channel, payload]
+		message, ok := reply.([]interface{})
+		if !ok || len(message) != 3 {
+			continue
+		}
+		if kind, _ := message[0].([]byte); string(kind) == "message" {
+			if payload, ok := message[2].([]byte); ok {
+				deliver(payload)
+			}
+		}
+	}
+}
+
+// Close implements HubAdapter
+func (a *redisAdapter) Close() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.conn == nil {
+		return nil
+	}
+	err := a.conn.Close()
+	a.conn, a.reader = nil, nil
+	return err
+}
+
+// dial connects and authenticates
+func (a *redisAdapter) dial(ctx context.Context) (net.Conn, *bufio.Reader, error) {
+	return dialRedis(ctx, a.addr, a.options)
+}
+
+// dialRedis connects to a Redis server and authenticates
+func dialRedis(ctx context.Context, addr string, options RedisOptions) (net.Conn, *bufio.Reader, error) {
+	dialer := &net.Dialer{Timeout: options.DialTimeout}
+	var conn net.Conn
+	var err error
+	if options.TLS != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: options.TLS}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to redis at %s: %w", addr, err)
+	}
+	reader := bufio.NewReader(conn)
+
+	if options.Password != "" {
+		args := []string{"AUTH", options.Password}
+		if options.Username != "" {
+			args = []string{"AUTH", options.Username, options.Password}
+		}
+		conn.SetDeadline(time.Now().Add(options.DialTimeout))
+		if err := writeRESP(conn, args...); err == nil {
+			_, err = readRESP(reader)
+		}
+		if err != nil {
+			conn.Close()
+			return nil, nil, fmt.Errorf("redis authentication failed: %w", err)
+		}
+		conn.SetDeadline(time.Time{})
+	}
+	return conn, reader, nil
+}
+
+// redisError is an error reply; unlike I/O errors it leaves the connection usable
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+// writeRESP writes a command as a RESP array of bulk strings
+func writeRESP(w io.Writer, args ...string) error {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// readRESP reads one RESP reply: simple strings as string, bulk strings as []byte,
+// integers as int64, arrays as []interface{} and error replies as errors
+func readRESP(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errors.New("redis: malformed reply")
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, n)
+		for i := range values {
+			if values[i], err = readRESP(r); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply type %q", kind)
+}
@@ -0,0 +1,50 @@
+package supergin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebSocketAuthenticator resolves the user behind a WebSocket upgrade request. Returning
+// an error rejects the request before it is upgraded: with the error's status when it
+// implements StatusCoder, otherwise 401.
+type WebSocketAuthenticator func(c *gin.Context) (user interface{}, err error)
+
+// WithAuthenticate sets the hook run before each upgrade; the user it returns is stored on
+// WebSocketConnection.User, where per-user limits and resumed sessions pick it up
+func WithAuthenticate(authenticate WebSocketAuthenticator) HubOption {
+	return func(h *WebSocketHub) {
+		h.authenticate = authenticate
+	}
+}
+
+// WithUpgradeMiddleware adds middleware to the hub's WebSocket route, run before the
+// authenticate hook and the upgrade. Aborting rejects the connection.
+func WithUpgradeMiddleware(middleware ...gin.HandlerFunc) HubOption {
+	return func(h *WebSocketHub) {
+		h.middleware = append(h.middleware, middleware...)
+	}
+}
+
+// authenticateUpgrade runs the hub's authenticate hook, responding with the rejection
+// when it fails
+func (h *WebSocketHub) authenticateUpgrade(c *gin.Context) (interface{}, bool) {
+	if h.authenticate == nil {
+		return nil, true
+	}
+
+	user, err := h.authenticate(c)
+	if err != nil {
+		status := http.StatusUnauthorized
+		var coded StatusCoder
+		if errors.As(err, &coded) {
+			status = coded.StatusCode()
+		}
+		LoggerFor(c).Info("WebSocket connection rejected", "status", status, "error", err)
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return user, true
+}
@@ -0,0 +1,142 @@
+package supergin
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a hub's time source: message timestamps, read and write deadlines, ping tickers
+// and resume token expiry all read it. Hubs use the system clock unless configured
+// WithClock.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) ClockTicker
+}
+
+// ClockTicker delivers ticks like time.Ticker, dropping ticks for slow receivers
+type ClockTicker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// WithClock sets the hub's time source, e.g. a FakeClock in tests
+func WithClock(clock Clock) HubOption {
+	return func(h *WebSocketHub) {
+		if clock != nil {
+			h.clock = clock
+		}
+	}
+}
+
+// now returns the current time on the hub's clock
+func (h *WebSocketHub) now() time.Time {
+	return h.clock.Now()
+}
+
+// systemClock is the Clock backed by package time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTicker(d time.Duration) ClockTicker {
+	return systemTicker{ticker: time.NewTicker(d)}
+}
+
+// systemTicker adapts time.Ticker to ClockTicker
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time { return t.ticker.C }
+func (t systemTicker) Stop()               { t.ticker.Stop() }
+
+// FakeClock is a Clock that only moves when advanced, firing its tickers on the way
+type FakeClock struct {
+	now     time.Time
+	tickers []*fakeTicker
+	mutex   sync.Mutex
+}
+
+// NewFakeClock creates a FakeClock reading start
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now implements Clock
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// NewTicker implements Clock; the ticker first fires once the clock is advanced by d
+func (c *FakeClock) NewTicker(d time.Duration) ClockTicker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTicker")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ticker := &fakeTicker{clock: c, period: d, next: c.now.Add(d), ch: make(chan time.Time, 1)}
+	c.tickers = append(c.tickers, ticker)
+	return ticker
+}
+
+// Advance moves the clock forward by d. Tickers due in the meantime fire once with the
+// latest tick time, as a time.Ticker would for a receiver that fell behind.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, ticker := range c.tickers {
+		if ticker.next.After(c.now) {
+			continue
+		}
+		tick := ticker.next
+		for !ticker.next.After(c.now) {
+			tick = ticker.next
+			ticker.next = ticker.next.Add(ticker.period)
+		}
+		select {
+		case ticker.ch <- tick:
+		default:
+		}
+	}
+}
+
+// nextTick returns when the earliest ticker fires next
+func (c *FakeClock) nextTick() (time.Time, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var next time.Time
+	for _, ticker := range c.tickers {
+		if next.IsZero() || ticker.next.Before(next) {
+			next = ticker.next
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// fakeTicker is a FakeClock ticker
+type fakeTicker struct {
+	clock  *FakeClock
+	period time.Duration
+	next   time.Time
+	ch     chan time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTicker) Stop() {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	for i, ticker := range t.clock.tickers {
+		if ticker == t {
+			t.clock.tickers = append(t.clock.tickers[:i], t.clock.tickers[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package supergin
+
+import (
+	"encoding/json"
+)
+
+// SendFilter transforms an outgoing message for a specific connection, e.g. locale
+// formatting, permission-based field stripping or protocol version downgrades.
+// Returning false drops the message for that connection. Filters receive a copy of
+// the message envelope but share Data with other connections, so they must replace
+// Data rather than mutate it in place.
+type SendFilter func(conn *WebSocketConnection, message *WebSocketMessage) (*WebSocketMessage, bool)
+
+// outgoingMessage is a message with its unfiltered encoding cached for fan-out
+type outgoingMessage struct {
+	message WebSocketMessage
+	encoded []byte
+}
+
+func newOutgoingMessage(message WebSocketMessage) (*outgoingMessage, error) {
+	encoded, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	return &outgoingMessage{message: message, encoded: encoded}, nil
+}
+
+// AddSendFilter appends per-connection filters applied to every outgoing message
+func (h *WebSocketHub) AddSendFilter(filters ...SendFilter) *WebSocketHub {
+	h.filtersMux.Lock()
+	defer h.filtersMux.Unlock()
+
+	h.sendFilters = append(h.sendFilters, filters...)
+	return h
+}
+
+// encodeFor runs the hub's send filters for a connection and returns the bytes to write.
+// Without filters the shared encoding is reused.
+func (h *WebSocketHub) encodeFor(conn *WebSocketConnection, outgoing *outgoingMessage) ([]byte, bool) {
+	if h == nil {
+		return outgoing.encoded, true
+	}
+
+	h.filtersMux.RLock()
+	filters := h.sendFilters
+	h.filtersMux.RUnlock()
+	if len(filters) == 0 {
+		return outgoing.encoded, true
+	}
+
+	message := outgoing.message
+	current := &message
+	for _, filter := range filters {
+		next, ok := filter(conn, current)
+		if !ok || next == nil {
+			return nil, false
+		}
+		current = next
+	}
+
+	encoded, err := json.Marshal(current)
+	if err != nil {
+		h.log().Error("WebSocket send filter produced unencodable message", "connection_id", conn.ID, "error", err)
+		return nil, false
+	}
+	return encoded, true
+}
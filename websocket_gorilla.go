@@ -0,0 +1,98 @@
+//go:build !nogorilla
+
+package supergin
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// GorillaTransport upgrades connections with gorilla/websocket, the default transport
+func GorillaTransport(config ...WebSocketConfig) WebSocketTransport {
+	cfg := webSocketConfig(config...)
+	return &gorillaTransport{checksOrigins: checksOrigins(config...), upgrader: websocket.Upgrader{
+		ReadBufferSize:    cfg.ReadBufferSize,
+		WriteBufferSize:   cfg.WriteBufferSize,
+		CheckOrigin:       cfg.CheckOrigin,
+		EnableCompression: cfg.EnableCompression,
+		HandshakeTimeout:  cfg.HandshakeTimeout,
+	}}
+}
+
+func defaultWebSocketTransport(config WebSocketConfig) WebSocketTransport {
+	return GorillaTransport(config)
+}
+
+type gorillaTransport struct {
+	upgrader      websocket.Upgrader
+	checksOrigins bool
+}
+
+func (t *gorillaTransport) checksOrigin() bool { return t.checksOrigins }
+
+func (t *gorillaTransport) Upgrade(w http.ResponseWriter, r *http.Request) (WebSocketConn, error) {
+	conn, err := t.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GorillaConn{conn: conn}, nil
+}
+
+// GorillaConn is a gorilla/websocket connection; Unwrap exposes it for gorilla-specific APIs
+type GorillaConn struct {
+	conn *websocket.Conn
+}
+
+// Unwrap returns the underlying gorilla connection
+func (c *GorillaConn) Unwrap() *websocket.Conn {
+	return c.conn
+}
+
+func (c *GorillaConn) ReadMessage() ([]byte, error) {
+	_, data, err := c.conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		return nil, &WebSocketCloseError{Code: closeErr.Code, Text: closeErr.Text}
+	}
+	return data, err
+}
+
+func (c *GorillaConn) WriteMessage(data []byte) error {
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (c *GorillaConn) WritePing() error {
+	return c.conn.WriteMessage(websocket.PingMessage, nil)
+}
+
+// WriteClose uses a control frame, which gorilla allows concurrently with other writes
+func (c *GorillaConn) WriteClose(code int, reason string) error {
+	return c.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+}
+
+func (c *GorillaConn) SetReadLimit(limit int64) {
+	c.conn.SetReadLimit(limit)
+}
+
+func (c *GorillaConn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+func (c *GorillaConn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+func (c *GorillaConn) SetPongHandler(handler func()) {
+	c.conn.SetPongHandler(func(string) error {
+		handler()
+		return nil
+	})
+}
+
+func (c *GorillaConn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,172 @@
+package supergin
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// LimitScope identifies which connection cap was exceeded
+type LimitScope string
+
+const (
+	LimitScopeHub  LimitScope = "hub"
+	LimitScopeRoom LimitScope = "room"
+	LimitScopeUser LimitScope = "user"
+)
+
+// OverflowAction decides what happens when a connection cap is reached
+type OverflowAction int
+
+const (
+	// OverflowReject refuses the new connection (or room join) with a reason frame
+	OverflowReject OverflowAction = iota
+	// OverflowEvictOldest makes room by evicting the oldest connection in the scope
+	OverflowEvictOldest
+)
+
+// OverflowPolicy chooses an action when a new connection would exceed a cap
+type OverflowPolicy func(scope LimitScope, conn *WebSocketConnection) OverflowAction
+
+// WebSocketLimits caps connections per hub, per room and per user. Zero disables a cap.
+type WebSocketLimits struct {
+	MaxConnections int
+	MaxPerRoom     int
+	MaxPerUser     int
+	// UserKey identifies the user owning a connection; defaults to formatting conn.User
+	UserKey func(conn *WebSocketConnection) string
+	// OnOverflow picks the overflow action; defaults to rejecting
+	OnOverflow OverflowPolicy
+}
+
+// SetLimits configures connection caps for the hub
+func (h *WebSocketHub) SetLimits(limits WebSocketLimits) *WebSocketHub {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.limits = limits
+	return h
+}
+
+func (l WebSocketLimits) overflowAction(scope LimitScope, conn *WebSocketConnection) OverflowAction {
+	if l.OnOverflow == nil {
+		return OverflowReject
+	}
+	return l.OnOverflow(scope, conn)
+}
+
+func (l WebSocketLimits) userKey(conn *WebSocketConnection) string {
+	if l.UserKey != nil {
+		return l.UserKey(conn)
+	}
+	if conn.User == nil {
+		return ""
+	}
+	return fmt.Sprint(conn.User)
+}
+
+// admit enforces hub and per-user caps for a freshly upgraded connection, counting
+// connections admitted but not registered yet. Evicted connections leave the hub right
+// away, so concurrent admits never pick the same one; only registered connections are
+// evicted. Rejected connections receive a reason frame and are closed.
+func (h *WebSocketHub) admit(conn *WebSocketConnection) bool {
+	h.mutex.Lock()
+	limits := h.limits
+	var evict []*WebSocketConnection
+	var rejected LimitScope
+
+	if limits.MaxConnections > 0 && len(h.connections)+len(h.admitted) >= limits.MaxConnections {
+		if old := h.evictableLocked(limits, LimitScopeHub, conn, h.connections); old != nil {
+			evict = append(evict, old)
+		} else {
+			rejected = LimitScopeHub
+		}
+	}
+
+	if rejected == "" && limits.MaxPerUser > 0 {
+		if key := limits.userKey(conn); key != "" {
+			owned := make(map[string]*WebSocketConnection)
+			for id, other := range h.connections {
+				if limits.userKey(other) == key {
+					owned[id] = other
+				}
+			}
+			pending := 0
+			for _, other := range h.admitted {
+				if limits.userKey(other) == key {
+					pending++
+				}
+			}
+			if len(owned)+pending >= limits.MaxPerUser {
+				if old := h.evictableLocked(limits, LimitScopeUser, conn, owned); old != nil {
+					evict = append(evict, old)
+				} else {
+					rejected = LimitScopeUser
+				}
+			}
+		}
+	}
+
+	if rejected != "" {
+		delete(h.reservedIDs, conn.ID)
+	} else {
+		h.admitted[conn.ID] = conn
+	}
+	h.mutex.Unlock()
+
+	if rejected != "" {
+		h.log().Warn("WebSocket connection rejected: connection limit reached", "connection_id", conn.ID, "limit", rejected)
+		closeWithReason(conn, "connection_rejected", fmt.Sprintf("%s connection limit reached", rejected))
+		return false
+	}
+
+	for _, old := range evict {
+		h.log().Info("WebSocket connection evicted", "connection_id", old.ID, "admitted", conn.ID)
+		closeConnection(old, CloseTryAgainLater, "connection limit reached")
+	}
+	return true
+}
+
+// evictableLocked removes and returns the oldest of candidates when the overflow policy
+// evicts for scope, or returns nil to reject. The evicted connection's readPump still
+// unregisters it from its rooms.
+func (h *WebSocketHub) evictableLocked(limits WebSocketLimits, scope LimitScope, conn *WebSocketConnection, candidates map[string]*WebSocketConnection) *WebSocketConnection {
+	if limits.overflowAction(scope, conn) != OverflowEvictOldest {
+		return nil
+	}
+	oldest := oldestConnection(candidates)
+	if oldest != nil {
+		delete(h.connections, oldest.ID)
+	}
+	return oldest
+}
+
+// closeWithReason writes a reason frame and closes a connection whose pumps are not running yet
+func closeWithReason(conn *WebSocketConnection, messageType, reason string) {
+	message, _ := json.Marshal(WebSocketMessage{
+		Type:      messageType,
+		Data:      map[string]interface{}{"reason": reason},
+		Timestamp: conn.Hub.now(),
+	})
+	conn.Conn.SetWriteDeadline(conn.Hub.now().Add(time.Second))
+	conn.Conn.WriteMessage(message)
+	closeConnection(conn, CloseTryAgainLater, reason)
+}
+
+// closeConnection sends a close frame and closes the socket. WriteClose is safe to call
+// concurrently with the connection's writePump.
+func closeConnection(conn *WebSocketConnection, code int, reason string) {
+	conn.Conn.WriteClose(code, reason)
+	conn.Conn.Close()
+}
+
+// oldestConnection returns the earliest connected connection of a set
+func oldestConnection(connections map[string]*WebSocketConnection) *WebSocketConnection {
+	var oldest *WebSocketConnection
+	for _, conn := range connections {
+		if oldest == nil || conn.ConnectedAt.Before(oldest.ConnectedAt) {
+			oldest = conn
+		}
+	}
+	return oldest
+}
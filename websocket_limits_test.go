@@ -0,0 +1,112 @@
+package supergin
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// admitConcurrently admits n new connections for user at once, as simultaneous upgrades
+// do, and returns those admitted. The hub loop is not running, so none get registered.
+func admitConcurrently(hub *WebSocketHub, n int, user interface{}) []*WebSocketConnection {
+	var mutex sync.Mutex
+	var admitted []*WebSocketConnection
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conn := newLimitTestConnection(hub, fmt.Sprintf("new_%d", i), user, hub.now())
+			if hub.admit(conn) {
+				mutex.Lock()
+				admitted = append(admitted, conn)
+				mutex.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	return admitted
+}
+
+func newLimitTestConnection(hub *WebSocketHub, id string, user interface{}, connectedAt time.Time) *WebSocketConnection {
+	return &WebSocketConnection{
+		ID:          id,
+		Conn:        &webSocketPipe{clock: hub.clock.(*FakeClock)},
+		send:        make(chan []byte, 256),
+		Hub:         hub,
+		User:        user,
+		Metadata:    make(map[string]interface{}),
+		ConnectedAt: connectedAt,
+		rooms:       make(map[string]time.Time),
+		writeDone:   make(chan struct{}),
+	}
+}
+
+func TestConcurrentAdmitsRespectLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits WebSocketLimits
+		user   interface{}
+	}{
+		{"hub", WebSocketLimits{MaxConnections: 3}, nil},
+		{"user", WebSocketLimits{MaxPerUser: 2}, "alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewWebSocketHub(&DefaultWebSocketHandler{}, WithLogger(quietLogger()),
+				WithClock(NewFakeClock(webSocketTestEpoch)), WithLimits(tt.limits))
+
+			admitted := admitConcurrently(hub, 20, tt.user)
+			want := tt.limits.MaxConnections + tt.limits.MaxPerUser
+			if len(admitted) != want {
+				t.Fatalf("%d of 20 concurrent connections admitted, want %d", len(admitted), want)
+			}
+
+			// Registering them makes room for no one else
+			for _, conn := range admitted {
+				hub.handleRegister(conn)
+			}
+			if late := admitConcurrently(hub, 5, tt.user); len(late) != 0 {
+				t.Errorf("%d connections admitted over the limit", len(late))
+			}
+		})
+	}
+}
+
+func TestConcurrentAdmitsEvictEachConnectionOnce(t *testing.T) {
+	const limit = 3
+	hub := NewWebSocketHub(&DefaultWebSocketHandler{}, WithLogger(quietLogger()),
+		WithClock(NewFakeClock(webSocketTestEpoch)),
+		WithLimits(WebSocketLimits{
+			MaxConnections: limit,
+			OnOverflow: func(LimitScope, *WebSocketConnection) OverflowAction {
+				return OverflowEvictOldest
+			},
+		}))
+	var existing []*WebSocketConnection
+	for i := 0; i < limit; i++ {
+		conn := newLimitTestConnection(hub, fmt.Sprintf("old_%d", i), nil, hub.now().Add(-time.Duration(limit-i)*time.Minute))
+		hub.handleRegister(conn)
+		existing = append(existing, conn)
+	}
+
+	admitted := admitConcurrently(hub, 10, nil)
+	if len(admitted) != limit {
+		t.Fatalf("%d of 10 concurrent connections admitted, want %d evicting the existing ones", len(admitted), limit)
+	}
+	for _, conn := range existing {
+		pipe := conn.Conn.(*webSocketPipe)
+		pipe.mutex.Lock()
+		evicted := pipe.closeFrame != nil && pipe.closeFrame.Code == CloseTryAgainLater
+		pipe.mutex.Unlock()
+		if !evicted {
+			t.Errorf("%s was not evicted", conn.ID)
+		}
+	}
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+	if total := len(hub.connections) + len(hub.admitted); total != limit {
+		t.Errorf("hub holds %d connections, want %d", total, limit)
+	}
+}
@@ -0,0 +1,195 @@
+package supergin
+
+import (
+	"sort"
+	"time"
+)
+
+// PresenceMessageType is the message type of presence events
+const PresenceMessageType = "presence"
+
+// Presence event kinds
+const (
+	PresenceJoin  = "join"
+	PresenceLeave = "leave"
+)
+
+// PresenceOptions configures how a hub tracks and announces who is online
+type PresenceOptions struct {
+	// UserKey identifies the user owning a connection; defaults to the hub limits' UserKey,
+	// then to formatting conn.User. Connections without a key are anonymous and not listed.
+	UserKey func(conn *WebSocketConnection) string
+	// Info describes a user in presence entries and events, e.g. a display name and avatar;
+	// defaults to conn.User
+	Info func(conn *WebSocketConnection) interface{}
+	// Events broadcasts a presence message when a user's first connection registers or
+	// their last one leaves, and to a room's members when a user joins or leaves the room
+	Events bool
+}
+
+// Presence is an online user and their connections, in the hub or in a room
+type Presence struct {
+	User        string      `json:"user"`
+	Info        interface{} `json:"info,omitempty"`
+	Connections []string    `json:"connections"`
+	// JoinedAt is when the user's earliest current connection connected, or joined the room
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// PresenceEvent is the data of a presence message
+type PresenceEvent struct {
+	Event string      `json:"event"`
+	Room  string      `json:"room,omitempty"`
+	User  string      `json:"user"`
+	Info  interface{} `json:"info,omitempty"`
+	At    time.Time   `json:"at"`
+}
+
+// WithPresence configures presence tracking; Presence and RoomPresence work without it
+// using the default user key, but events are only broadcast when enabled here
+func WithPresence(options PresenceOptions) HubOption {
+	return func(h *WebSocketHub) {
+		h.presence = options
+	}
+}
+
+// Presence returns the users connected to this instance of the hub, earliest first
+func (h *WebSocketHub) Presence() []Presence {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.collectPresence(h.connections, func(conn *WebSocketConnection) time.Time {
+		return conn.ConnectedAt
+	})
+}
+
+// RoomPresence returns the users in a room on this instance, earliest to join first
+func (h *WebSocketHub) RoomPresence(room string) []Presence {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.collectPresence(h.rooms[room], func(conn *WebSocketConnection) time.Time {
+		conn.mutex.RLock()
+		defer conn.mutex.RUnlock()
+		if joinedAt, joined := conn.rooms[room]; joined {
+			return joinedAt
+		}
+		return conn.ConnectedAt
+	})
+}
+
+// collectPresence groups connections by user. Caller must hold h.mutex.
+func (h *WebSocketHub) collectPresence(connections map[string]*WebSocketConnection, joinedAt func(conn *WebSocketConnection) time.Time) []Presence {
+	byUser := make(map[string]*Presence)
+	for _, conn := range connections {
+		key := h.presenceKeyLocked(conn)
+		if key == "" {
+			continue
+		}
+		at := joinedAt(conn)
+		entry, exists := byUser[key]
+		if !exists {
+			entry = &Presence{User: key, JoinedAt: at, Info: h.presenceInfo(conn)}
+			byUser[key] = entry
+		} else if at.Before(entry.JoinedAt) {
+			entry.JoinedAt = at
+			entry.Info = h.presenceInfo(conn)
+		}
+		entry.Connections = append(entry.Connections, conn.ID)
+	}
+
+	presence := make([]Presence, 0, len(byUser))
+	for _, entry := range byUser {
+		sort.Strings(entry.Connections)
+		presence = append(presence, *entry)
+	}
+	sort.Slice(presence, func(i, j int) bool {
+		if !presence[i].JoinedAt.Equal(presence[j].JoinedAt) {
+			return presence[i].JoinedAt.Before(presence[j].JoinedAt)
+		}
+		return presence[i].User < presence[j].User
+	})
+	return presence
+}
+
+// presenceKeyLocked returns the user owning a connection. Caller must hold h.mutex.
+func (h *WebSocketHub) presenceKeyLocked(conn *WebSocketConnection) string {
+	if h.presence.UserKey != nil {
+		return h.presence.UserKey(conn)
+	}
+	return h.limits.userKey(conn)
+}
+
+// presenceInfo describes a connection's user
+func (h *WebSocketHub) presenceInfo(conn *WebSocketConnection) interface{} {
+	if h.presence.Info != nil {
+		return h.presence.Info(conn)
+	}
+	return conn.User
+}
+
+// userConnectedLocked reports whether any connection among connections other than conn
+// belongs to key. Caller must hold h.mutex.
+func (h *WebSocketHub) userConnectedLocked(connections map[string]*WebSocketConnection, conn *WebSocketConnection, key string) bool {
+	for id, other := range connections {
+		if id != conn.ID && h.presenceKeyLocked(other) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// presenceChangesLocked returns the user a connection belongs to when presence events are on,
+// and whether it is the user's only connection in the hub and in each of rooms. Caller
+// must hold h.mutex.
+func (h *WebSocketHub) presenceChangesLocked(conn *WebSocketConnection, rooms []string) (string, bool, []string) {
+	if !h.presence.Events || h.closing {
+		return "", false, nil
+	}
+	key := h.presenceKeyLocked(conn)
+	if key == "" {
+		return "", false, nil
+	}
+	var changedRooms []string
+	for _, room := range rooms {
+		if !h.userConnectedLocked(h.rooms[room], conn, key) {
+			changedRooms = append(changedRooms, room)
+		}
+	}
+	return key, !h.userConnectedLocked(h.connections, conn, key), changedRooms
+}
+
+// announcePresence broadcasts a user's presence change to the hub, and to each room whose
+// membership changed. It runs on the hub loop, so it delivers hub-wide events directly.
+func (h *WebSocketHub) announcePresence(conn *WebSocketConnection, event, key string, hubWide bool, rooms []string) {
+	if key == "" {
+		return
+	}
+	info := h.presenceInfo(conn)
+	now := h.now()
+
+	if hubWide {
+		outgoing, err := newOutgoingMessage(WebSocketMessage{
+			Type:      PresenceMessageType,
+			Data:      PresenceEvent{Event: event, User: key, Info: info, At: now},
+			Timestamp: now,
+		})
+		if err != nil {
+			h.log().Warn("failed to encode presence event", "error", err)
+			return
+		}
+		h.handleBroadcast(outgoing)
+		h.relay("", outgoing)
+	}
+	for _, room := range rooms {
+		h.announceRoomPresence(room, event, key, info, now)
+	}
+}
+
+// announceRoomPresence broadcasts a user joining or leaving a room to its members
+func (h *WebSocketHub) announceRoomPresence(room, event, key string, info interface{}, now time.Time) {
+	err := h.BroadcastToRoom(room, PresenceMessageType, PresenceEvent{Event: event, Room: room, User: key, Info: info, At: now})
+	if err != nil {
+		h.log().Warn("failed to broadcast room presence event", "room", room, "error", err)
+	}
+}
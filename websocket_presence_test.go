@@ -0,0 +1,94 @@
+package supergin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// headerUser authenticates test connections as the user named in X-User, anonymous without it
+func headerUser(c *gin.Context) (interface{}, error) {
+	if user := c.GetHeader("X-User"); user != "" {
+		return user, nil
+	}
+	return nil, nil
+}
+
+// asUser connects as a user through headerUser
+func asUser(user string, resumeToken string) WebSocketTestConnect {
+	header := http.Header{}
+	if user != "" {
+		header.Set("X-User", user)
+	}
+	return WebSocketTestConnect{Header: header, ResumeToken: resumeToken}
+}
+
+// messagesOfType filters received messages by type
+func messagesOfType(messages []WebSocketMessage, messageType string) []WebSocketMessage {
+	var matched []WebSocketMessage
+	for _, message := range messages {
+		if message.Type == messageType {
+			matched = append(matched, message)
+		}
+	}
+	return matched
+}
+
+func TestPresenceEvents(t *testing.T) {
+	th := NewWebSocketTestHub(&DefaultWebSocketHandler{}, WithLogger(quietLogger()),
+		WithAuthenticate(headerUser),
+		WithPresence(PresenceOptions{Events: true}))
+
+	alice := th.Connect(asUser("alice", ""))
+	bob := th.Connect(asUser("bob", ""))
+	secondBob := th.Connect(asUser("bob", ""))
+	th.Connect(asUser("", ""))
+
+	presence := th.Hub.Presence()
+	if len(presence) != 2 || presence[0].User != "alice" || presence[1].User != "bob" {
+		t.Fatalf("Presence() = %+v, want alice then bob", presence)
+	}
+	if len(presence[1].Connections) != 2 {
+		t.Errorf("bob has %d connections, want 2", len(presence[1].Connections))
+	}
+
+	events := messagesOfType(alice.Messages(), PresenceMessageType)
+	if len(events) != 2 {
+		t.Fatalf("alice got %d presence events, want her own join and bob's", len(events))
+	}
+	if event := events[1].Data.(map[string]interface{}); event["event"] != PresenceJoin || event["user"] != "bob" {
+		t.Errorf("event = %v, want bob joining", event)
+	}
+
+	th.Hub.Join(alice.Connection().ID, "room")
+	th.Hub.Join(bob.Connection().ID, "room")
+	if roomPresence := th.Hub.RoomPresence("room"); len(roomPresence) != 2 {
+		t.Errorf("RoomPresence = %+v, want alice and bob", roomPresence)
+	}
+	alice.Messages()
+
+	// Bob's second connection closing leaves him online
+	secondBob.Close(CloseNormalClosure, "")
+	if events := messagesOfType(alice.Messages(), PresenceMessageType); len(events) != 0 {
+		t.Fatalf("alice got %v while bob is still connected", events)
+	}
+
+	bob.Close(CloseNormalClosure, "")
+	events = messagesOfType(alice.Messages(), PresenceMessageType)
+	var hubLeave, roomLeave bool
+	for _, message := range events {
+		event := message.Data.(map[string]interface{})
+		if event["event"] != PresenceLeave || event["user"] != "bob" {
+			t.Errorf("event = %v, want bob leaving", event)
+		}
+		if event["room"] == "room" {
+			roomLeave = true
+		} else {
+			hubLeave = true
+		}
+	}
+	if !hubLeave || !roomLeave {
+		t.Errorf("events = %v, want bob leaving the hub and the room", events)
+	}
+}
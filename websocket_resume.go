@@ -0,0 +1,264 @@
+package supergin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResumeTokenQuery and ResumeTokenHeader carry the resume token on reconnect
+const (
+	ResumeTokenQuery  = "resume_token"
+	ResumeTokenHeader = "X-Resume-Token"
+)
+
+// ResumeConfig enables session resumption for a hub
+type ResumeConfig struct {
+	// Secret signs resume tokens; required
+	Secret []byte
+	// TTL is how long a disconnected session can be resumed; defaults to 2 minutes
+	TTL time.Duration
+	// BufferSize caps messages buffered for a disconnected session; defaults to 100
+	BufferSize int
+}
+
+// resumeSession is the server-side state restored on reconnect
+type resumeSession struct {
+	id             string
+	user           interface{}
+	metadata       map[string]interface{}
+	rooms          []string
+	connected      bool
+	disconnectedAt time.Time
+	buffer         []*outgoingMessage
+}
+
+// resumeState holds a hub's resumable sessions
+type resumeState struct {
+	config   ResumeConfig
+	sessions map[string]*resumeSession
+	mutex    sync.Mutex
+}
+
+// resumeTokenClaims is the signed token payload
+type resumeTokenClaims struct {
+	SessionID string `json:"sid"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// WithResume enables resume tokens: each connection's first frame is a "session" message
+// carrying a signed token that can be presented on reconnect to restore metadata, room
+// membership and messages missed while disconnected. Sessions of authenticated users can
+// only be resumed by connections authenticated as the same user.
+func WithResume(config ResumeConfig) HubOption {
+	return func(h *WebSocketHub) {
+		if len(config.Secret) == 0 {
+			panic("WithResume requires a non-empty secret")
+		}
+		if config.TTL <= 0 {
+			config.TTL = 2 * time.Minute
+		}
+		if config.BufferSize <= 0 {
+			config.BufferSize = 100
+		}
+		h.resume = &resumeState{
+			config:   config,
+			sessions: make(map[string]*resumeSession),
+		}
+	}
+}
+
+// resumeTokenFromRequest extracts a presented resume token
+func resumeTokenFromRequest(c *gin.Context) string {
+	if token := c.Query(ResumeTokenQuery); token != "" {
+		return token
+	}
+	return c.GetHeader(ResumeTokenHeader)
+}
+
+// attachSession restores or creates the connection's session and sends the session frame.
+// It runs in the hub loop right after registration, before OnConnect.
+func (h *WebSocketHub) attachSession(conn *WebSocketConnection) {
+	rs := h.resume
+	if rs == nil {
+		return
+	}
+
+	rs.mutex.Lock()
+	now := h.now()
+	rs.sweepLocked(now)
+
+	var session *resumeSession
+	resumed := false
+	if sid, err := rs.verify(conn.resumeToken, now); conn.resumeToken != "" && err == nil {
+		if existing, ok := rs.sessions[sid]; ok && !existing.connected && sameUser(existing.user, conn.User) {
+			session = existing
+			resumed = true
+		}
+	} else if conn.resumeToken != "" {
+		h.log().Warn("WebSocket resume token rejected", "connection_id", conn.ID, "error", err)
+	}
+	if session == nil {
+		session = &resumeSession{id: newUUID()}
+		rs.sessions[session.id] = session
+	}
+	session.connected = true
+	missed := session.buffer
+	session.buffer = nil
+	rooms := session.rooms
+	rs.mutex.Unlock()
+
+	conn.sessionID = session.id
+	if resumed {
+		// The user is never restored: connections authenticate themselves
+		conn.mutex.Lock()
+		for k, v := range session.metadata {
+			conn.Metadata[k] = v
+		}
+		conn.mutex.Unlock()
+		for _, room := range rooms {
+			if err := h.Join(conn.ID, room); err != nil {
+				h.log().Warn("WebSocket resume could not rejoin room", "connection_id", conn.ID, "room", room, "error", err)
+			}
+		}
+	}
+
+	token, expiresAt := rs.issue(session.id, now)
+	conn.Send("session", map[string]interface{}{
+		"connection_id": conn.ID,
+		"session_id":    session.id,
+		"resume_token":  token,
+		"expires_at":    expiresAt,
+		"resumed":       resumed,
+		"missed":        len(missed),
+	})
+
+	// Missed messages go through the send filters for the connection they are replayed to
+	for _, outgoing := range missed {
+		msg, ok := h.encodeFor(conn, outgoing)
+		if !ok {
+			continue
+		}
+		if err := conn.enqueue(msg); err != nil {
+			h.log().Warn("WebSocket resume dropped missed messages", "connection_id", conn.ID, "error", err)
+			return
+		}
+	}
+}
+
+// detachSession snapshots the connection's state so it can be resumed later
+func (h *WebSocketHub) detachSession(conn *WebSocketConnection) {
+	rs := h.resume
+	if rs == nil || conn.sessionID == "" {
+		return
+	}
+
+	conn.mutex.RLock()
+	metadata := make(map[string]interface{}, len(conn.Metadata))
+	for k, v := range conn.Metadata {
+		metadata[k] = v
+	}
+	user := conn.User
+	conn.mutex.RUnlock()
+	rooms := conn.Rooms()
+
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	session, ok := rs.sessions[conn.sessionID]
+	if !ok {
+		return
+	}
+	session.connected = false
+	session.disconnectedAt = h.now()
+	session.user = user
+	session.metadata = metadata
+	session.rooms = rooms
+}
+
+// bufferForSessions records a message for disconnected sessions that would have received it.
+// An empty room means a hub-wide broadcast.
+func (h *WebSocketHub) bufferForSessions(outgoing *outgoingMessage, room string) {
+	rs := h.resume
+	if rs == nil {
+		return
+	}
+
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	for _, session := range rs.sessions {
+		if session.connected {
+			continue
+		}
+		if room != "" && !contains(session.rooms, room) {
+			continue
+		}
+		session.buffer = append(session.buffer, outgoing)
+		if overflow := len(session.buffer) - rs.config.BufferSize; overflow > 0 {
+			session.buffer = session.buffer[overflow:]
+		}
+	}
+}
+
+// sweepLocked drops sessions that can no longer be resumed. Caller must hold rs.mutex.
+func (rs *resumeState) sweepLocked(now time.Time) {
+	for id, session := range rs.sessions {
+		if !session.connected && now.Sub(session.disconnectedAt) > rs.config.TTL {
+			delete(rs.sessions, id)
+		}
+	}
+}
+
+// issue creates a signed resume token for a session
+func (rs *resumeState) issue(sessionID string, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(rs.config.TTL)
+	claims, _ := json.Marshal(resumeTokenClaims{SessionID: sessionID, ExpiresAt: expiresAt.Unix()})
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+	return payload + "." + rs.sign(payload), expiresAt
+}
+
+// verify checks a token's signature and expiry and returns its session ID
+func (rs *resumeState) verify(token string, now time.Time) (string, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", fmt.Errorf("malformed token")
+	}
+	if !hmac.Equal([]byte(signature), []byte(rs.sign(payload))) {
+		return "", fmt.Errorf("invalid signature")
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("malformed token payload")
+	}
+	var claims resumeTokenClaims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return "", fmt.Errorf("malformed token claims")
+	}
+	if now.Unix() > claims.ExpiresAt {
+		return "", fmt.Errorf("token expired")
+	}
+	return claims.SessionID, nil
+}
+
+func (rs *resumeState) sign(payload string) string {
+	mac := hmac.New(sha256.New, rs.config.Secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// sameUser reports whether a session may be resumed by a connection: a session of an
+// authenticated user can only be resumed by a connection authenticated as the same user,
+// so a resume token alone never signs anyone in
+func sameUser(sessionUser, connUser interface{}) bool {
+	return sessionUser == nil || reflect.DeepEqual(sessionUser, connUser)
+}
@@ -0,0 +1,165 @@
+package supergin
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sessionFrame returns the data of the session frame among messages
+func sessionFrame(t *testing.T, messages []WebSocketMessage) map[string]interface{} {
+	t.Helper()
+	frames := messagesOfType(messages, "session")
+	if len(frames) != 1 {
+		t.Fatalf("got %d session frames, want 1", len(frames))
+	}
+	data, ok := frames[0].Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("session frame data is %T", frames[0].Data)
+	}
+	return data
+}
+
+func TestResumeReplaysMissedMessagesThroughSendFilters(t *testing.T) {
+	redact := func(conn *WebSocketConnection, message *WebSocketMessage) (*WebSocketMessage, bool) {
+		data, ok := message.Data.(map[string]interface{})
+		if !ok {
+			return message, true
+		}
+		redacted := make(map[string]interface{}, len(data))
+		for key, value := range data {
+			if key != "secret" {
+				redacted[key] = value
+			}
+		}
+		message.Data = redacted
+		return message, true
+	}
+	th := NewWebSocketTestHub(&DefaultWebSocketHandler{}, WithLogger(quietLogger()),
+		WithResume(ResumeConfig{Secret: []byte("test-secret")}),
+		WithSendFilter(redact))
+
+	client := th.Connect()
+	token, _ := sessionFrame(t, client.Messages())["resume_token"].(string)
+	client.Close(CloseNormalClosure, "")
+
+	th.Hub.Broadcast("note", map[string]interface{}{"text": "hi", "secret": "s3cret"})
+
+	resumed := th.Connect(WebSocketTestConnect{ResumeToken: token})
+	messages := resumed.Messages()
+	if session := sessionFrame(t, messages); session["resumed"] != true || session["missed"] != float64(1) {
+		t.Fatalf("session frame = %v, want resumed with 1 missed message", session)
+	}
+	notes := messagesOfType(messages, "note")
+	if len(notes) != 1 {
+		t.Fatalf("got %d replayed notes, want 1", len(notes))
+	}
+	data := notes[0].Data.(map[string]interface{})
+	if _, leaked := data["secret"]; leaked || data["text"] != "hi" {
+		t.Errorf("replayed note = %v, want it filtered", data)
+	}
+}
+
+func TestResumeRequiresSessionUser(t *testing.T) {
+	th := NewWebSocketTestHub(&DefaultWebSocketHandler{}, WithLogger(quietLogger()),
+		WithResume(ResumeConfig{Secret: []byte("test-secret")}),
+		WithAuthenticate(headerUser))
+
+	alice := th.Connect(asUser("alice", ""))
+	token, _ := sessionFrame(t, alice.Messages())["resume_token"].(string)
+	th.Hub.Join(alice.Connection().ID, "private")
+	alice.Close(CloseNormalClosure, "")
+
+	for _, user := range []string{"", "mallory"} {
+		intruder := th.Connect(asUser(user, token))
+		if session := sessionFrame(t, intruder.Messages()); session["resumed"] != false {
+			t.Errorf("user %q resumed alice's session", user)
+		}
+		if conn := intruder.Connection(); conn.User != nil && conn.User != user {
+			t.Errorf("user %q connection got user %v", user, conn.User)
+		}
+		if rooms := intruder.Connection().Rooms(); len(rooms) != 0 {
+			t.Errorf("user %q joined %v", user, rooms)
+		}
+		intruder.Close(CloseNormalClosure, "")
+	}
+
+	again := th.Connect(asUser("alice", token))
+	if session := sessionFrame(t, again.Messages()); session["resumed"] != true {
+		t.Fatal("alice could not resume her own session")
+	}
+	if rooms := again.Connection().Rooms(); len(rooms) != 1 || rooms[0] != "private" {
+		t.Errorf("resumed rooms = %v, want [private]", rooms)
+	}
+}
+
+func TestResumeTokenValidation(t *testing.T) {
+	const ttl = time.Minute
+	th := NewWebSocketTestHub(&DefaultWebSocketHandler{}, WithLogger(quietLogger()),
+		WithResume(ResumeConfig{Secret: []byte("test-secret"), TTL: ttl}))
+
+	// disconnect ends a session after d and returns its ID and token
+	disconnect := func(d time.Duration) (string, string) {
+		client := th.Connect()
+		session := sessionFrame(t, client.Messages())
+		th.Advance(d)
+		client.Close(CloseNormalClosure, "")
+		sid, _ := session["session_id"].(string)
+		token, _ := session["resume_token"].(string)
+		return sid, token
+	}
+	// forge signs a token for sid expiring at expiresAt with secret
+	forge := func(sid string, expiresAt time.Time, secret string) string {
+		forger := &resumeState{config: ResumeConfig{Secret: []byte(secret)}}
+		raw, _ := json.Marshal(resumeTokenClaims{SessionID: sid, ExpiresAt: expiresAt.Unix()})
+		payload := base64.RawURLEncoding.EncodeToString(raw)
+		return payload + "." + forger.sign(payload)
+	}
+	later := webSocketTestEpoch.Add(24 * time.Hour)
+
+	tests := []struct {
+		name  string
+		token func(sid, token string) string
+		after time.Duration
+		want  bool
+	}{
+		{"valid", func(_, token string) string { return token }, 0, true},
+		{"extended expiry", func(sid, token string) string {
+			_, signature, _ := strings.Cut(token, ".")
+			payload, _, _ := strings.Cut(forge(sid, later, "test-secret"), ".")
+			return payload + "." + signature
+		}, 0, false},
+		{"signed with another secret", func(sid, _ string) string { return forge(sid, later, "other-secret") }, 0, false},
+		{"malformed", func(_, token string) string { return strings.ReplaceAll(token, ".", "") }, 0, false},
+		{"expired while connected", func(_, token string) string { return token }, ttl + time.Second, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sid, token := disconnect(tt.after)
+
+			client := th.Connect(WebSocketTestConnect{ResumeToken: tt.token(sid, token)})
+			session := sessionFrame(t, client.Messages())
+			if session["resumed"] != tt.want {
+				t.Errorf("resumed = %v, want %v", session["resumed"], tt.want)
+			}
+			if resumed := session["session_id"] == sid; resumed != tt.want {
+				t.Errorf("session_id = %v, resumed session %s: %v, want %v", session["session_id"], sid, resumed, tt.want)
+			}
+			client.Close(CloseNormalClosure, "")
+		})
+	}
+
+	t.Run("session already resumed", func(t *testing.T) {
+		_, token := disconnect(0)
+		first := th.Connect(WebSocketTestConnect{ResumeToken: token})
+		if session := sessionFrame(t, first.Messages()); session["resumed"] != true {
+			t.Fatal("first reconnect did not resume")
+		}
+		second := th.Connect(WebSocketTestConnect{ResumeToken: token})
+		if session := sessionFrame(t, second.Messages()); session["resumed"] != false {
+			t.Error("a connected session was resumed a second time")
+		}
+	})
+}
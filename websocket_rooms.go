@@ -0,0 +1,174 @@
+package supergin
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Join adds a connection to a room, enforcing the hub's per-room limit
+func (h *WebSocketHub) Join(connID, room string) error {
+	h.mutex.Lock()
+	conn, exists := h.connections[connID]
+	if !exists {
+		h.mutex.Unlock()
+		return fmt.Errorf("connection %s not found", connID)
+	}
+
+	members := h.rooms[room]
+	if _, joined := members[connID]; joined {
+		h.mutex.Unlock()
+		return nil
+	}
+
+	var evicted *WebSocketConnection
+	var evictedUser string
+	var evictedLeft []string
+	if limit := h.limits.MaxPerRoom; limit > 0 && len(members) >= limit {
+		if h.limits.overflowAction(LimitScopeRoom, conn) != OverflowEvictOldest {
+			h.mutex.Unlock()
+			return NewSuperGinError(ErrLimitExceeded, "room '%s' is full (%d connections)", room, limit)
+		}
+		evicted = oldestConnection(members)
+		delete(members, evicted.ID)
+		evictedUser, _, evictedLeft = h.presenceChangesLocked(evicted, []string{room})
+	}
+
+	user, _, joined := h.presenceChangesLocked(conn, []string{room})
+	if members == nil {
+		members = make(map[string]*WebSocketConnection)
+		h.rooms[room] = members
+	}
+	members[connID] = conn
+	h.mutex.Unlock()
+
+	now := h.now()
+	conn.mutex.Lock()
+	conn.rooms[room] = now
+	conn.mutex.Unlock()
+
+	if evicted != nil {
+		evicted.mutex.Lock()
+		delete(evicted.rooms, room)
+		evicted.mutex.Unlock()
+		evicted.Send("room_evicted", map[string]interface{}{"room": room, "reason": "room connection limit reached"})
+		if len(evictedLeft) > 0 {
+			h.announceRoomPresence(room, PresenceLeave, evictedUser, h.presenceInfo(evicted), now)
+		}
+	}
+	if len(joined) > 0 {
+		h.announceRoomPresence(room, PresenceJoin, user, h.presenceInfo(conn), now)
+	}
+	return nil
+}
+
+// Leave removes a connection from a room
+func (h *WebSocketHub) Leave(connID, room string) {
+	h.mutex.Lock()
+	conn, exists := h.connections[connID]
+	var user string
+	var left []string
+	if members, ok := h.rooms[room]; ok {
+		if _, member := members[connID]; member && exists {
+			user, _, left = h.presenceChangesLocked(conn, []string{room})
+		}
+		delete(members, connID)
+		if len(members) == 0 {
+			delete(h.rooms, room)
+		}
+	}
+	h.mutex.Unlock()
+
+	if exists {
+		conn.mutex.Lock()
+		delete(conn.rooms, room)
+		conn.mutex.Unlock()
+	}
+	if len(left) > 0 {
+		h.announceRoomPresence(room, PresenceLeave, user, h.presenceInfo(conn), h.now())
+	}
+}
+
+// BroadcastToRoom sends a message to every connection in a room, on every instance when
+// the hub has an adapter
+func (h *WebSocketHub) BroadcastToRoom(room, messageType string, data interface{}) error {
+	message := WebSocketMessage{
+		Type:      messageType,
+		Data:      data,
+		Timestamp: h.now(),
+	}
+
+	outgoing, err := newOutgoingMessage(message)
+	if err != nil {
+		return err
+	}
+
+	h.deliverToRoom(room, outgoing)
+	h.relay(room, outgoing)
+	return nil
+}
+
+// deliverToRoom queues a message for the room's local members
+func (h *WebSocketHub) deliverToRoom(room string, outgoing *outgoingMessage) {
+	h.bufferForSessions(outgoing, room)
+
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	for _, conn := range h.rooms[room] {
+		msgBytes, ok := h.encodeFor(conn, outgoing)
+		if !ok {
+			continue
+		}
+		// Slow consumers and exhausted memory budgets drop the message rather than block the room
+		conn.enqueue(msgBytes)
+	}
+}
+
+// RoomMembers returns the connections currently in a room
+func (h *WebSocketHub) RoomMembers(room string) []*WebSocketConnection {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	members := make([]*WebSocketConnection, 0, len(h.rooms[room]))
+	for _, conn := range h.rooms[room] {
+		members = append(members, conn)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+	return members
+}
+
+// Rooms returns the names of all non-empty rooms
+func (h *WebSocketHub) Rooms() []string {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	rooms := make([]string, 0, len(h.rooms))
+	for room := range h.rooms {
+		rooms = append(rooms, room)
+	}
+	sort.Strings(rooms)
+	return rooms
+}
+
+// Rooms returns the rooms this connection has joined
+func (conn *WebSocketConnection) Rooms() []string {
+	conn.mutex.RLock()
+	defer conn.mutex.RUnlock()
+
+	rooms := make([]string, 0, len(conn.rooms))
+	for room := range conn.rooms {
+		rooms = append(rooms, room)
+	}
+	sort.Strings(rooms)
+	return rooms
+}
+
+// removeFromRoomsLocked removes a connection from all rooms. Caller must hold h.mutex.
+func (h *WebSocketHub) removeFromRoomsLocked(conn *WebSocketConnection) {
+	for room, members := range h.rooms {
+		delete(members, conn.ID)
+		if len(members) == 0 {
+			delete(h.rooms, room)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package supergin
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultHubCloseReason is the close frame reason hubs send on shutdown
+const DefaultHubCloseReason = "server shutting down"
+
+// WithCloseFrame sets the close code and reason the hub sends its connections on Shutdown,
+// e.g. CloseTryAgainLater to ask clients to reconnect elsewhere. The default is
+// CloseGoingAway with DefaultHubCloseReason.
+func WithCloseFrame(code int, reason string) HubOption {
+	return func(h *WebSocketHub) {
+		h.shutdownCode = code
+		h.shutdownReason = reason
+	}
+}
+
+// Shutdown closes the hub gracefully: new upgrades are refused with 503, every connection
+// is unregistered, sent the messages already queued for it and then the hub's close frame,
+// and the hub loop stops once all writes are flushed. If ctx ends first, the remaining
+// sockets are closed without waiting. Engine shutdown calls it for every hub; calling it
+// again waits for the first call to finish.
+func (h *WebSocketHub) Shutdown(ctx context.Context) error {
+	h.mutex.Lock()
+	if h.closing {
+		h.mutex.Unlock()
+		select {
+		case <-h.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	h.closing = true
+	connections := make([]*WebSocketConnection, 0, len(h.connections))
+	for _, conn := range h.connections {
+		connections = append(connections, conn)
+	}
+	h.mutex.Unlock()
+
+	code, reason := h.shutdownCode, h.shutdownReason
+	if code == 0 {
+		code, reason = CloseGoingAway, DefaultHubCloseReason
+	}
+	for _, conn := range connections {
+		conn.setCloseFrame(code, reason)
+		// Closing the send queue lets the write pump flush it, then send the close frame
+		h.handleUnregister(conn)
+	}
+	if h.harness != nil {
+		h.harness.Flush()
+	}
+
+	var err error
+	for i, conn := range connections {
+		select {
+		case <-conn.writeDone:
+			continue
+		case <-ctx.Done():
+		}
+		for _, remaining := range connections[i:] {
+			remaining.Conn.Close()
+		}
+		err = fmt.Errorf("WebSocket hub shutdown interrupted: %w", ctx.Err())
+		break
+	}
+
+	if adapterErr := h.stopAdapter(); adapterErr != nil && err == nil {
+		err = fmt.Errorf("failed to close WebSocket hub adapter: %w", adapterErr)
+	}
+	close(h.done)
+	h.log().Info("WebSocket hub shut down", "connections", len(connections))
+	return err
+}
+
+// isClosing reports whether Shutdown has been called
+func (h *WebSocketHub) isClosing() bool {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return h.closing
+}
+
+// setCloseFrame sets the close frame the write pump sends once the send queue is closed
+func (conn *WebSocketConnection) setCloseFrame(code int, reason string) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.closeCode, conn.closeReason = code, reason
+}
+
+// closeFrame returns the close frame to send, a normal closure by default
+func (conn *WebSocketConnection) closeFrame() (int, string) {
+	conn.mutex.RLock()
+	defer conn.mutex.RUnlock()
+	if conn.closeCode == 0 {
+		return CloseNormalClosure, ""
+	}
+	return conn.closeCode, conn.closeReason
+}
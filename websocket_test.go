@@ -0,0 +1,43 @@
+package supergin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSlowConsumerIsDroppedFromRooms(t *testing.T) {
+	th := NewWebSocketTestHub(&DefaultWebSocketHandler{}, WithLogger(quietLogger()))
+	client := th.Connect()
+	conn := client.Connection()
+	if err := th.Hub.Join(conn.ID, "general"); err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+
+	// Fill the send queue without letting the client read
+	for i := 0; i < cap(conn.send)+10; i++ {
+		if err := th.Hub.Broadcast("tick", i); err != nil {
+			t.Fatalf("Broadcast: %v", err)
+		}
+	}
+
+	if members := th.Hub.RoomMembers("general"); len(members) != 0 {
+		t.Fatalf("dropped connection is still in the room: %d members", len(members))
+	}
+	if err := th.Hub.BroadcastToRoom("general", "chat", "hello"); err != nil {
+		t.Fatalf("BroadcastToRoom: %v", err)
+	}
+	if err := conn.Send("direct", "hello"); !errors.Is(err, errConnectionClosed) {
+		t.Fatalf("Send to dropped connection = %v, want errConnectionClosed", err)
+	}
+	if len(th.Hub.GetConnections()) != 0 {
+		t.Fatal("dropped connection is still registered")
+	}
+
+	if got := len(messagesOfType(client.Messages(), "tick")); got != cap(conn.send) {
+		t.Errorf("client received %d queued messages, want %d", got, cap(conn.send))
+	}
+	code, reason, ok := client.CloseFrame()
+	if !ok || code != CloseTryAgainLater {
+		t.Errorf("close frame = %d %q, want %d", code, reason, CloseTryAgainLater)
+	}
+}
@@ -0,0 +1,459 @@
+package supergin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// webSocketTestEpoch is where a WebSocketTestHub's clock starts
+var webSocketTestEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var (
+	errPipeClosed    = errors.New("websocket: use of closed test connection")
+	errPipeTimeout   = errors.New("websocket: read deadline exceeded")
+	errPipeReadLimit = errors.New("websocket: read limit exceeded")
+	errPipeEmpty     = errors.New("websocket: no frame to read")
+)
+
+// WebSocketTestHub runs a hub deterministically for tests. Connections are in-memory pipes
+// instead of sockets, time is a FakeClock, and there is no hub loop or pump goroutine:
+// registration, broadcasts and unregistration happen on the calling goroutine, and each
+// client call, Flush and Advance processes every connection's reads then writes, in connect
+// order, until nothing is left to do. Build it with the handler and options of the hub under
+// test; it is not safe for concurrent use.
+//
+//	th := supergin.NewWebSocketTestHub(chatHandler, supergin.WithLimits(limits))
+//	alice := th.Connect()
+//	alice.Send("join", map[string]interface{}{"room": "general"})
+//	th.Advance(2 * time.Minute) // alice answers pings, so she stays connected
+type WebSocketTestHub struct {
+	Hub   *WebSocketHub
+	Clock *FakeClock
+
+	clients    []*WebSocketTestClient
+	connecting *WebSocketTestClient
+	nextID     int
+}
+
+// WebSocketTestConnect configures a test connection's upgrade request
+type WebSocketTestConnect struct {
+	// Header is sent with the upgrade request, e.g. for the hub's authenticate hook
+	Header http.Header
+	// ResumeToken presents a token from an earlier session frame
+	ResumeToken string
+}
+
+// NewWebSocketTestHub creates a hub driven by the test harness. Connection IDs are
+// sequential ("ws_1", "ws_2", ...) unless opts set a generator; opts cannot replace the
+// harness's transport or clock.
+func NewWebSocketTestHub(handler WebSocketHandler, opts ...HubOption) *WebSocketTestHub {
+	th := &WebSocketTestHub{Clock: NewFakeClock(webSocketTestEpoch)}
+
+	options := append([]HubOption{WithIDGenerator(th.nextConnectionID)}, opts...)
+	options = append(options, WithTransport(webSocketTestTransport{harness: th}), WithClock(th.Clock))
+	th.Hub = NewWebSocketHub(handler, options...)
+	th.Hub.harness = th
+	return th
+}
+
+// nextConnectionID generates sequential connection IDs
+func (th *WebSocketTestHub) nextConnectionID(c *gin.Context) string {
+	th.nextID++
+	return fmt.Sprintf("ws_%d", th.nextID)
+}
+
+// Connect opens a connection through the hub's upgrade path, so authentication, limits and
+// session resumption apply. Rejected connections are returned closed, with the rejection's
+// status or the frames the hub sent before closing them.
+func (th *WebSocketTestHub) Connect(opts ...WebSocketTestConnect) *WebSocketTestClient {
+	opt := WebSocketTestConnect{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	for key, values := range opt.Header {
+		req.Header[key] = values
+	}
+	if opt.ResumeToken != "" {
+		req.Header.Set(ResumeTokenHeader, opt.ResumeToken)
+	}
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = req
+
+	th.connecting = nil
+	handleWebSocketUpgrade(c, th.Hub)
+	client := th.connecting
+	th.connecting = nil
+
+	if client == nil {
+		// Rejected before the upgrade
+		client = &WebSocketTestClient{harness: th, pipe: &webSocketPipe{clock: th.Clock, closed: true}}
+		client.status = recorder.Code
+		return client
+	}
+	th.Flush()
+	return client
+}
+
+// attach takes over the pumps of a connection the hub registered
+func (th *WebSocketTestHub) attach(conn *WebSocketConnection) {
+	client := th.connecting
+	client.conn = conn
+	client.ticker = th.Clock.NewTicker(th.Hub.config.PingInterval)
+	conn.startReading()
+	th.clients = append(th.clients, client)
+}
+
+// Flush delivers everything pending: what clients sent is read and handled, and what the
+// hub queued is written, repeatedly until no connection makes progress. Call it after
+// using the hub directly, e.g. Broadcast or Join, from the test.
+func (th *WebSocketTestHub) Flush() {
+	for progressed := true; progressed; {
+		progressed = false
+		for _, client := range th.clients {
+			if client.step() {
+				progressed = true
+			}
+		}
+	}
+}
+
+// Advance moves the clock forward tick by tick, flushing at each ping and at the end, so
+// pings fire and read deadlines expire in order. Clients answer pings unless
+// SetAutoPong(false) was called; silent clients are dropped once the pong wait passes.
+func (th *WebSocketTestHub) Advance(d time.Duration) {
+	target := th.Clock.Now().Add(d)
+	for {
+		next, ok := th.Clock.nextTick()
+		if !ok || next.After(target) {
+			break
+		}
+		th.Clock.Advance(next.Sub(th.Clock.Now()))
+		th.Flush()
+	}
+	th.Clock.Advance(target.Sub(th.Clock.Now()))
+	th.Flush()
+}
+
+// WebSocketTestClient is the client end of a test connection
+type WebSocketTestClient struct {
+	harness *WebSocketTestHub
+	pipe    *webSocketPipe
+	status  int
+
+	// conn and ticker are the server side, nil when the connection was rejected
+	conn         *WebSocketConnection
+	ticker       ClockTicker
+	readStopped  bool
+	writeStopped bool
+}
+
+// step runs the connection's pumps until they would block, reporting whether anything
+// happened. Reads go first, then queued messages, then a due ping.
+func (client *WebSocketTestClient) step() bool {
+	conn := client.conn
+	progressed := false
+
+	for !client.readStopped && client.pipe.readable() {
+		progressed = true
+		if !conn.readNext() {
+			client.readStopped = true
+			conn.stopReading()
+		}
+	}
+
+	for !client.writeStopped {
+		select {
+		case message, ok := <-conn.send:
+			progressed = true
+			if !conn.writeQueued(message, ok) {
+				client.stopWriting()
+			}
+			continue
+		default:
+		}
+		break
+	}
+
+	if !client.writeStopped {
+		select {
+		case <-client.ticker.C():
+			progressed = true
+			if !conn.writePing() {
+				client.stopWriting()
+			}
+		default:
+		}
+	}
+	return progressed
+}
+
+// stopWriting ends the connection's write side as writePump would on return
+func (client *WebSocketTestClient) stopWriting() {
+	client.writeStopped = true
+	client.conn.stopWriting(client.ticker)
+}
+
+// Status is the upgrade response status: 101 when the connection was upgraded
+func (client *WebSocketTestClient) Status() int {
+	return client.status
+}
+
+// Connection returns the hub's side of the connection, nil if the upgrade was rejected
+func (client *WebSocketTestClient) Connection() *WebSocketConnection {
+	return client.conn
+}
+
+// Send sends a structured message as the client, then flushes
+func (client *WebSocketTestClient) Send(messageType string, data interface{}) error {
+	message, err := json.Marshal(WebSocketMessage{Type: messageType, Data: data, Timestamp: client.harness.Clock.Now()})
+	if err != nil {
+		return err
+	}
+	return client.SendRaw(message)
+}
+
+// SendRaw sends a frame as is, e.g. malformed JSON or an oversized message, then flushes
+func (client *WebSocketTestClient) SendRaw(data []byte) error {
+	if err := client.pipe.push(webSocketFrame{data: data}); err != nil {
+		return err
+	}
+	client.harness.Flush()
+	return nil
+}
+
+// Pong answers a ping, then flushes; only needed with auto-pong disabled
+func (client *WebSocketTestClient) Pong() error {
+	if err := client.pipe.push(webSocketFrame{pong: true}); err != nil {
+		return err
+	}
+	client.harness.Flush()
+	return nil
+}
+
+// SetAutoPong sets whether the client answers pings itself, as browsers do; it does by default
+func (client *WebSocketTestClient) SetAutoPong(enabled bool) {
+	client.pipe.mutex.Lock()
+	defer client.pipe.mutex.Unlock()
+	client.pipe.noAutoPong = !enabled
+}
+
+// Close sends a close frame with a status code and reason, then flushes
+func (client *WebSocketTestClient) Close(code int, reason string) error {
+	if err := client.pipe.push(webSocketFrame{close: &WebSocketCloseError{Code: code, Text: reason}}); err != nil {
+		return err
+	}
+	client.harness.Flush()
+	return nil
+}
+
+// Messages flushes and returns the messages received since the last call, in order.
+// Batched frames are split into their messages.
+func (client *WebSocketTestClient) Messages() []WebSocketMessage {
+	client.harness.Flush()
+
+	client.pipe.mutex.Lock()
+	defer client.pipe.mutex.Unlock()
+	messages := client.pipe.received
+	client.pipe.received = nil
+	return messages
+}
+
+// Pings returns how many pings the client has received
+func (client *WebSocketTestClient) Pings() int {
+	client.pipe.mutex.Lock()
+	defer client.pipe.mutex.Unlock()
+	return client.pipe.pings
+}
+
+// Closed reports whether the hub closed the connection
+func (client *WebSocketTestClient) Closed() bool {
+	client.pipe.mutex.Lock()
+	defer client.pipe.mutex.Unlock()
+	return client.pipe.closed
+}
+
+// CloseFrame returns the close frame the hub sent, if any
+func (client *WebSocketTestClient) CloseFrame() (code int, reason string, ok bool) {
+	client.pipe.mutex.Lock()
+	defer client.pipe.mutex.Unlock()
+	if client.pipe.closeFrame == nil {
+		return 0, "", false
+	}
+	return client.pipe.closeFrame.Code, client.pipe.closeFrame.Text, true
+}
+
+// webSocketTestTransport upgrades to in-memory pipes for a test harness
+type webSocketTestTransport struct {
+	harness *WebSocketTestHub
+}
+
+// Upgrade implements WebSocketTransport
+func (t webSocketTestTransport) Upgrade(w http.ResponseWriter, r *http.Request) (WebSocketConn, error) {
+	pipe := &webSocketPipe{clock: t.harness.Clock}
+	t.harness.connecting = &WebSocketTestClient{harness: t.harness, pipe: pipe, status: http.StatusSwitchingProtocols}
+	return pipe, nil
+}
+
+// webSocketFrame is a frame sent by a test client
+type webSocketFrame struct {
+	data  []byte
+	pong  bool
+	close *WebSocketCloseError
+}
+
+// webSocketPipe is the hub's side of an in-memory test connection. Reads never block: the
+// harness only reads when readable reports a frame, an expired deadline or a closed pipe.
+type webSocketPipe struct {
+	clock        *FakeClock
+	inbound      []webSocketFrame
+	received     []WebSocketMessage
+	pings        int
+	noAutoPong   bool
+	readLimit    int64
+	readDeadline time.Time
+	pongHandler  func()
+	closeFrame   *WebSocketCloseError
+	closed       bool
+	mutex        sync.Mutex
+}
+
+// push queues a frame from the client
+func (p *webSocketPipe) push(frame webSocketFrame) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		return errPipeClosed
+	}
+	p.inbound = append(p.inbound, frame)
+	return nil
+}
+
+// readable handles queued pongs, as a real connection does while reading, and reports
+// whether ReadMessage has something to return
+func (p *webSocketPipe) readable() bool {
+	for {
+		p.mutex.Lock()
+		if p.closed || len(p.inbound) == 0 || !p.inbound[0].pong || p.deadlineExceededLocked() {
+			break
+		}
+		p.inbound = p.inbound[1:]
+		handler := p.pongHandler
+		p.mutex.Unlock()
+
+		if handler != nil {
+			handler()
+		}
+	}
+	defer p.mutex.Unlock()
+	return p.closed || len(p.inbound) > 0 || p.deadlineExceededLocked()
+}
+
+func (p *webSocketPipe) deadlineExceededLocked() bool {
+	return !p.readDeadline.IsZero() && !p.clock.Now().Before(p.readDeadline)
+}
+
+func (p *webSocketPipe) ReadMessage() ([]byte, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	switch {
+	case p.closed:
+		return nil, errPipeClosed
+	case p.deadlineExceededLocked():
+		return nil, errPipeTimeout
+	case len(p.inbound) == 0:
+		return nil, errPipeEmpty
+	}
+
+	frame := p.inbound[0]
+	p.inbound = p.inbound[1:]
+	if frame.close != nil {
+		return nil, frame.close
+	}
+	if p.readLimit > 0 && int64(len(frame.data)) > p.readLimit {
+		return nil, errPipeReadLimit
+	}
+	return frame.data, nil
+}
+
+func (p *webSocketPipe) WriteMessage(data []byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		return errPipeClosed
+	}
+
+	for _, raw := range bytes.Split(data, []byte{'\n'}) {
+		var message WebSocketMessage
+		if err := json.Unmarshal(raw, &message); err != nil {
+			return fmt.Errorf("test client received an invalid message: %v", err)
+		}
+		p.received = append(p.received, message)
+	}
+	return nil
+}
+
+func (p *webSocketPipe) WritePing() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		return errPipeClosed
+	}
+
+	p.pings++
+	if !p.noAutoPong {
+		p.inbound = append(p.inbound, webSocketFrame{pong: true})
+	}
+	return nil
+}
+
+func (p *webSocketPipe) WriteClose(code int, reason string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		return errPipeClosed
+	}
+	if p.closeFrame == nil {
+		p.closeFrame = &WebSocketCloseError{Code: code, Text: reason}
+	}
+	return nil
+}
+
+func (p *webSocketPipe) SetReadLimit(limit int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.readLimit = limit
+}
+
+func (p *webSocketPipe) SetReadDeadline(t time.Time) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.readDeadline = t
+	return nil
+}
+
+func (p *webSocketPipe) SetWriteDeadline(t time.Time) error { return nil }
+
+func (p *webSocketPipe) SetPongHandler(handler func()) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.pongHandler = handler
+}
+
+func (p *webSocketPipe) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.closed = true
+	return nil
+}
@@ -0,0 +1,135 @@
+package supergin
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// WebSocket close codes (RFC 6455)
+const (
+	CloseNormalClosure   = 1000
+	CloseGoingAway       = 1001
+	CloseAbnormalClosure = 1006
+	CloseTryAgainLater   = 1013
+)
+
+// WebSocketTransport upgrades HTTP requests to WebSocket connections. Hubs use gorilla/websocket
+// unless configured WithTransport; builds tagged nogorilla default to XNetTransport.
+type WebSocketTransport interface {
+	Upgrade(w http.ResponseWriter, r *http.Request) (WebSocketConn, error)
+}
+
+// WebSocketConn is a connection upgraded by a WebSocketTransport. Writes come from a single
+// goroutine except WriteClose and Close, which must be safe to call concurrently.
+type WebSocketConn interface {
+	// ReadMessage returns the next text or binary message; a close frame from the peer is
+	// returned as *WebSocketCloseError where the transport reports it
+	ReadMessage() ([]byte, error)
+	// WriteMessage writes a text message
+	WriteMessage(data []byte) error
+	// WritePing writes a ping; pongs are reported to the handler set with SetPongHandler
+	WritePing() error
+	// WriteClose writes a close frame with a status code and reason
+	WriteClose(code int, reason string) error
+	SetReadLimit(limit int64)
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+	SetPongHandler(handler func())
+	Close() error
+}
+
+// WebSocketCloseError reports a close frame received from the peer
+type WebSocketCloseError struct {
+	Code int
+	Text string
+}
+
+// Error implements the error interface
+func (e *WebSocketCloseError) Error() string {
+	return fmt.Sprintf("websocket: close %d %s", e.Code, e.Text)
+}
+
+// WithTransport sets the hub's WebSocket implementation, e.g. WithTransport(XNetTransport())
+func WithTransport(transport WebSocketTransport) HubOption {
+	return func(h *WebSocketHub) {
+		if transport != nil {
+			h.transport = transport
+		}
+	}
+}
+
+// originChecker is implemented by transports that know whether they check request origins
+type originChecker interface {
+	checksOrigin() bool
+}
+
+// checksOrigins reports whether a transport config sets CheckOrigin or AllowedOrigins
+// rather than leaving the allow-all default
+func checksOrigins(config ...WebSocketConfig) bool {
+	return len(config) > 0 && (config[0].CheckOrigin != nil || len(config[0].AllowedOrigins) > 0)
+}
+
+// WithWebSocketConfig configures the hub's upgrades, connection limits and timeouts, e.g.
+// allowed origins, buffer sizes, MaxMessageSize and PingInterval. A transport set
+// WithTransport keeps the upgrade settings it was created with.
+func WithWebSocketConfig(config WebSocketConfig) HubOption {
+	return func(h *WebSocketHub) {
+		h.config = config
+	}
+}
+
+// checksOrigin reports whether the hub's transport rejects cross-origin upgrades. Custom
+// transports are trusted to check origins themselves.
+func (h *WebSocketHub) checksOrigin() bool {
+	if checker, ok := h.transport.(originChecker); ok {
+		return checker.checksOrigin()
+	}
+	return true
+}
+
+// webSocketConfig fills unset WebSocketConfig fields with the defaults used by all transports
+func webSocketConfig(config ...WebSocketConfig) WebSocketConfig {
+	cfg := WebSocketConfig{}
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.ReadBufferSize == 0 {
+		cfg.ReadBufferSize = 1024
+	}
+	if cfg.WriteBufferSize == 0 {
+		cfg.WriteBufferSize = 1024
+	}
+	if cfg.CheckOrigin == nil && len(cfg.AllowedOrigins) > 0 {
+		allowed := append([]string(nil), cfg.AllowedOrigins...)
+		cfg.CheckOrigin = func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			return origin == "" || slices.Contains(allowed, origin)
+		}
+	}
+	if cfg.CheckOrigin == nil {
+		cfg.CheckOrigin = func(r *http.Request) bool {
+			return true // Allow all origins in development
+		}
+	}
+	if cfg.ReadTimeout <= 0 {
+		cfg.ReadTimeout = wsPongWait
+		if cfg.PingInterval > 0 {
+			cfg.ReadTimeout = cfg.PingInterval * 10 / 9
+		}
+	}
+	if cfg.PingInterval <= 0 {
+		cfg.PingInterval = cfg.ReadTimeout * 9 / 10
+	}
+	if cfg.PingInterval >= cfg.ReadTimeout {
+		panic("WebSocket ping interval must be shorter than the read timeout")
+	}
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = wsWriteWait
+	}
+	if cfg.MaxMessageSize <= 0 {
+		cfg.MaxMessageSize = wsMaxMessageSize
+	}
+	return cfg
+}
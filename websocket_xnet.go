@@ -0,0 +1,122 @@
+package supergin
+
+import (
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// XNetTransport upgrades connections with golang.org/x/net/websocket. That package handles
+// pongs internally, so read deadlines are not applied and dead peers are detected when a
+// ping fails to write. Buffer sizes and compression are not configurable.
+func XNetTransport(config ...WebSocketConfig) WebSocketTransport {
+	return &xnetTransport{config: webSocketConfig(config...), checksOrigins: checksOrigins(config...)}
+}
+
+type xnetTransport struct {
+	config        WebSocketConfig
+	checksOrigins bool
+}
+
+func (t *xnetTransport) checksOrigin() bool { return t.checksOrigins }
+
+func (t *xnetTransport) Upgrade(w http.ResponseWriter, r *http.Request) (WebSocketConn, error) {
+	conns := make(chan *XNetConn, 1)
+	done := make(chan struct{})
+	server := websocket.Server{
+		Handshake: func(_ *websocket.Config, r *http.Request) error {
+			if !t.config.CheckOrigin(r) {
+				return errors.New("websocket: request origin not allowed")
+			}
+			return nil
+		},
+		// x/net closes the connection when the handler returns, so it waits for Close
+		Handler: func(ws *websocket.Conn) {
+			conn := &XNetConn{ws: ws, closed: make(chan struct{})}
+			conns <- conn
+			<-conn.closed
+		},
+	}
+
+	go func() {
+		defer close(done)
+		server.ServeHTTP(w, r)
+	}()
+
+	select {
+	case conn := <-conns:
+		return conn, nil
+	case <-done:
+		return nil, errors.New("websocket: handshake failed")
+	}
+}
+
+// XNetConn is a golang.org/x/net/websocket connection; Unwrap exposes it
+type XNetConn struct {
+	ws        *websocket.Conn
+	writeMux  sync.Mutex
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Unwrap returns the underlying x/net connection
+func (c *XNetConn) Unwrap() *websocket.Conn {
+	return c.ws
+}
+
+func (c *XNetConn) ReadMessage() ([]byte, error) {
+	var data []byte
+	err := websocket.Message.Receive(c.ws, &data)
+	return data, err
+}
+
+func (c *XNetConn) WriteMessage(data []byte) error {
+	return c.writeFrame(websocket.TextFrame, data)
+}
+
+func (c *XNetConn) WritePing() error {
+	return c.writeFrame(websocket.PingFrame, nil)
+}
+
+func (c *XNetConn) WriteClose(code int, reason string) error {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	c.ws.SetWriteDeadline(time.Now().Add(time.Second))
+	return c.writeFrame(websocket.CloseFrame, append(payload, reason...))
+}
+
+// writeFrame serializes writes, since the frame type is connection state in x/net
+func (c *XNetConn) writeFrame(frameType byte, data []byte) error {
+	c.writeMux.Lock()
+	defer c.writeMux.Unlock()
+
+	c.ws.PayloadType = frameType
+	_, err := c.ws.Write(data)
+	return err
+}
+
+func (c *XNetConn) SetReadLimit(limit int64) {
+	c.ws.MaxPayloadBytes = int(limit)
+}
+
+// SetReadDeadline is a no-op: without pong notifications, idle but healthy peers would
+// hit the deadline
+func (c *XNetConn) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (c *XNetConn) SetWriteDeadline(t time.Time) error {
+	return c.ws.SetWriteDeadline(t)
+}
+
+// SetPongHandler is a no-op: x/net consumes pongs without reporting them
+func (c *XNetConn) SetPongHandler(func()) {}
+
+func (c *XNetConn) Close() error {
+	err := c.ws.Close()
+	c.closeOnce.Do(func() { close(c.closed) })
+	return err
+}
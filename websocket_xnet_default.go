@@ -0,0 +1,7 @@
+//go:build nogorilla
+
+package supergin
+
+func defaultWebSocketTransport(config WebSocketConfig) WebSocketTransport {
+	return XNetTransport(config)
+}